@@ -17,7 +17,9 @@
 package cmdutils
 
 import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/spf13/cobra"
+	"path/filepath"
 )
 
 // SilenceUsageCommand gives back a command that is just configured to skip printing of usage info.
@@ -28,3 +30,13 @@ func SilenceUsageCommand() func(cmd *cobra.Command, args []string) {
 		cmd.SilenceUsage = true
 	}
 }
+
+// ProjectFolders returns the folders of the manifest's projects by their name. Folders are resolved relative to the
+// folder of the manifest.
+func ProjectFolders(manifestPath string, m manifest.Manifest) map[string]string {
+	folders := make(map[string]string, len(m.Projects))
+	for name, p := range m.Projects {
+		folders[name] = filepath.Join(filepath.Dir(manifestPath), p.Path)
+	}
+	return folders
+}
@@ -124,8 +124,14 @@ func loadEnvironmentsFromManifest(manifestPath string) ([]string, cobra.ShellCom
 }
 
 func ProjectsFromManifest(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return loadProjectsFromManifest(args[0])
+}
+
+func ProjectsByManifestFlag(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return loadProjectsFromManifest(cmd.Flag("manifest").Value.String())
+}
 
-	manifestPath := args[0]
+func loadProjectsFromManifest(manifestPath string) ([]string, cobra.ShellCompDirective) {
 	mani, _ := manifest.LoadManifest(&manifest.LoaderContext{
 		Fs:           afero.NewOsFs(),
 		ManifestPath: manifestPath,
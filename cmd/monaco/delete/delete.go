@@ -23,106 +23,59 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
-	"golang.org/x/exp/maps"
-	"path/filepath"
-
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/monaco"
 	"github.com/spf13/afero"
+	"golang.org/x/exp/maps"
 )
 
 func Delete(fs afero.Fs, deploymentManifestPath string, deleteFile string, environmentNames []string, environmentGroups []string) error {
+	ws, err := monaco.Load(fs, deploymentManifestPath, monaco.WithEnvironments(environmentNames...), monaco.WithGroups(environmentGroups...), monaco.WithoutProjects())
 
-	deploymentManifestPath = filepath.Clean(deploymentManifestPath)
-	deploymentManifestPath, manifestErr := filepath.Abs(deploymentManifestPath)
-
-	if manifestErr != nil {
-		return fmt.Errorf("error while finding absolute path for `%s`: %w", deploymentManifestPath, manifestErr)
-	}
-
-	apis := api.NewAPIs()
-
-	manifest, manifestLoadError := manifest.LoadManifest(&manifest.LoaderContext{
-		Fs:           fs,
-		ManifestPath: deploymentManifestPath,
-		Environments: environmentNames,
-		Groups:       environmentGroups,
-	})
-
-	if manifestLoadError != nil {
-		errutils.PrintErrors(manifestLoadError)
+	var manifestErr monaco.ManifestLoadError
+	if errors.As(err, &manifestErr) {
+		errutils.PrintErrors(manifestErr.Errors)
 		return errors.New("error while loading manifest")
 	}
-
-	entriesToDelete, errs := delete.LoadEntriesToDelete(fs, apis.GetNames(), deleteFile)
-	if errs != nil {
-		return fmt.Errorf("encountered errors while parsing delete.yaml: %s", errs)
+	if err != nil {
+		return err
 	}
 
-	deleteErrors := deleteConfigs(maps.Values(manifest.Environments), apis, entriesToDelete)
-
-	for _, e := range deleteErrors {
-		log.WithFields(field.Error(e)).Error("Deletion error: %s", e)
-	}
-	if len(deleteErrors) > 0 {
-		return fmt.Errorf("encountered %v errors during delete", len(deleteErrors))
+	entriesToDelete, err := monaco.LoadDeleteFile(fs, deleteFile)
+	if err != nil {
+		return fmt.Errorf("encountered errors while parsing delete.yaml: %w", err)
 	}
-	return nil
-}
 
-func deleteConfigs(environments []manifest.EnvironmentDefinition, apis api.APIs, entriesToDelete map[string][]delete.DeletePointer) (errors []error) {
+	warnAboutPlatformTypes(ws, entriesToDelete)
 
-	for _, env := range environments {
-		deleteErrors := deleteConfigForEnvironment(env, apis, entriesToDelete)
+	err = ws.Delete(context.TODO(), entriesToDelete, monaco.WithClientFactory(dynatrace.NewClientFactory()))
 
-		if deleteErrors != nil {
-			errors = append(errors, deleteErrors...)
+	var deleteErrors monaco.EnvironmentErrors
+	if errors.As(err, &deleteErrors) {
+		for _, e := range deleteErrors.All() {
+			log.WithFields(field.Error(e)).Error("Deletion error: %s", e)
 		}
+		return fmt.Errorf("encountered %v errors during delete", len(deleteErrors.All()))
 	}
-
-	return errors
+	return err
 }
 
-func deleteConfigForEnvironment(env manifest.EnvironmentDefinition, apis api.APIs, entriesToDelete map[string][]delete.DeletePointer) []error {
-
-	ctx := context.WithValue(context.TODO(), log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
-
-	automationResources := map[string]config.AutomationResource{
-		string(config.Workflow):         config.Workflow,
-		string(config.BusinessCalendar): config.BusinessCalendar,
-		string(config.SchedulingRule):   config.SchedulingRule,
+// warnAboutPlatformTypes warns about environments without OAuth credentials, from which the Dynatrace Platform
+// specific types in the delete file can't be deleted
+func warnAboutPlatformTypes(ws *monaco.Workspace, entriesToDelete monaco.DeleteEntries) {
+	platformTypes := []string{string(config.Workflow), string(config.BusinessCalendar), string(config.SchedulingRule), "bucket", string(config.DocumentTypeId)}
+	if !containsPlatformTypes(entriesToDelete, platformTypes) {
+		return
 	}
 
-	platformTypes := maps.Keys(automationResources)
-	platformTypes = append(platformTypes, "bucket")
-
-	if env.Auth.OAuth == nil && containsPlatformTypes(entriesToDelete, platformTypes) {
-		log.WithCtxFields(ctx).Warn("Delete file contains Dynatrace Platform specific types, but no oAuth credentials are defined for environment %q - Dynatrace Platform configurations won't be deleted.", env.Name)
-	}
-
-	clientSet, err := dynatrace.CreateClientSet(env.URL.Value, env.Auth)
-	if err != nil {
-		return []error{
-			fmt.Errorf("failed to create API client for environment %q due to the following error: %w", env.Name, err),
+	for _, env := range maps.Values(ws.Manifest.Environments) {
+		if env.Auth.OAuth == nil {
+			ctx := context.WithValue(context.TODO(), log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+			log.WithCtxFields(ctx).Warn("Delete file contains Dynatrace Platform specific types, but no oAuth credentials are defined for environment %q - Dynatrace Platform configurations won't be deleted.", env.Name)
 		}
 	}
-
-	log.WithCtxFields(ctx).Info("Deleting configs for environment %q...", env.Name)
-
-	return delete.Configs(
-		ctx,
-		delete.ClientSet{
-			Classic:    clientSet.Classic(),
-			Settings:   clientSet.Settings(),
-			Automation: clientSet.Automation(),
-		},
-		apis,
-		automationResources,
-		entriesToDelete)
 }
 
-func containsPlatformTypes(entriesToDelete map[string][]delete.DeletePointer, platformTypes []string) bool {
+func containsPlatformTypes(entriesToDelete monaco.DeleteEntries, platformTypes []string) bool {
 	for _, t := range platformTypes {
 		if _, contains := entriesToDelete[t]; contains {
 			return true
@@ -22,14 +22,15 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2/selection"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
 func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
-	var dryRun, continueOnError bool
-	var manifestName string
-	var environment, project, groups []string
+	var dryRun, continueOnError, remoteReferences, onlyChanged, noDeps, watch bool
+	var manifestName, stateFile string
+	var environment, project, groups, types, configs, labels, valuesFiles []string
 
 	deployCmd = &cobra.Command{
 		Use:               "deploy <manifest.yaml>",
@@ -47,7 +48,20 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 				return err
 			}
 
-			return deployConfigs(fs, manifestName, groups, environment, project, continueOnError, dryRun)
+			return deployConfigs(fs, manifestName, deployOptions{
+				environmentGroups:    groups,
+				specificEnvironments: environment,
+				specificProjects:     project,
+				valuesFiles:          valuesFiles,
+				remoteReferences:     remoteReferences,
+				selector:             selection.Selector{Types: types, Coordinates: configs, Labels: labels},
+				noDeps:               noDeps,
+				stateFile:            stateFile,
+				onlyChanged:          onlyChanged,
+				continueOnErr:        continueOnError,
+				dryRun:               dryRun,
+				watch:                watch,
+			})
 		},
 	}
 
@@ -61,7 +75,38 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 			"If this flag is specified, all environments within this group will be used for deployment. "+
 			"This flag is mutually exclusive with '--environment'")
 	deployCmd.Flags().StringSliceVarP(&project, "project", "p", make([]string, 0), "Project configuration to deploy (also deploys any dependent configurations)")
+	deployCmd.Flags().StringSliceVar(&valuesFiles, "values", []string{},
+		"Values files applied on top of the values files defined in the manifest, which can be referenced by 'values' parameters. "+
+			"To set multiple files either repeat this flag, or separate them using a comma (,). Files given later take precedence.")
+	deployCmd.Flags().BoolVar(&remoteReferences, "remote-references", false,
+		"Only deploy the projects given by '--project', without the projects they depend on. "+
+			"References to configurations of other projects are resolved by looking up the objects they were deployed as on the environment: "+
+			"Settings by their externalId, classic configurations by their name, and Automations and Buckets by their generated ID.")
+	deployCmd.Flags().StringSliceVar(&types, "type", []string{},
+		"Only deploy configurations of the given type(s), e.g. 'builtin:alerting.profile' or 'dashboard', and the configurations they depend on. "+
+			"To set multiple types either repeat this flag, or separate them using a comma (,).")
+	deployCmd.Flags().StringSliceVar(&configs, "config", []string{},
+		"Only deploy configurations with the given coordinate(s) in the form 'project:type:id', and the configurations they depend on. "+
+			"Coordinates may contain glob patterns, e.g. 'infra:*:team-*'. "+
+			"To set multiple coordinates either repeat this flag, or separate them using a comma (,).")
+	deployCmd.Flags().StringSliceVar(&labels, "label", []string{},
+		"Only deploy configurations with the given label(s) in the form 'key=value', or 'key' for any value, and the configurations they depend on. "+
+			"To set multiple labels either repeat this flag, or separate them using a comma (,).")
+	deployCmd.Flags().BoolVar(&noDeps, "no-deps", false,
+		"Do not deploy the configurations which the ones selected by '--type', '--config' or '--label' depend on. "+
+			"References to them are resolved using the deployment state given by '--state', or by looking up the objects on the environment.")
+	deployCmd.Flags().StringVar(&stateFile, "state", "",
+		"Record the objects configurations are deployed as in the given deployment state, and use it to resolve references to configurations which are not deployed, "+
+			"and to update renamed configurations instead of creating new objects. "+
+			"Paths with a '.json' extension are a single file, all other paths are a directory containing one file per environment.")
+	deployCmd.Flags().BoolVar(&onlyChanged, "only-changed", false,
+		"Only deploy configurations which changed since their last deployment. Configurations are compared to the deployment state given by --state, "+
+			"or to the objects on the environment if they are not recorded in the state. Only Settings 2.0, classic and extension configurations are skipped if unchanged. "+
+			"Unchanged configurations can still be referenced.")
 	deployCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Validate the structure of your manifest, projects and configurations. Dry-run will resolve all configuration parameters and render JSON templates, but can not validate the content of JSON payloads. After a successful dry-run, deployments may still fail with Dynatrace API errors if the content of JSONs is not valid.")
+	deployCmd.Flags().BoolVar(&watch, "watch", false,
+		"After the deployment, watch the project folders, templates and values files for changes until interrupted. "+
+			"Projects using changed files are reloaded, and their changed configurations are deployed again together with all configurations depending on them.")
 	deployCmd.Flags().BoolVarP(&continueOnError, "continue-on-error", "c", false, "Proceed deployment even if individual configuration deployments fail.")
 
 	err := deployCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByManifestFlag)
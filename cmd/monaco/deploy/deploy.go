@@ -15,344 +15,179 @@
 package deploy
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/slices"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
-	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/sequential"
-	"path/filepath"
-	"strings"
-
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
-	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2/sort"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/monaco"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2/selection"
 	"github.com/spf13/afero"
+	"path/filepath"
 )
 
-func deployConfigs(fs afero.Fs, manifestPath string, environmentGroups []string, specificEnvironments []string, specificProjects []string, continueOnErr bool, dryRun bool) error {
+// deployOptions are the options of a deployment given on the command line
+type deployOptions struct {
+	environmentGroups    []string
+	specificEnvironments []string
+	specificProjects     []string
+	// valuesFiles are values files applied on top of the ones defined in the manifest
+	valuesFiles []string
+	// remoteReferences states that only the specific projects are deployed, and references to other projects are
+	// resolved by looking up the referenced objects on the environments
+	remoteReferences bool
+	// stateFile is the path of the deployment state. If empty, no state is loaded or saved.
+	stateFile string
+	// selector narrows the deployed configurations down further than to the specific projects
+	selector selection.Selector
+	// noDeps states that configurations the selected ones depend on are not deployed, but resolved like remote references
+	noDeps bool
+	// onlyChanged states that configurations are only deployed if they changed since their last deployment
+	onlyChanged   bool
+	continueOnErr bool
+	dryRun        bool
+	// watch states that the projects are watched for changes after the deployment, and changed configurations are deployed again
+	watch bool
+}
+
+func deployConfigs(fs afero.Fs, manifestPath string, opts deployOptions) error {
+	if err := opts.selector.Validate(); err != nil {
+		return err
+	}
+	if opts.noDeps && opts.selector.IsEmpty() {
+		return errors.New("'no-deps' requires 'type', 'config' or 'label' to be set")
+	}
+
 	absManifestPath, err := absPath(manifestPath)
 	if err != nil {
 		return fmt.Errorf("error while finding absolute path for `%s`: %w", manifestPath, err)
 	}
-	loadedManifest, err := loadManifest(fs, absManifestPath, environmentGroups, specificEnvironments)
+	ws, err := loadWorkspace(fs, absManifestPath, opts)
 	if err != nil {
 		return err
 	}
 
-	ok := verifyEnvironmentGen(loadedManifest.Environments, dryRun)
+	ok := verifyEnvironmentGen(ws.Manifest.Environments, opts.dryRun)
 	if !ok {
 		return fmt.Errorf("unable to verify Dynatrace environment generation")
 	}
 
-	loadedProjects, err := loadProjects(fs, absManifestPath, loadedManifest)
-	if err != nil {
-		return err
+	if opts.watch {
+		return watchAndDeploy(fs, ws, opts)
 	}
 
-	filteredProjects, err := filterProjects(loadedProjects, specificProjects, loadedManifest.Environments.Names())
+	sel, err := selectProjects(ws, opts)
 	if err != nil {
-		return fmt.Errorf("error while loading relevant projects to deploy: %w", err)
-	}
-
-	if err := checkEnvironments(filteredProjects, loadedManifest.Environments); err != nil {
 		return err
 	}
 
-	logProjectsInfo(filteredProjects)
-	logEnvironmentsInfo(loadedManifest.Environments)
-
-	if featureflags.DependencyGraphBasedDeploy().Enabled() {
-		clientSets, err := createDeployClientSets(loadedManifest.Environments, dryRun)
-		if err != nil {
-			return fmt.Errorf("failed to create API clients: %w", err)
-		}
-		deployErr := deploy.DeployConfigGraph(filteredProjects, clientSets, deploy.DeployConfigsOptions{
-			ContinueOnErr: continueOnErr,
-			DryRun:        dryRun,
-		})
-		if deployErr != nil {
-			var deployErrs []error
+	logProjectsInfo(sel.Projects)
+	logEnvironmentsInfo(ws.Manifest.Environments)
 
-			var environmentDeployErrs deployErrors.EnvironmentDeploymentErrors
-			if errors.As(deployErr, &environmentDeployErrs) {
-				for _, errs := range environmentDeployErrs {
-					// TODO error handling can change to remove the repetitive grouping to env errors - for now we just build a list to be grouped again by printErrorReport
-					deployErrs = append(deployErrs, errs...)
-				}
-			} else {
-				deployErrs = append(deployErrs, deployErr)
-			}
-
-			printErrorReport(deployErrs)
-			return fmt.Errorf("errors during %s", getOperationNounForLogging(dryRun))
-		}
-	} else {
-		var deployErrs []error
-		sortedConfigs, err := sortConfigs(filteredProjects, loadedManifest.Environments.Names())
-		if err != nil {
-			return fmt.Errorf("error during configuration sort: %w", err)
-		}
-
-		for envName, cfgs := range sortedConfigs {
-			env := loadedManifest.Environments[envName]
-			errs := deployOnEnvironment(env, cfgs, continueOnErr, dryRun)
-			deployErrs = append(deployErrs, errs...)
-			if len(errs) > 0 && !continueOnErr {
-				break
-			}
-		}
-
-		if len(deployErrs) > 0 {
-			printErrorReport(deployErrs)
-			return fmt.Errorf("errors during %s", getOperationNounForLogging(dryRun))
-		}
-	}
-
-	log.Info("%s finished without errors", getOperationNounForLogging(dryRun))
-	return nil
+	return deployProjects(context.TODO(), fs, ws, sel, opts)
 }
 
-func deployOnEnvironment(env manifest.EnvironmentDefinition, cfgs []config.Config, continueOnErr bool, dryRun bool) []error {
-	logDeploymentInfo(dryRun, env.Name)
+// loadWorkspace loads the manifest and its projects, printing all errors encountered while loading them
+func loadWorkspace(fs afero.Fs, manifestPath string, opts deployOptions) (*monaco.Workspace, error) {
+	ws, err := monaco.Load(fs, manifestPath,
+		monaco.WithGroups(opts.environmentGroups...),
+		monaco.WithEnvironments(opts.specificEnvironments...),
+		monaco.WithValuesFiles(opts.valuesFiles...))
 
-	clientSet, err := createDeployClientSet(env, dryRun)
-	if err != nil {
-		return []error{fmt.Errorf("failed to create clients for envrionment %q: %w", env.Name, err)}
+	var manifestErr monaco.ManifestLoadError
+	if errors.As(err, &manifestErr) {
+		errutils.PrintErrors(manifestErr.Errors)
+		return nil, errors.New("error while loading manifest")
 	}
 
-	errs := sequential.DeployConfigs(clientSet, api.NewAPIs(), cfgs, deploy.DeployConfigsOptions{
-		ContinueOnErr: continueOnErr,
-		DryRun:        dryRun,
-	})
-	return errs
-}
-
-func createDeployClientSets(environments manifest.Environments, dryRun bool) (deploy.EnvironmentClients, error) {
-	clients := make(deploy.EnvironmentClients, len(environments))
-	for _, env := range environments {
-		clientSet, err := createDeployClientSet(env, dryRun)
-		if err != nil {
-			return deploy.EnvironmentClients{}, err
-		}
-
-		clients[deploy.EnvironmentInfo{
-			Name:  env.Name,
-			Group: env.Group,
-		}] = clientSet
+	var projectErr monaco.ProjectLoadError
+	if errors.As(err, &projectErr) {
+		printErrorReport(projectErr.Errors)
+		return nil, errors.New("error while loading projects - you may be loading v1 projects, please 'convert' to v2")
 	}
 
-	return clients, nil
+	return ws, err
 }
 
-func createDeployClientSet(env manifest.EnvironmentDefinition, dryRun bool) (deploy.ClientSet, error) {
-	if dryRun {
-		return deploy.DummyClientSet, nil
+// selectProjects returns the configurations to deploy according to the given options
+func selectProjects(ws *monaco.Workspace, opts deployOptions) (monaco.Selection, error) {
+	selectOpts := []monaco.SelectOption{monaco.WithProjects(opts.specificProjects...), monaco.WithSelector(opts.selector)}
+	if opts.remoteReferences {
+		selectOpts = append(selectOpts, monaco.WithRemoteReferences())
 	}
-
-	cl, err := dynatrace.CreateClientSet(env.URL.Value, env.Auth)
-	if err != nil {
-		return deploy.ClientSet{}, err
+	if opts.noDeps {
+		selectOpts = append(selectOpts, monaco.WithoutDependencies())
 	}
 
-	return deploy.ClientSet{
-		Classic:    cl.Classic(),
-		Settings:   cl.Settings(),
-		Automation: cl.Automation(),
-		Bucket:     cl.Bucket(),
-	}, nil
-}
-
-func absPath(manifestPath string) (string, error) {
-	manifestPath = filepath.Clean(manifestPath)
-	return filepath.Abs(manifestPath)
+	return ws.Select(selectOpts...)
 }
 
-func loadManifest(fs afero.Fs, manifestPath string, groups []string, environments []string) (*manifest.Manifest, error) {
-	m, errs := manifest.LoadManifest(&manifest.LoaderContext{
-		Fs:           fs,
-		ManifestPath: manifestPath,
-		Groups:       groups,
-		Environments: environments,
-	})
-
-	if len(errs) > 0 {
-		errutils.PrintErrors(errs)
-		return nil, errors.New("error while loading manifest")
+// deployProjects deploys the selected configurations to the environments of the manifest. Configurations of the
+// referenced projects are not deployed, but references to them are resolved.
+func deployProjects(ctx context.Context, fs afero.Fs, ws *monaco.Workspace, sel monaco.Selection, opts deployOptions) (err error) {
+	deployOpts := []monaco.Option{monaco.WithClientFactory(dynatrace.NewClientFactory())}
+	if opts.continueOnErr {
+		deployOpts = append(deployOpts, monaco.WithContinueOnError())
 	}
-
-	return &m, nil
-}
-
-func verifyEnvironmentGen(environments manifest.Environments, dryRun bool) bool {
-	if !dryRun {
-		return dynatrace.VerifyEnvironmentGeneration(environments)
-
+	if opts.dryRun {
+		deployOpts = append(deployOpts, monaco.WithDryRun())
 	}
-	return true
-}
-
-func loadProjects(fs afero.Fs, manifestPath string, man *manifest.Manifest) ([]project.Project, error) {
-	projects, errs := project.LoadProjects(fs, project.ProjectLoaderContext{
-		KnownApis:       api.NewAPIs().GetApiNameLookup(),
-		WorkingDir:      filepath.Dir(manifestPath),
-		Manifest:        *man,
-		ParametersSerde: config.DefaultParameterParsers,
-	})
-
-	if errs != nil {
-		printErrorReport(errs)
-		return nil, errors.New("error while loading projects - you may be loading v1 projects, please 'convert' to v2")
+	if opts.onlyChanged {
+		deployOpts = append(deployOpts, monaco.WithOnlyChanged())
 	}
 
-	return projects, nil
-}
-
-func filterProjects(projects []project.Project, specificProjects []string, specificEnvironments []string) ([]project.Project, error) {
-
-	if len(specificProjects) > 0 {
-		filtered, err := filterProjectsByName(projects, specificProjects)
-
+	if opts.stateFile != "" {
+		store := state.NewStore(fs, opts.stateFile)
+		deployState, err := store.Load()
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("failed to load deployment state: %w", err)
 		}
+		deployOpts = append(deployOpts, monaco.WithState(deployState))
 
-		projectsWithDependencies, err := loadProjectsWithDependencies(projects, filtered, specificEnvironments)
-
-		if err != nil {
-			return nil, err
+		// the state is saved even if the deployment failed, as it contains all configs which were deployed successfully
+		if !opts.dryRun {
+			defer func() {
+				if saveErr := store.Save(deployState); saveErr != nil {
+					err = errors.Join(err, fmt.Errorf("failed to save deployment state: %w", saveErr))
+				}
+			}()
 		}
-
-		projects = projectsWithDependencies
 	}
 
-	return projects, nil
-}
-
-func sortConfigs(projects []project.Project, environmentNames []string) (project.ConfigsPerEnvironment, error) {
-	sortedConfigs, errs := sort.ConfigsPerEnvironment(projects, environmentNames)
-	if errs != nil {
-		errutils.PrintErrors(errs)
-		return nil, errors.New("error during sort")
-	}
-	return sortedConfigs, nil
-}
-
-func filterProjectsByName(projects []project.Project, names []string) ([]string, error) {
-	var result []string
-
-	foundProjects := map[string]struct{}{}
-
-	for _, p := range projects {
-		if slices.Contains(names, p.Id) {
-			foundProjects[p.Id] = struct{}{}
-			result = append(result, p.Id)
-		} else if slices.Contains(names, p.GroupId) {
-			foundProjects[p.GroupId] = struct{}{}
-			result = append(result, p.Id)
+	if !featureflags.DependencyGraphBasedDeploy().Enabled() {
+		// the graph based deployment logs the environments it deploys to itself
+		for _, name := range ws.Manifest.Environments.Names() {
+			logDeploymentInfo(opts.dryRun, name)
 		}
 	}
 
-	var notFoundProjects []string
-
-	for _, name := range names {
-		if _, found := foundProjects[name]; !found {
-			notFoundProjects = append(notFoundProjects, name)
+	if _, err := ws.Deploy(ctx, sel, deployOpts...); err != nil {
+		var envErrs monaco.EnvironmentErrors
+		if !errors.As(err, &envErrs) {
+			return err
 		}
-	}
 
-	if notFoundProjects != nil {
-		return nil, fmt.Errorf("no project with names `%s` found", strings.Join(names, ", "))
+		printErrorReport(envErrs.All())
+		return fmt.Errorf("errors during %s", getOperationNounForLogging(opts.dryRun))
 	}
 
-	return result, nil
-}
-
-func loadProjectsWithDependencies(projects []project.Project, projectIdsToLoad []string, environments []string) ([]project.Project, error) {
-	lookupMap := toProjectMap(projects)
-	alreadyChecked := map[string]struct{}{}
-	toCheck := append(make([]string, 0, len(projectIdsToLoad)), projectIdsToLoad...)
-
-	var result []project.Project
-	var unknownProjects []string
-
-	for len(toCheck) > 0 {
-		current := toCheck[0]
-		toCheck = toCheck[1:]
-
-		if _, found := alreadyChecked[current]; found {
-			continue
-		}
-
-		if project, found := lookupMap[current]; found {
-			alreadyChecked[current] = struct{}{}
-			result = append(result, project)
-
-			// we need to load only the dependencies of environments we are going to deploy
-			for _, env := range environments {
-				toCheck = append(toCheck, project.Dependencies[env]...)
-			}
-		} else {
-			unknownProjects = append(unknownProjects, current)
-		}
-	}
-
-	if unknownProjects != nil {
-		return nil, fmt.Errorf("error while gathering dependencies. no projects with name `%s` found", unknownProjects)
-	}
-
-	return result, nil
-}
-
-func toProjectMap(projects []project.Project) map[string]project.Project {
-	result := make(map[string]project.Project)
-
-	for _, p := range projects {
-		result[p.Id] = p
-	}
-
-	return result
-}
-
-func checkEnvironments(projects []project.Project, envs manifest.Environments) error {
-	for _, p := range projects {
-		for envName, cfgPerType := range p.Configs {
-			if _, found := envs[envName]; !found {
-				return fmt.Errorf("cannot find environment `%s`", envName)
-			}
-			for _, cfgs := range cfgPerType {
-				if err := checkConfigsForEnvironment(envs[envName], cfgs); err != nil {
-					return err
-				}
-			}
-		}
-	}
+	log.Info("%s finished without errors", getOperationNounForLogging(opts.dryRun))
 	return nil
 }
 
-func checkConfigsForEnvironment(env manifest.EnvironmentDefinition, cfgs []config.Config) error {
-	for i := range cfgs {
-		if !cfgs[i].Skip && onlyAvailableOnPlatform(&cfgs[i]) && !platformEnvironment(env) {
-			return fmt.Errorf("enviroment %q is not specified as platform, but at least one of configurations (e.g. %q) is platform exclusive", env.Name, cfgs[i].Coordinate)
-		}
-	}
-	return nil
+func absPath(manifestPath string) (string, error) {
+	manifestPath = filepath.Clean(manifestPath)
+	return filepath.Abs(manifestPath)
 }
 
-func platformEnvironment(e manifest.EnvironmentDefinition) bool {
-	return e.Auth.OAuth != nil
-}
+func verifyEnvironmentGen(environments manifest.Environments, dryRun bool) bool {
+	if !dryRun {
+		return dynatrace.VerifyEnvironmentGeneration(environments)
 
-func onlyAvailableOnPlatform(c *config.Config) bool {
-	if _, ok := c.Type.(config.AutomationType); ok {
-		return true
 	}
-	_, ok := c.Type.(config.BucketType)
-	return ok
+	return true
 }
@@ -17,235 +17,20 @@
 package deploy
 
 import (
-	p "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/emulator"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2/selection"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
 	"path/filepath"
-	"reflect"
 	"testing"
 )
 
-func Test_filterProjectsByName(t *testing.T) {
-	type args struct {
-		projects []p.Project
-		names    []string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    []string
-		wantErr bool
-	}{
-		{
-			"returns nothing if no names given",
-			args{
-				[]p.Project{
-					{
-						Id:           "project A",
-						GroupId:      "",
-						Configs:      nil,
-						Dependencies: nil,
-					},
-					{
-						Id:           "project B",
-						GroupId:      "",
-						Configs:      nil,
-						Dependencies: nil,
-					},
-				},
-				[]string{},
-			},
-			nil,
-			false,
-		},
-		{
-			"filters for project by name",
-			args{
-				[]p.Project{
-					{
-						Id:           "project A",
-						GroupId:      "",
-						Configs:      nil,
-						Dependencies: nil,
-					},
-					{
-						Id:           "project B",
-						GroupId:      "",
-						Configs:      nil,
-						Dependencies: nil,
-					},
-				},
-				[]string{"project A"},
-			},
-			[]string{"project A"},
-			false,
-		},
-		{
-			"filters for grouping projects by name",
-			args{
-				[]p.Project{
-					{
-						Id:           "project.a",
-						GroupId:      "project",
-						Configs:      nil,
-						Dependencies: nil,
-					},
-					{
-						Id:           "project.b",
-						GroupId:      "project",
-						Configs:      nil,
-						Dependencies: nil,
-					},
-					{
-						Id:           "project2",
-						GroupId:      "",
-						Configs:      nil,
-						Dependencies: nil,
-					},
-					{
-						Id:           "project3.a",
-						GroupId:      "project3",
-						Configs:      nil,
-						Dependencies: nil,
-					},
-				},
-				[]string{"project"},
-			},
-			[]string{"project.a", "project.b"},
-			false,
-		},
-		{
-			"returns error if project of given name is not found",
-			args{
-				[]p.Project{
-					{
-						Id:           "project.a",
-						GroupId:      "project",
-						Configs:      nil,
-						Dependencies: nil,
-					},
-					{
-						Id:           "project.b",
-						GroupId:      "project",
-						Configs:      nil,
-						Dependencies: nil,
-					},
-					{
-						Id:           "project2",
-						GroupId:      "",
-						Configs:      nil,
-						Dependencies: nil,
-					},
-					{
-						Id:           "project3.a",
-						GroupId:      "project3",
-						Configs:      nil,
-						Dependencies: nil,
-					},
-				},
-				[]string{"project", "UNDEFINED PROJECT"},
-			},
-			nil,
-			true,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := filterProjectsByName(tt.args.projects, tt.args.names)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("filterProjectsByName() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("filterProjectsByName() got = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func Test_filterProjects(t *testing.T) {
-	type args struct {
-		projects             []p.Project
-		specificProjects     []string
-		specificEnvironments []string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    []p.Project
-		wantErr bool
-	}{
-		{
-			name: "empty projects",
-			args: args{
-				projects:             []p.Project{},
-				specificProjects:     []string{"a-project"},
-				specificEnvironments: []string{"an-env"},
-			},
-			want:    nil,
-			wantErr: true,
-		},
-		{
-			name: "specific project not found",
-			args: args{
-				projects:             []p.Project{{Id: "a-project"}},
-				specificProjects:     []string{"another-project"},
-				specificEnvironments: []string{"an-env"},
-			},
-			want:    nil,
-			wantErr: true,
-		},
-		{
-			name: "filter by specific project",
-			args: args{
-				projects:             []p.Project{{Id: "a-project"}, {Id: "another-project"}},
-				specificProjects:     []string{"a-project"},
-				specificEnvironments: []string{"an-env"},
-			},
-			want:    []p.Project{{Id: "a-project"}},
-			wantErr: false,
-		},
-		{
-			name: "filter by specific project and specific environment",
-			args: args{
-				projects: []p.Project{
-					{
-						Id:           "a-project",
-						Dependencies: p.DependenciesPerEnvironment{"another-env": []string{"another-project"}},
-					},
-					{
-						Id: "another-project",
-					},
-				},
-				specificProjects:     []string{"a-project"},
-				specificEnvironments: []string{"another-env"},
-			},
-			want: []p.Project{
-				{
-					Id:           "a-project",
-					Dependencies: p.DependenciesPerEnvironment{"another-env": []string{"another-project"}},
-				},
-				{
-					Id: "another-project",
-				},
-			},
-			wantErr: false,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := filterProjects(tt.args.projects, tt.args.specificProjects, tt.args.specificEnvironments)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("filterProjects() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("filterProjects() got = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func Test_DoDeploy_InvalidManifest(t *testing.T) {
 	t.Setenv("ENV_TOKEN", "mock env token")
 	t.Setenv("ENV_URL", "https://example.com")
@@ -270,7 +55,7 @@ func Test_DoDeploy_InvalidManifest(t *testing.T) {
 	manifestPath, _ := filepath.Abs("manifest.yaml")
 	_ = afero.WriteFile(testFs, manifestPath, []byte(manifestYaml), 0644)
 
-	err := deployConfigs(testFs, manifestPath, []string{}, []string{}, []string{}, true, true)
+	err := deployConfigs(testFs, manifestPath, deployOptions{continueOnErr: true, dryRun: true})
 	assert.Error(t, err)
 }
 
@@ -311,27 +96,413 @@ environmentGroups:
 	_ = afero.WriteFile(testFs, manifestPath, []byte(manifestYaml), 0644)
 
 	t.Run("Wrong environment group", func(t *testing.T) {
-		err := deployConfigs(testFs, manifestPath, []string{"NOT_EXISTING_GROUP"}, []string{}, []string{}, true, true)
+		err := deployConfigs(testFs, manifestPath, deployOptions{environmentGroups: []string{"NOT_EXISTING_GROUP"}, continueOnErr: true, dryRun: true})
 		assert.Error(t, err)
 	})
 	t.Run("Wrong environment name", func(t *testing.T) {
-		err := deployConfigs(testFs, manifestPath, []string{"default"}, []string{"NOT_EXISTING_ENV"}, []string{}, true, true)
+		err := deployConfigs(testFs, manifestPath, deployOptions{environmentGroups: []string{"default"}, specificEnvironments: []string{"NOT_EXISTING_ENV"}, continueOnErr: true, dryRun: true})
 		assert.Error(t, err)
 	})
 
 	t.Run("Wrong project name", func(t *testing.T) {
-		err := deployConfigs(testFs, manifestPath, []string{"default"}, []string{"project"}, []string{"NON_EXISTING_PROJECT"}, true, true)
+		err := deployConfigs(testFs, manifestPath, deployOptions{environmentGroups: []string{"default"}, specificEnvironments: []string{"project"}, specificProjects: []string{"NON_EXISTING_PROJECT"}, continueOnErr: true, dryRun: true})
 		assert.Error(t, err)
 	})
 
 	t.Run("no parameters", func(t *testing.T) {
-		err := deployConfigs(testFs, manifestPath, []string{}, []string{}, []string{}, true, true)
+		err := deployConfigs(testFs, manifestPath, deployOptions{continueOnErr: true, dryRun: true})
 		assert.NoError(t, err)
 	})
 
 	t.Run("correct parameters", func(t *testing.T) {
-		err := deployConfigs(testFs, manifestPath, []string{"default"}, []string{"project"}, []string{"project"}, true, true)
+		err := deployConfigs(testFs, manifestPath, deployOptions{environmentGroups: []string{"default"}, specificEnvironments: []string{"project"}, specificProjects: []string{"project"}, continueOnErr: true, dryRun: true})
+		assert.NoError(t, err)
+	})
+
+}
+
+func Test_DeployWithRemoteReferences(t *testing.T) {
+	server := httptest.NewServer(emulator.New())
+	defer server.Close()
+
+	t.Setenv("EMULATOR_URL", server.URL)
+	t.Setenv("EMULATOR_TOKEN", "dt0c01.ANY.TOKEN")
+
+	fs := afero.NewMemMapFs()
+	dir := t.TempDir()
+	files := map[string]string{
+		"manifest.yaml": `manifestVersion: 1.0
+projects:
+- name: shared
+- name: team
+environmentGroups:
+- name: default
+  environments:
+  - name: emulated
+    url:
+      type: environment
+      value: EMULATOR_URL
+    auth:
+      token:
+        name: EMULATOR_TOKEN
+`,
+		"shared/config.yaml": `configs:
+- id: profile
+  type:
+    api: alerting-profile
+  config:
+    name: Shared profile
+    template: profile.json
+`,
+		"shared/profile.json": `{"name": "{{.name}}", "rules": []}`,
+		"team/config.yaml": `configs:
+- id: attribute
+  type:
+    api: request-attributes
+  config:
+    name: Team attribute
+    template: attribute.json
+    parameters:
+      profile:
+        type: reference
+        project: shared
+        configType: alerting-profile
+        configId: profile
+        property: id
+`,
+		"team/attribute.json": `{"name": "{{.name}}", "profile": "{{.profile}}"}`,
+	}
+	for name, content := range files {
+		assert.NoError(t, afero.WriteFile(fs, filepath.Join(dir, name), []byte(content), 0644))
+	}
+	manifestPath := filepath.Join(dir, "manifest.yaml")
+
+	restClient := rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy())
+	c, err := dtclient.NewClassicClient(server.URL, restClient, dtclient.WithCachingDisabled(true))
+	assert.NoError(t, err)
+	apis := api.NewAPIs()
+
+	t.Run("fails if referenced config was never deployed", func(t *testing.T) {
+		err := deployConfigs(fs, manifestPath, deployOptions{specificProjects: []string{"team"}, remoteReferences: true})
+		assert.Error(t, err)
+
+		attributes, err := c.ListConfigs(context.TODO(), apis["request-attributes"])
+		assert.NoError(t, err)
+		assert.Empty(t, attributes)
+	})
+
+	t.Run("resolves reference to previously deployed config", func(t *testing.T) {
+		profile, err := c.UpsertConfigByName(context.TODO(), apis["alerting-profile"], "Shared profile", []byte(`{"name": "Shared profile", "rules": ["not-overwritten"]}`))
+		assert.NoError(t, err)
+
+		err = deployConfigs(fs, manifestPath, deployOptions{specificProjects: []string{"team"}, remoteReferences: true})
+		assert.NoError(t, err)
+
+		attributes, err := c.ListConfigs(context.TODO(), apis["request-attributes"])
 		assert.NoError(t, err)
+		assert.Len(t, attributes, 1)
+
+		payload, err := c.ReadConfigById(apis["request-attributes"], attributes[0].Id)
+		assert.NoError(t, err)
+		assert.Contains(t, string(payload), profile.Id)
+
+		payload, err = c.ReadConfigById(apis["alerting-profile"], profile.Id)
+		assert.NoError(t, err)
+		assert.Contains(t, string(payload), "not-overwritten", "expected referenced config not to be deployed")
 	})
+}
+
+func Test_DeployWithStateFile(t *testing.T) {
+	server := httptest.NewServer(emulator.New())
+	defer server.Close()
 
+	t.Setenv("EMULATOR_URL", server.URL)
+	t.Setenv("EMULATOR_TOKEN", "dt0c01.ANY.TOKEN")
+
+	fs := afero.NewMemMapFs()
+	dir := t.TempDir()
+	teamConfig := `configs:
+- id: attribute
+  type:
+    api: request-attributes
+  config:
+    name: %s
+    template: attribute.json
+    parameters:
+      profile:
+        type: reference
+        project: shared
+        configType: alerting-profile
+        configId: profile
+        property: id
+`
+	files := map[string]string{
+		"manifest.yaml": `manifestVersion: 1.0
+projects:
+- name: shared
+- name: team
+environmentGroups:
+- name: default
+  environments:
+  - name: emulated
+    url:
+      type: environment
+      value: EMULATOR_URL
+    auth:
+      token:
+        name: EMULATOR_TOKEN
+`,
+		"shared/config.yaml": `configs:
+- id: profile
+  type:
+    api: alerting-profile
+  config:
+    name: Shared profile
+    template: profile.json
+`,
+		"shared/profile.json": `{"name": "{{.name}}", "rules": []}`,
+		"team/config.yaml":    fmt.Sprintf(teamConfig, "Team attribute"),
+		"team/attribute.json": `{"name": "{{.name}}", "profile": "{{.profile}}"}`,
+	}
+	for name, content := range files {
+		assert.NoError(t, afero.WriteFile(fs, filepath.Join(dir, name), []byte(content), 0644))
+	}
+	manifestPath := filepath.Join(dir, "manifest.yaml")
+	stateDir := filepath.Join(dir, "state")
+
+	restClient := rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy())
+	c, err := dtclient.NewClassicClient(server.URL, restClient, dtclient.WithCachingDisabled(true))
+	assert.NoError(t, err)
+	apis := api.NewAPIs()
+
+	err = deployConfigs(fs, manifestPath, deployOptions{stateFile: stateDir, dryRun: true})
+	assert.NoError(t, err)
+	exists, err := afero.Exists(fs, stateDir)
+	assert.NoError(t, err)
+	assert.False(t, exists, "expected no state to be saved in dry-run")
+
+	err = deployConfigs(fs, manifestPath, deployOptions{stateFile: stateDir})
+	assert.NoError(t, err)
+	exists, err = afero.Exists(fs, filepath.Join(stateDir, "emulated.json"))
+	assert.NoError(t, err)
+	assert.True(t, exists, "expected state of environment to be saved")
+
+	profiles, err := c.ListConfigs(context.TODO(), apis["alerting-profile"])
+	assert.NoError(t, err)
+	assert.Len(t, profiles, 1)
+
+	// rename the attribute and only deploy the team project, the profile is resolved using the state
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "team", "config.yaml"), []byte(fmt.Sprintf(teamConfig, "Renamed attribute")), 0644))
+	err = deployConfigs(fs, manifestPath, deployOptions{specificProjects: []string{"team"}, stateFile: stateDir})
+	assert.NoError(t, err)
+
+	attributes, err := c.ListConfigs(context.TODO(), apis["request-attributes"])
+	assert.NoError(t, err)
+	assert.Len(t, attributes, 1, "expected renamed config to update the existing object")
+	if len(attributes) == 1 {
+		assert.Equal(t, "Renamed attribute", attributes[0].Name)
+
+		payload, err := c.ReadConfigById(apis["request-attributes"], attributes[0].Id)
+		assert.NoError(t, err)
+		assert.Contains(t, string(payload), profiles[0].Id)
+	}
+}
+
+func Test_DeployOnlyChanged(t *testing.T) {
+	server := httptest.NewServer(emulator.New())
+	defer server.Close()
+
+	t.Setenv("EMULATOR_URL", server.URL)
+	t.Setenv("EMULATOR_TOKEN", "dt0c01.ANY.TOKEN")
+
+	fs := afero.NewMemMapFs()
+	dir := t.TempDir()
+	files := map[string]string{
+		"manifest.yaml": `manifestVersion: 1.0
+projects:
+- name: project
+environmentGroups:
+- name: default
+  environments:
+  - name: emulated
+    url:
+      type: environment
+      value: EMULATOR_URL
+    auth:
+      token:
+        name: EMULATOR_TOKEN
+`,
+		"project/config.yaml": `configs:
+- id: attribute
+  type:
+    api: request-attributes
+  config:
+    name: Attribute
+    template: attribute.json
+`,
+		"project/attribute.json": `{"name": "{{.name}}", "enabled": true}`,
+	}
+	for name, content := range files {
+		assert.NoError(t, afero.WriteFile(fs, filepath.Join(dir, name), []byte(content), 0644))
+	}
+	manifestPath := filepath.Join(dir, "manifest.yaml")
+
+	restClient := rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy())
+	c, err := dtclient.NewClassicClient(server.URL, restClient, dtclient.WithCachingDisabled(true))
+	assert.NoError(t, err)
+	a := api.NewAPIs()["request-attributes"]
+
+	err = deployConfigs(fs, manifestPath, deployOptions{})
+	assert.NoError(t, err)
+
+	t.Run("unchanged configs are not deployed", func(t *testing.T) {
+		// fields which are not part of the template don't count as change, but are removed if the config is deployed
+		attribute, err := c.UpsertConfigByName(context.TODO(), a, "Attribute", []byte(`{"name": "Attribute", "enabled": true, "marker": "not-deployed"}`))
+		assert.NoError(t, err)
+
+		err = deployConfigs(fs, manifestPath, deployOptions{onlyChanged: true})
+		assert.NoError(t, err)
+
+		payload, err := c.ReadConfigById(a, attribute.Id)
+		assert.NoError(t, err)
+		assert.Contains(t, string(payload), "not-deployed")
+	})
+
+	t.Run("changed configs are deployed", func(t *testing.T) {
+		attribute, err := c.UpsertConfigByName(context.TODO(), a, "Attribute", []byte(`{"name": "Attribute", "enabled": false, "marker": "not-deployed"}`))
+		assert.NoError(t, err)
+
+		err = deployConfigs(fs, manifestPath, deployOptions{onlyChanged: true})
+		assert.NoError(t, err)
+
+		payload, err := c.ReadConfigById(a, attribute.Id)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(payload), "not-deployed")
+	})
+
+	t.Run("unchanged configs are recorded in the state", func(t *testing.T) {
+		stateFile := filepath.Join(dir, "state.json")
+
+		err = deployConfigs(fs, manifestPath, deployOptions{stateFile: stateFile, onlyChanged: true})
+		assert.NoError(t, err)
+
+		content, err := afero.ReadFile(fs, stateFile)
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), `"configId": "attribute"`)
+	})
+}
+
+func Test_DeploySelectedConfigs(t *testing.T) {
+	server := httptest.NewServer(emulator.New())
+	defer server.Close()
+
+	t.Setenv("EMULATOR_URL", server.URL)
+	t.Setenv("EMULATOR_TOKEN", "dt0c01.ANY.TOKEN")
+
+	fs := afero.NewMemMapFs()
+	dir := t.TempDir()
+	files := map[string]string{
+		"manifest.yaml": `manifestVersion: 1.0
+projects:
+- name: shared
+- name: team
+environmentGroups:
+- name: default
+  environments:
+  - name: emulated
+    url:
+      type: environment
+      value: EMULATOR_URL
+    auth:
+      token:
+        name: EMULATOR_TOKEN
+`,
+		"shared/config.yaml": `configs:
+- id: profile
+  type:
+    api: alerting-profile
+  config:
+    name: Shared profile
+    template: profile.json
+`,
+		"shared/profile.json": `{"name": "{{.name}}", "rules": []}`,
+		"team/config.yaml": `configs:
+- id: attribute
+  type:
+    api: request-attributes
+  config:
+    name: Team attribute
+    template: attribute.json
+    parameters:
+      profile:
+        type: reference
+        project: shared
+        configType: alerting-profile
+        configId: profile
+        property: id
+`,
+		"team/attribute.json": `{"name": "{{.name}}", "profile": "{{.profile}}"}`,
+	}
+	for name, content := range files {
+		assert.NoError(t, afero.WriteFile(fs, filepath.Join(dir, name), []byte(content), 0644))
+	}
+	manifestPath := filepath.Join(dir, "manifest.yaml")
+
+	restClient := rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy())
+	c, err := dtclient.NewClassicClient(server.URL, restClient, dtclient.WithCachingDisabled(true))
+	assert.NoError(t, err)
+	apis := api.NewAPIs()
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		err := deployConfigs(fs, manifestPath, deployOptions{selector: selection.Selector{Coordinates: []string{"team:[*"}}})
+		assert.Error(t, err)
+	})
+
+	t.Run("no-deps without selector", func(t *testing.T) {
+		err := deployConfigs(fs, manifestPath, deployOptions{noDeps: true})
+		assert.ErrorContains(t, err, "'no-deps' requires")
+	})
+
+	t.Run("deploys only selected type", func(t *testing.T) {
+		err := deployConfigs(fs, manifestPath, deployOptions{selector: selection.Selector{Types: []string{"alerting-profile"}}})
+		assert.NoError(t, err)
+
+		profiles, err := c.ListConfigs(context.TODO(), apis["alerting-profile"])
+		assert.NoError(t, err)
+		assert.Len(t, profiles, 1)
+
+		attributes, err := c.ListConfigs(context.TODO(), apis["request-attributes"])
+		assert.NoError(t, err)
+		assert.Empty(t, attributes)
+	})
+
+	// changes to the profile are only overwritten if it is deployed
+	profile, err := c.UpsertConfigByName(context.TODO(), apis["alerting-profile"], "Shared profile", []byte(`{"name": "Shared profile", "rules": ["not-overwritten"]}`))
+	assert.NoError(t, err)
+
+	t.Run("resolves dependencies which are not selected with no-deps", func(t *testing.T) {
+		err := deployConfigs(fs, manifestPath, deployOptions{selector: selection.Selector{Coordinates: []string{"team:*:*"}}, noDeps: true})
+		assert.NoError(t, err)
+
+		attributes, err := c.ListConfigs(context.TODO(), apis["request-attributes"])
+		assert.NoError(t, err)
+		assert.Len(t, attributes, 1)
+
+		payload, err := c.ReadConfigById(apis["request-attributes"], attributes[0].Id)
+		assert.NoError(t, err)
+		assert.Contains(t, string(payload), profile.Id)
+
+		payload, err = c.ReadConfigById(apis["alerting-profile"], profile.Id)
+		assert.NoError(t, err)
+		assert.Contains(t, string(payload), "not-overwritten")
+	})
+
+	t.Run("deploys dependencies of selected configs", func(t *testing.T) {
+		err := deployConfigs(fs, manifestPath, deployOptions{selector: selection.Selector{Coordinates: []string{"team:*:*"}}})
+		assert.NoError(t, err)
+
+		payload, err := c.ReadConfigById(apis["alerting-profile"], profile.Id)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(payload), "not-overwritten")
+	})
 }
@@ -0,0 +1,363 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/watch"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/plugin"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/monaco"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2/selection"
+	"github.com/spf13/afero"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	gonumGraph "gonum.org/v1/gonum/graph"
+)
+
+// watchInterval is the interval in which the watched projects are checked for changes
+const watchInterval = time.Second
+
+// coordinatesPerEnvironment holds sets of config coordinates by environment name
+type coordinatesPerEnvironment = map[string]map[coordinate.Coordinate]struct{}
+
+// watchAndDeploy deploys the given projects, and then watches their folders, as well as the folders of their templates
+// and values files, until interrupted. Whenever files change, the affected projects are reloaded, and their changed
+// configurations are deployed together with all configurations depending on them.
+func watchAndDeploy(fs afero.Fs, ws *monaco.Workspace, opts deployOptions) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	w := &projectWatcher{
+		fs:   fs,
+		ws:   ws,
+		opts: opts,
+	}
+	return w.run(ctx, watchInterval)
+}
+
+// projectWatcher holds the state of the projects between deployments in watch mode
+type projectWatcher struct {
+	fs afero.Fs
+	// ws holds the manifest and projects as loaded for the last deployment
+	ws   *monaco.Workspace
+	opts deployOptions
+}
+
+func (w *projectWatcher) run(ctx context.Context, interval time.Duration) error {
+	watcher, err := watch.New(w.fs, interval, w.watchedPaths()...)
+	if err != nil {
+		return err
+	}
+
+	if err := w.deployAll(ctx); err != nil {
+		log.Error("%v", err)
+	}
+
+	log.Info("Watching %d projects for changes. Press Ctrl+C to stop.", len(w.ws.Projects))
+	for {
+		changedFiles, err := watcher.Wait(ctx)
+		if ctx.Err() != nil {
+			log.Info("Stopped watching projects")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := w.redeploy(ctx, changedFiles); err != nil {
+			log.Error("%v", err)
+		}
+
+		// reloaded configurations may use templates or values files in folders not watched so far
+		if err := watcher.Add(w.watchedPaths()...); err != nil {
+			return err
+		}
+	}
+}
+
+// deployAll deploys all projects selected by the options
+func (w *projectWatcher) deployAll(ctx context.Context) error {
+	sel, err := selectProjects(w.ws, w.opts)
+	if err != nil {
+		return err
+	}
+
+	logProjectsInfo(sel.Projects)
+	logEnvironmentsInfo(w.ws.Manifest.Environments)
+
+	return deployProjects(ctx, w.fs, w.ws, sel, w.opts)
+}
+
+// redeploy reloads the projects using the changed files, and deploys their changed configurations and all
+// configurations depending on them. If a values file changed, the manifest and all projects are reloaded. If the
+// projects cannot be reloaded, the previously loaded ones are kept.
+func (w *projectWatcher) redeploy(ctx context.Context, changedFiles []string) error {
+	previous := w.ws.Projects
+
+	var affected []string
+	if slices.ContainsFunc(changedFiles, w.isValuesFile) {
+		ws, err := loadWorkspace(w.fs, w.ws.ManifestPath, w.opts)
+		if err != nil {
+			return fmt.Errorf("failed to reload changed values files, waiting for further changes: %w", err)
+		}
+		w.ws = ws
+
+		for _, p := range ws.Projects {
+			affected = append(affected, p.Id)
+		}
+		slices.Sort(affected)
+	} else {
+		affected = w.affectedProjects(changedFiles)
+		if len(affected) == 0 {
+			return nil
+		}
+
+		if err := w.ws.Reload(w.fs, affected...); err != nil {
+			var loadErr monaco.ProjectLoadError
+			if !errors.As(err, &loadErr) {
+				return err
+			}
+			printErrorReport(loadErr.Errors)
+			return errors.New("failed to reload changed projects, waiting for further changes")
+		}
+	}
+
+	environments := w.ws.Manifest.Environments.Names()
+	changed := changedConfigs(previous, w.ws.Projects, affected, environments)
+
+	if count(changed) == 0 {
+		log.Info("Files of projects %s changed, but none of their configurations", strings.Join(affected, ", "))
+		return nil
+	}
+
+	toDeploy := withDependents(graph.New(w.ws.Projects, environments), changed)
+
+	sel, err := selectProjects(w.ws, w.opts)
+	if err != nil {
+		return err
+	}
+	selected, excluded := selection.SelectCoordinates(sel.Projects, toDeploy)
+	if len(selected) == 0 {
+		log.Info("None of the %d changed configurations is selected for %s", count(changed), strings.ToLower(getOperationNounForLogging(w.opts.dryRun)))
+		return nil
+	}
+
+	log.Info("Redeploying %d changed configurations and %d configurations depending on them", count(changed), count(toDeploy)-count(changed))
+	start := time.Now()
+	if err := deployProjects(ctx, w.fs, w.ws, monaco.Selection{Projects: selected, Referenced: append(sel.Referenced, excluded...)}, w.opts); err != nil {
+		return err
+	}
+	log.Info("Redeployed in %s", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// watchedPaths returns the folders of all projects, and the folders of all templates and values files outside of them
+func (w *projectWatcher) watchedPaths() []string {
+	var folders []string
+	for _, folder := range cmdutils.ProjectFolders(w.ws.ManifestPath, w.ws.Manifest) {
+		folders = append(folders, folder)
+	}
+	for _, files := range w.templateFiles() {
+		for _, f := range files {
+			folders = append(folders, filepath.Dir(f))
+		}
+	}
+	for _, f := range w.valuesFiles() {
+		folders = append(folders, filepath.Dir(f))
+	}
+
+	// folders within other watched folders are watched already
+	var result []string
+	for _, folder := range folders {
+		if !slices.ContainsFunc(folders, func(other string) bool { return other != folder && containsPath(other, folder) }) && !slices.Contains(result, folder) {
+			result = append(result, folder)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// templateFiles returns the paths of the templates used by the configurations of each project, by project ID
+func (w *projectWatcher) templateFiles() map[string][]string {
+	result := make(map[string][]string, len(w.ws.Projects))
+	for _, p := range w.ws.Projects {
+		p.ForEveryConfigDo(func(c config.Config) {
+			if t, ok := c.Template.(template.FileBasedTemplate); ok {
+				result[p.Id] = append(result[p.Id], filepath.Join(filepath.Dir(w.ws.ManifestPath), t.FilePath()))
+			}
+		})
+	}
+	return result
+}
+
+// valuesFiles returns the paths of the values files of all environments, and of the additional values files
+func (w *projectWatcher) valuesFiles() []string {
+	var result []string
+	for _, env := range w.ws.Manifest.Environments {
+		for _, f := range append(slices.Clone(env.GroupValuesFiles), env.ValuesFiles...) {
+			result = append(result, filepath.Join(filepath.Dir(w.ws.ManifestPath), f))
+		}
+	}
+	return append(result, w.opts.valuesFiles...)
+}
+
+func (w *projectWatcher) isValuesFile(file string) bool {
+	return slices.ContainsFunc(w.valuesFiles(), func(f string) bool { return filepath.Clean(f) == filepath.Clean(file) })
+}
+
+// affectedProjects returns the sorted IDs of the projects whose folders contain any of the given files, or whose
+// configurations use any of them as template
+func (w *projectWatcher) affectedProjects(files []string) []string {
+	templates := w.templateFiles()
+
+	var result []string
+	for id, folder := range cmdutils.ProjectFolders(w.ws.ManifestPath, w.ws.Manifest) {
+		for _, f := range files {
+			if containsPath(folder, f) || slices.Contains(templates[id], filepath.Clean(f)) {
+				result = append(result, id)
+				break
+			}
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// containsPath returns whether the given path is the folder itself or within it
+func containsPath(folder string, path string) bool {
+	rel, err := filepath.Rel(folder, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// changedConfigs returns the coordinates of the configurations of the given projects which are new or differ between
+// the previous and the current projects, per environment. Removed configurations are only logged, as they are not
+// deleted from the environments.
+func changedConfigs(previous, current []project.Project, projectIds []string, environments []string) coordinatesPerEnvironment {
+	result := make(coordinatesPerEnvironment, len(environments))
+	for _, env := range environments {
+		previousConfigs := configsOf(previous, projectIds, env)
+		currentConfigs := configsOf(current, projectIds, env)
+
+		result[env] = map[coordinate.Coordinate]struct{}{}
+		for c, cfg := range currentConfigs {
+			if p, found := previousConfigs[c]; !found || !reflect.DeepEqual(comparableConfig(p), comparableConfig(cfg)) {
+				result[env][c] = struct{}{}
+			}
+		}
+
+		for c := range previousConfigs {
+			if _, found := currentConfigs[c]; !found {
+				log.Warn("Configuration %s was removed, but is not deleted from environment %q", c, env)
+			}
+		}
+	}
+	return result
+}
+
+// comparableConfig returns the given config with the plugins of its plugin parameters replaced by their definition.
+// Plugins are created anew whenever projects are reloaded, and cache their responses, so comparing them as they are
+// would report every configuration using a plugin as changed.
+func comparableConfig(c config.Config) config.Config {
+	params := make(config.Parameters, len(c.Parameters))
+	for name, param := range c.Parameters {
+		if p, ok := param.(*plugin.PluginParameter); ok {
+			param = &plugin.PluginParameter{
+				Plugin:     plugin.New(p.Plugin.Name, p.Plugin.Command, p.Plugin.Args, p.Plugin.Timeout),
+				Value:      p.Value,
+				References: p.References,
+			}
+		}
+		params[name] = param
+	}
+	c.Parameters = params
+	return c
+}
+
+func configsOf(projects []project.Project, projectIds []string, environment string) map[coordinate.Coordinate]config.Config {
+	result := map[coordinate.Coordinate]config.Config{}
+	for _, p := range projects {
+		if !slices.Contains(projectIds, p.Id) {
+			continue
+		}
+		for _, cfgs := range p.Configs[environment] {
+			for _, c := range cfgs {
+				result[c.Coordinate] = c
+			}
+		}
+	}
+	return result
+}
+
+// withDependents returns the given coordinates together with the coordinates of all configurations depending on them,
+// directly or transitively, per environment
+func withDependents(graphs graph.ConfigGraphPerEnvironment, coordinates coordinatesPerEnvironment) coordinatesPerEnvironment {
+	result := make(coordinatesPerEnvironment, len(coordinates))
+	for env, coords := range coordinates {
+		result[env] = maps.Clone(coords)
+
+		g, found := graphs[env]
+		if !found {
+			continue
+		}
+
+		nodes := g.Nodes()
+		for nodes.Next() {
+			n := nodes.Node().(graph.ConfigNode)
+			if _, found := coords[n.Config.Coordinate]; found {
+				addDependents(g, n, result[env])
+			}
+		}
+	}
+	return result
+}
+
+func addDependents(g gonumGraph.Directed, n graph.ConfigNode, result map[coordinate.Coordinate]struct{}) {
+	dependents := g.From(n.ID())
+	for dependents.Next() {
+		dependent := dependents.Node().(graph.ConfigNode)
+		if _, found := result[dependent.Config.Coordinate]; found {
+			continue
+		}
+		result[dependent.Config.Coordinate] = struct{}{}
+		addDependents(g, dependent, result)
+	}
+}
+
+func count(coordinates coordinatesPerEnvironment) int {
+	n := 0
+	for _, coords := range coordinates {
+		n += len(coords)
+	}
+	return n
+}
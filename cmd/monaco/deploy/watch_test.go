@@ -0,0 +1,264 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/plugin"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/emulator"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	p "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func watchTestConfig(id string, name string, refs ...string) config.Config {
+	params := config.Parameters{config.NameParameter: value.New(name)}
+	for _, ref := range refs {
+		params[ref] = reference.New("project", "dashboard", ref, "id")
+	}
+	return config.Config{
+		Coordinate:  coordinate.Coordinate{Project: "project", Type: "dashboard", ConfigId: id},
+		Environment: "env",
+		Type:        config.ClassicApiType{Api: "dashboard"},
+		Parameters:  params,
+	}
+}
+
+func watchTestProject(configs ...config.Config) p.Project {
+	return p.Project{
+		Id:      "project",
+		Configs: p.ConfigsPerTypePerEnvironments{"env": {"dashboard": configs}},
+	}
+}
+
+func TestChangedConfigs(t *testing.T) {
+	previous := []p.Project{watchTestProject(watchTestConfig("a", "A"), watchTestConfig("b", "B"), watchTestConfig("removed", "Removed"))}
+	current := []p.Project{watchTestProject(watchTestConfig("a", "A"), watchTestConfig("b", "Changed B"), watchTestConfig("added", "Added"))}
+
+	changed := changedConfigs(previous, current, []string{"project"}, []string{"env"})
+
+	assert.Equal(t, coordinatesPerEnvironment{
+		"env": {
+			{Project: "project", Type: "dashboard", ConfigId: "b"}:     {},
+			{Project: "project", Type: "dashboard", ConfigId: "added"}: {},
+		},
+	}, changed)
+}
+
+func TestChangedConfigs_ComparesPluginsByDefinition(t *testing.T) {
+	withPlugin := func(value string) config.Config {
+		c := watchTestConfig("a", "A")
+		c.Parameters["plugin"] = &plugin.PluginParameter{Plugin: plugin.New("vault", "vault-plugin", nil, 0), Value: value}
+		return c
+	}
+
+	previous := []p.Project{watchTestProject(withPlugin("secret"))}
+	// the plugin of the previous load has cached a response
+	_, _ = previous[0].Configs["env"]["dashboard"][0].Parameters["plugin"].(*plugin.PluginParameter).Plugin.Call(plugin.Request{})
+	current := []p.Project{watchTestProject(withPlugin("secret"))}
+
+	assert.Empty(t, changedConfigs(previous, current, []string{"project"}, []string{"env"})["env"])
+
+	changed := []p.Project{watchTestProject(withPlugin("other secret"))}
+	assert.Equal(t, coordinatesPerEnvironment{
+		"env": {{Project: "project", Type: "dashboard", ConfigId: "a"}: {}},
+	}, changedConfigs(previous, changed, []string{"project"}, []string{"env"}))
+}
+
+func TestWithDependents(t *testing.T) {
+	projects := []p.Project{watchTestProject(
+		watchTestConfig("a", "A"),
+		watchTestConfig("b", "B", "a"),
+		watchTestConfig("c", "C", "b"),
+		watchTestConfig("independent", "Independent"),
+	)}
+
+	changed := coordinatesPerEnvironment{"env": {{Project: "project", Type: "dashboard", ConfigId: "a"}: {}}}
+
+	assert.Equal(t, coordinatesPerEnvironment{
+		"env": {
+			{Project: "project", Type: "dashboard", ConfigId: "a"}: {},
+			{Project: "project", Type: "dashboard", ConfigId: "b"}: {},
+			{Project: "project", Type: "dashboard", ConfigId: "c"}: {},
+		},
+	}, withDependents(graph.New(projects, []string{"env"}), changed))
+
+	assert.Equal(t, 1, count(changed))
+}
+
+func TestProjectWatcher_Redeploy(t *testing.T) {
+	server := httptest.NewServer(emulator.New())
+	defer server.Close()
+
+	t.Setenv("EMULATOR_URL", server.URL)
+	t.Setenv("EMULATOR_TOKEN", "dt0c01.ANY.TOKEN")
+
+	fs := afero.NewMemMapFs()
+	dir := t.TempDir()
+	files := map[string]string{
+		"manifest.yaml": `manifestVersion: 1.0
+projects:
+- name: project
+- name: other
+environmentGroups:
+- name: default
+  environments:
+  - name: emulated
+    url:
+      type: environment
+      value: EMULATOR_URL
+    auth:
+      token:
+        name: EMULATOR_TOKEN
+    values:
+    - values/emulated.yaml
+`,
+		"values/emulated.yaml": `enabled: true`,
+		"project/config.yaml": `configs:
+- id: attribute
+  type:
+    api: request-attributes
+  config:
+    name: Attribute
+    template: attribute.json
+- id: shared
+  type:
+    api: request-attributes
+  config:
+    name: Shared
+    template: ../shared/shared.json
+- id: valued
+  type:
+    api: request-attributes
+  config:
+    name: Valued
+    template: valued.json
+    parameters:
+      enabled:
+        type: values
+        key: enabled
+`,
+		"project/attribute.json": `{"name": "{{.name}}", "enabled": true}`,
+		"project/valued.json":    `{"name": "{{.name}}", "enabled": {{.enabled}}}`,
+		"shared/shared.json":     `{"name": "{{.name}}", "enabled": true}`,
+		"other/config.yaml": `configs:
+- id: other
+  type:
+    api: request-attributes
+  config:
+    name: Other
+    template: other.json
+`,
+		"other/other.json": `{"name": "{{.name}}", "enabled": true}`,
+	}
+	for name, content := range files {
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, name), []byte(content), 0644))
+	}
+	manifestPath := filepath.Join(dir, "manifest.yaml")
+
+	ws, err := loadWorkspace(fs, manifestPath, deployOptions{})
+	require.NoError(t, err)
+	projects := ws.Projects
+
+	w := &projectWatcher{
+		fs: fs,
+		ws: ws,
+	}
+	require.NoError(t, w.deployAll(context.TODO()))
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "other"),
+		filepath.Join(dir, "project"),
+		filepath.Join(dir, "shared"),
+		filepath.Join(dir, "values"),
+	}, w.watchedPaths())
+
+	restClient := rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy())
+	c, err := dtclient.NewClassicClient(server.URL, restClient, dtclient.WithCachingDisabled(true))
+	require.NoError(t, err)
+	a := api.NewAPIs()["request-attributes"]
+
+	readAttribute := func(t *testing.T, name string) string {
+		values, err := c.ListConfigs(context.TODO(), a)
+		require.NoError(t, err)
+		for _, v := range values {
+			if v.Name == name {
+				payload, err := c.ReadConfigById(a, v.Id)
+				require.NoError(t, err)
+				return string(payload)
+			}
+		}
+		require.Fail(t, "request attribute not found")
+		return ""
+	}
+
+	t.Run("changed configurations are deployed", func(t *testing.T) {
+		template := filepath.Join(dir, "project", "attribute.json")
+		require.NoError(t, afero.WriteFile(fs, template, []byte(`{"name": "{{.name}}", "enabled": false}`), 0644))
+
+		require.NoError(t, w.redeploy(context.TODO(), []string{template}))
+		assert.Contains(t, readAttribute(t, "Attribute"), `"enabled":false`)
+	})
+
+	t.Run("configurations with changed templates outside of projects are deployed", func(t *testing.T) {
+		template := filepath.Join(dir, "shared", "shared.json")
+		require.NoError(t, afero.WriteFile(fs, template, []byte(`{"name": "{{.name}}", "enabled": false}`), 0644))
+
+		require.NoError(t, w.redeploy(context.TODO(), []string{template}))
+		assert.Contains(t, readAttribute(t, "Shared"), `"enabled":false`)
+	})
+
+	t.Run("configurations using changed values files are deployed", func(t *testing.T) {
+		valuesFile := filepath.Join(dir, "values", "emulated.yaml")
+		require.NoError(t, afero.WriteFile(fs, valuesFile, []byte(`enabled: false`), 0644))
+
+		require.NoError(t, w.redeploy(context.TODO(), []string{valuesFile}))
+		assert.Contains(t, readAttribute(t, "Valued"), `"enabled":false`)
+	})
+
+	t.Run("files outside of projects are ignored", func(t *testing.T) {
+		assert.NoError(t, w.redeploy(context.TODO(), []string{filepath.Join(dir, "unrelated.json")}))
+	})
+
+	t.Run("unchanged projects are not deployed", func(t *testing.T) {
+		assert.NoError(t, w.redeploy(context.TODO(), []string{filepath.Join(dir, "other", "config.yaml")}))
+	})
+
+	t.Run("projects which fail to load are kept", func(t *testing.T) {
+		configFile := filepath.Join(dir, "project", "config.yaml")
+		require.NoError(t, afero.WriteFile(fs, configFile, []byte("configs: [{id: broken}]"), 0644))
+
+		assert.ErrorContains(t, w.redeploy(context.TODO(), []string{configFile}), "failed to reload changed projects")
+		assert.Equal(t, projects[0].Id, w.ws.Projects[0].Id)
+		assert.NotEmpty(t, w.ws.Projects[0].Configs)
+	})
+}
@@ -58,9 +58,16 @@ type sharedDownloadCmdOptions struct {
 type downloadOptionsShared struct {
 	environmentURL         string
 	auth                   manifest.Auth
+	environmentOptions     manifest.EnvironmentOptions
 	outputFolder           string
 	projectName            string
 	forceOverwriteManifest bool
+	// environment is the manifest environment configurations are downloaded from. It is only set for downloads based
+	// on a manifest.
+	environment *manifest.EnvironmentDefinition
+	// accounts are the accounts defined in the manifest, whose resources are downloaded if the environment belongs
+	// to one of them
+	accounts map[string]manifest.Account
 }
 
 func writeConfigs(downloadedConfigs project.ConfigsPerType, opts downloadOptionsShared, fs afero.Fs) error {
@@ -73,6 +80,11 @@ func writeConfigs(downloadedConfigs project.ConfigsPerType, opts downloadOptions
 		OutputFolder:   opts.outputFolder,
 		ForceOverwrite: opts.forceOverwriteManifest,
 	}
+	if opts.environment != nil && opts.environment.Account != "" {
+		if a, found := opts.accounts[opts.environment.Account]; found {
+			downloadWriterContext.Account = &a
+		}
+	}
 	err := download.WriteToDisk(fs, downloadWriterContext)
 	if err != nil {
 		return err
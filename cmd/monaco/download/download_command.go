@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/support"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
@@ -79,6 +80,7 @@ func GetDownloadCommand(fs afero.Fs, command Command) (cmd *cobra.Command) {
 	// download options
 	cmd.Flags().StringSliceVarP(&f.specificAPIs, "api", "a", nil, "Download one or more classic configuration APIs, including deprecated ones. (Repeat flag or use comma-separated values)")
 	cmd.Flags().StringSliceVarP(&f.specificSchemas, "settings-schema", "s", nil, "Download settings 2.0 objects of one or more settings 2.0 schemas. (Repeat flag or use comma-separated values)")
+	cmd.Flags().StringSliceVar(&f.specificExtensions, "extension", nil, "Download the monitoring configurations of one or more Extensions 2.0 extensions, together with the package of their active version. (Repeat flag or use comma-separated values)")
 	cmd.Flags().BoolVar(&f.onlyAPIs, "only-apis", false, "Download only classic configuration APIs. Deprecated configuration APIs will not be included.")
 	cmd.Flags().BoolVar(&f.onlySettings, "only-settings", false, "Download only settings 2.0 objects")
 
@@ -256,14 +258,14 @@ func printUploadToSameEnvironmentWarning(env manifest.EnvironmentDefinition) {
 
 	var httpClient *http.Client
 	if env.Auth.OAuth == nil {
-		httpClient = clientAuth.NewTokenAuthClient(env.Auth.Token.Value)
+		httpClient = clientAuth.NewTokenAuthClientWithTransport(support.WrapTransport(nil), env.Auth.Token.Value)
 	} else {
 		credentials := clientAuth.OauthCredentials{
 			ClientID:     env.Auth.OAuth.ClientID.Value,
 			ClientSecret: env.Auth.OAuth.ClientSecret.Value,
 			TokenURL:     env.Auth.OAuth.GetTokenEndpointValue(),
 		}
-		httpClient = clientAuth.NewOAuthClient(context.TODO(), credentials)
+		httpClient = clientAuth.NewOAuthClient(support.OAuthContext(context.TODO()), credentials)
 	}
 
 	serverVersion, err = versionClient.GetDynatraceVersion(context.TODO(), rest.NewRestClient(httpClient, nil, rest.CreateRateLimitStrategy()), env.URL.Value)
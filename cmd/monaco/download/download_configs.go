@@ -15,10 +15,12 @@
 package download
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/tracing"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/dependency_resolution"
@@ -26,6 +28,8 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
 	"github.com/spf13/afero"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"os"
 )
 
@@ -37,6 +41,7 @@ type downloadCmdOptions struct {
 	specificEnvironmentName string
 	specificAPIs            []string
 	specificSchemas         []string
+	specificExtensions      []string
 	onlyAPIs                bool
 	onlySettings            bool
 	onlyAutomation          bool
@@ -114,15 +119,19 @@ func (d DefaultCommand) DownloadConfigsBasedOnManifest(fs afero.Fs, cmdOptions d
 		downloadOptionsShared: downloadOptionsShared{
 			environmentURL:         env.URL.Value,
 			auth:                   env.Auth,
+			environmentOptions:     env.Options,
 			outputFolder:           cmdOptions.outputFolder,
 			projectName:            cmdOptions.projectName,
 			forceOverwriteManifest: cmdOptions.forceOverwrite,
+			environment:            &env,
+			accounts:               m.Accounts,
 		},
-		specificAPIs:    cmdOptions.specificAPIs,
-		specificSchemas: cmdOptions.specificSchemas,
-		onlyAPIs:        cmdOptions.onlyAPIs,
-		onlySettings:    cmdOptions.onlySettings,
-		onlyAutomation:  cmdOptions.onlyAutomation,
+		specificAPIs:       cmdOptions.specificAPIs,
+		specificSchemas:    cmdOptions.specificSchemas,
+		specificExtensions: cmdOptions.specificExtensions,
+		onlyAPIs:           cmdOptions.onlyAPIs,
+		onlySettings:       cmdOptions.onlySettings,
+		onlyAutomation:     cmdOptions.onlyAutomation,
 	}
 
 	if errs := options.valid(); len(errs) != 0 {
@@ -153,11 +162,12 @@ func (d DefaultCommand) DownloadConfigs(fs afero.Fs, cmdOptions downloadCmdOptio
 			projectName:            cmdOptions.projectName,
 			forceOverwriteManifest: cmdOptions.forceOverwrite,
 		},
-		specificAPIs:    cmdOptions.specificAPIs,
-		specificSchemas: cmdOptions.specificSchemas,
-		onlyAPIs:        cmdOptions.onlyAPIs,
-		onlySettings:    cmdOptions.onlySettings,
-		onlyAutomation:  cmdOptions.onlyAutomation,
+		specificAPIs:       cmdOptions.specificAPIs,
+		specificSchemas:    cmdOptions.specificSchemas,
+		specificExtensions: cmdOptions.specificExtensions,
+		onlyAPIs:           cmdOptions.onlyAPIs,
+		onlySettings:       cmdOptions.onlySettings,
+		onlyAutomation:     cmdOptions.onlyAutomation,
 	}
 
 	if errs := options.valid(); len(errs) != 0 {
@@ -176,9 +186,11 @@ type downloadConfigsOptions struct {
 	downloadOptionsShared
 	specificAPIs    []string
 	specificSchemas []string
-	onlyAPIs        bool
-	onlySettings    bool
-	onlyAutomation  bool
+	// specificExtensions are the names of the extensions whose monitoring configurations are downloaded
+	specificExtensions []string
+	onlyAPIs           bool
+	onlySettings       bool
+	onlyAutomation     bool
 }
 
 func (opts downloadConfigsOptions) valid() []error {
@@ -193,14 +205,19 @@ func (opts downloadConfigsOptions) valid() []error {
 	return retVal
 }
 
-func doDownloadConfigs(fs afero.Fs, downloaders downloaders, opts downloadConfigsOptions) error {
-	err := preDownloadValidations(fs, opts.downloadOptionsShared)
+func doDownloadConfigs(fs afero.Fs, downloaders downloaders, opts downloadConfigsOptions) (err error) {
+	ctx, span := tracing.Tracer().Start(context.Background(), "download environment", trace.WithAttributes(environmentAttributes(opts.downloadOptionsShared)...))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	err = preDownloadValidations(fs, opts.downloadOptionsShared)
 	if err != nil {
 		return err
 	}
 
 	log.Info("Downloading from environment '%v' into project '%v'", opts.environmentURL, opts.projectName)
-	downloadedConfigs, err := downloadConfigs(downloaders, opts)
+	downloadedConfigs, err := downloadConfigs(ctx, downloaders, opts)
 	if err != nil {
 		return err
 	}
@@ -220,11 +237,20 @@ func doDownloadConfigs(fs afero.Fs, downloaders downloaders, opts downloadConfig
 	return writeConfigs(downloadedConfigs, opts.downloadOptionsShared, fs)
 }
 
-func downloadConfigs(downloaders downloaders, opts downloadConfigsOptions) (project.ConfigsPerType, error) {
+// environmentAttributes returns the tracing attributes identifying the environment downloaded from. Direct downloads
+// have no manifest environment; their environment is named after the project, as in the manifest written for them.
+func environmentAttributes(opts downloadOptionsShared) []attribute.KeyValue {
+	if opts.environment == nil {
+		return []attribute.KeyValue{tracing.Environment.String(opts.projectName)}
+	}
+	return []attribute.KeyValue{tracing.Environment.String(opts.environment.Name), tracing.EnvironmentGroup.String(opts.environment.Group)}
+}
+
+func downloadConfigs(ctx context.Context, downloaders downloaders, opts downloadConfigsOptions) (project.ConfigsPerType, error) {
 	configs := make(project.ConfigsPerType)
 
 	{
-		classicCfgs, err := downloaders.Classic().Download(opts.projectName)
+		classicCfgs, err := downloaders.Classic().Download(ctx, opts.projectName)
 		if err != nil {
 			return nil, err
 		}
@@ -235,7 +261,7 @@ func downloadConfigs(downloaders downloaders, opts downloadConfigsOptions) (proj
 		log.Info("Downloading settings objects")
 
 		settingTypes := makeSettingTypes(opts.specificSchemas)
-		settingCfgs, err := downloaders.Settings().Download(opts.projectName, settingTypes...)
+		settingCfgs, err := downloaders.Settings().Download(ctx, opts.projectName, settingTypes...)
 		if err != nil {
 			return nil, err
 		}
@@ -246,7 +272,7 @@ func downloadConfigs(downloaders downloaders, opts downloadConfigsOptions) (proj
 		if opts.auth.OAuth != nil {
 			log.Info("Downloading automation resources")
 
-			automationCfgs, err := downloaders.Automation().Download(opts.projectName)
+			automationCfgs, err := downloaders.Automation().Download(ctx, opts.projectName)
 			if err != nil {
 				return nil, err
 			}
@@ -256,6 +282,36 @@ func downloadConfigs(downloaders downloaders, opts downloadConfigsOptions) (proj
 		}
 	}
 
+	if shouldDownloadDocuments(opts) && opts.auth.OAuth != nil {
+		log.Info("Downloading documents")
+
+		documentCfgs, err := downloaders.Document().Download(ctx, opts.projectName)
+		if err != nil {
+			return nil, err
+		}
+		copyConfigs(configs, documentCfgs)
+	}
+
+	if shouldDownloadAccountResources(opts) {
+		log.Info("Downloading account resources")
+
+		accountCfgs, err := downloaders.Account().Download(ctx, opts.projectName)
+		if err != nil {
+			return nil, err
+		}
+		copyConfigs(configs, accountCfgs)
+	}
+
+	if len(opts.specificExtensions) > 0 {
+		log.Info("Downloading monitoring configurations of extensions")
+
+		extensionCfgs, err := downloaders.Extension().Download(ctx, opts.projectName, makeExtensionTypes(opts.specificExtensions)...)
+		if err != nil {
+			return nil, err
+		}
+		copyConfigs(configs, extensionCfgs)
+	}
+
 	return configs, nil
 }
 
@@ -267,6 +323,14 @@ func makeSettingTypes(specificSchemas []string) []config.SettingsType {
 	return settingTypes
 }
 
+func makeExtensionTypes(names []string) []config.ExtensionType {
+	var extensionTypes []config.ExtensionType
+	for _, name := range names {
+		extensionTypes = append(extensionTypes, config.ExtensionType{Name: name})
+	}
+	return extensionTypes
+}
+
 func copyConfigs(dest, src project.ConfigsPerType) {
 	for k, v := range src {
 		dest[k] = v
@@ -278,6 +342,17 @@ func shouldDownloadSettings(opts downloadConfigsOptions) bool {
 	return !opts.onlyAutomation && !opts.onlyAPIs && (len(opts.specificAPIs) == 0 || len(opts.specificSchemas) > 0)
 }
 
+// shouldDownloadDocuments returns true unless only specific kinds of configurations are requested
+func shouldDownloadDocuments(opts downloadConfigsOptions) bool {
+	return !opts.onlyAutomation && shouldDownloadAutomationResources(opts)
+}
+
+// shouldDownloadAccountResources returns true if the environment belongs to an account, unless only specific kinds
+// of configurations are requested
+func shouldDownloadAccountResources(opts downloadConfigsOptions) bool {
+	return opts.environment != nil && opts.environment.Account != "" && shouldDownloadDocuments(opts)
+}
+
 func shouldDownloadAutomationResources(opts downloadConfigsOptions) bool {
 	return !opts.onlySettings && len(opts.specificAPIs) == 0 &&
 		!opts.onlyAPIs && len(opts.specificSchemas) == 0
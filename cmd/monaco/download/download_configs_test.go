@@ -19,6 +19,7 @@
 package download
 
 import (
+	"context"
 	"errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
@@ -152,7 +153,7 @@ func TestDownloadConfigsBehaviour(t *testing.T) {
 
 			downloaders := downloaders{settings.NewDownloader(c), classicDownloader(c, tt.givenOpts)}
 
-			_, err := downloadConfigs(downloaders, tt.givenOpts)
+			_, err := downloadConfigs(context.TODO(), downloaders, tt.givenOpts)
 			assert.NoError(t, err)
 		})
 	}
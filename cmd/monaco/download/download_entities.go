@@ -17,6 +17,7 @@ package download
 import (
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/support"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/concurrency"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
@@ -77,6 +78,7 @@ func (d DefaultCommand) DownloadEntitiesBasedOnManifest(fs afero.Fs, cmdOptions
 		downloadOptionsShared: downloadOptionsShared{
 			environmentURL:         env.URL.Value,
 			auth:                   env.Auth,
+			environmentOptions:     env.Options,
 			outputFolder:           cmdOptions.outputFolder,
 			projectName:            cmdOptions.projectName,
 			forceOverwriteManifest: cmdOptions.forceOverwrite,
@@ -84,7 +86,7 @@ func (d DefaultCommand) DownloadEntitiesBasedOnManifest(fs afero.Fs, cmdOptions
 		specificEntitiesTypes: cmdOptions.specificEntitiesTypes,
 	}
 
-	clients, err := dynatrace.CreateClientSet(options.environmentURL, options.auth)
+	clients, err := dynatrace.CreateClientSet(options.environmentURL, options.auth, options.environmentOptions)
 	if err != nil {
 		return err
 	}
@@ -118,7 +120,7 @@ func (d DefaultCommand) DownloadEntities(fs afero.Fs, cmdOptions entitiesDirectD
 	dtClient, err := dtclient.NewClassicClient(
 		cmdOptions.environmentURL,
 		rest.NewRestClient(
-			clientAuth.NewTokenAuthClient(token),
+			clientAuth.NewTokenAuthClientWithTransport(support.WrapTransport(nil), token),
 			nil,
 			rest.CreateRateLimitStrategy()),
 		dtclient.WithClientRequestLimiter(
@@ -24,8 +24,11 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download"
+	dlaccount "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/account"
 	dlautomation "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/automation"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/classic"
+	dldocument "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/document"
+	dlextension "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/extension"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/settings"
 )
 
@@ -43,8 +46,20 @@ func (d downloaders) Automation() download.Downloader[config.AutomationType] {
 	return getDownloader[config.AutomationType](d)
 }
 
+func (d downloaders) Extension() download.Downloader[config.ExtensionType] {
+	return getDownloader[config.ExtensionType](d)
+}
+
+func (d downloaders) Document() download.Downloader[config.DocumentType] {
+	return getDownloader[config.DocumentType](d)
+}
+
+func (d downloaders) Account() download.Downloader[config.AccountType] {
+	return getDownloader[config.AccountType](d)
+}
+
 func makeDownloaders(options downloadConfigsOptions) (downloaders, error) {
-	clients, err := dynatrace.CreateClientSet(options.environmentURL, options.auth)
+	clients, err := dynatrace.CreateClientSet(options.environmentURL, options.auth, options.environmentOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +70,25 @@ func makeDownloaders(options downloadConfigsOptions) (downloaders, error) {
 	}
 	var settingsDownloader download.Downloader[config.SettingsType] = settings.NewDownloader(clients.Settings())
 	var classicDownloader download.Downloader[config.ClassicApiType] = classicDownloader(clients.Classic(), options)
-	return downloaders{settingsDownloader, classicDownloader, automationDownloader}, nil
+	var extensionDownloader download.Downloader[config.ExtensionType] = dlextension.NoopExtensionDownloader{}
+	if clients.Extension() != nil {
+		extensionDownloader = dlextension.NewDownloader(clients.Extension())
+	}
+	var documentDownloader download.Downloader[config.DocumentType] = dldocument.NoopDocumentDownloader{}
+	if clients.Document() != nil {
+		documentDownloader = dldocument.NewDownloader(clients.Document())
+	}
+	var accountDownloader download.Downloader[config.AccountType] = dlaccount.NoopAccountDownloader{}
+	if options.environment != nil {
+		accountClient, err := dynatrace.CreateAccountClient(*options.environment, options.accounts)
+		if err != nil {
+			return nil, err
+		}
+		if accountClient != nil {
+			accountDownloader = dlaccount.NewDownloader(accountClient)
+		}
+	}
+	return downloaders{settingsDownloader, classicDownloader, automationDownloader, extensionDownloader, documentDownloader, accountDownloader}, nil
 }
 
 func classicDownloader(client dtclient.Client, opts downloadConfigsOptions) *classic.Downloader {
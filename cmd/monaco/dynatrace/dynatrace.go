@@ -24,10 +24,12 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/account"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/auth"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/metadata"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/version"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/monaco"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
 )
 
@@ -46,7 +48,7 @@ func VerifyEnvironmentGeneration(envs manifest.Environments) bool {
 }
 
 func isClassicEnvironment(env manifest.EnvironmentDefinition) bool {
-	if _, err := version.GetDynatraceVersion(context.TODO(), rest.NewRestClient(auth.NewTokenAuthClient(env.Auth.Token.Value), nil, rest.CreateRateLimitStrategy()), env.URL.Value); err != nil {
+	if _, err := version.GetDynatraceVersion(context.TODO(), rest.NewRestClient(auth.NewTokenAuthClientWithTransport(support.WrapTransport(nil), env.Auth.Token.Value), nil, rest.CreateRateLimitStrategy()), env.URL.Value); err != nil {
 		var respErr rest.RespError
 		if errors.As(err, &respErr) {
 			log.WithFields(field.Error(err)).Error("Could not authorize against the environment with name %q (%s) using token authorization: %v", env.Name, env.URL.Value, err)
@@ -65,7 +67,7 @@ func isPlatformEnvironment(env manifest.EnvironmentDefinition) bool {
 		ClientSecret: env.Auth.OAuth.ClientSecret.Value,
 		TokenURL:     env.Auth.OAuth.GetTokenEndpointValue(),
 	}
-	if _, err := metadata.GetDynatraceClassicURL(context.TODO(), rest.NewRestClient(auth.NewOAuthClient(context.TODO(), oauthCredentials), nil, rest.CreateRateLimitStrategy()), env.URL.Value); err != nil {
+	if _, err := metadata.GetDynatraceClassicURL(context.TODO(), rest.NewRestClient(auth.NewOAuthClient(support.OAuthContext(context.TODO()), oauthCredentials), nil, rest.CreateRateLimitStrategy()), env.URL.Value); err != nil {
 		var respErr rest.RespError
 		if errors.As(err, &respErr) {
 			log.WithFields(field.Error(err)).Error("Could not authorize against the environment with name %q (%s) using oAuth authorization: %v", env.Name, env.URL.Value, err)
@@ -78,19 +80,28 @@ func isPlatformEnvironment(env manifest.EnvironmentDefinition) bool {
 	return true
 }
 
-func CreateClientSet(url string, auth manifest.Auth) (*client.ClientSet, error) {
-	if auth.OAuth == nil {
-		return client.CreateClassicClientSet(url, auth.Token.Value, client.ClientOptions{
-			SupportArchive: support.SupportArchive,
-		})
-	}
-	return client.CreatePlatformClientSet(url, client.PlatformAuth{
-		OauthClientID:     auth.OAuth.ClientID.Value,
-		OauthClientSecret: auth.OAuth.ClientSecret.Value,
-		Token:             auth.Token.Value,
-		OauthTokenURL:     auth.OAuth.GetTokenEndpointValue(),
-	}, client.ClientOptions{
-		SupportArchive: support.SupportArchive,
-	})
+func CreateClientSet(url string, auth manifest.Auth, options manifest.EnvironmentOptions) (*client.ClientSet, error) {
+	return monaco.CreateClientSet(url, auth, toClientOptions(options))
+}
 
+// CreateAccountClient creates the client for the account the given environment belongs to. Policy bindings are managed
+// on the level of the environment. It returns nil if the environment does not belong to an account.
+func CreateAccountClient(env manifest.EnvironmentDefinition, accounts map[string]manifest.Account) (*account.Client, error) {
+	return monaco.CreateAccountClient(env, accounts, toClientOptions(env.Options))
+}
+
+// NewClientFactory returns the monaco.ClientFactory creating clients with the support options given on the command line
+func NewClientFactory() monaco.ClientFactory {
+	return monaco.NewClientFactory(supportClientOptions())
+}
+
+func toClientOptions(options manifest.EnvironmentOptions) client.ClientOptions {
+	return monaco.ClientOptions(supportClientOptions(), options)
+}
+
+func supportClientOptions() client.ClientOptions {
+	return client.ClientOptions{
+		SupportArchive: support.SupportArchive,
+		WrapTransport:  support.WrapTransport,
+	}
 }
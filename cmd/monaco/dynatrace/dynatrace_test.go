@@ -21,6 +21,7 @@ package dynatrace
 import (
 	"encoding/json"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/oauth2"
 	"net/http"
@@ -229,3 +230,36 @@ func TestVerifyEnvironmentGen(t *testing.T) {
 		assert.False(t, ok)
 	})
 }
+
+func TestToClientOptions(t *testing.T) {
+	t.Run("defaults are kept if no options are set", func(t *testing.T) {
+		opts := toClientOptions(manifest.EnvironmentOptions{})
+		assert.Nil(t, opts.RetrySettings)
+		assert.Zero(t, opts.ConcurrentRequests)
+		assert.Zero(t, opts.RequestTimeout)
+	})
+
+	t.Run("options are mapped", func(t *testing.T) {
+		opts := toClientOptions(manifest.EnvironmentOptions{
+			ConcurrentRequests: 3,
+			RequestsPerSecond:  10,
+			Retry:              &manifest.RetryOptions{WaitTime: 2 * time.Second, MaxRetries: 5},
+			ProxyURL:           "http://proxy:8080",
+			CAFile:             "ca.pem",
+			CACertificates:     []byte("cert"),
+			RequestTimeout:     time.Minute,
+		})
+
+		assert.Equal(t, 3, opts.ConcurrentRequests)
+		assert.Equal(t, float64(10), opts.RequestsPerSecond)
+		assert.Equal(t, "http://proxy:8080", opts.ProxyURL)
+		assert.Equal(t, []byte("cert"), opts.CACertificates)
+		assert.Equal(t, time.Minute, opts.RequestTimeout)
+
+		assert.Equal(t, &rest.RetrySettings{
+			Normal:   rest.RetrySetting{WaitTime: 2 * time.Second, MaxRetries: 5},
+			Long:     rest.RetrySetting{WaitTime: 2 * time.Second, MaxRetries: 10},
+			VeryLong: rest.RetrySetting{WaitTime: 2 * time.Second, MaxRetries: 20},
+		}, opts.RetrySettings)
+	})
+}
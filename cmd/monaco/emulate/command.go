@@ -0,0 +1,47 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulate
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var address string
+	var version string
+
+	cmd := &cobra.Command{
+		Use:   "emulate",
+		Short: "Serves an in-memory emulation of the Dynatrace APIs used by monaco",
+		Long: `Serves an in-memory emulation of the Dynatrace APIs used by monaco, to test deployments and downloads without a Dynatrace environment.
+
+Any token or OAuth credentials are accepted. Configurations are only kept in memory while the emulator is running.`,
+		Example: `monaco emulate --address localhost:8080
+  Use http://localhost:8080 as the URL of an environment in your manifest, and deploy to it using any token`,
+		Args:   cobra.NoArgs,
+		PreRun: cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return emulate(cmd.Context(), address, version)
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "localhost:8080", "The address the emulator listens on")
+	cmd.Flags().StringVar(&version, "dynatrace-version", "", "The Dynatrace version reported by the emulator, in the format MAJOR.MINOR.PATCH.DATE")
+
+	return cmd
+}
@@ -0,0 +1,100 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulate_test
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/runner"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/emulator"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployAndDownloadRoundTrip(t *testing.T) {
+	server := httptest.NewServer(emulator.New())
+	defer server.Close()
+
+	t.Setenv("EMULATOR_URL", server.URL)
+	t.Setenv("EMULATOR_TOKEN", "dt0c01.ANY.TOKEN")
+
+	fs := afero.NewMemMapFs()
+	dir := t.TempDir()
+	files := map[string]string{
+		"manifest.yaml": `manifestVersion: 1.0
+projects:
+- name: project
+environmentGroups:
+- name: default
+  environments:
+  - name: emulated
+    url:
+      type: environment
+      value: EMULATOR_URL
+    auth:
+      token:
+        name: EMULATOR_TOKEN
+`,
+		"project/config.yaml": `configs:
+- id: attribute
+  type:
+    api: request-attributes
+  config:
+    name: My Attribute
+    template: attribute.json
+- id: tag
+  type:
+    settings:
+      schema: builtin:tags.auto-tagging
+      scope: environment
+  config:
+    name: My Tag
+    template: tag.json
+`,
+		"project/attribute.json": `{"name": "{{.name}}", "enabled": true}`,
+		"project/tag.json":       `{"name": "{{.name}}", "rules": []}`,
+	}
+	for name, content := range files {
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	cmd := runner.BuildCli(fs)
+	cmd.SetArgs([]string{"deploy", filepath.Join(dir, "manifest.yaml")})
+	require.NoError(t, cmd.Execute())
+
+	// deploying again must update the existing configurations instead of creating new ones
+	cmd = runner.BuildCli(fs)
+	cmd.SetArgs([]string{"deploy", filepath.Join(dir, "manifest.yaml")})
+	require.NoError(t, cmd.Execute())
+
+	cmd = runner.BuildCli(fs)
+	cmd.SetArgs([]string{"download", "--manifest", filepath.Join(dir, "manifest.yaml"), "--environment", "emulated", "--output-folder", filepath.Join(dir, "download")})
+	require.NoError(t, cmd.Execute())
+
+	attributes, err := afero.Glob(fs, filepath.Join(dir, "download", "project_emulated", "request-attributes", "*.json"))
+	require.NoError(t, err)
+	assert.Len(t, attributes, 1)
+
+	tags, err := afero.Glob(fs, filepath.Join(dir, "download", "project_emulated", "builtintags.auto-tagging", "*.json"))
+	require.NoError(t, err)
+	assert.Len(t, tags, 1)
+}
@@ -0,0 +1,64 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var check bool
+	var projects []string
+
+	cmd = &cobra.Command{
+		Use:   "fmt <manifest.yaml>",
+		Short: "Format config files and JSON templates of projects",
+		Long: "Rewrite the config files of the projects of a manifest in the canonical form written by 'monaco download', and pretty-print their JSON templates. " +
+			"Config files get a stable order of configs and properties, and use the shorthand syntax for string values. Comments are kept. " +
+			"Go template actions in JSON templates are kept, templates which are no valid JSON without them are left unchanged. " +
+			"With --check, no files are changed, but the command fails if any file is not formatted.",
+		Example:           "monaco fmt manifest.yaml --check",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				err := fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
+				return err
+			}
+
+			return formatProjects(fs, manifestName, options{check: check, projects: projects})
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "Only check whether files are formatted, without changing them. Fails if any file is not formatted.")
+	cmd.Flags().StringSliceVarP(&projects, "project", "p", nil, "Projects to format. If not defined, all projects in the manifest are formatted.")
+
+	if err := cmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	return cmd
+}
@@ -0,0 +1,200 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/writer"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/spf13/afero"
+	"golang.org/x/exp/slices"
+	"path/filepath"
+	"strings"
+)
+
+type options struct {
+	// check states that files are only checked, but not changed
+	check    bool
+	projects []string
+}
+
+func formatProjects(fs afero.Fs, manifestPath string, opts options) error {
+	m, errs := manifest.LoadManifest(&manifest.LoaderContext{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Opts: manifest.LoaderOptions{
+			DontResolveEnvVars: true,
+		},
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load manifest %q", manifestPath)
+	}
+
+	for _, p := range opts.projects {
+		if _, found := m.Projects[p]; !found {
+			return fmt.Errorf("requested project %q not found in manifest", p)
+		}
+	}
+
+	// projects are loaded to only format valid projects, and to find the templates of their configs
+	workingDir := filepath.Dir(manifestPath)
+	projects, errs := project.LoadProjects(fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().GetApiNameLookup(),
+		WorkingDir:      workingDir,
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load projects")
+	}
+
+	configFiles, templateFiles, err := filesToFormat(fs, workingDir, m, projects, opts.projects)
+	if err != nil {
+		return err
+	}
+
+	var unformatted []string
+	formatFiles := func(paths []string, format func([]byte) ([]byte, error)) error {
+		for _, path := range paths {
+			changed, err := formatFile(fs, path, format, opts.check)
+			if err != nil {
+				return err
+			}
+			if changed {
+				unformatted = append(unformatted, path)
+			}
+		}
+		return nil
+	}
+	if err := formatFiles(configFiles, writer.FormatConfigFile); err != nil {
+		return err
+	}
+	if err := formatFiles(templateFiles, formatTemplate); err != nil {
+		return err
+	}
+
+	if opts.check {
+		for _, f := range unformatted {
+			log.WithFields(field.F("file", f)).Warn("File %s is not formatted", f)
+		}
+		if len(unformatted) > 0 {
+			return fmt.Errorf("%d files are not formatted, run 'monaco fmt' to format them", len(unformatted))
+		}
+		log.Info("All files are formatted")
+		return nil
+	}
+
+	for _, f := range unformatted {
+		log.WithFields(field.F("file", f)).Debug("Formatted %s", f)
+	}
+	log.Info("Formatted %d files", len(unformatted))
+	return nil
+}
+
+// filesToFormat returns the config files and JSON templates of the given projects, or of all projects if none are given
+func filesToFormat(fs afero.Fs, workingDir string, m manifest.Manifest, projects []project.Project, projectIDs []string) (configFiles, templateFiles []string, err error) {
+	formats := func(projectID string) bool {
+		return len(projectIDs) == 0 || slices.Contains(projectIDs, projectID)
+	}
+
+	projectFs := fs
+	if workingDir != "." {
+		projectFs = afero.NewBasePathFs(fs, workingDir)
+	}
+	for _, p := range m.Projects {
+		if !formats(p.Name) {
+			continue
+		}
+
+		found, err := project.FindConfigFiles(projectFs, p.Path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find config files of project %q: %w", p.Name, err)
+		}
+		for _, f := range found {
+			configFiles = append(configFiles, filepath.Join(workingDir, f))
+		}
+	}
+
+	for _, p := range projects {
+		if !formats(p.Id) {
+			continue
+		}
+		p.ForEveryConfigDo(func(c config.Config) {
+			t, ok := c.Template.(template.FileBasedTemplate)
+			if !ok || !strings.EqualFold(filepath.Ext(t.FilePath()), ".json") {
+				return
+			}
+			path := filepath.Join(workingDir, t.FilePath())
+			if !slices.Contains(templateFiles, path) {
+				templateFiles = append(templateFiles, path)
+			}
+		})
+	}
+
+	slices.Sort(configFiles)
+	slices.Sort(templateFiles)
+	return configFiles, templateFiles, nil
+}
+
+func formatTemplate(content []byte) ([]byte, error) {
+	formatted, err := template.FormatJSON(string(content))
+	if errors.Is(err, template.ErrNotJSON) {
+		return content, nil
+	}
+	return []byte(formatted), err
+}
+
+// formatFile formats the given file and returns whether it was not formatted before. Files are only changed if check
+// is false.
+func formatFile(fs afero.Fs, path string, format func([]byte) ([]byte, error), check bool) (bool, error) {
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %q: %w", path, err)
+	}
+
+	formatted, err := format(content)
+	if err != nil {
+		return false, fmt.Errorf("failed to format %q: %w", path, err)
+	}
+
+	if bytes.Equal(content, formatted) {
+		return false, nil
+	}
+	if check {
+		return true, nil
+	}
+
+	info, err := fs.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to access %q: %w", path, err)
+	}
+	if err := afero.WriteFile(fs, path, formatted, info.Mode()); err != nil {
+		return false, fmt.Errorf("failed to write %q: %w", path, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,126 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/format"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+func TestInvalidCommandUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		errMsgContains string
+	}{
+		{
+			name:           "Manifest argument is required",
+			args:           []string{},
+			errMsgContains: "accepts 1 arg(s), received 0",
+		},
+		{
+			name:           "Manifest must be a YAML file",
+			args:           []string{"manifest.json"},
+			errMsgContains: "expected a .yaml file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := format.Command(afero.NewMemMapFs())
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.ErrorContains(t, err, tt.errMsgContains)
+		})
+	}
+}
+
+func runFormat(t *testing.T, fs afero.Fs, args ...string) error {
+	cmd := format.Command(fs)
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+func TestFormat(t *testing.T) {
+	t.Setenv("TOKEN", "some-value")
+	fs := testutils.CreateTestFileSystem()
+
+	err := runFormat(t, fs, "./test-resources/manifest.yaml", "--check")
+	assert.ErrorContains(t, err, "2 files are not formatted")
+
+	require.NoError(t, runFormat(t, fs, "./test-resources/manifest.yaml"))
+
+	config, err := afero.ReadFile(fs, "test-resources/project/dashboard/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, `configs:
+  - id: generated
+    config:
+      name: Generated dashboard
+      parameters:
+        tags:
+          type: value
+          value:
+            - a
+            - b
+      template: generated.json
+    type:
+      api: dashboard
+  # the main dashboard
+  - id: main
+    config:
+      name: Main dashboard
+      parameters:
+        owner: team-a # owning team
+      template: main.json
+    type:
+      api: dashboard
+`, string(config))
+
+	main, err := afero.ReadFile(fs, "test-resources/project/dashboard/main.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "dashboardMetadata": {
+    "name": "{{ .name }}",
+    "owner": "{{ .owner }}"
+  },
+  "tiles": []
+}
+`, string(main))
+
+	generated, err := afero.ReadFile(fs, "test-resources/project/dashboard/generated.json")
+	require.NoError(t, err)
+	original, err := os.ReadFile("test-resources/project/dashboard/generated.json")
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(generated), "templates which are no valid JSON without their actions are not changed")
+
+	assert.NoError(t, runFormat(t, fs, "./test-resources/manifest.yaml", "--check"), "formatted files pass the check")
+}
+
+func TestFormat_UnknownProject(t *testing.T) {
+	t.Setenv("TOKEN", "some-value")
+
+	err := runFormat(t, testutils.CreateTestFileSystem(), "./test-resources/manifest.yaml", "-p", "unknown")
+	assert.ErrorContains(t, err, `requested project "unknown" not found in manifest`)
+}
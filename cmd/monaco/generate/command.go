@@ -17,6 +17,7 @@
 package generate
 
 import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/generate/configskeleton"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/generate/deletefile"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/generate/dependencygraph"
 	"github.com/spf13/afero"
@@ -37,6 +38,7 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 
 	cmd.AddCommand(dependencygraph.Command(fs))
 	cmd.AddCommand(deletefile.Command(fs))
+	cmd.AddCommand(configskeleton.Command(fs))
 
 	return cmd
 }
@@ -0,0 +1,83 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configskeleton
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var opts options
+
+	cmd = &cobra.Command{
+		Use:   "config",
+		Short: "Generate a skeleton of a new configuration and its template in a project",
+		Long: "Generate a skeleton of a new configuration in the given project of a manifest. " +
+			"The configuration is added to the config file of its type, and a JSON template is created next to it. " +
+			"For Settings 2.0 schemas, the template contains all required properties of the schema, which is fetched from an environment of the manifest. " +
+			"For classic APIs, the template only contains the configuration's name.",
+		Example: "monaco generate config --schema builtin:alerting.profile --project p --id my-profile\n" +
+			"monaco generate config --api dashboard --project p --id my-dashboard --manifest my-manifest.yaml",
+		Args:   cobra.NoArgs,
+		PreRun: cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !files.IsYamlFileExtension(opts.manifestPath) {
+				return fmt.Errorf("wrong format for manifest file! Expected a .yaml file, but got %s", opts.manifestPath)
+			}
+
+			if (opts.schema == "") == (opts.api == "") {
+				return fmt.Errorf("exactly one of the flags '--schema' or '--api' is required")
+			}
+
+			if opts.project == "" {
+				return fmt.Errorf("the flag '--project' is required")
+			}
+
+			if opts.id == "" {
+				return fmt.Errorf("the flag '--id' is required")
+			}
+
+			return generateConfig(fs, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.manifestPath, "manifest", "manifest.yaml", "The manifest defining the project the configuration is added to")
+	cmd.Flags().StringVar(&opts.schema, "schema", "", "The Settings 2.0 schema of the configuration, e.g. 'builtin:alerting.profile'")
+	cmd.Flags().StringVar(&opts.api, "api", "", "The classic API of the configuration, e.g. 'dashboard'")
+	cmd.Flags().StringVarP(&opts.project, "project", "p", "", "The project the configuration is added to")
+	cmd.Flags().StringVar(&opts.id, "id", "", "The config ID of the configuration")
+	cmd.Flags().StringVarP(&opts.environment, "environment", "e", "", "The environment the Settings 2.0 schema is fetched from. Required if the manifest defines more than one environment.")
+	cmd.MarkFlagsMutuallyExclusive("schema", "api")
+
+	if err := cmd.RegisterFlagCompletionFunc("manifest", completion.YamlFile); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+	if err := cmd.RegisterFlagCompletionFunc("project", completion.ProjectsByManifestFlag); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+	if err := cmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByManifestFlag); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	return cmd
+}
@@ -0,0 +1,174 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configskeleton
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/writer"
+	"github.com/spf13/afero"
+	"golang.org/x/exp/maps"
+	"path/filepath"
+)
+
+type options struct {
+	manifestPath string
+	// schema is the Settings 2.0 schema of the generated config. Either schema or api is set.
+	schema string
+	// api is the classic API of the generated config. Either schema or api is set.
+	api         string
+	project     string
+	id          string
+	environment string
+}
+
+func generateConfig(fs afero.Fs, opts options) error {
+	m, err := loadManifest(fs, opts.manifestPath, opts)
+	if err != nil {
+		return err
+	}
+
+	p, found := m.Projects[opts.project]
+	if !found {
+		return fmt.Errorf("requested project %q not found in manifest", opts.project)
+	}
+
+	var c config.Config
+	if opts.schema != "" {
+		c, err = settingsConfig(m, opts)
+	} else {
+		c, err = classicConfig(opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	errs := writer.AddConfigs(&writer.WriterContext{
+		Fs:              fs,
+		OutputFolder:    filepath.Dir(opts.manifestPath),
+		ProjectFolder:   p.Path,
+		ParametersSerde: config.DefaultParameterParsers,
+	}, []config.Config{c})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to add config %q to project %q", opts.id, opts.project)
+	}
+
+	log.Info("Added config %q of type %q to project %q", opts.id, c.Coordinate.Type, opts.project)
+	return nil
+}
+
+// loadManifest loads the given manifest. Environment variables are only resolved if a schema needs to be fetched,
+// and only for the environment it is fetched from.
+func loadManifest(fs afero.Fs, manifestPath string, opts options) (manifest.Manifest, error) {
+	ctx := manifest.LoaderContext{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Opts: manifest.LoaderOptions{
+			DontResolveEnvVars: opts.schema == "",
+		},
+	}
+	if opts.environment != "" {
+		ctx.Environments = []string{opts.environment}
+	}
+
+	m, errs := manifest.LoadManifest(&ctx)
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return manifest.Manifest{}, fmt.Errorf("failed to load manifest %q", manifestPath)
+	}
+	return m, nil
+}
+
+func settingsConfig(m manifest.Manifest, opts options) (config.Config, error) {
+	if len(m.Environments) != 1 {
+		return config.Config{}, errors.New("the manifest defines more than one environment, please select the environment to fetch the schema from using '--environment'")
+	}
+	env := maps.Values(m.Environments)[0]
+
+	clients, err := dynatrace.CreateClientSet(env.URL.Value, env.Auth, env.Options)
+	if err != nil {
+		return config.Config{}, fmt.Errorf("failed to create a client for environment %q: %w", env.Name, err)
+	}
+
+	schema, err := clients.Settings().FetchSchema(opts.schema)
+	if err != nil {
+		return config.Config{}, fmt.Errorf("failed to fetch schema %q from environment %q: %w", opts.schema, env.Name, err)
+	}
+
+	content, err := settingsTemplate(schema)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	return config.Config{
+		Template: template.NewDownloadTemplate(opts.id, opts.id, content),
+		Coordinate: coordinate.Coordinate{
+			Project:  opts.project,
+			Type:     opts.schema,
+			ConfigId: opts.id,
+		},
+		Type: config.SettingsType{SchemaId: opts.schema},
+		Parameters: config.Parameters{
+			config.NameParameter:  valueParam.New(opts.id),
+			config.ScopeParameter: valueParam.New(scopePlaceholder(schema)),
+		},
+	}, nil
+}
+
+// scopePlaceholder returns 'environment' if the schema can be configured for the whole environment, or a placeholder
+// naming the first allowed scope otherwise.
+func scopePlaceholder(schema dtclient.Schema) string {
+	if len(schema.AllowedScopes) == 0 {
+		return "environment"
+	}
+	for _, s := range schema.AllowedScopes {
+		if s == "environment" {
+			return s
+		}
+	}
+	return fmt.Sprintf("<%s ID>", schema.AllowedScopes[0])
+}
+
+func classicConfig(opts options) (config.Config, error) {
+	if _, found := api.NewAPIs()[opts.api]; !found {
+		return config.Config{}, fmt.Errorf("unknown API %q", opts.api)
+	}
+
+	return config.Config{
+		Template: template.NewDownloadTemplate(opts.id, opts.id, classicTemplate),
+		Coordinate: coordinate.Coordinate{
+			Project:  opts.project,
+			Type:     opts.api,
+			ConfigId: opts.id,
+		},
+		Type: config.ClassicApiType{Api: opts.api},
+		Parameters: config.Parameters{
+			config.NameParameter: valueParam.New(opts.id),
+		},
+	}, nil
+}
@@ -0,0 +1,219 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configskeleton_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/generate/configskeleton"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInvalidCommandUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		errMsgContains string
+	}{
+		{
+			name:           "Manifest must be a YAML file",
+			args:           []string{"--manifest", "manifest.json", "--api", "dashboard", "--project", "project", "--id", "id"},
+			errMsgContains: "Expected a .yaml file",
+		},
+		{
+			name:           "Schema or API is required",
+			args:           []string{"--project", "project", "--id", "id"},
+			errMsgContains: "exactly one of the flags '--schema' or '--api' is required",
+		},
+		{
+			name:           "Schema and API are mutually exclusive",
+			args:           []string{"--schema", "builtin:alerting.profile", "--api", "dashboard", "--project", "project", "--id", "id"},
+			errMsgContains: "none of the others can be",
+		},
+		{
+			name:           "Project is required",
+			args:           []string{"--api", "dashboard", "--id", "id"},
+			errMsgContains: "the flag '--project' is required",
+		},
+		{
+			name:           "ID is required",
+			args:           []string{"--api", "dashboard", "--project", "project"},
+			errMsgContains: "the flag '--id' is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := configskeleton.Command(afero.NewMemMapFs())
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.ErrorContains(t, err, tt.errMsgContains)
+		})
+	}
+}
+
+func runGenerateConfig(fs afero.Fs, args ...string) error {
+	cmd := configskeleton.Command(fs)
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+const alertingProfileSchema = `{
+  "schemaId": "builtin:alerting.profile",
+  "version": "8.1",
+  "allowedScopes": ["environment"],
+  "properties": {
+    "name": {"type": "text", "nullable": false},
+    "managementZone": {"type": "text", "nullable": true},
+    "severityRules": {"type": "list", "nullable": false, "items": {"type": {"$ref": "#/types/SeverityRule"}}},
+    "eventFilters": {"type": "list", "nullable": false, "default": []},
+    "defaultRule": {"type": {"$ref": "#/types/SeverityRule"}, "nullable": false}
+  },
+  "types": {
+    "SeverityRule": {
+      "properties": {
+        "severityLevel": {"type": {"$ref": "#/enums/SeverityLevel"}, "nullable": false},
+        "delayInMinutes": {"type": "integer", "nullable": false, "default": 0},
+        "tagFilterIncludeMode": {"type": "text", "nullable": false, "precondition": {"type": "NOT_NULL"}}
+      }
+    }
+  },
+  "enums": {
+    "SeverityLevel": {"items": [{"value": "AVAILABILITY"}, {"value": "ERRORS"}]}
+  }
+}`
+
+func TestGenerateConfig_Schema(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/v2/settings/schemas/builtin:alerting.profile" {
+			_, _ = rw.Write([]byte(alertingProfileSchema))
+			return
+		}
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	t.Setenv("URL", server.URL)
+	t.Setenv("TOKEN", "some-value")
+	fs := testutils.CreateTestFileSystem()
+
+	err := runGenerateConfig(fs, "--manifest", "test-resources/manifest.yaml", "--schema", "builtin:alerting.profile", "--project", "project", "--id", "my-profile")
+	require.NoError(t, err)
+
+	config, err := afero.ReadFile(fs, "test-resources/project/builtinalerting.profile/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, `configs:
+  - id: my-profile
+    config:
+      name: my-profile
+      template: my-profile.json
+      skip: false
+    type:
+      settings:
+        schema: builtin:alerting.profile
+        scope: environment
+`, string(config))
+
+	template, err := afero.ReadFile(fs, "test-resources/project/builtinalerting.profile/my-profile.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "defaultRule": {
+    "delayInMinutes": 0,
+    "severityLevel": "AVAILABILITY"
+  },
+  "eventFilters": [],
+  "name": "{{ .name }}",
+  "severityRules": []
+}
+`, string(template))
+}
+
+func TestGenerateConfig_SchemaNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	t.Setenv("URL", server.URL)
+	t.Setenv("TOKEN", "some-value")
+	fs := testutils.CreateTestFileSystem()
+
+	err := runGenerateConfig(fs, "--manifest", "test-resources/manifest.yaml", "--schema", "builtin:unknown", "--project", "project", "--id", "my-profile")
+	assert.ErrorContains(t, err, `failed to fetch schema "builtin:unknown" from environment "env1"`)
+}
+
+func TestGenerateConfig_SchemaRequiresEnvironment(t *testing.T) {
+	t.Setenv("URL", "http://localhost")
+	t.Setenv("TOKEN", "some-value")
+	fs := testutils.CreateTestFileSystem()
+
+	err := runGenerateConfig(fs, "--manifest", "test-resources/manifest-two-environments.yaml", "--schema", "builtin:alerting.profile", "--project", "project", "--id", "my-profile")
+	assert.ErrorContains(t, err, "please select the environment")
+}
+
+func TestGenerateConfig_ClassicAPI(t *testing.T) {
+	fs := testutils.CreateTestFileSystem()
+
+	err := runGenerateConfig(fs, "--manifest", "test-resources/manifest.yaml", "--api", "dashboard", "--project", "project", "--id", "my-dashboard")
+	require.NoError(t, err)
+
+	config, err := afero.ReadFile(fs, "test-resources/project/dashboard/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, `configs:
+  - id: existing
+    config:
+      name: Existing dashboard
+      template: existing.json
+    type:
+      api: dashboard
+  - id: my-dashboard
+    config:
+      name: my-dashboard
+      template: my-dashboard.json
+      skip: false
+    type:
+      api: dashboard
+`, string(config))
+
+	template, err := afero.ReadFile(fs, "test-resources/project/dashboard/my-dashboard.json")
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"name\": \"{{ .name }}\"\n}\n", string(template))
+}
+
+func TestGenerateConfig_FailsForExistingConfig(t *testing.T) {
+	fs := testutils.CreateTestFileSystem()
+
+	err := runGenerateConfig(fs, "--manifest", "test-resources/manifest.yaml", "--api", "dashboard", "--project", "project", "--id", "existing")
+	assert.ErrorContains(t, err, `failed to add config "existing" to project "project"`)
+}
+
+func TestGenerateConfig_FailsForUnknownProjectOrAPI(t *testing.T) {
+	fs := testutils.CreateTestFileSystem()
+
+	err := runGenerateConfig(fs, "--manifest", "test-resources/manifest.yaml", "--api", "dashboard", "--project", "unknown", "--id", "id")
+	assert.ErrorContains(t, err, `requested project "unknown" not found in manifest`)
+
+	err = runGenerateConfig(fs, "--manifest", "test-resources/manifest.yaml", "--api", "unknown", "--project", "project", "--id", "id")
+	assert.ErrorContains(t, err, `unknown API "unknown"`)
+}
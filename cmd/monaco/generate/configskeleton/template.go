@@ -0,0 +1,114 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configskeleton
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"strings"
+)
+
+// classicTemplate is the template generated for classic configs. As there is no schema of classic APIs available, it
+// only references the name of the config.
+const classicTemplate = `{
+  "name": "{{ .name }}"
+}
+`
+
+// settingsTemplate returns a JSON template containing the required properties of the given schema. Properties are set
+// to their default value, if the schema defines one, or to an empty value of their type otherwise. A 'name' property
+// references the name parameter of the config.
+func settingsTemplate(schema dtclient.Schema) (string, error) {
+	s := skeletonBuilder{schema: schema, visitedTypes: map[string]bool{}}
+	properties := s.properties(schema.Properties)
+
+	if p, found := schema.Properties[nameProperty]; found && p.Type.Name == "text" {
+		properties[nameProperty] = "{{ .name }}"
+	}
+
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(properties); err != nil {
+		return "", fmt.Errorf("failed to create template of schema %q: %w", schema.SchemaId, err)
+	}
+	return b.String(), nil
+}
+
+const nameProperty = "name"
+
+type skeletonBuilder struct {
+	schema dtclient.Schema
+	// visitedTypes holds the types currently being built, to stop on recursive types
+	visitedTypes map[string]bool
+}
+
+// properties returns the required properties of the given ones. Properties are required unless they are nullable or
+// depend on a precondition.
+func (s skeletonBuilder) properties(properties map[string]dtclient.SchemaProperty) map[string]any {
+	result := make(map[string]any)
+	for name, p := range properties {
+		if p.Nullable || p.Precondition != nil {
+			continue
+		}
+		result[name] = s.value(p)
+	}
+	return result
+}
+
+func (s skeletonBuilder) value(p dtclient.SchemaProperty) any {
+	if p.Default != nil {
+		return p.Default
+	}
+
+	if p.Type.Ref != "" {
+		return s.refValue(p.Type.Ref)
+	}
+
+	switch p.Type.Name {
+	case "boolean":
+		return false
+	case "integer", "float":
+		return 0
+	case "list", "set":
+		return []any{}
+	default:
+		return ""
+	}
+}
+
+func (s skeletonBuilder) refValue(ref string) any {
+	if name, found := strings.CutPrefix(ref, "#/enums/"); found {
+		if e, found := s.schema.Enums[name]; found && len(e.Items) > 0 {
+			return e.Items[0].Value
+		}
+		return ""
+	}
+
+	name, _ := strings.CutPrefix(ref, "#/types/")
+	t, found := s.schema.Types[name]
+	if !found || s.visitedTypes[name] {
+		return map[string]any{}
+	}
+
+	s.visitedTypes[name] = true
+	defer delete(s.visitedTypes, name)
+	return s.properties(t.Properties)
+}
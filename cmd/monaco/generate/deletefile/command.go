@@ -22,19 +22,20 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2/selection"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
 func Command(fs afero.Fs) (cmd *cobra.Command) {
 
-	var fileName, outputFolder string
-	var projects []string
+	var fileName, outputFolder, since, compare string
+	var projects, labels []string
 
 	cmd = &cobra.Command{
 		Use:               "deletefile <manifest.yaml>",
 		Short:             "Generate a delete file for all configurations defined in the given manifest's projects",
-		Example:           "monaco generate deletefile manifest.yaml -o deletefiles --file my-projects-delete-file.yaml",
+		Example:           "monaco generate deletefile manifest.yaml -o deletefiles --file my-projects-delete-file.yaml\nmonaco generate deletefile manifest.yaml --since main",
 		Args:              cobra.ExactArgs(1),
 		PreRun:            cmdutils.SilenceUsageCommand(),
 		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
@@ -47,7 +48,11 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 				return err
 			}
 
-			return createDeleteFile(fs, manifestName, projects, fileName, outputFolder)
+			if since != "" || compare != "" {
+				return createDiffDeleteFile(fs, manifestName, previousState{gitRef: since, compareDir: compare}, projects, selection.Selector{Labels: labels}, fileName, outputFolder)
+			}
+
+			return createDeleteFile(fs, manifestName, projects, selection.Selector{Labels: labels}, fileName, outputFolder)
 		},
 	}
 
@@ -55,6 +60,15 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&fileName, "file", "", "delete.yaml", "The name of the generated delete file. If a file of this name already exists, a timestamp will be appended.")
 
 	cmd.Flags().StringSliceVarP(&projects, "project", "p", nil, "Projects to generate delete file entries for. If not defined, all projects in the manifest will be used.")
+	cmd.Flags().StringSliceVar(&labels, "label", nil, "Only generate delete file entries for configurations with the given label(s) in the form 'key=value', or 'key' for any value.")
+
+	cmd.Flags().StringVar(&since, "since", "",
+		"Only generate delete file entries for configurations which were removed, or whose identity changed, since the given git revision of the repository containing the manifest. "+
+			"A configuration's identity changes if its config ID, its schema, or for classic configurations its name changes.")
+	cmd.Flags().StringVar(&compare, "compare", "",
+		"Only generate delete file entries for configurations which were removed, or whose identity changed, compared to the given directory containing the previous state of the manifest's directory. "+
+			"The previous manifest is expected to have the same file name.")
+	cmd.MarkFlagsMutuallyExclusive("since", "compare")
 
 	if err := cmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest); err != nil {
 		log.Fatal("failed to setup CLI %v", err)
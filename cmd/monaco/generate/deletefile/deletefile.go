@@ -28,13 +28,35 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/persistence"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2/selection"
 	"github.com/spf13/afero"
 	"gopkg.in/yaml.v2"
 	"path/filepath"
 )
 
-func createDeleteFile(fs afero.Fs, manifestPath string, projectNames []string, filename, outputFolder string) error {
+func createDeleteFile(fs afero.Fs, manifestPath string, projectNames []string, selector selection.Selector, filename, outputFolder string) error {
+	if err := selector.Validate(); err != nil {
+		return err
+	}
+
+	apis := api.NewAPIs()
+	env, projects, err := loadProjects(fs, manifestPath, projectNames, apis)
+	if err != nil {
+		return err
+	}
 
+	content, err := generateDeleteFileContent(env, projects, selector, apis)
+	if err != nil {
+		log.WithFields(field.Error(err)).Error("Failed to generate delete file content: %v", err)
+		return err
+	}
+
+	return writeDeleteFile(fs, content, filename, outputFolder)
+}
+
+// loadProjects loads the manifest at the given path and the given projects defined in it. All projects are loaded if
+// no project names are given. The name of the environment the returned configs are loaded for is returned as well.
+func loadProjects(fs afero.Fs, manifestPath string, projectNames []string, apis api.APIs) (string, []project.Project, error) {
 	m, errs := manifest.LoadManifest(&manifest.LoaderContext{
 		Fs:           fs,
 		ManifestPath: manifestPath,
@@ -44,10 +66,9 @@ func createDeleteFile(fs afero.Fs, manifestPath string, projectNames []string, f
 	})
 	if len(errs) > 0 {
 		errutils.PrintErrors(errs)
-		return fmt.Errorf("failed to load manifest %q", manifestPath)
+		return "", nil, fmt.Errorf("failed to load manifest %q", manifestPath)
 	}
 
-	apis := api.NewAPIs()
 	projects, errs := project.LoadProjects(fs, project.ProjectLoaderContext{
 		KnownApis:       apis.GetApiNameLookup(),
 		WorkingDir:      filepath.Dir(manifestPath),
@@ -57,23 +78,21 @@ func createDeleteFile(fs afero.Fs, manifestPath string, projectNames []string, f
 
 	if len(errs) > 0 {
 		errutils.PrintErrors(errs)
-		return fmt.Errorf("failed to load projects")
+		return "", nil, fmt.Errorf("failed to load projects")
 	}
 
 	projects, err := filterProjects(projects, projectNames)
 
 	if err != nil {
 		log.WithFields(field.Error(err)).Error("Failed to filter requested projects: %v", err)
-		return err
+		return "", nil, err
 	}
 
 	env := m.Environments.Names()[0] // take the first environment, as overwrites do not impact the configs that exist (as skipped configs are still loaded)
-	content, err := generateDeleteFileContent(env, projects, apis)
-	if err != nil {
-		log.WithFields(field.Error(err)).Error("Failed to generate delete file content: %v", err)
-		return err
-	}
+	return env, projects, nil
+}
 
+func writeDeleteFile(fs afero.Fs, content []byte, filename, outputFolder string) error {
 	folderPath, err := filepath.Abs(outputFolder)
 	if err != nil {
 		return fmt.Errorf("failed to access output path: %q: %w", outputFolder, err)
@@ -132,7 +151,7 @@ func filterProjects(projects []project.Project, projectsToUse []string) ([]proje
 	return filteredProjects, nil
 }
 
-func generateDeleteFileContent(environment string, projects []project.Project, apis api.APIs) ([]byte, error) {
+func generateDeleteFileContent(environment string, projects []project.Project, selector selection.Selector, apis api.APIs) ([]byte, error) {
 
 	log.Info("Generating delete file...")
 
@@ -143,33 +162,56 @@ func generateDeleteFileContent(environment string, projects []project.Project, a
 		cfgsPerType := p.Configs[environment]
 		for _, cfgs := range cfgsPerType {
 			for _, c := range cfgs {
-				if apis.Contains(c.Coordinate.Type) {
-					val, err := c.Parameters[config.NameParameter].ResolveValue(parameter.ResolveContext{ParameterName: config.NameParameter})
-					if err != nil {
-						log.WithFields(field.Error(err)).Warn("Failed to automatically create delete entry for %q - unable to get name: %v", c.Coordinate, err)
-						continue
-					}
-					name, ok := val.(string)
-					if !ok {
-						log.Warn("Failed to automatically create delete entry for %q - value of 'name' parameter '%v' was not a string", c.Coordinate, val)
-						continue
-					}
-
-					entries = append(entries, persistence.DeleteEntry{
-						Type:       c.Coordinate.Type,
-						ConfigName: name,
-					})
-				} else {
-					entries = append(entries, persistence.DeleteEntry{
-						Project:  c.Coordinate.Project,
-						Type:     c.Coordinate.Type,
-						ConfigId: c.Coordinate.ConfigId,
-					})
+				if !selector.Matches(c) {
+					continue
+				}
+
+				if entry, ok := deleteEntry(c, apis); ok {
+					entries = append(entries, entry)
 				}
 			}
 		}
 	}
 
+	return marshalDeleteFile(entries)
+}
+
+// deleteEntry returns the delete entry identifying the given config. Classic configs are identified by their name,
+// all others by their coordinate.
+func deleteEntry(c config.Config, apis api.APIs) (persistence.DeleteEntry, bool) {
+	if !apis.Contains(c.Coordinate.Type) {
+		return persistence.DeleteEntry{
+			Project:  c.Coordinate.Project,
+			Type:     c.Coordinate.Type,
+			ConfigId: c.Coordinate.ConfigId,
+		}, true
+	}
+
+	name, err := configName(c)
+	if err != nil {
+		log.WithFields(field.Error(err)).Warn("Failed to automatically create delete entry for %q - %v", c.Coordinate, err)
+		return persistence.DeleteEntry{}, false
+	}
+
+	return persistence.DeleteEntry{
+		Type:       c.Coordinate.Type,
+		ConfigName: name,
+	}, true
+}
+
+func configName(c config.Config) (string, error) {
+	val, err := c.Parameters[config.NameParameter].ResolveValue(parameter.ResolveContext{ParameterName: config.NameParameter})
+	if err != nil {
+		return "", fmt.Errorf("unable to get name: %w", err)
+	}
+	name, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("value of 'name' parameter '%v' was not a string", val)
+	}
+	return name, nil
+}
+
+func marshalDeleteFile(entries []persistence.DeleteEntry) ([]byte, error) {
 	f := persistence.FullFileDefinition{DeleteEntries: entries}
 	b, err := yaml.Marshal(&f)
 	if err != nil {
@@ -27,6 +27,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"os/exec"
 	"path/filepath"
 	"testing"
 )
@@ -125,6 +126,36 @@ func TestGeneratesValidDeleteFile_ForSingleProject(t *testing.T) {
 	assertDeleteEntries(t, entries, "alerting-profile", "Lord of the Rings Service", "A Song of Ice and Fire Service")
 }
 
+func TestGeneratesValidDeleteFile_ForLabel(t *testing.T) {
+
+	t.Setenv("TOKEN", "some-value")
+
+	fs := testutils.CreateTestFileSystem()
+
+	outputFolder := "output-folder"
+
+	cmd := deletefile.Command(fs)
+
+	cmd.SetArgs([]string{
+		"./test-resources/manifest.yaml",
+		"--label",
+		"genre=sci-fi",
+		"-o",
+		outputFolder,
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+
+	expectedFile := filepath.Join(outputFolder, "delete.yaml")
+	assertFileExists(t, fs, expectedFile)
+
+	entries, errs := delete.LoadEntriesToDelete(fs, api.NewAPIs().GetNames(), expectedFile)
+	assert.Len(t, errs, 0)
+
+	assert.Len(t, entries, 1, "expected only entries of labeled configs")
+	assertDeleteEntries(t, entries, "alerting-profile", "Star Trek Service", "Star Gate Service")
+}
+
 func assertDeleteEntries(t *testing.T, entries map[string][]delete.DeletePointer, cfgType string, expectedCfgIdentifiers ...string) {
 	vals, ok := entries[cfgType]
 	assert.True(t, ok, "expected delete pointers for type %s", cfgType)
@@ -194,3 +225,167 @@ func assertFileExists(t *testing.T, fs afero.Fs, file string) {
 	assert.NoError(t, err)
 	assert.True(t, exists)
 }
+
+const diffManifest = `manifestVersion: 1.0
+projects:
+- name: project
+environmentGroups:
+- name: default
+  environments:
+  - name: env
+    url:
+      value: http://www.url.com
+    auth:
+      token:
+        name: TOKEN
+`
+
+const previousDiffConfigs = `configs:
+- id: kept
+  config:
+    name: Kept Profile
+    template: t.json
+  type:
+    api: alerting-profile
+- id: renamed
+  config:
+    name: Old Name
+    template: t.json
+  type:
+    api: alerting-profile
+- id: removed
+  config:
+    name: Removed Profile
+    template: t.json
+  type:
+    api: alerting-profile
+- id: setting
+  config:
+    template: t.json
+  type:
+    settings:
+      schema: builtin:old-schema
+      scope: environment
+- id: old-id
+  config:
+    template: t.json
+  type:
+    settings:
+      schema: builtin:kept-schema
+      scope: environment
+`
+
+const currentDiffConfigs = `configs:
+- id: kept
+  config:
+    name: Kept Profile
+    template: t.json
+  type:
+    api: alerting-profile
+- id: renamed
+  config:
+    name: New Name
+    template: t.json
+  type:
+    api: alerting-profile
+- id: setting
+  config:
+    template: t.json
+  type:
+    settings:
+      schema: builtin:new-schema
+      scope: environment
+- id: new-id
+  config:
+    template: t.json
+  type:
+    settings:
+      schema: builtin:kept-schema
+      scope: environment
+`
+
+func writeDiffState(t *testing.T, fs afero.Fs, dir, configs string) {
+	assert.NoError(t, fs.MkdirAll(filepath.Join(dir, "project", "configs"), 0777))
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "manifest.yaml"), []byte(diffManifest), 0666))
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "project", "configs", "config.yaml"), []byte(configs), 0666))
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "project", "configs", "t.json"), []byte("{}"), 0666))
+}
+
+func assertDiffDeleteEntries(t *testing.T, fs afero.Fs, file string) {
+	entries, errs := delete.LoadEntriesToDelete(fs, api.NewAPIs().GetNames(), file)
+	assert.Len(t, errs, 0)
+
+	assert.Len(t, entries, 3)
+	assertDeleteEntries(t, entries, "alerting-profile", "Old Name", "Removed Profile")
+	assertDeleteEntries(t, entries, "builtin:old-schema", "setting")
+	assertDeleteEntries(t, entries, "builtin:kept-schema", "old-id")
+}
+
+func TestGeneratesDeleteFile_ComparedToDirectory(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeDiffState(t, fs, "previous", previousDiffConfigs)
+	writeDiffState(t, fs, "current", currentDiffConfigs)
+
+	cmd := deletefile.Command(fs)
+	cmd.SetArgs([]string{
+		"current/manifest.yaml",
+		"--compare",
+		"previous",
+		"-o",
+		"output-folder",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+
+	expectedFile := filepath.Join("output-folder", "delete.yaml")
+	assertFileExists(t, fs, expectedFile)
+	assertDiffDeleteEntries(t, fs, expectedFile)
+}
+
+func TestGeneratesDeleteFile_SinceGitRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	repo := t.TempDir()
+	runGit := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(cmd.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+
+	fs := afero.NewOsFs()
+	monacoDir := filepath.Join(repo, "monaco")
+	writeDiffState(t, fs, monacoDir, previousDiffConfigs)
+	runGit("init", "-q")
+	runGit("add", "-A")
+	runGit("commit", "-q", "-m", "previous")
+	writeDiffState(t, fs, monacoDir, currentDiffConfigs)
+
+	outputFolder := filepath.Join(repo, "output-folder")
+	cmd := deletefile.Command(fs)
+	cmd.SetArgs([]string{
+		filepath.Join(monacoDir, "manifest.yaml"),
+		"--since",
+		"HEAD",
+		"-o",
+		outputFolder,
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+
+	expectedFile := filepath.Join(outputFolder, "delete.yaml")
+	assertFileExists(t, fs, expectedFile)
+	assertDiffDeleteEntries(t, fs, expectedFile)
+}
+
+func TestGenerateDeleteFile_SinceAndCompareAreExclusive(t *testing.T) {
+	cmd := deletefile.Command(afero.NewMemMapFs())
+	cmd.SetArgs([]string{"manifest.yaml", "--since", "main", "--compare", "previous"})
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "none of the others can be")
+}
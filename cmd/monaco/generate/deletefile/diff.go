@@ -0,0 +1,220 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deletefile
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/persistence"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2/selection"
+	"github.com/spf13/afero"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// previousState defines where the previous state of the manifest and its projects is loaded from. Exactly one of the
+// fields is set.
+type previousState struct {
+	// gitRef is a git revision of the repository containing the manifest
+	gitRef string
+	// compareDir is a directory containing the previous state of the manifest's directory
+	compareDir string
+}
+
+// createDiffDeleteFile generates a delete file for the configurations which were removed, or whose identity changed,
+// since the previous state.
+func createDiffDeleteFile(fs afero.Fs, manifestPath string, previous previousState, projectNames []string, selector selection.Selector, filename, outputFolder string) error {
+	if err := selector.Validate(); err != nil {
+		return err
+	}
+
+	apis := api.NewAPIs()
+	env, projects, err := loadProjects(fs, manifestPath, projectNames, apis)
+	if err != nil {
+		return err
+	}
+
+	previousFs, previousManifestPath, err := loadPreviousState(fs, manifestPath, previous)
+	if err != nil {
+		return err
+	}
+
+	previousEnv, previousProjects, err := loadProjects(previousFs, previousManifestPath, nil, apis)
+	if err != nil {
+		return fmt.Errorf("failed to load previous state: %w", err)
+	}
+	previousProjects = retainProjects(previousProjects, projectNames)
+
+	content, err := generateDiffDeleteFileContent(previousEnv, previousProjects, env, projects, selector, apis)
+	if err != nil {
+		log.WithFields(field.Error(err)).Error("Failed to generate delete file content: %v", err)
+		return err
+	}
+
+	return writeDeleteFile(fs, content, filename, outputFolder)
+}
+
+// loadPreviousState returns the file system containing the previous state, and the path of the manifest in it
+func loadPreviousState(fs afero.Fs, manifestPath string, previous previousState) (afero.Fs, string, error) {
+	if previous.compareDir != "" {
+		return fs, filepath.Join(previous.compareDir, filepath.Base(manifestPath)), nil
+	}
+
+	absManifestPath, err := filepath.Abs(manifestPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to access manifest path %q: %w", manifestPath, err)
+	}
+
+	repoRoot, err := git(filepath.Dir(absManifestPath), "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, "", fmt.Errorf("manifest %q is not part of a git repository: %w", manifestPath, err)
+	}
+	root := strings.TrimSpace(string(repoRoot))
+
+	archive, err := git(root, "archive", "--format=tar", previous.gitRef)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read git revision %q: %w", previous.gitRef, err)
+	}
+
+	revisionFs := afero.NewMemMapFs()
+	if err := extractTar(revisionFs, root, bytes.NewReader(archive)); err != nil {
+		return nil, "", fmt.Errorf("failed to read git revision %q: %w", previous.gitRef, err)
+	}
+
+	log.Debug("Loaded git revision %q of repository %q", previous.gitRef, root)
+	return revisionFs, absManifestPath, nil
+}
+
+func git(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// extractTar writes all files of the given tar archive into the given directory of the file system
+func extractTar(fs afero.Fs, dir string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if err := fs.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			return err
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if err := afero.WriteFile(fs, path, data, 0666); err != nil {
+			return err
+		}
+	}
+}
+
+// retainProjects returns the given projects with one of the given names. All projects are returned if no names are
+// given. Unlike filterProjects, it's no error if none of the projects exist, as they may be new.
+func retainProjects(projects []project.Project, names []string) []project.Project {
+	if len(names) == 0 {
+		return projects
+	}
+
+	var result []project.Project
+	for _, p := range projects {
+		for _, n := range names {
+			if p.Id == n {
+				result = append(result, p)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// generateDiffDeleteFileContent generates delete entries for all configs of the previous projects whose delete entry
+// does not exist for the current projects anymore. This is the case if a config was removed, or if its identity
+// changed, e.g. its config ID, its name for classic APIs, or its schema.
+func generateDiffDeleteFileContent(previousEnvironment string, previousProjects []project.Project, environment string, projects []project.Project, selector selection.Selector, apis api.APIs) ([]byte, error) {
+
+	log.Info("Generating delete file for removed configurations...")
+
+	current := make(map[persistence.DeleteEntry]struct{})
+	for _, p := range projects {
+		for _, cfgs := range p.Configs[environment] {
+			for _, c := range cfgs {
+				if entry, ok := deleteEntry(c, apis); ok {
+					current[entry] = struct{}{}
+				}
+			}
+		}
+	}
+
+	var entries []persistence.DeleteEntry
+	added := make(map[persistence.DeleteEntry]struct{})
+	for _, p := range previousProjects {
+		for _, cfgs := range p.Configs[previousEnvironment] {
+			for _, c := range cfgs {
+				if !selector.Matches(c) {
+					continue
+				}
+
+				entry, ok := deleteEntry(c, apis)
+				if !ok {
+					continue
+				}
+				if _, exists := current[entry]; exists {
+					continue
+				}
+				if _, exists := added[entry]; exists {
+					continue
+				}
+
+				log.WithFields(field.Coordinate(c.Coordinate)).Debug("Adding delete entry for removed config %s", c.Coordinate)
+				added[entry] = struct{}{}
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	log.Info("Found %d removed configurations", len(entries))
+	return marshalDeleteFile(entries)
+}
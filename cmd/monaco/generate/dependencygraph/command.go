@@ -23,13 +23,14 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2/selection"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
 func Command(fs afero.Fs) (cmd *cobra.Command) {
 
-	var environments, groups []string
+	var environments, groups, labels []string
 	var outputFolder string
 
 	cmd = &cobra.Command{
@@ -48,7 +49,7 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 				return err
 			}
 
-			err := writeGraphFiles(fs, manifestName, environments, groups, outputFolder)
+			err := writeGraphFiles(fs, manifestName, environments, groups, selection.Selector{Labels: labels}, outputFolder)
 			if err != nil {
 				log.WithFields(field.Error(err), field.F("manifestFile", manifestName), field.F("outputFolder", outputFolder)).Error("Failed to create dependency graph files: %v", err)
 			}
@@ -69,6 +70,9 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 			"If this flag is specified, a dependency graph will be generated for each specified environment. "+
 			"If neither --groups nor --environment is present, all environments are used.")
 
+	cmd.Flags().StringSliceVar(&labels, "label", []string{},
+		"Only include configurations with the given label(s) in the form 'key=value', or 'key' for any value, and the configurations they depend on. "+
+			"To set multiple labels either repeat this flag, or separate them using a comma (,).")
 	cmd.Flags().StringVarP(&outputFolder, "output-folder", "o", "", "The folder generated dependency graph DOT files should be written to. If not set, files will be created in the current directory.")
 
 	if err := cmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByArg0); err != nil {
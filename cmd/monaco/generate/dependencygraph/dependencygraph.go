@@ -28,6 +28,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2/selection"
 	"github.com/spf13/afero"
 	"path/filepath"
 )
@@ -49,7 +50,7 @@ func (e ExportError) Error() string {
 	return fmt.Sprintf("%s: %v", e.message, e.Reason)
 }
 
-func writeGraphFiles(fs afero.Fs, manifestPath string, environmentNames []string, environmentGroups []string, outputFolder string) error {
+func writeGraphFiles(fs afero.Fs, manifestPath string, environmentNames []string, environmentGroups []string, selector selection.Selector, outputFolder string) error {
 
 	m, errs := manifest.LoadManifest(&manifest.LoaderContext{
 		Fs:           fs,
@@ -85,6 +86,18 @@ func writeGraphFiles(fs afero.Fs, manifestPath string, environmentNames []string
 		}
 	}
 
+	if !selector.IsEmpty() {
+		selected, _, err := selection.Select(projects, m.Environments.Names(), selector, true)
+		if err != nil {
+			return ExportError{
+				ManifestFile: manifestPath,
+				message:      "failed to select configurations",
+				Reason:       err,
+			}
+		}
+		projects = selected
+	}
+
 	graphs := graph.New(projects, m.Environments.Names())
 
 	folderPath, err := filepath.Abs(outputFolder)
@@ -0,0 +1,69 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package initialize
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var opts options
+
+	cmd = &cobra.Command{
+		Use:   "init",
+		Short: "Create a new manifest with the given environments and projects",
+		Long: "Create a new manifest with the given environments and projects, and create the folders of the projects. " +
+			"Environments are defined as 'name=url'. Their access token is read from the environment variable '<NAME>_TOKEN', " +
+			"and if '--oauth' is set, their OAuth client credentials from '<NAME>_CLIENT_ID' and '<NAME>_CLIENT_SECRET'.",
+		Example: "monaco init --environment dev=https://abc12345.live.dynatrace.com --environment prod=https://xyz98765.live.dynatrace.com --project infrastructure",
+		Args:    cobra.NoArgs,
+		PreRun:  cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !files.IsYamlFileExtension(opts.manifestPath) {
+				return fmt.Errorf("wrong format for manifest file! Expected a .yaml file, but got %s", opts.manifestPath)
+			}
+
+			if len(opts.environments) == 0 {
+				return fmt.Errorf("at least one environment is required")
+			}
+
+			if len(opts.projects) == 0 {
+				return fmt.Errorf("at least one project is required")
+			}
+
+			return initialize(fs, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.manifestPath, "manifest", "manifest.yaml", "The manifest file to create")
+	cmd.Flags().StringArrayVarP(&opts.environments, "environment", "e", nil, "Environment to add to the manifest in the form 'name=url'. Can be repeated.")
+	cmd.Flags().StringSliceVarP(&opts.projects, "project", "p", []string{"project"}, "Projects to add to the manifest")
+	cmd.Flags().StringVar(&opts.group, "group", "default", "The environment group of the environments")
+	cmd.Flags().BoolVar(&opts.oauth, "oauth", false, "Use OAuth client credentials in addition to access tokens, as required for platform environments")
+
+	if err := cmd.RegisterFlagCompletionFunc("manifest", completion.YamlFile); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	return cmd
+}
@@ -0,0 +1,118 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package initialize
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/spf13/afero"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type options struct {
+	manifestPath string
+	// environments are the environments of the manifest in the form 'name=url'
+	environments []string
+	projects     []string
+	group        string
+	oauth        bool
+}
+
+func initialize(fs afero.Fs, opts options) error {
+	if exists, err := afero.Exists(fs, opts.manifestPath); err != nil {
+		return err
+	} else if exists {
+		return fmt.Errorf("manifest %q already exists", opts.manifestPath)
+	}
+
+	environments, err := parseEnvironments(opts.environments, opts.group, opts.oauth)
+	if err != nil {
+		return err
+	}
+
+	projects := make(manifest.ProjectDefinitionByProjectID, len(opts.projects))
+	for _, p := range opts.projects {
+		projects[p] = manifest.ProjectDefinition{Name: p, Path: p}
+	}
+
+	err = manifest.WriteManifest(&manifest.WriterContext{Fs: fs, ManifestPath: opts.manifestPath}, manifest.Manifest{
+		Projects:     projects,
+		Environments: environments,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write manifest %q: %w", opts.manifestPath, err)
+	}
+
+	for _, p := range opts.projects {
+		if err := fs.MkdirAll(filepath.Join(filepath.Dir(opts.manifestPath), p), 0777); err != nil {
+			return fmt.Errorf("failed to create folder of project %q: %w", p, err)
+		}
+	}
+
+	log.Info("Created manifest %q with %d environment(s) and %d project(s)", opts.manifestPath, len(environments), len(projects))
+	for _, env := range environments {
+		log.Info("Environment %q reads its access token from the environment variable %q", env.Name, env.Auth.Token.Name)
+	}
+	return nil
+}
+
+func parseEnvironments(definitions []string, group string, oauth bool) (manifest.Environments, error) {
+	environments := make(manifest.Environments, len(definitions))
+
+	for _, d := range definitions {
+		name, url, found := strings.Cut(d, "=")
+		if !found || name == "" || url == "" {
+			return nil, fmt.Errorf("invalid environment %q, expected the form 'name=url'", d)
+		}
+		if _, exists := environments[name]; exists {
+			return nil, fmt.Errorf("environment %q is defined more than once", name)
+		}
+
+		env := manifest.EnvironmentDefinition{
+			Name:  name,
+			Group: group,
+			URL: manifest.URLDefinition{
+				Type:  manifest.ValueURLType,
+				Value: strings.TrimSuffix(url, "/"),
+			},
+			Auth: manifest.Auth{
+				Token: manifest.AuthSecret{Name: envVarName(name, "TOKEN")},
+			},
+		}
+		if oauth {
+			env.Auth.OAuth = &manifest.OAuth{
+				ClientID:     manifest.AuthSecret{Name: envVarName(name, "CLIENT_ID")},
+				ClientSecret: manifest.AuthSecret{Name: envVarName(name, "CLIENT_SECRET")},
+			}
+		}
+
+		environments[name] = env
+	}
+
+	return environments, nil
+}
+
+var invalidEnvVarChars = regexp.MustCompile("[^A-Z0-9_]")
+
+// envVarName returns the name of the environment variable holding the given secret of an environment, e.g.
+// 'MY_ENV_TOKEN' for the token of the environment 'my-env'.
+func envVarName(environment, secret string) string {
+	return invalidEnvVarChars.ReplaceAllString(strings.ToUpper(environment), "_") + "_" + secret
+}
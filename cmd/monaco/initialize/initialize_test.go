@@ -0,0 +1,131 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package initialize_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/initialize"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInvalidCommandUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		errMsgContains string
+	}{
+		{
+			name:           "Manifest must be a YAML file",
+			args:           []string{"--manifest", "manifest.json", "--environment", "dev=https://dev.example.com"},
+			errMsgContains: "Expected a .yaml file",
+		},
+		{
+			name:           "Environment is required",
+			args:           []string{},
+			errMsgContains: "at least one environment is required",
+		},
+		{
+			name:           "Environment needs name and URL",
+			args:           []string{"--environment", "https://dev.example.com"},
+			errMsgContains: "expected the form 'name=url'",
+		},
+		{
+			name:           "Environments must be unique",
+			args:           []string{"--environment", "dev=https://dev.example.com", "--environment", "dev=https://other.example.com"},
+			errMsgContains: `environment "dev" is defined more than once`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := initialize.Command(afero.NewMemMapFs())
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.ErrorContains(t, err, tt.errMsgContains)
+		})
+	}
+}
+
+func runInit(fs afero.Fs, args ...string) error {
+	cmd := initialize.Command(fs)
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+func TestInit(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	err := runInit(fs, "--manifest", "monaco/manifest.yaml", "-e", "dev=https://dev.example.com/", "-e", "prod-eu=https://prod.example.com", "-p", "infrastructure,apps")
+	require.NoError(t, err)
+
+	t.Setenv("DEV_TOKEN", "dev-token")
+	t.Setenv("PROD_EU_TOKEN", "prod-token")
+	m, errs := manifest.LoadManifest(&manifest.LoaderContext{Fs: fs, ManifestPath: "monaco/manifest.yaml"})
+	require.Empty(t, errs)
+
+	assert.Equal(t, manifest.ProjectDefinitionByProjectID{
+		"infrastructure": {Name: "infrastructure", Path: "infrastructure"},
+		"apps":           {Name: "apps", Path: "apps"},
+	}, m.Projects)
+
+	require.Len(t, m.Environments, 2)
+	assert.Equal(t, "https://dev.example.com", m.Environments["dev"].URL.Value)
+	assert.Equal(t, "default", m.Environments["dev"].Group)
+	assert.Equal(t, manifest.AuthSecret{Name: "DEV_TOKEN", Value: "dev-token"}, m.Environments["dev"].Auth.Token)
+	assert.Equal(t, "https://prod.example.com", m.Environments["prod-eu"].URL.Value)
+	assert.Equal(t, manifest.AuthSecret{Name: "PROD_EU_TOKEN", Value: "prod-token"}, m.Environments["prod-eu"].Auth.Token)
+	assert.Nil(t, m.Environments["dev"].Auth.OAuth)
+
+	for _, p := range []string{"monaco/infrastructure", "monaco/apps"} {
+		exists, err := afero.DirExists(fs, p)
+		require.NoError(t, err)
+		assert.True(t, exists, "project folder %q does not exist", p)
+	}
+}
+
+func TestInit_OAuth(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	err := runInit(fs, "-e", "dev=https://abc.apps.dynatrace.com", "--oauth")
+	require.NoError(t, err)
+
+	m, errs := manifest.LoadManifest(&manifest.LoaderContext{Fs: fs, ManifestPath: "manifest.yaml", Opts: manifest.LoaderOptions{DontResolveEnvVars: true}})
+	require.Empty(t, errs)
+
+	require.NotNil(t, m.Environments["dev"].Auth.OAuth)
+	assert.Equal(t, "DEV_CLIENT_ID", m.Environments["dev"].Auth.OAuth.ClientID.Name)
+	assert.Equal(t, "DEV_CLIENT_SECRET", m.Environments["dev"].Auth.OAuth.ClientSecret.Name)
+	assert.Contains(t, m.Projects, "project")
+}
+
+func TestInit_FailsForExistingManifest(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte("existing"), 0644))
+
+	err := runInit(fs, "-e", "dev=https://dev.example.com")
+	assert.ErrorContains(t, err, `manifest "manifest.yaml" already exists`)
+
+	content, err := afero.ReadFile(fs, "manifest.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "existing", string(content))
+}
@@ -0,0 +1,95 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/lint"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"strings"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var configFile, format string
+	var projects []string
+
+	cmd = &cobra.Command{
+		Use:   "lint <manifest.yaml>",
+		Short: "Check projects for common mistakes",
+		Long: "Check the projects of a manifest for common mistakes, like unused parameters or plaintext secrets. " +
+			"Rules can be disabled or their severity changed in a lint configuration file - by default '" + lint.DefaultConfigFileName + "' next to the manifest is used if it exists. " +
+			"Findings are reported as text, JSON or SARIF. The command fails if any finding has severity 'error'.\n\n" +
+			"Rules:\n" + describeRules(),
+		Example:           "monaco lint manifest.yaml --output-format sarif > lint.sarif",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				err := fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
+				return err
+			}
+
+			if _, found := reporters[format]; !found {
+				return fmt.Errorf("unknown output format %q, supported formats are %s", format, strings.Join(formats(), ", "))
+			}
+
+			return lintProjects(fs, manifestName, options{
+				configFile: configFile,
+				format:     format,
+				projects:   projects,
+				out:        cmd.OutOrStdout(),
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&configFile, "config", "", "Lint configuration file enabling or disabling rules and changing their severity. Defaults to '"+lint.DefaultConfigFileName+"' next to the manifest, if it exists.")
+	cmd.Flags().StringVar(&format, "output-format", formatText, fmt.Sprintf("Format findings are reported in. Supported formats: %s", strings.Join(formats(), ", ")))
+	cmd.Flags().StringSliceVarP(&projects, "project", "p", nil, "Projects to lint. If not defined, all projects in the manifest are linted.")
+
+	if err := cmd.RegisterFlagCompletionFunc("output-format", cobra.FixedCompletions(formats(), cobra.ShellCompDirectiveNoFileComp)); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+	if err := cmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	return cmd
+}
+
+func describeRules() string {
+	b := strings.Builder{}
+	for _, r := range lint.Rules {
+		b.WriteString(fmt.Sprintf("  %s (%s): %s\n", r.ID, r.DefaultSeverity, r.Description))
+	}
+	return b.String()
+}
+
+func formats() []string {
+	f := maps.Keys(reporters)
+	slices.Sort(f)
+	return f
+}
@@ -0,0 +1,117 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/lint"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/spf13/afero"
+	"io"
+	"path/filepath"
+)
+
+type options struct {
+	// configFile is the lint configuration to use. If empty, the default configuration file next to the manifest is
+	// used if it exists.
+	configFile string
+	format     string
+	projects   []string
+	out        io.Writer
+}
+
+func lintProjects(fs afero.Fs, manifestPath string, opts options) error {
+	cfg, err := loadConfig(fs, manifestPath, opts.configFile)
+	if err != nil {
+		return err
+	}
+
+	m, errs := manifest.LoadManifest(&manifest.LoaderContext{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Opts: manifest.LoaderOptions{
+			DontResolveEnvVars: true,
+		},
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load manifest %q", manifestPath)
+	}
+
+	for _, p := range opts.projects {
+		if _, found := m.Projects[p]; !found {
+			return fmt.Errorf("requested project %q not found in manifest", p)
+		}
+	}
+
+	apis := api.NewAPIs()
+	workingDir := filepath.Dir(manifestPath)
+	projects, errs := project.LoadProjects(fs, project.ProjectLoaderContext{
+		KnownApis:       apis.GetApiNameLookup(),
+		WorkingDir:      workingDir,
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load projects")
+	}
+
+	findings, err := lint.Lint(lint.Input{
+		Fs:             fs,
+		WorkingDir:     workingDir,
+		Manifest:       m,
+		Projects:       projects,
+		LintedProjects: opts.projects,
+		APIs:           apis,
+	}, cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := reporters[opts.format](opts.out, findings); err != nil {
+		return fmt.Errorf("failed to report findings: %w", err)
+	}
+
+	if lint.HasErrors(findings) {
+		return fmt.Errorf("linting found errors")
+	}
+	log.Info("Linting finished with %d findings", len(findings))
+	return nil
+}
+
+func loadConfig(fs afero.Fs, manifestPath, configFile string) (lint.Config, error) {
+	if configFile != "" {
+		return lint.LoadConfig(fs, configFile)
+	}
+
+	defaultFile := filepath.Join(filepath.Dir(manifestPath), lint.DefaultConfigFileName)
+	exists, err := afero.Exists(fs, defaultFile)
+	if err != nil {
+		return lint.Config{}, fmt.Errorf("failed to access lint configuration %q: %w", defaultFile, err)
+	}
+	if !exists {
+		return lint.Config{}, nil
+	}
+	log.Debug("Using lint configuration %q", defaultFile)
+	return lint.LoadConfig(fs, defaultFile)
+}
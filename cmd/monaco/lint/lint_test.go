@@ -0,0 +1,158 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/lint"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
+	lintpkg "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/lint"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestInvalidCommandUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		errMsgContains string
+	}{
+		{
+			name:           "Manifest argument is required",
+			args:           []string{},
+			errMsgContains: "accepts 1 arg(s), received 0",
+		},
+		{
+			name:           "Fails on unknown output format",
+			args:           []string{"manifest.yaml", "--output-format", "xml"},
+			errMsgContains: `unknown output format "xml", supported formats are json, sarif, text`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := lint.Command(afero.NewMemMapFs())
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.ErrorContains(t, err, tt.errMsgContains)
+		})
+	}
+}
+
+func runLint(t *testing.T, args ...string) ([]byte, error) {
+	t.Setenv("TOKEN", "some-value")
+
+	out := &bytes.Buffer{}
+	cmd := lint.Command(testutils.CreateTestFileSystem())
+	cmd.SetOut(out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.Bytes(), err
+}
+
+func TestLint(t *testing.T) {
+	out, err := runLint(t, "./test-resources/manifest.yaml", "--output-format", "json")
+	assert.ErrorContains(t, err, "linting found errors")
+
+	var findings []lintpkg.Finding
+	require.NoError(t, json.Unmarshal(out, &findings))
+
+	rules := make(map[string]int)
+	for _, f := range findings {
+		rules[f.RuleID]++
+	}
+	assert.Equal(t, map[string]int{
+		lintpkg.RuleUndefinedEnvironmentOverride: 1,
+		lintpkg.RulePlaintextSecret:              1,
+		lintpkg.RuleReferenceToSkippedConfig:     1,
+		lintpkg.RuleUnusedParameter:              1,
+		lintpkg.RuleUndefinedTemplateVariable:    1,
+		lintpkg.RuleDeprecatedAPI:                2,
+		lintpkg.RuleDuplicateClassicName:         1,
+	}, rules)
+
+	for _, f := range findings {
+		if f.RuleID == lintpkg.RuleUndefinedTemplateVariable {
+			assert.Equal(t, "test-resources/project/dashboard/dashboard.json", f.File)
+			assert.Equal(t, []string{"env1", "env2"}, f.Environments)
+		}
+		if f.RuleID == lintpkg.RuleReferenceToSkippedConfig {
+			assert.Equal(t, "test-resources/project/dashboard/config.yaml", f.File)
+			assert.Equal(t, []string{"env2"}, f.Environments)
+		}
+	}
+}
+
+func TestLint_ConfigFile(t *testing.T) {
+	out, err := runLint(t, "./test-resources/manifest.yaml", "--config", "./test-resources/lint-config.yaml", "--output-format", "text")
+	assert.Error(t, err)
+
+	assert.NotContains(t, string(out), "[deprecated-api]")
+	assert.Contains(t, string(out), `note [unused-parameter] parameter "unused" is neither used in template`)
+	assert.Contains(t, string(out), "error [plaintext-secret]")
+}
+
+func TestLint_SARIF(t *testing.T) {
+	out, err := runLint(t, "./test-resources/manifest.yaml", "--output-format", "sarif")
+	assert.Error(t, err)
+
+	var sarif struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(out, &sarif))
+
+	assert.Equal(t, "2.1.0", sarif.Version)
+	require.Len(t, sarif.Runs, 1)
+	assert.Len(t, sarif.Runs[0].Tool.Driver.Rules, len(lintpkg.Rules))
+	require.Len(t, sarif.Runs[0].Results, 8)
+	for _, r := range sarif.Runs[0].Results {
+		require.Len(t, r.Locations, 1, r.RuleID)
+		assert.True(t, strings.HasPrefix(r.Locations[0].PhysicalLocation.ArtifactLocation.URI, "test-resources/project/"))
+	}
+}
+
+func TestLint_SelectedProjects(t *testing.T) {
+	_, err := runLint(t, "./test-resources/manifest.yaml", "-p", "unknown")
+	assert.ErrorContains(t, err, `requested project "unknown" not found in manifest`)
+}
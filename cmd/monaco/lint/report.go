@@ -0,0 +1,163 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/lint"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+)
+
+type reporter func(w io.Writer, findings []lint.Finding) error
+
+var reporters = map[string]reporter{
+	formatText:  reportText,
+	formatJSON:  reportJSON,
+	formatSARIF: reportSARIF,
+}
+
+func reportText(w io.Writer, findings []lint.Finding) error {
+	for _, f := range findings {
+		var location []string
+		if f.File != "" {
+			location = append(location, filepath.ToSlash(f.File))
+		}
+		if f.Config != nil {
+			location = append(location, f.Config.String())
+		}
+		if len(f.Environments) > 0 {
+			location = append(location, "environments: "+strings.Join(f.Environments, ","))
+		}
+		if _, err := fmt.Fprintf(w, "%s [%s] %s (%s)\n", f.Severity, f.RuleID, f.Message, strings.Join(location, "; ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func reportJSON(w io.Writer, findings []lint.Finding) error {
+	if findings == nil {
+		findings = []lint.Finding{}
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(findings)
+}
+
+// SARIF 2.1.0 types, limited to the properties monaco reports. See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		Version        string      `json:"version"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID                   string             `json:"id"`
+		ShortDescription     sarifMessage       `json:"shortDescription"`
+		DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	}
+	sarifConfiguration struct {
+		Level lint.Severity `json:"level"`
+	}
+	sarifResult struct {
+		RuleID     string          `json:"ruleId"`
+		Level      lint.Severity   `json:"level"`
+		Message    sarifMessage    `json:"message"`
+		Locations  []sarifLocation `json:"locations,omitempty"`
+		Properties map[string]any  `json:"properties,omitempty"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	}
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+)
+
+func reportSARIF(w io.Writer, findings []lint.Finding) error {
+	driver := sarifDriver{
+		Name:           "monaco",
+		Version:        version.MonitoringAsCode,
+		InformationURI: "https://github.com/dynatrace/dynatrace-configuration-as-code",
+	}
+	for _, r := range lint.Rules {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   r.ID,
+			ShortDescription:     sarifMessage{Text: r.Description},
+			DefaultConfiguration: sarifConfiguration{Level: r.DefaultSeverity},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(findings))
+	for _, f := range findings {
+		r := sarifResult{
+			RuleID:  f.RuleID,
+			Level:   f.Severity,
+			Message: sarifMessage{Text: f.Message},
+		}
+		if f.File != "" {
+			r.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(f.File)}}}}
+		}
+		if f.Config != nil || len(f.Environments) > 0 {
+			r.Properties = make(map[string]any)
+			if f.Config != nil {
+				r.Properties["config"] = f.Config.String()
+			}
+			if len(f.Environments) > 0 {
+				r.Properties["environments"] = f.Environments
+			}
+		}
+		results = append(results, r)
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	})
+}
@@ -0,0 +1,81 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/migrate"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"os"
+	"path"
+	"strings"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var outputFolder string
+	var projects, apis []string
+
+	cmd = &cobra.Command{
+		Use:   "migrate <manifest.yaml>",
+		Short: "Migrate configurations of deprecated classic APIs to their Settings 2.0 schemas",
+		Long: "Migrate configurations of deprecated classic APIs to the Settings 2.0 schemas replacing them. " +
+			"The migrated projects are written to the output folder, together with a delete file for the classic objects replaced by the migrated configurations. " +
+			"References to migrated configurations are rewritten. Configurations which can not be migrated are kept unchanged.",
+		Example:           "monaco migrate manifest.yaml -o migrated --api management-zone,auto-tag",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				err := fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
+				return err
+			}
+
+			if outputFolder == "" {
+				folder, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+
+				outputFolder = path.Base(folder) + "-migrated"
+			}
+
+			return migrateProjects(fs, manifestName, outputFolder, migrate.Options{APIs: apis, Projects: projects})
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFolder, "output-folder", "o", "", "Folder where to write the migrated projects to. Defaults to the name of the current directory with a '-migrated' suffix.")
+	cmd.Flags().StringSliceVarP(&projects, "project", "p", nil, "Projects to migrate configurations of. If not defined, configurations of all projects in the manifest are migrated.")
+	cmd.Flags().StringSliceVar(&apis, "api", nil,
+		fmt.Sprintf("Classic APIs to migrate configurations of. If not defined, configurations of all supported APIs are migrated. Supported APIs: %s", strings.Join(migrate.SupportedAPIs(), ", ")))
+
+	if err := cmd.MarkFlagDirname("output-folder"); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+	if err := cmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	return cmd
+}
@@ -0,0 +1,114 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/persistence"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/migrate"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/writer"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+	"path/filepath"
+)
+
+// deleteFileName is the name of the delete file for the classic objects replaced by migrated configurations
+const deleteFileName = "delete.yaml"
+
+func migrateProjects(fs afero.Fs, manifestPath string, outputFolder string, opts migrate.Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	m, errs := manifest.LoadManifest(&manifest.LoaderContext{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Opts: manifest.LoaderOptions{
+			DontResolveEnvVars: true,
+		},
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load manifest %q", manifestPath)
+	}
+
+	for _, p := range opts.Projects {
+		if _, found := m.Projects[p]; !found {
+			return fmt.Errorf("requested project %q not found in manifest", p)
+		}
+	}
+
+	apis := api.NewAPIs()
+	projects, errs := project.LoadProjects(fs, project.ProjectLoaderContext{
+		KnownApis:       apis.GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(manifestPath),
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load projects")
+	}
+
+	result := migrate.Migrate(projects, apis, opts)
+	for _, err := range result.Failed {
+		log.WithFields(field.Error(err)).Warn("%v - the configuration is kept unchanged", err)
+	}
+
+	if len(result.Migrated) == 0 {
+		log.Info("No configurations to migrate found")
+		return nil
+	}
+
+	outputFolder, err := filepath.Abs(outputFolder)
+	if err != nil {
+		return fmt.Errorf("failed to access output path: %q: %w", outputFolder, err)
+	}
+
+	errs = writer.WriteToDisk(&writer.WriterContext{
+		Fs:                 fs,
+		SourceManifestPath: manifestPath,
+		OutputDir:          outputFolder,
+		ManifestName:       filepath.Base(manifestPath),
+		ParametersSerde:    config.DefaultParameterParsers,
+	}, m, result.Projects)
+	if len(errs) > 0 {
+		err := fmt.Errorf("encountered %d errors while writing migrated projects to %s", len(errs), outputFolder)
+		log.WithFields(field.Error(err)).Error("%s:", err)
+		errutils.PrintErrors(errs)
+		return err
+	}
+
+	content, err := yaml.Marshal(persistence.FullFileDefinition{DeleteEntries: result.DeleteEntries()})
+	if err != nil {
+		return fmt.Errorf("failed to marshall delete file definition to YAML: %w", err)
+	}
+	deleteFile := filepath.Join(outputFolder, deleteFileName)
+	if err := afero.WriteFile(fs, deleteFile, content, 0644); err != nil {
+		return fmt.Errorf("failed to create delete file %q: %w", deleteFile, err)
+	}
+
+	log.Info("Migrated configurations stored in %q. After deploying them, the classic objects they replace can be removed using the delete file %q", outputFolder, deleteFile)
+	return nil
+}
@@ -0,0 +1,154 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/migrate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+func TestInvalidCommandUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		errMsgContains string
+	}{
+		{
+			name:           "Manifest argument is required",
+			args:           []string{},
+			errMsgContains: "accepts 1 arg(s), received 0",
+		},
+		{
+			name:           "Fails on unsupported API",
+			args:           []string{"manifest.yaml", "--api", "dashboard"},
+			errMsgContains: `configurations of API "dashboard" can not be migrated`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := migrate.Command(afero.NewMemMapFs())
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.ErrorContains(t, err, tt.errMsgContains)
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	t.Setenv("TOKEN", "some-value")
+
+	fs := testutils.CreateTestFileSystem()
+	outputFolder := "output-folder"
+
+	cmd := migrate.Command(fs)
+	cmd.SetArgs([]string{"./test-resources/manifest.yaml", "-o", outputFolder})
+	require.NoError(t, cmd.Execute())
+
+	outputFolder, err := filepath.Abs(outputFolder)
+	require.NoError(t, err)
+
+	projects := loadProjects(t, fs, filepath.Join(outputFolder, "manifest.yaml"))
+	require.Len(t, projects, 1)
+
+	for _, env := range []string{"env1", "env2"} {
+		configs := projects[0].Configs[env]
+		assert.NotContains(t, configs, "management-zone")
+
+		require.Len(t, configs["builtin:management-zones"], 1)
+		zone := configs["builtin:management-zones"][0]
+		assert.Equal(t, config.SettingsType{SchemaId: "builtin:management-zones"}, zone.Type)
+		assert.Contains(t, zone.Template.Content(), `"tag": "[AWS]kubernetes.io/cluster/{{ .name }}"`)
+		assert.Contains(t, zone.Template.Content(), `"entityId": "{{ .meId }}"`)
+
+		migratedZone := coordinate.Coordinate{Project: "project", Type: "builtin:management-zones", ConfigId: "zone"}
+		profile := coordinate.Coordinate{Project: "project", Type: "alerting-profile", ConfigId: "profile"}
+
+		require.Len(t, configs["alerting-profile"], 1, "alerting profile is kept, as the classic JIRA notification references its ID")
+		assert.NotContains(t, configs, "builtin:alerting.profile")
+		assert.Equal(t, migratedZone, configs["alerting-profile"][0].Parameters["zoneId"].(*refParam.ReferenceParameter).Config, "management zones are referenced by their numeric ID")
+
+		require.Len(t, configs["dashboard"], 1)
+		assert.Equal(t, migratedZone, configs["dashboard"][0].Parameters["zoneId"].(*refParam.ReferenceParameter).Config)
+
+		require.Len(t, configs["builtin:problem.notifications"], 1)
+		slack := configs["builtin:problem.notifications"][0]
+		assert.Equal(t, "slack", slack.Coordinate.ConfigId)
+		assert.Contains(t, slack.Template.Content(), `"slackNotification"`)
+		assert.Equal(t, profile, slack.Parameters["alertingProfileId"].(*refParam.ReferenceParameter).Config)
+
+		require.Len(t, configs["notification"], 1, "unsupported JIRA notification is kept")
+		assert.Equal(t, "jira", configs["notification"][0].Coordinate.ConfigId)
+	}
+
+	entries, errs := delete.LoadEntriesToDelete(fs, api.NewAPIs().GetNames(), filepath.Join(outputFolder, "delete.yaml"))
+	require.Empty(t, errs)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "mzone-1", entries["management-zone"][0].Identifier)
+	assert.Equal(t, "Star Trek to #team-star-trek", entries["notification"][0].Identifier)
+}
+
+func TestMigrate_SelectedAPIs(t *testing.T) {
+	t.Setenv("TOKEN", "some-value")
+
+	fs := testutils.CreateTestFileSystem()
+	outputFolder := "output-folder"
+
+	cmd := migrate.Command(fs)
+	cmd.SetArgs([]string{"./test-resources/manifest.yaml", "-o", outputFolder, "--api", "management-zone"})
+	require.NoError(t, cmd.Execute())
+
+	outputFolder, err := filepath.Abs(outputFolder)
+	require.NoError(t, err)
+
+	projects := loadProjects(t, fs, filepath.Join(outputFolder, "manifest.yaml"))
+	require.Len(t, projects, 1)
+
+	configs := projects[0].Configs["env1"]
+	assert.Len(t, configs["builtin:management-zones"], 1)
+	assert.Len(t, configs["alerting-profile"], 1)
+	assert.Len(t, configs["notification"], 2)
+}
+
+func loadProjects(t *testing.T, fs afero.Fs, manifestPath string) []project.Project {
+	m, errs := manifest.LoadManifest(&manifest.LoaderContext{Fs: fs, ManifestPath: manifestPath})
+	require.Empty(t, errs)
+
+	projects, errs := project.LoadProjects(fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(manifestPath),
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	})
+	require.Empty(t, errs)
+	return projects
+}
@@ -0,0 +1,79 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package promote
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var opts options
+
+	cmd = &cobra.Command{
+		Use:   "promote <manifest.yaml> --from <environment> --to <environment>",
+		Short: "Promote the configurations of one environment to another",
+		Long: "Compare the configurations of two environments after applying their overrides, and rewrite the environment overrides of the target environment, " +
+			"so that its configurations match the ones of the source environment. " +
+			"Parameters listed in the 'environmentSpecific' property of a configuration keep the value of the target environment. " +
+			"A summary of the promoted configurations and the changes of the config files is printed.",
+		Example:           "monaco promote manifest.yaml --from dev --to staging\nmonaco promote manifest.yaml --from staging --to prod -p infrastructure --dry-run",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
+			}
+
+			if opts.from == "" || opts.to == "" {
+				return errors.New("the environments to promote from and to must be set using '--from' and '--to'")
+			}
+
+			if opts.from == opts.to {
+				return fmt.Errorf("cannot promote environment %q to itself", opts.from)
+			}
+
+			return promote(fs, manifestName, cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", "", "The environment to promote configurations from")
+	cmd.Flags().StringVar(&opts.to, "to", "", "The environment to promote configurations to. Its environment overrides are rewritten.")
+	cmd.Flags().StringSliceVarP(&opts.projects, "project", "p", nil, "Projects to promote configurations of. If not defined, all projects of the manifest are promoted.")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Only print the summary of the promotion, without changing any config file")
+
+	for _, flag := range []string{"from", "to"} {
+		if err := cmd.RegisterFlagCompletionFunc(flag, completion.EnvironmentByArg0); err != nil {
+			log.Fatal("failed to setup CLI %v", err)
+		}
+	}
+	if err := cmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	return cmd
+}
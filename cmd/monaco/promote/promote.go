@@ -0,0 +1,267 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package promote
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/values"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/writer"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+type options struct {
+	from     string
+	to       string
+	projects []string
+	dryRun   bool
+}
+
+// promotedConfig is a config which differs between the source and target environment
+type promotedConfig struct {
+	coordinate coordinate.Coordinate
+	// differences are the names of the properties which differ
+	differences []string
+}
+
+// promote rewrites the environment overrides of the target environment, so that the configs of the selected projects
+// match the ones of the source environment. A summary of the promoted configs and the diff of the changed config files
+// is written to out.
+func promote(fs afero.Fs, manifestPath string, out io.Writer, opts options) error {
+	m, errs := manifest.LoadManifest(&manifest.LoaderContext{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Environments: []string{opts.from, opts.to},
+		Opts: manifest.LoaderOptions{
+			DontResolveEnvVars: true,
+		},
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load manifest %q", manifestPath)
+	}
+
+	selectedProjects := opts.projects
+	if len(selectedProjects) == 0 {
+		selectedProjects = maps.Keys(m.Projects)
+	}
+	slices.Sort(selectedProjects)
+	for _, p := range selectedProjects {
+		if _, found := m.Projects[p]; !found {
+			return fmt.Errorf("requested project %q not found in manifest", p)
+		}
+	}
+
+	projects, errs := project.LoadProjects(fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(manifestPath),
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return errors.New("failed to load projects")
+	}
+
+	promoted := findPromotedConfigs(projects, selectedProjects, opts.from, opts.to)
+	if len(promoted) == 0 {
+		log.Info("The configurations of environment %q already match the ones of environment %q", opts.to, opts.from)
+		return nil
+	}
+
+	promotion := writer.Promotion{
+		From: writer.PromotionEnvironment{Name: opts.from, Group: m.Environments[opts.from].Group},
+		To:   writer.PromotionEnvironment{Name: opts.to, Group: m.Environments[opts.to].Group},
+	}
+	for _, p := range promoted {
+		promotion.Configs = append(promotion.Configs, p.coordinate)
+	}
+
+	changedFiles, err := promoteConfigFiles(fs, filepath.Dir(manifestPath), m, selectedProjects, promotion)
+	if err != nil {
+		return err
+	}
+
+	writeSummary(out, promoted, changedFiles)
+
+	if opts.dryRun {
+		log.Info("Dry run: %d config files would be changed to promote %d configurations from %q to %q", len(changedFiles), len(promoted), opts.from, opts.to)
+		return nil
+	}
+
+	for _, f := range changedFiles {
+		if err := afero.WriteFile(fs, f.path, f.promoted, 0664); err != nil {
+			return fmt.Errorf("failed to write config file %q: %w", f.path, err)
+		}
+	}
+
+	log.Info("Promoted %d configurations from %q to %q by changing %d config files", len(promoted), opts.from, opts.to, len(changedFiles))
+	return nil
+}
+
+// findPromotedConfigs returns the configs of the given projects which differ between the source and target
+// environment, sorted by their coordinate
+func findPromotedConfigs(projects []project.Project, selectedProjects []string, from, to string) []promotedConfig {
+	var result []promotedConfig
+	for _, p := range projects {
+		if !slices.Contains(selectedProjects, p.Id) {
+			continue
+		}
+
+		sourceConfigs := map[coordinate.Coordinate]config.Config{}
+		for _, cfgs := range p.Configs[from] {
+			for _, c := range cfgs {
+				sourceConfigs[c.Coordinate] = c
+			}
+		}
+
+		for _, cfgs := range p.Configs[to] {
+			for _, target := range cfgs {
+				source, found := sourceConfigs[target.Coordinate]
+				if !found {
+					continue
+				}
+
+				if d := differences(source, target); len(d) > 0 {
+					result = append(result, promotedConfig{coordinate: target.Coordinate, differences: d})
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(result, func(a, b promotedConfig) bool {
+		return a.coordinate.String() < b.coordinate.String()
+	})
+	return result
+}
+
+// differences returns the names of the properties of a config which differ between the source and target
+// environment. Parameters marked as environment-specific in the target are ignored, as well as the scope, which
+// cannot be overridden per environment.
+func differences(source, target config.Config) []string {
+	var result []string
+
+	if source.Template.Content() != target.Template.Content() {
+		result = append(result, "template")
+	}
+
+	if source.Skip != target.Skip && !slices.Contains(target.EnvironmentSpecific, config.SkipParameter) {
+		result = append(result, config.SkipParameter)
+	}
+
+	names := maps.Keys(source.Parameters)
+	for name := range target.Parameters {
+		if _, found := source.Parameters[name]; !found {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if name == config.ScopeParameter || slices.Contains(target.EnvironmentSpecific, name) {
+			continue
+		}
+
+		sourceParam, targetParam := source.Parameters[name], target.Parameters[name]
+
+		// values parameters resolve to the value of the environment they are loaded for, so only their keys are compared
+		sourceValues, sourceIsValues := sourceParam.(*values.ValuesParameter)
+		targetValues, targetIsValues := targetParam.(*values.ValuesParameter)
+		if sourceIsValues && targetIsValues {
+			if sourceValues.Key != targetValues.Key {
+				result = append(result, name)
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(sourceParam, targetParam) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+// promotedFile is a config file changed by the promotion
+type promotedFile struct {
+	path     string
+	original []byte
+	promoted []byte
+}
+
+// promoteConfigFiles returns the config files of the selected projects that change by the promotion
+func promoteConfigFiles(fs afero.Fs, workingDir string, m manifest.Manifest, selectedProjects []string, promotion writer.Promotion) ([]promotedFile, error) {
+	var result []promotedFile
+	for _, p := range selectedProjects {
+		configFiles, err := project.FindConfigFiles(fs, filepath.Join(workingDir, m.Projects[p].Path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find config files of project %q: %w", p, err)
+		}
+		slices.Sort(configFiles)
+
+		for _, f := range configFiles {
+			original, err := afero.ReadFile(fs, f)
+			if err != nil {
+				return nil, err
+			}
+
+			promoted, changed, err := writer.PromoteConfigFile(fs, f, p, promotion)
+			if err != nil {
+				return nil, err
+			}
+
+			if changed {
+				result = append(result, promotedFile{path: f, original: original, promoted: promoted})
+			}
+		}
+	}
+	return result, nil
+}
+
+// writeSummary writes the promoted configs with the properties that differed, and the diff of each changed config file
+func writeSummary(out io.Writer, promoted []promotedConfig, changedFiles []promotedFile) {
+	_, _ = fmt.Fprintf(out, "Promoting %d configurations:\n", len(promoted))
+	for _, p := range promoted {
+		_, _ = fmt.Fprintf(out, "  %s: %s\n", p.coordinate, strings.Join(p.differences, ", "))
+	}
+
+	for _, f := range changedFiles {
+		diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(f.original)),
+			B:        difflib.SplitLines(string(f.promoted)),
+			FromFile: filepath.ToSlash(f.path),
+			ToFile:   filepath.ToSlash(f.path),
+			Context:  3,
+		})
+		_, _ = fmt.Fprintf(out, "\n%s", diff)
+	}
+}
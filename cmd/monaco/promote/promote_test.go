@@ -0,0 +1,134 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package promote_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/promote"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const configFile = "test-resources/project/dashboard/config.yaml"
+
+func TestInvalidCommandUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		errMsgContains string
+	}{
+		{
+			name:           "manifest argument is required",
+			args:           []string{"--from", "dev", "--to", "staging"},
+			errMsgContains: "accepts 1 arg(s), received 0",
+		},
+		{
+			name:           "manifest must be a YAML file",
+			args:           []string{"manifest.json", "--from", "dev", "--to", "staging"},
+			errMsgContains: "expected a .yaml file",
+		},
+		{
+			name:           "source environment is required",
+			args:           []string{"manifest.yaml", "--to", "staging"},
+			errMsgContains: "must be set using '--from' and '--to'",
+		},
+		{
+			name:           "target environment is required",
+			args:           []string{"manifest.yaml", "--from", "dev"},
+			errMsgContains: "must be set using '--from' and '--to'",
+		},
+		{
+			name:           "environment cannot be promoted to itself",
+			args:           []string{"manifest.yaml", "--from", "dev", "--to", "dev"},
+			errMsgContains: `cannot promote environment "dev" to itself`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := promote.Command(afero.NewMemMapFs())
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.ErrorContains(t, err, tt.errMsgContains)
+		})
+	}
+}
+
+func run(fs afero.Fs, args ...string) (string, error) {
+	var out bytes.Buffer
+	cmd := promote.Command(fs)
+	cmd.SetArgs(args)
+	cmd.SetOut(&out)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestPromote(t *testing.T) {
+	fs := testutils.CreateTestFileSystem()
+
+	out, err := run(fs, "test-resources/manifest.yaml", "--from", "dev", "--to", "staging")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "Promoting 1 configurations:\n  project:dashboard:overview: owner, threshold\n")
+	assert.Contains(t, out, `
+             environmentUrl: http://staging.example.com
+-            # staging is owned by another team
+-            owner: team-b
++            threshold: 20
+`)
+
+	content, err := afero.ReadFile(fs, configFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `
+      - environment: staging
+        override:
+          parameters:
+            environmentUrl: http://staging.example.com
+            threshold: 20
+`)
+
+	out, err = run(fs, "test-resources/manifest.yaml", "--from", "dev", "--to", "staging")
+	require.NoError(t, err)
+	assert.Empty(t, out, "promoting again must not change anything")
+}
+
+func TestPromote_DryRun(t *testing.T) {
+	fs := testutils.CreateTestFileSystem()
+
+	original, err := afero.ReadFile(fs, configFile)
+	require.NoError(t, err)
+
+	out, err := run(fs, "test-resources/manifest.yaml", "--from", "dev", "--to", "staging", "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, out, "project:dashboard:overview: owner, threshold")
+
+	content, err := afero.ReadFile(fs, configFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(content))
+}
+
+func TestPromote_UnknownProject(t *testing.T) {
+	_, err := run(testutils.CreateTestFileSystem(), "test-resources/manifest.yaml", "--from", "dev", "--to", "staging", "-p", "unknown")
+	assert.ErrorContains(t, err, `requested project "unknown" not found in manifest`)
+}
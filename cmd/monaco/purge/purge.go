@@ -69,7 +69,7 @@ func purgeConfigs(environments []manifest.EnvironmentDefinition, apis api.APIs)
 }
 
 func purgeForEnvironment(env manifest.EnvironmentDefinition, apis api.APIs) error {
-	clients, err := dynatrace.CreateClientSet(env.URL.Value, env.Auth)
+	clients, err := dynatrace.CreateClientSet(env.URL.Value, env.Auth, env.Options)
 
 	if err != nil {
 		return fmt.Errorf("failed to create a client for env `%s` due to the following error: %w", env.Name, err)
@@ -82,6 +82,9 @@ func purgeForEnvironment(env manifest.EnvironmentDefinition, apis api.APIs) erro
 	deleteErrors := delete.AllConfigs(ctx, clients.Classic(), apis)
 	deleteErrors = append(deleteErrors, delete.AllSettingsObjects(ctx, clients.Settings())...)
 	deleteErrors = append(deleteErrors, delete.AllAutomations(ctx, clients.Automation())...)
+	if clients.Document() != nil {
+		deleteErrors = append(deleteErrors, delete.AllDocuments(ctx, clients.Document())...)
+	}
 
 	if len(deleteErrors) > 0 {
 		log.Error("Encountered %d errors while puring configurations from environment %s, further manual cleanup may be needed. Errors:", len(deleteErrors), env.Name)
@@ -0,0 +1,123 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package render
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"path/filepath"
+)
+
+// Command returns the 'render' command, writing the rendered configurations of a manifest's projects to a folder
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var opts options
+	var outputFolder string
+	var watch bool
+
+	cmd = &cobra.Command{
+		Use:   "render <manifest.yaml>",
+		Short: "Render the payloads of configurations without deploying them",
+		Long: "Render the payload of every configuration of the manifest's projects per environment, as it would be deployed, and write it to '<output-folder>/<environment>/<project>/<type>/<config id>.json'. " +
+			"No environment is accessed: references are resolved to placeholder IDs, which are derived from the referenced configuration and stay the same between runs. " +
+			"Previously rendered files of the selected environments and projects are replaced.",
+		Example:           "monaco render manifest.yaml -e dev-environment -o rendered",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
+			}
+
+			if outputFolder == "" {
+				outputFolder = filepath.Join(filepath.Dir(manifestName), "rendered")
+			}
+
+			if watch {
+				return watchAndRender(fs, manifestName, outputFolder, opts)
+			}
+
+			return renderToFolder(fs, manifestName, outputFolder, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFolder, "output-folder", "o", "", "The folder rendered configurations are written to. Defaults to 'rendered' next to the manifest.")
+	cmd.Flags().BoolVar(&watch, "watch", false, "Watch the manifest, the values files given by '--values', and the project folders for changes until interrupted, and render the configurations again whenever files change")
+	setupSelectionFlags(cmd, &opts)
+
+	return cmd
+}
+
+// TestCommand returns the 'test' command, comparing the rendered configurations of a manifest's projects to golden files
+func TestCommand(fs afero.Fs) (cmd *cobra.Command) {
+	var opts options
+	var goldenFolder string
+	var update bool
+
+	cmd = &cobra.Command{
+		Use:   "test <manifest.yaml>",
+		Short: "Compare the rendered payloads of configurations to golden files",
+		Long: "Render the payload of every configuration of the manifest's projects per environment like 'monaco render', and compare it to the golden files checked in at '<golden-folder>/<environment>/<project>/<type>/<config id>.json'. " +
+			"The command fails if a golden file is missing, obsolete, or differs from the rendered payload, which catches unintended changes of templates, overrides and parameters. " +
+			"Use '--update' to replace the golden files with the rendered payloads.",
+		Example:           "monaco test manifest.yaml\nmonaco test manifest.yaml --update",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
+			}
+
+			if goldenFolder == "" {
+				goldenFolder = filepath.Join(filepath.Dir(manifestName), "golden")
+			}
+
+			return testGoldenFiles(fs, manifestName, goldenFolder, update, cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&goldenFolder, "golden-folder", "", "The folder holding the golden files. Defaults to 'golden' next to the manifest.")
+	cmd.Flags().BoolVar(&update, "update", false, "Replace the golden files with the rendered configurations instead of comparing them")
+	setupSelectionFlags(cmd, &opts)
+
+	return cmd
+}
+
+func setupSelectionFlags(cmd *cobra.Command, opts *options) {
+	cmd.Flags().StringSliceVarP(&opts.environments, "environment", "e", nil, "Environments to render configurations for. If not defined, all environments of the manifest are used.")
+	cmd.Flags().StringSliceVarP(&opts.groups, "group", "g", nil, "Environment groups to render configurations for. If not defined, all environments of the manifest are used.")
+	cmd.Flags().StringSliceVarP(&opts.projects, "project", "p", nil, "Projects to render configurations of. If not defined, all projects of the manifest are used.")
+	cmd.Flags().StringSliceVar(&opts.valuesFiles, "values", nil, "Values files applied on top of the values files defined in the manifest. Files given later take precedence.")
+	cmd.MarkFlagsMutuallyExclusive("environment", "group")
+
+	if err := cmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByArg0); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+	if err := cmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package render
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"io"
+	"path/filepath"
+)
+
+// renderToFolder renders the configurations of the manifest's projects to the given output folder
+func renderToFolder(fs afero.Fs, manifestPath, outputFolder string, opts options) error {
+	files, folders, err := renderManifest(fs, manifestPath, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFiles(fs, outputFolder, folders, files); err != nil {
+		return err
+	}
+
+	log.Info("Rendered %d configurations to %q", len(files), outputFolder)
+	return nil
+}
+
+// testGoldenFiles compares the rendered configurations of the manifest's projects to the golden files in the given
+// folder, and reports the differences to out. If update is set, the golden files are replaced by the rendered
+// configurations instead.
+func testGoldenFiles(fs afero.Fs, manifestPath, goldenFolder string, update bool, out io.Writer, opts options) error {
+	files, folders, err := renderManifest(fs, manifestPath, opts)
+	if err != nil {
+		return err
+	}
+
+	if update {
+		if err := writeFiles(fs, goldenFolder, folders, files); err != nil {
+			return err
+		}
+		log.Info("Updated %d golden files in %q", len(files), goldenFolder)
+		return nil
+	}
+
+	golden, err := readFiles(fs, goldenFolder, folders)
+	if err != nil {
+		return err
+	}
+
+	differences := compare(golden, files)
+	for _, d := range differences {
+		_, _ = fmt.Fprintln(out, d)
+	}
+
+	if len(differences) > 0 {
+		return fmt.Errorf("%d golden files differ from the rendered configurations, run with '--update' to update them", len(differences))
+	}
+
+	log.Info("All %d rendered configurations match their golden files", len(files))
+	return nil
+}
+
+// compare returns a description of each golden file that is missing, obsolete, or differs from the rendered file
+func compare(golden, rendered renderedFiles) []string {
+	paths := maps.Keys(rendered)
+	for p := range golden {
+		if _, found := rendered[p]; !found {
+			paths = append(paths, p)
+		}
+	}
+	slices.Sort(paths)
+
+	var differences []string
+	for _, p := range paths {
+		expected, isGolden := golden[p]
+		actual, isRendered := rendered[p]
+
+		switch {
+		case !isGolden:
+			differences = append(differences, fmt.Sprintf("golden file %q is missing", filepath.ToSlash(p)))
+		case !isRendered:
+			differences = append(differences, fmt.Sprintf("golden file %q is obsolete, as the configuration is no longer rendered", filepath.ToSlash(p)))
+		case expected != actual:
+			diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(expected),
+				B:        difflib.SplitLines(actual),
+				FromFile: "golden/" + filepath.ToSlash(p),
+				ToFile:   "rendered/" + filepath.ToSlash(p),
+				Context:  3,
+			})
+			differences = append(differences, fmt.Sprintf("golden file %q differs from the rendered configuration:\n%s", filepath.ToSlash(p), diff))
+		}
+	}
+	return differences
+}
@@ -0,0 +1,166 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/monaco"
+	"github.com/spf13/afero"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"io/fs"
+	"path/filepath"
+)
+
+type options struct {
+	environments []string
+	groups       []string
+	projects     []string
+	// valuesFiles are values files applied on top of the ones defined in the manifest
+	valuesFiles []string
+}
+
+// renderedFiles holds the content of rendered configurations by their path relative to the output folder
+type renderedFiles map[string]string
+
+// renderManifest renders the configurations of the manifest's projects. It returns the rendered files, and the
+// folders relative to the output folder that hold all files of the selected environments and projects.
+func renderManifest(fs afero.Fs, manifestPath string, opts options) (renderedFiles, []string, error) {
+	ws, err := monaco.Load(fs, manifestPath,
+		monaco.WithEnvironments(opts.environments...),
+		monaco.WithGroups(opts.groups...),
+		monaco.WithValuesFiles(opts.valuesFiles...),
+		monaco.WithoutEnvVarResolution())
+
+	var manifestErr monaco.ManifestLoadError
+	if errors.As(err, &manifestErr) {
+		errutils.PrintErrors(manifestErr.Errors)
+		return nil, nil, fmt.Errorf("failed to load manifest %q", manifestPath)
+	}
+	var projectErr monaco.ProjectLoadError
+	if errors.As(err, &projectErr) {
+		errutils.PrintErrors(projectErr.Errors)
+		return nil, nil, errors.New("failed to load projects")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	m := ws.Manifest
+
+	for _, p := range opts.projects {
+		if _, found := m.Projects[p]; !found {
+			return nil, nil, fmt.Errorf("requested project %q not found in manifest", p)
+		}
+	}
+
+	// all projects are selected, the requested ones are filtered from the rendered files below
+	sel, err := ws.Select()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rendered, err := ws.Render(context.TODO(), sel)
+	var renderErr monaco.RenderError
+	if errors.As(err, &renderErr) {
+		errutils.PrintErrors(renderErr.Errors)
+		return nil, nil, errors.New("failed to render configurations")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := renderedFiles{}
+	for _, c := range rendered {
+		if len(opts.projects) == 0 || slices.Contains(opts.projects, c.Coordinate.Project) {
+			files[c.Path()] = c.Content
+		}
+	}
+
+	var folders []string
+	for _, env := range m.Environments.Names() {
+		if len(opts.projects) == 0 {
+			folders = append(folders, env)
+			continue
+		}
+		for _, p := range opts.projects {
+			folders = append(folders, filepath.Join(env, p))
+		}
+	}
+	slices.Sort(folders)
+
+	log.Debug("Rendered %d configurations", len(files))
+	return files, folders, nil
+}
+
+// readFiles returns the content of all files in the given folders of dir, by their path relative to dir
+func readFiles(afs afero.Fs, dir string, folders []string) (renderedFiles, error) {
+	files := renderedFiles{}
+	for _, folder := range folders {
+		root := filepath.Join(dir, folder)
+		if exists, err := afero.DirExists(afs, root); err != nil {
+			return nil, err
+		} else if !exists {
+			continue
+		}
+
+		err := afero.Walk(afs, root, func(path string, info fs.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			content, err := afero.ReadFile(afs, path)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files[rel] = string(content)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read files of %q: %w", root, err)
+		}
+	}
+	return files, nil
+}
+
+// writeFiles writes the given files to dir, replacing any files previously written to the given folders of dir
+func writeFiles(afs afero.Fs, dir string, folders []string, files renderedFiles) error {
+	for _, folder := range folders {
+		if err := afs.RemoveAll(filepath.Join(dir, folder)); err != nil {
+			return fmt.Errorf("failed to remove previous files of %q: %w", filepath.Join(dir, folder), err)
+		}
+	}
+
+	paths := maps.Keys(files)
+	slices.Sort(paths)
+	for _, p := range paths {
+		path := filepath.Join(dir, p)
+		if err := afs.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			return fmt.Errorf("failed to create folder for %q: %w", path, err)
+		}
+		if err := afero.WriteFile(afs, path, []byte(files[p]), 0644); err != nil {
+			return fmt.Errorf("failed to write %q: %w", path, err)
+		}
+	}
+	return nil
+}
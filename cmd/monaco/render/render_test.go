@@ -0,0 +1,161 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package render_test
+
+import (
+	"bytes"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/render"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInvalidCommandUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		command        func(afero.Fs) *cobra.Command
+		args           []string
+		errMsgContains string
+	}{
+		{
+			name:           "Render: manifest argument is required",
+			command:        render.Command,
+			args:           []string{},
+			errMsgContains: "accepts 1 arg(s), received 0",
+		},
+		{
+			name:           "Render: manifest must be a YAML file",
+			command:        render.Command,
+			args:           []string{"manifest.json"},
+			errMsgContains: "expected a .yaml file",
+		},
+		{
+			name:           "Test: manifest argument is required",
+			command:        render.TestCommand,
+			args:           []string{},
+			errMsgContains: "accepts 1 arg(s), received 0",
+		},
+		{
+			name:           "Test: manifest must be a YAML file",
+			command:        render.TestCommand,
+			args:           []string{"manifest.json"},
+			errMsgContains: "expected a .yaml file",
+		},
+		{
+			name:           "Test: environment and group are mutually exclusive",
+			command:        render.TestCommand,
+			args:           []string{"manifest.yaml", "-e", "env1", "-g", "default"},
+			errMsgContains: "none of the others can be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.command(afero.NewMemMapFs())
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.ErrorContains(t, err, tt.errMsgContains)
+		})
+	}
+}
+
+func run(fs afero.Fs, command func(afero.Fs) *cobra.Command, args ...string) (string, error) {
+	var out bytes.Buffer
+	cmd := command(fs)
+	cmd.SetArgs(args)
+	cmd.SetOut(&out)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestRender(t *testing.T) {
+	fs := testutils.CreateTestFileSystem()
+
+	_, err := run(fs, render.Command, "test-resources/manifest.yaml", "-o", "out", "-e", "env2")
+	require.NoError(t, err)
+
+	dashboard, err := afero.ReadFile(fs, "out/env2/project/dashboard/overview.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "dashboardMetadata": {
+    "name": "Overview",
+    "owner": "team-b",
+    "tags": [
+      "fc674ae2-143a-3933-a99e-4cd414b482f3"
+    ]
+  }
+}
+`, string(dashboard))
+
+	exists, err := afero.Exists(fs, "out/env2/project/auto-tag/tag.json")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = afero.DirExists(fs, "out/env1")
+	require.NoError(t, err)
+	assert.False(t, exists, "environments which are not selected must not be rendered")
+}
+
+func TestTest_MatchingGoldenFiles(t *testing.T) {
+	fs := testutils.CreateTestFileSystem()
+
+	_, err := run(fs, render.TestCommand, "test-resources/manifest.yaml")
+	assert.NoError(t, err)
+}
+
+func TestTest_ChangedTemplate(t *testing.T) {
+	fs := testutils.CreateTestFileSystem()
+	require.NoError(t, afero.WriteFile(fs, "test-resources/project/auto-tag/tag.json", []byte(`{"name": "{{ .name }}", "rules": [], "description": "new"}`), 0644))
+
+	out, err := run(fs, render.TestCommand, "test-resources/manifest.yaml", "-e", "env1")
+	assert.ErrorContains(t, err, "1 golden files differ from the rendered configurations")
+	assert.Contains(t, out, `golden file "env1/project/auto-tag/tag.json" differs from the rendered configuration:`)
+	assert.Contains(t, out, `+  "description": "new"`)
+	assert.NotContains(t, out, "env2")
+
+	_, err = run(fs, render.TestCommand, "test-resources/manifest.yaml", "--update")
+	require.NoError(t, err)
+
+	_, err = run(fs, render.TestCommand, "test-resources/manifest.yaml")
+	assert.NoError(t, err)
+}
+
+func TestTest_MissingAndObsoleteGoldenFiles(t *testing.T) {
+	fs := testutils.CreateTestFileSystem()
+	_, err := run(fs, render.TestCommand, "test-resources/manifest.yaml", "--golden-folder", "golden", "--update")
+	require.NoError(t, err)
+	require.NoError(t, fs.Remove("golden/env1/project/dashboard/overview.json"))
+	require.NoError(t, afero.WriteFile(fs, "golden/env1/project/dashboard/removed.json", []byte("{}"), 0644))
+
+	out, err := run(fs, render.TestCommand, "test-resources/manifest.yaml", "--golden-folder", "golden", "-p", "project")
+	assert.ErrorContains(t, err, "2 golden files differ from the rendered configurations")
+	assert.Contains(t, out, `golden file "env1/project/dashboard/overview.json" is missing`)
+	assert.Contains(t, out, `golden file "env1/project/dashboard/removed.json" is obsolete`)
+
+	_, err = run(fs, render.TestCommand, "test-resources/manifest.yaml", "--golden-folder", "golden", "--update")
+	require.NoError(t, err)
+
+	exists, err := afero.Exists(fs, "golden/env1/project/dashboard/removed.json")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
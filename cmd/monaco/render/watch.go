@@ -0,0 +1,91 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package render
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/watch"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/spf13/afero"
+	"golang.org/x/exp/maps"
+)
+
+// watchInterval is the interval in which the watched files are checked for changes
+const watchInterval = time.Second
+
+// watchAndRender renders the configurations to the output folder, and renders them again whenever the manifest, its
+// values files or files of its projects change, until interrupted
+func watchAndRender(fs afero.Fs, manifestPath, outputFolder string, opts options) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return renderOnChange(ctx, fs, manifestPath, outputFolder, opts, watchInterval)
+}
+
+func renderOnChange(ctx context.Context, fs afero.Fs, manifestPath, outputFolder string, opts options, interval time.Duration) error {
+	m, errs := manifest.LoadManifest(&manifest.LoaderContext{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Opts: manifest.LoaderOptions{
+			DontResolveEnvVars: true,
+			ValuesFiles:        opts.valuesFiles,
+		},
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load manifest %q", manifestPath)
+	}
+
+	// projects added to the manifest later on are not watched
+	paths := append(maps.Values(cmdutils.ProjectFolders(manifestPath, m)), manifestPath)
+	paths = append(paths, opts.valuesFiles...)
+
+	watcher, err := watch.New(fs, interval, paths...)
+	if err != nil {
+		return err
+	}
+
+	if err := renderToFolder(fs, manifestPath, outputFolder, opts); err != nil {
+		log.Error("%v", err)
+	}
+
+	log.Info("Watching %d projects for changes. Press Ctrl+C to stop.", len(m.Projects))
+	for {
+		changed, err := watcher.Wait(ctx)
+		if ctx.Err() != nil {
+			log.Info("Stopped watching projects")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		log.Info("%d files changed, rendering again", len(changed))
+		if err := renderToFolder(fs, manifestPath, outputFolder, opts); err != nil {
+			log.Error("%v", err)
+		}
+	}
+}
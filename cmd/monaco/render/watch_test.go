@@ -0,0 +1,57 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package render
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderOnChange(t *testing.T) {
+	fs := testutils.CreateTestFileSystem()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		done <- renderOnChange(ctx, fs, "test-resources/manifest.yaml", "out", options{environments: []string{"env1"}}, time.Millisecond)
+	}()
+
+	rendered := func() string {
+		content, _ := afero.ReadFile(fs, "out/env1/project/dashboard/overview.json")
+		return string(content)
+	}
+
+	assert.Eventually(t, func() bool { return rendered() != "" }, 5*time.Second, time.Millisecond)
+	assert.NotContains(t, rendered(), "description")
+
+	require.NoError(t, afero.WriteFile(fs, "test-resources/project/dashboard/overview.json",
+		[]byte(`{"dashboardMetadata": {"name": "{{ .name }}", "description": "changed"}}`), 0644))
+	assert.Eventually(t, func() bool { return strings.Contains(rendered(), `"description": "changed"`) }, 5*time.Second, time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -19,8 +19,15 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/delete"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/download"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/emulate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/format"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/generate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/initialize"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/lint"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/migrate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/promote"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/purge"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/render"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/support"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/version"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
@@ -58,8 +65,12 @@ Examples:
   Deploy a specific environment within an manifest
     monaco deploy service.yaml -e dev`,
 
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			log.PrepareLogging(fs, &verbose, logSpy)
+			if err := support.PrepareTracing(fs); err != nil {
+				return err
+			}
+			return support.PrepareTraffic(fs)
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			_ = cmd.Help()
@@ -68,6 +79,12 @@ Examples:
 
 	// define finalizer method(s) run after cobra commands ran
 	cobra.OnFinalize(func() {
+		if err := support.SaveRecording(fs); err != nil {
+			log.WithFields(field.Error(err)).Error("Encountered error saving recorded HTTP traffic: %s", err)
+		}
+		if err := support.ShutdownTracing(); err != nil {
+			log.WithFields(field.Error(err)).Error("Encountered error exporting traces: %s", err)
+		}
 		if support.SupportArchive {
 			if err := support.Archive(fs); err != nil {
 				log.WithFields(field.Error(err)).Error("Encountered error creating support archive. Archive may be missing or incomplete: %s", err)
@@ -78,6 +95,10 @@ Examples:
 	// global flags
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable debug logging")
 	rootCmd.PersistentFlags().BoolVar(&support.SupportArchive, "support-archive", false, "Create support archive")
+	rootCmd.PersistentFlags().StringVar(&support.RecordFile, "record-traffic", "", "Record all HTTP requests and responses to the given cassette file, which can be replayed using --replay-traffic")
+	rootCmd.PersistentFlags().StringVar(&support.ReplayFile, "replay-traffic", "", "Replay HTTP responses from the given cassette file recorded with --record-traffic, instead of sending requests to Dynatrace")
+	rootCmd.MarkFlagsMutuallyExclusive("record-traffic", "replay-traffic")
+	rootCmd.PersistentFlags().StringVar(&support.TraceFile, "trace-file", "", "Write OpenTelemetry traces of the run as JSON to the given file. Traces are exported via OTLP if OTEL_EXPORTER_OTLP_ENDPOINT is set")
 
 	// commands
 	rootCmd.AddCommand(download.GetDownloadCommand(fs, &download.DefaultCommand{}))
@@ -86,6 +107,14 @@ Examples:
 	rootCmd.AddCommand(delete.GetDeleteCommand(fs))
 	rootCmd.AddCommand(version.GetVersionCommand())
 	rootCmd.AddCommand(generate.Command(fs))
+	rootCmd.AddCommand(migrate.Command(fs))
+	rootCmd.AddCommand(lint.Command(fs))
+	rootCmd.AddCommand(format.Command(fs))
+	rootCmd.AddCommand(initialize.Command(fs))
+	rootCmd.AddCommand(render.Command(fs))
+	rootCmd.AddCommand(render.TestCommand(fs))
+	rootCmd.AddCommand(promote.Command(fs))
+	rootCmd.AddCommand(emulate.Command())
 
 	if featureflags.DangerousCommands().Enabled() {
 		log.Warn("MONACO_ENABLE_DANGEROUS_COMMANDS environment var detected!")
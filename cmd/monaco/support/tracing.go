@@ -0,0 +1,49 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package support
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/tracing"
+	"github.com/spf13/afero"
+)
+
+// TraceFile is the path of the file spans are written to. If empty, spans are only exported if OpenTelemetry is
+// configured using the standard 'OTEL_EXPORTER_OTLP_*' environment variables.
+var TraceFile string
+
+var shutdownTracing tracing.ShutdownFunc
+
+// PrepareTracing sets up tracing, as defined by TraceFile and the OpenTelemetry environment variables.
+func PrepareTracing(fs afero.Fs) error {
+	shutdown, err := tracing.Setup(context.Background(), fs, TraceFile)
+	if err != nil {
+		return err
+	}
+	shutdownTracing = shutdown
+	return nil
+}
+
+// ShutdownTracing exports all pending spans. It does nothing if tracing was not set up.
+func ShutdownTracing() error {
+	if shutdownTracing == nil {
+		return nil
+	}
+	err := shutdownTracing(context.Background())
+	shutdownTracing = nil
+	return err
+}
@@ -0,0 +1,91 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package support
+
+import (
+	"context"
+	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/trafficlogs"
+	"github.com/spf13/afero"
+	"golang.org/x/oauth2"
+	"net/http"
+)
+
+// RecordFile is the path of the cassette all HTTP traffic is recorded to. If empty, nothing is recorded.
+var RecordFile string
+
+// ReplayFile is the path of the cassette all HTTP responses are replayed from. If empty, requests are sent to the
+// Dynatrace environments.
+var ReplayFile string
+
+var (
+	recorder *trafficlogs.Recorder
+	replayer *trafficlogs.Replayer
+)
+
+// PrepareTraffic sets up recording or replaying of HTTP traffic, as defined by RecordFile and ReplayFile.
+func PrepareTraffic(fs afero.Fs) error {
+	recorder, replayer = nil, nil
+
+	if RecordFile != "" && ReplayFile != "" {
+		return errors.New("HTTP traffic can not be recorded and replayed at the same time")
+	}
+
+	if RecordFile != "" {
+		log.Info("Recording HTTP traffic to %q", RecordFile)
+		recorder = trafficlogs.NewRecorder()
+	}
+
+	if ReplayFile != "" {
+		c, err := trafficlogs.LoadCassette(fs, ReplayFile)
+		if err != nil {
+			return err
+		}
+		log.Info("Replaying HTTP traffic from %q. No requests are sent to any Dynatrace environment", ReplayFile)
+		replayer = trafficlogs.NewReplayer(c)
+	}
+	return nil
+}
+
+// WrapTransport wraps the given base transport to record or replay HTTP traffic, if configured by PrepareTraffic.
+// Otherwise, the base transport is returned as is.
+func WrapTransport(base http.RoundTripper) http.RoundTripper {
+	switch {
+	case replayer != nil:
+		return replayer.Wrap(base)
+	case recorder != nil:
+		return recorder.Wrap(base)
+	default:
+		return base
+	}
+}
+
+// OAuthContext returns a context for creating OAuth clients, which send their requests via WrapTransport
+func OAuthContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: WrapTransport(nil)})
+}
+
+// SaveRecording writes all HTTP traffic recorded so far to RecordFile. It does nothing if traffic is not recorded.
+func SaveRecording(fs afero.Fs) error {
+	if recorder == nil {
+		return nil
+	}
+
+	log.Info("Saving recorded HTTP traffic to %q", RecordFile)
+	return recorder.Cassette().Save(fs, RecordFile)
+}
@@ -17,6 +17,7 @@
 package environment
 
 import (
+	"math"
 	"os"
 	"strconv"
 
@@ -24,22 +25,45 @@ import (
 )
 
 const (
-	ConcurrentRequestsEnvKey = "MONACO_CONCURRENT_REQUESTS"
-	defaultValueKey          = "DEFAULT"
+	ConcurrentRequestsEnvKey         = "MONACO_CONCURRENT_REQUESTS"
+	RateLimitRequestsPerSecondEnvKey = "MONACO_RATE_LIMIT_REQUESTS_PER_SECOND"
+	RateLimitBurstEnvKey             = "MONACO_RATE_LIMIT_BURST"
+	RateLimitMaxRetriesEnvKey        = "MONACO_RATE_LIMIT_MAX_RETRIES"
+	defaultValueKey                  = "DEFAULT"
 )
 
 var defaultValuesInt = map[string]int{
-	ConcurrentRequestsEnvKey: 5,
-	defaultValueKey:          0,
+	ConcurrentRequestsEnvKey:  5,
+	RateLimitBurstEnvKey:      10,
+	RateLimitMaxRetriesEnvKey: 10,
+	defaultValueKey:           0,
+}
+
+var defaultValuesFloat = map[string]float64{
+	RateLimitRequestsPerSecondEnvKey: 10,
+	defaultValueKey:                  0,
 }
 
 var logStringInt = map[string]string{
-	ConcurrentRequestsEnvKey: "Concurrent Request Limit: %d, from '%s' environment variable",
-	defaultValueKey:          "Environment variable %s: %d",
+	ConcurrentRequestsEnvKey:  "Concurrent Request Limit: %d, from '%s' environment variable",
+	RateLimitBurstEnvKey:      "Rate Limit Burst: %d requests, from '%s' environment variable",
+	RateLimitMaxRetriesEnvKey: "Rate Limit Max Retries: %d, from '%s' environment variable",
+	defaultValueKey:           "Environment variable %s: %d",
 }
 var logStringIntDefault = map[string]string{
-	ConcurrentRequestsEnvKey: "Concurrent Request Limit: %d, '%s' environment variable is NOT set, using default value",
-	defaultValueKey:          "Environment variable %s: %d, variable is NOT set, using default value",
+	ConcurrentRequestsEnvKey:  "Concurrent Request Limit: %d, '%s' environment variable is NOT set, using default value",
+	RateLimitBurstEnvKey:      "Rate Limit Burst: %d requests, '%s' environment variable is NOT set, using default value",
+	RateLimitMaxRetriesEnvKey: "Rate Limit Max Retries: %d, '%s' environment variable is NOT set, using default value",
+	defaultValueKey:           "Environment variable %s: %d, variable is NOT set, using default value",
+}
+
+var logStringFloat = map[string]string{
+	RateLimitRequestsPerSecondEnvKey: "Rate Limit: %g requests per second, from '%s' environment variable",
+	defaultValueKey:                  "Environment variable %s: %g",
+}
+var logStringFloatDefault = map[string]string{
+	RateLimitRequestsPerSecondEnvKey: "Rate Limit: %g requests per second, '%s' environment variable is NOT set, using default value",
+	defaultValueKey:                  "Environment variable %s: %g, variable is NOT set, using default value",
 }
 
 func getDefaultInt(env string) int {
@@ -66,6 +90,30 @@ func getEnvValueIntInternal(env string) (int, bool) {
 	return getDefaultInt(env), true
 }
 
+func getDefaultFloat(env string) float64 {
+	defValue, ok := defaultValuesFloat[env]
+	if ok {
+		return defValue
+	}
+	return defaultValuesFloat[defaultValueKey]
+}
+
+func parseEnvToFloat(env string, val string) (float64, bool) {
+	value, err := strconv.ParseFloat(val, 64)
+	if err != nil || value < 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return getDefaultFloat(env), true
+	}
+	return value, false
+}
+
+func getEnvValueFloatInternal(env string) (float64, bool) {
+	val, ok := os.LookupEnv(env)
+	if ok {
+		return parseEnvToFloat(env, val)
+	}
+	return getDefaultFloat(env), true
+}
+
 func getLogMessage(env string, messageMap map[string]string) string {
 	logMessage, ok := messageMap[env]
 	if ok {
@@ -94,3 +142,19 @@ func GetEnvValueIntLog(env string) int {
 
 	return value
 }
+
+func GetEnvValueFloatLog(env string) float64 {
+	value, isDefault := getEnvValueFloatInternal(env)
+
+	var logMessage string
+
+	if isDefault {
+		logMessage = getLogMessage(env, logStringFloatDefault)
+	} else {
+		logMessage = getLogMessage(env, logStringFloat)
+	}
+
+	log.Debug(logMessage, value, env)
+
+	return value
+}
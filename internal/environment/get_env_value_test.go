@@ -57,3 +57,20 @@ func TestWithParallelRequestLimitFromEnvOption(t *testing.T) {
 	assert.Equal(t, 11, GetEnvValueIntLog(testEnvVar))
 	assert.Equal(t, "Environment variable %s: %d", getLogMessage(testEnvVar, logStringInt))
 }
+
+func TestGetEnvValueFloatLog(t *testing.T) {
+	t.Setenv(RateLimitRequestsPerSecondEnvKey, "")
+	assert.Equal(t, defaultValuesFloat[RateLimitRequestsPerSecondEnvKey], GetEnvValueFloatLog(RateLimitRequestsPerSecondEnvKey), "expected default value if env var is empty")
+
+	t.Setenv(RateLimitRequestsPerSecondEnvKey, "NOT_A_NUMBER")
+	assert.Equal(t, defaultValuesFloat[RateLimitRequestsPerSecondEnvKey], GetEnvValueFloatLog(RateLimitRequestsPerSecondEnvKey), "expected default value if env var is not a number")
+
+	t.Setenv(RateLimitRequestsPerSecondEnvKey, "-1")
+	assert.Equal(t, defaultValuesFloat[RateLimitRequestsPerSecondEnvKey], GetEnvValueFloatLog(RateLimitRequestsPerSecondEnvKey), "expected default value if env var is negative")
+
+	t.Setenv(RateLimitRequestsPerSecondEnvKey, "0.5")
+	assert.Equal(t, 0.5, GetEnvValueFloatLog(RateLimitRequestsPerSecondEnvKey))
+
+	t.Setenv(RateLimitRequestsPerSecondEnvKey, "20")
+	assert.Equal(t, float64(20), GetEnvValueFloatLog(RateLimitRequestsPerSecondEnvKey))
+}
@@ -103,6 +103,15 @@ func ManagementZoneSettingsNumericIDs() FeatureFlag {
 	}
 }
 
+// TokenBucketRateLimit returns the feature flag controlling whether requests are rate limited proactively by an adaptive
+// token bucket per environment, instead of only reacting to HTTP 429 responses.
+func TokenBucketRateLimit() FeatureFlag {
+	return FeatureFlag{
+		envName:        "MONACO_FEAT_TOKEN_BUCKET_RATE_LIMIT",
+		defaultEnabled: false,
+	}
+}
+
 // FastDependencyResolver returns the feature flag controlling whether the fast (but memory intensive) Aho-Corasick
 // algorithm based dependency resolver is used when downloading. If set to false, the old naive and CPU intensive resolver
 // is used.
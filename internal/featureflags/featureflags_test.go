@@ -58,3 +58,10 @@ func TestEntities(t *testing.T) {
 	t.Setenv(ff.EnvName(), "1")
 	assert.True(t, ff.Enabled())
 }
+
+func TestTokenBucketRateLimit(t *testing.T) {
+	ff := featureflags.TokenBucketRateLimit()
+	assert.False(t, ff.Enabled())
+	t.Setenv(ff.EnvName(), "1")
+	assert.True(t, ff.Enabled())
+}
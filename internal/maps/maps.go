@@ -29,3 +29,30 @@ func ToStringMap(m map[interface{}]interface{}) map[string]interface{} {
 
 	return result
 }
+
+// NormalizeKeys turns the keys of all maps nested in the given value, e.g. as parsed from YAML, into string keys.
+// Keys are transformed using fmt.Sprint. The given value is not modified.
+func NormalizeKeys(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, e := range val {
+			m[fmt.Sprint(k)] = NormalizeKeys(e)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, e := range val {
+			m[k] = NormalizeKeys(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(val))
+		for i, e := range val {
+			l[i] = NormalizeKeys(e)
+		}
+		return l
+	default:
+		return v
+	}
+}
@@ -78,6 +78,28 @@ func TestToStringMap(t *testing.T) {
 	}
 }
 
+func TestNormalizeKeys(t *testing.T) {
+	input := map[interface{}]interface{}{
+		"name": "value",
+		1:      []interface{}{map[interface{}]interface{}{true: "yes"}},
+		"nested": map[string]interface{}{
+			"map": map[interface{}]interface{}{"key": 42},
+		},
+	}
+
+	want := map[string]interface{}{
+		"name": "value",
+		"1":    []interface{}{map[string]interface{}{"true": "yes"}},
+		"nested": map[string]interface{}{
+			"map": map[string]interface{}{"key": 42},
+		},
+	}
+
+	if got := NormalizeKeys(input); !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeKeys() = %v, want %v", got, want)
+	}
+}
+
 // OrderInts can be used in assert.DeepEqual to order an int-slice before comparing
 var OrderInts = cmpopts.SortSlices(func(a, b int) bool {
 	return a < b
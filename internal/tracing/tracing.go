@@ -0,0 +1,165 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tracing sets up OpenTelemetry tracing of monaco runs, and provides the tracer and attributes used for all
+// spans created by monaco.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
+	"github.com/spf13/afero"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+	"path/filepath"
+)
+
+const instrumentationName = "github.com/dynatrace/dynatrace-configuration-as-code/v2"
+
+// Attributes added to spans created by monaco
+const (
+	Environment      = attribute.Key("monaco.environment")
+	EnvironmentGroup = attribute.Key("monaco.environment.group")
+	GraphComponentID = attribute.Key("monaco.graph.component.id")
+	ConfigCount      = attribute.Key("monaco.config.count")
+	Coordinate       = attribute.Key("monaco.coordinate")
+	ConfigType       = attribute.Key("monaco.config.type")
+	API              = attribute.Key("monaco.api")
+	Skipped          = attribute.Key("monaco.skipped")
+	Unchanged        = attribute.Key("monaco.unchanged")
+	Attempts         = attribute.Key("monaco.http.attempts")
+	Retry            = attribute.Key("monaco.http.retry")
+)
+
+// otlpEndpointEnvKeys are the environment variables defining where traces are exported to via OTLP
+var otlpEndpointEnvKeys = []string{"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"}
+
+// ShutdownFunc flushes all pending spans and stops exporting
+type ShutdownFunc func(ctx context.Context) error
+
+// Tracer returns the tracer used for all spans created by monaco
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup configures tracing for the current run. Spans are exported via OTLP/HTTP if one of the standard
+// 'OTEL_EXPORTER_OTLP_ENDPOINT' or 'OTEL_EXPORTER_OTLP_TRACES_ENDPOINT' environment variables is set, and written
+// as JSON to traceFile, if it is not empty. All other OTLP exporter options are read from the standard
+// 'OTEL_EXPORTER_OTLP_*' environment variables as well.
+//
+// If neither is configured, tracing stays disabled and creating spans is a no-op.
+func Setup(ctx context.Context, fs afero.Fs, traceFile string) (ShutdownFunc, error) {
+	var opts []sdktrace.TracerProviderOption
+
+	if otlpConfigured() {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	if traceFile != "" {
+		exporter, err := newFileExporter(fs, traceFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	if len(opts) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName("monaco"),
+		semconv.ServiceVersion(version.MonitoringAsCode),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(append(opts, sdktrace.WithResource(res))...)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func otlpConfigured() bool {
+	for _, k := range otlpEndpointEnvKeys {
+		if os.Getenv(k) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func newFileExporter(fs afero.Fs, path string) (sdktrace.SpanExporter, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := fs.MkdirAll(dir, 0777); err != nil {
+			return nil, fmt.Errorf("failed to create directory for trace file %q: %w", path, err)
+		}
+	}
+
+	f, err := fs.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace file %q: %w", path, err)
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create trace file exporter: %w", err), f.Close())
+	}
+	return &fileExporter{SpanExporter: exporter, file: f}, nil
+}
+
+// fileExporter closes the underlying file once the exporter is shut down
+type fileExporter struct {
+	sdktrace.SpanExporter
+	file afero.File
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.SpanExporter.Shutdown(ctx), e.file.Close())
+}
+
+// CoordinateAttributes returns the attributes describing the config with the given coordinate
+func CoordinateAttributes(c coordinate.Coordinate) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		Coordinate.String(c.String()),
+		ConfigType.String(c.Type),
+	}
+}
+
+// EndSpan ends the given span, recording the given error on it if it is not nil
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,81 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+)
+
+func TestSetup_WritesSpansToTraceFile(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	fs := afero.NewMemMapFs()
+	shutdown, err := Setup(context.TODO(), fs, "traces/trace.json")
+	require.NoError(t, err)
+
+	_, span := Tracer().Start(context.TODO(), "test span")
+	span.SetAttributes(Environment.String("env"))
+	span.End()
+
+	require.NoError(t, shutdown(context.TODO()))
+
+	content, err := afero.ReadFile(fs, "traces/trace.json")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"Name":"test span"`)
+	assert.Contains(t, string(content), `"Key":"monaco.environment"`)
+}
+
+func TestSetup_DisabledIfNotConfigured(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	previous := otel.GetTracerProvider()
+	fs := afero.NewMemMapFs()
+	shutdown, err := Setup(context.TODO(), fs, "")
+	require.NoError(t, err)
+	assert.Equal(t, previous, otel.GetTracerProvider())
+	assert.NoError(t, shutdown(context.TODO()))
+}
+
+func TestEndSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	_, ok := tracer.Start(context.TODO(), "ok")
+	EndSpan(ok, nil)
+	_, failed := tracer.Start(context.TODO(), "failed")
+	EndSpan(failed, errors.New("some error"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "some error", spans[1].Status().Description)
+	assert.Len(t, spans[1].Events(), 1, "expected error to be recorded as event")
+}
@@ -0,0 +1,305 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trafficlogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/spf13/afero"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CassetteVersion is the version of the cassette file format written by the Recorder
+const CassetteVersion = 1
+
+// Cassette is a structured recording of HTTP request/response pairs, which can be replayed by a Replayer.
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded HTTP request together with the response returned for it
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest holds the parts of a request needed to match it on replay.
+// Headers are not recorded, to not leak any credentials.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse holds all information needed to replay a response
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// LoadCassette reads the cassette stored at the given path
+func LoadCassette(fs afero.Fs, path string) (Cassette, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return Cassette{}, fmt.Errorf("failed to read cassette %q: %w", path, err)
+	}
+
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Cassette{}, fmt.Errorf("failed to parse cassette %q: %w", path, err)
+	}
+
+	if c.Version != CassetteVersion {
+		return Cassette{}, fmt.Errorf("cassette %q has unsupported version %d, expected %d", path, c.Version, CassetteVersion)
+	}
+	return c, nil
+}
+
+// Save writes the cassette to the given path, creating parent directories if needed
+func (c Cassette) Save(fs afero.Fs, path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize cassette: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := fs.MkdirAll(dir, 0777); err != nil {
+			return fmt.Errorf("failed to create directory for cassette %q: %w", path, err)
+		}
+	}
+
+	if err := afero.WriteFile(fs, path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cassette %q: %w", path, err)
+	}
+	return nil
+}
+
+// Recorder records all HTTP request/response pairs sent through the transports created by Wrap.
+// Requests fetching OAuth tokens are not recorded, to not leak any credentials.
+// A Recorder is safe for concurrent use.
+type Recorder struct {
+	lock         sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecorder creates a new, empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Wrap returns a http.RoundTripper sending requests using the given base transport, and recording them.
+// If base is nil, http.DefaultTransport is used.
+func (r *Recorder) Wrap(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &recordingTransport{recorder: r, base: base}
+}
+
+// Cassette returns a Cassette of all interactions recorded so far, in the order their responses were received
+func (r *Recorder) Cassette() Cassette {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	interactions := make([]Interaction, len(r.interactions))
+	copy(interactions, r.interactions)
+	return Cassette{Version: CassetteVersion, Interactions: interactions}
+}
+
+func (r *Recorder) record(i Interaction) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.interactions = append(r.interactions, i)
+}
+
+type recordingTransport struct {
+	recorder *Recorder
+	base     http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record request body: %w", err)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || isTokenRequest(req, reqBody) {
+		return resp, err
+	}
+
+	respBody, err := drainBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record response body: %w", err)
+	}
+
+	headers := resp.Header.Clone()
+	headers.Del("Set-Cookie")
+
+	t.recorder.record(Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   reqBody,
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    headers,
+			Body:       respBody,
+		},
+	})
+	return resp, nil
+}
+
+// drainBody reads the given body and replaces it with a reader returning the same content again
+func drainBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+
+	b, err := io.ReadAll(*body)
+	if err != nil {
+		return "", err
+	}
+	if err := (*body).Close(); err != nil {
+		return "", err
+	}
+	*body = io.NopCloser(bytes.NewReader(b))
+	return string(b), nil
+}
+
+// isTokenRequest returns whether the request fetches an OAuth token using the client credentials flow
+func isTokenRequest(req *http.Request, body string) bool {
+	return strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") &&
+		strings.Contains(body, "grant_type=")
+}
+
+// Replayer serves the responses of a Cassette for matching requests, without sending any requests.
+// Requests match a recorded interaction if their method, URL path, query and body are equal. The host of the URL is
+// ignored, so a cassette can be replayed against any environment URL. If no interaction matches the body, the first
+// one matching the remaining properties is used.
+// Requests repeated more often than recorded are answered with the last matching response.
+// A Replayer is safe for concurrent use.
+type Replayer struct {
+	lock         sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer creates a Replayer for the interactions of the given cassette
+func NewReplayer(c Cassette) *Replayer {
+	return &Replayer{
+		interactions: c.Interactions,
+		used:         make([]bool, len(c.Interactions)),
+	}
+}
+
+// Wrap returns a http.RoundTripper serving responses from the cassette. The base transport is never used; the
+// signature allows using Wrap interchangeably with Recorder.Wrap.
+func (r *Replayer) Wrap(_ http.RoundTripper) http.RoundTripper {
+	return r
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	if isTokenRequest(req, body) {
+		return newReplayedResponse(req, RecordedResponse{
+			StatusCode: http.StatusOK,
+			Headers:    http.Header{"Content-Type": {"application/json"}},
+			Body:       `{"access_token":"replayed-token","token_type":"Bearer","expires_in":3600}`,
+		}), nil
+	}
+
+	i, found := r.match(req.Method, req.URL, body)
+	if !found {
+		return nil, fmt.Errorf("no recorded response found for request %s %s", req.Method, req.URL)
+	}
+	return newReplayedResponse(req, r.interactions[i].Response), nil
+}
+
+func (r *Replayer) match(method string, u *url.URL, body string) (int, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := requestKey(method, u)
+	var unusedExact, unused, lastExact, last = -1, -1, -1, -1
+	for i, interaction := range r.interactions {
+		recordedURL, err := url.Parse(interaction.Request.URL)
+		if err != nil || requestKey(interaction.Request.Method, recordedURL) != key {
+			continue
+		}
+
+		sameBody := interaction.Request.Body == body
+		if sameBody {
+			lastExact = i
+		}
+		last = i
+
+		if r.used[i] {
+			continue
+		}
+		if sameBody && unusedExact == -1 {
+			unusedExact = i
+		}
+		if unused == -1 {
+			unused = i
+		}
+	}
+
+	for _, i := range []int{unusedExact, lastExact, unused, last} {
+		if i != -1 {
+			r.used[i] = true
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func requestKey(method string, u *url.URL) string {
+	return method + " " + u.EscapedPath() + "?" + u.Query().Encode()
+}
+
+func newReplayedResponse(req *http.Request, r RecordedResponse) *http.Response {
+	headers := r.Headers
+	if headers == nil {
+		headers = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers.Clone(),
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,138 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trafficlogs
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		body, _ := io.ReadAll(req.Body)
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Header().Set("Set-Cookie", "secret")
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`{"call":` + strconv.Itoa(calls) + `,"body":"` + string(body) + `"}`))
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder()
+	client := &http.Client{Transport: recorder.Wrap(nil)}
+
+	for _, body := range []string{"first", "second"} {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/api/config/v1/alertingProfiles?x=1", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Api-Token secret")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), body, "expected response to be passed through while recording")
+	}
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, recorder.Cassette().Save(fs, "recordings/cassette.json"))
+
+	data, err := afero.ReadFile(fs, "recordings/cassette.json")
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret", "expected credentials and cookies not to be recorded")
+
+	c, err := LoadCassette(fs, "recordings/cassette.json")
+	require.NoError(t, err)
+	assert.Len(t, c.Interactions, 2)
+
+	server.Close()
+	replayClient := &http.Client{Transport: NewReplayer(c).Wrap(nil)}
+
+	// the host is ignored when replaying, and the body selects the matching interaction
+	for _, body := range []string{"second", "first"} {
+		resp, err := replayClient.Post("https://other.example.com/api/config/v1/alertingProfiles?x=1", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), body)
+	}
+}
+
+func TestReplayer_RepeatedRequestsGetLastMatchingResponse(t *testing.T) {
+	replayer := NewReplayer(Cassette{
+		Version: CassetteVersion,
+		Interactions: []Interaction{
+			{Request: RecordedRequest{Method: http.MethodGet, URL: "https://a.com/api/x"}, Response: RecordedResponse{StatusCode: 404}},
+			{Request: RecordedRequest{Method: http.MethodGet, URL: "https://a.com/api/x"}, Response: RecordedResponse{StatusCode: 200}},
+		},
+	})
+	client := &http.Client{Transport: replayer}
+
+	for _, expected := range []int{404, 200, 200} {
+		resp, err := client.Get("https://a.com/api/x")
+		require.NoError(t, err)
+		assert.Equal(t, expected, resp.StatusCode)
+	}
+}
+
+func TestReplayer_UnknownRequestFails(t *testing.T) {
+	client := &http.Client{Transport: NewReplayer(Cassette{Version: CassetteVersion})}
+
+	_, err := client.Get("https://a.com/api/x")
+	assert.ErrorContains(t, err, "no recorded response found for request GET https://a.com/api/x")
+}
+
+func TestTokenRequestsAreNotRecordedButReplayed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		_, _ = rw.Write([]byte(`{"access_token":"real-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder()
+	client := &http.Client{Transport: recorder.Wrap(nil)}
+	_, err := client.PostForm(server.URL+"/sso/oauth2/token", url.Values{"grant_type": {"client_credentials"}, "client_secret": {"secret"}})
+	require.NoError(t, err)
+
+	assert.Empty(t, recorder.Cassette().Interactions)
+
+	replayClient := &http.Client{Transport: NewReplayer(recorder.Cassette())}
+	resp, err := replayClient.PostForm("https://sso.example.com/sso/oauth2/token", url.Values{"grant_type": {"client_credentials"}})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestLoadCassette_UnsupportedVersion(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "cassette.json", []byte(`{"version": 42}`), 0644))
+
+	_, err := LoadCassette(fs, "cassette.json")
+	assert.ErrorContains(t, err, "unsupported version 42")
+}
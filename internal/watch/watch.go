@@ -0,0 +1,160 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package watch detects changes of files by periodically comparing their modification times and sizes. Polling is
+// used instead of file system notifications, so that changes are detected on any afero.Fs.
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+type snapshot map[string]fileState
+
+// Watcher watches files and all files within directories for changes. Hidden directories are not watched.
+type Watcher struct {
+	fs       afero.Fs
+	paths    []string
+	interval time.Duration
+	last     snapshot
+}
+
+// New returns a Watcher checking the given files and directories in the given interval. The current state of the
+// files is taken as reference for the first call of Wait.
+func New(fs afero.Fs, interval time.Duration, paths ...string) (*Watcher, error) {
+	w := &Watcher{fs: fs, paths: paths, interval: interval}
+
+	s, err := w.snapshot(paths)
+	if err != nil {
+		return nil, err
+	}
+	w.last = s
+	return w, nil
+}
+
+// Add watches the given files and directories in addition to the ones already watched. The current state of their
+// files is taken as reference for the next call of Wait, unless they are watched already.
+func (w *Watcher) Add(paths ...string) error {
+	var added []string
+	for _, p := range paths {
+		if !slices.Contains(w.paths, p) && !slices.Contains(added, p) {
+			added = append(added, p)
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	s, err := w.snapshot(added)
+	if err != nil {
+		return err
+	}
+	for path, state := range s {
+		if _, found := w.last[path]; !found {
+			w.last[path] = state
+		}
+	}
+	w.paths = append(w.paths, added...)
+	return nil
+}
+
+// Wait blocks until at least one file has been created, changed or removed since the last call, and returns the
+// sorted paths of all such files. It returns the context's error if the context is done before.
+func (w *Watcher) Wait(ctx context.Context) ([]string, error) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, err := w.snapshot(w.paths)
+		if err != nil {
+			return nil, err
+		}
+
+		changed := diff(w.last, current)
+		w.last = current
+		if len(changed) > 0 {
+			return changed, nil
+		}
+	}
+}
+
+func (w *Watcher) snapshot(paths []string) (snapshot, error) {
+	s := snapshot{}
+	for _, root := range paths {
+		err := afero.Walk(w.fs, root, func(path string, info fs.FileInfo, err error) error {
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					// files may be removed while walking, and watched paths may not exist yet
+					return nil
+				}
+				return err
+			}
+
+			if info.IsDir() {
+				if path != root && len(info.Name()) > 1 && info.Name()[0] == '.' {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			s[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// diff returns the sorted paths of all files which are only contained in one of the snapshots, or differ between them
+func diff(previous, current snapshot) []string {
+	changed := map[string]struct{}{}
+	for path, state := range current {
+		if p, found := previous[path]; !found || !p.modTime.Equal(state.modTime) || p.size != state.size {
+			changed[path] = struct{}{}
+		}
+	}
+	for path := range previous {
+		if _, found := current[path]; !found {
+			changed[path] = struct{}{}
+		}
+	}
+
+	result := maps.Keys(changed)
+	slices.Sort(result)
+	return result
+}
@@ -0,0 +1,79 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package watch
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("project", "config.yaml"), []byte("configs: []"), 0644))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("project", ".git", "HEAD"), []byte("main"), 0644))
+
+	w, err := New(fs, time.Millisecond, "project", "manifest.yaml")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("detects changed and created files", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, filepath.Join("project", "config.yaml"), []byte("configs: [{}]"), 0644))
+		require.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte("manifestVersion: 1.0"), 0644))
+
+		changed, err := w.Wait(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"manifest.yaml", filepath.Join("project", "config.yaml")}, changed)
+	})
+
+	t.Run("detects removed files", func(t *testing.T) {
+		require.NoError(t, fs.Remove("manifest.yaml"))
+
+		changed, err := w.Wait(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"manifest.yaml"}, changed)
+	})
+
+	t.Run("ignores hidden directories", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, filepath.Join("project", ".git", "HEAD"), []byte("feature-branch"), 0644))
+
+		shortCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		_, err := w.Wait(shortCtx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("detects changes of added paths", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, filepath.Join("shared", "template.json"), []byte("{}"), 0644))
+		require.NoError(t, w.Add("project", "shared"))
+
+		require.NoError(t, afero.WriteFile(fs, filepath.Join("shared", "template.json"), []byte(`{"name": "shared"}`), 0644))
+
+		changed, err := w.Wait(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join("shared", "template.json")}, changed)
+	})
+}
@@ -0,0 +1,294 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"net/http"
+	"net/url"
+)
+
+// DefaultURL is the URL of the account management API of Dynatrace SaaS
+const DefaultURL = "https://api.dynatrace.com"
+
+type (
+	// Group is a user group of an account
+	Group struct {
+		UUID        string `json:"uuid,omitempty"`
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+	}
+
+	// Policy is an IAM policy defined on the account level
+	Policy struct {
+		UUID           string   `json:"uuid,omitempty"`
+		Name           string   `json:"name"`
+		Description    string   `json:"description,omitempty"`
+		Tags           []string `json:"tags,omitempty"`
+		StatementQuery string   `json:"statementQuery"`
+	}
+
+	// PolicyBinding holds all groups a policy is bound to
+	PolicyBinding struct {
+		PolicyUUID string   `json:"policyUuid"`
+		Groups     []string `json:"groups"`
+	}
+
+	// Permission is a permission of a group, e.g. 'tenant-viewer' on an environment
+	Permission struct {
+		PermissionName string `json:"permissionName"`
+		Scope          string `json:"scope"`
+		ScopeType      string `json:"scopeType"`
+	}
+
+	listGroupsResponse struct {
+		Items []Group `json:"items"`
+	}
+
+	listPoliciesResponse struct {
+		Policies []Policy `json:"policies"`
+	}
+
+	listBindingsResponse struct {
+		PolicyBindings []PolicyBinding `json:"policyBindings"`
+	}
+
+	groupPermissionsResponse struct {
+		Permissions []Permission `json:"permissions"`
+	}
+
+	updateBindingsRequest struct {
+		PolicyUUIDs []string `json:"policyUuids"`
+	}
+
+	// Client abstracts the account management API of a single account. Policy bindings are managed on the level of the
+	// environment set by WithEnvironment.
+	Client struct {
+		url           string
+		accountUUID   string
+		client        *rest.Client
+		environmentID string
+	}
+)
+
+// NewClient creates a new client to interact with the account management API of the given account
+func NewClient(url, accountUUID string, client *rest.Client) *Client {
+	return &Client{
+		url:         url,
+		accountUUID: accountUUID,
+		client:      client,
+	}
+}
+
+// WithEnvironment returns a copy of the client which manages policy bindings of the given environment
+func (c *Client) WithEnvironment(environmentID string) *Client {
+	cp := *c
+	cp.environmentID = environmentID
+	return &cp
+}
+
+// ListGroups returns all user groups of the account
+func (c *Client) ListGroups(ctx context.Context) ([]Group, error) {
+	var r listGroupsResponse
+	if err := c.get(ctx, "user groups", &r, "iam/v1/accounts", c.accountUUID, "groups"); err != nil {
+		return nil, err
+	}
+	return r.Items, nil
+}
+
+// CreateGroup creates the given user group and returns it including its UUID
+func (c *Client) CreateGroup(ctx context.Context, group Group) (Group, error) {
+	var created []Group
+	if err := c.send(ctx, http.MethodPost, fmt.Sprintf("user group %q", group.Name), []Group{group}, &created, "iam/v1/accounts", c.accountUUID, "groups"); err != nil {
+		return Group{}, err
+	}
+	if len(created) != 1 {
+		return Group{}, fmt.Errorf("failed to create user group %q: expected one created group, but got %d", group.Name, len(created))
+	}
+	return created[0], nil
+}
+
+// UpdateGroup updates the user group with the UUID of the given group
+func (c *Client) UpdateGroup(ctx context.Context, group Group) error {
+	return c.send(ctx, http.MethodPut, fmt.Sprintf("user group %q", group.Name), group, nil, "iam/v1/accounts", c.accountUUID, "groups", group.UUID)
+}
+
+// DeleteGroup deletes the user group with the given UUID
+func (c *Client) DeleteGroup(ctx context.Context, uuid string) error {
+	return c.delete(ctx, fmt.Sprintf("user group %q", uuid), "iam/v1/accounts", c.accountUUID, "groups", uuid)
+}
+
+// ListPolicies returns all policies defined on the account level. The statements of the policies are not returned.
+func (c *Client) ListPolicies(ctx context.Context) ([]Policy, error) {
+	var r listPoliciesResponse
+	if err := c.get(ctx, "policies", &r, "iam/v1/repo/account", c.accountUUID, "policies"); err != nil {
+		return nil, err
+	}
+	return r.Policies, nil
+}
+
+// GetPolicy returns the account level policy with the given UUID
+func (c *Client) GetPolicy(ctx context.Context, uuid string) (Policy, error) {
+	var p Policy
+	if err := c.get(ctx, fmt.Sprintf("policy %q", uuid), &p, "iam/v1/repo/account", c.accountUUID, "policies", uuid); err != nil {
+		return Policy{}, err
+	}
+	return p, nil
+}
+
+// CreatePolicy creates the given account level policy and returns it including its UUID
+func (c *Client) CreatePolicy(ctx context.Context, policy Policy) (Policy, error) {
+	var created Policy
+	if err := c.send(ctx, http.MethodPost, fmt.Sprintf("policy %q", policy.Name), policy, &created, "iam/v1/repo/account", c.accountUUID, "policies"); err != nil {
+		return Policy{}, err
+	}
+	return created, nil
+}
+
+// UpdatePolicy updates the account level policy with the UUID of the given policy
+func (c *Client) UpdatePolicy(ctx context.Context, policy Policy) error {
+	uuid := policy.UUID
+	policy.UUID = ""
+	return c.send(ctx, http.MethodPut, fmt.Sprintf("policy %q", policy.Name), policy, nil, "iam/v1/repo/account", c.accountUUID, "policies", uuid)
+}
+
+// DeletePolicy deletes the account level policy with the given UUID
+func (c *Client) DeletePolicy(ctx context.Context, uuid string) error {
+	return c.delete(ctx, fmt.Sprintf("policy %q", uuid), "iam/v1/repo/account", c.accountUUID, "policies", uuid)
+}
+
+// ListEnvironmentBindings returns all policy bindings of the environment of the client
+func (c *Client) ListEnvironmentBindings(ctx context.Context) ([]PolicyBinding, error) {
+	if c.environmentID == "" {
+		return nil, errors.New("no environment defined to list policy bindings of")
+	}
+
+	var r listBindingsResponse
+	if err := c.get(ctx, "policy bindings", &r, "iam/v1/repo/environment", c.environmentID, "bindings"); err != nil {
+		return nil, err
+	}
+	return r.PolicyBindings, nil
+}
+
+// UpdateEnvironmentBindings binds exactly the given policies to the given group on the environment of the client. All
+// other policies bound to the group on the environment are unbound.
+func (c *Client) UpdateEnvironmentBindings(ctx context.Context, groupUUID string, policyUUIDs []string) error {
+	if c.environmentID == "" {
+		return errors.New("no environment defined to bind policies on")
+	}
+	if policyUUIDs == nil {
+		policyUUIDs = []string{}
+	}
+
+	return c.send(ctx, http.MethodPut, fmt.Sprintf("policy bindings of user group %q", groupUUID), updateBindingsRequest{PolicyUUIDs: policyUUIDs}, nil, "iam/v1/repo/environment", c.environmentID, "bindings/groups", groupUUID)
+}
+
+// GetGroupPermissions returns all permissions of the given group
+func (c *Client) GetGroupPermissions(ctx context.Context, groupUUID string) ([]Permission, error) {
+	var r groupPermissionsResponse
+	if err := c.get(ctx, fmt.Sprintf("permissions of user group %q", groupUUID), &r, "iam/v1/accounts", c.accountUUID, "groups", groupUUID, "permissions"); err != nil {
+		return nil, err
+	}
+	return r.Permissions, nil
+}
+
+// UpdateGroupPermissions replaces all permissions of the given group with the given ones
+func (c *Client) UpdateGroupPermissions(ctx context.Context, groupUUID string, permissions []Permission) error {
+	if permissions == nil {
+		permissions = []Permission{}
+	}
+	return c.send(ctx, http.MethodPut, fmt.Sprintf("permissions of user group %q", groupUUID), permissions, nil, "iam/v1/accounts", c.accountUUID, "groups", groupUUID, "permissions")
+}
+
+func (c *Client) get(ctx context.Context, what string, result any, path ...string) error {
+	u, err := url.JoinPath(c.url, path...)
+	if err != nil {
+		return fmt.Errorf("failed to create sound url: %w", err)
+	}
+
+	resp, err := c.client.Get(ctx, u)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", what, err)
+	}
+	if !resp.IsSuccess() {
+		return rest.NewRespErr(fmt.Sprintf("failed to get %s (HTTP %d): %s", what, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodGet, u)
+	}
+
+	if err := json.Unmarshal(resp.Body, result); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", what, err)
+	}
+	return nil
+}
+
+// send sends the given payload with a POST or PUT request. If result is not nil, the response is unmarshalled into it.
+func (c *Client) send(ctx context.Context, method string, what string, payload any, result any, path ...string) error {
+	u, err := url.JoinPath(c.url, path...)
+	if err != nil {
+		return fmt.Errorf("failed to create sound url: %w", err)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", what, err)
+	}
+
+	var resp rest.Response
+	if method == http.MethodPost {
+		resp, err = c.client.Post(ctx, u, data)
+	} else {
+		resp, err = c.client.Put(ctx, u, data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", what, err)
+	}
+	if !resp.IsSuccess() {
+		return rest.NewRespErr(fmt.Sprintf("failed to update %s (HTTP %d): %s", what, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(method, u)
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(resp.Body, result); err != nil {
+			return fmt.Errorf("failed to unmarshal %s: %w", what, err)
+		}
+	}
+	log.WithCtxFields(ctx).Debug("Updated %s", what)
+	return nil
+}
+
+func (c *Client) delete(ctx context.Context, what string, path ...string) error {
+	u, err := url.JoinPath(c.url, path...)
+	if err != nil {
+		return fmt.Errorf("failed to create sound url: %w", err)
+	}
+
+	resp, err := c.client.Delete(ctx, u)
+	if err != nil {
+		return fmt.Errorf("unable to delete %s: %w", what, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		log.Debug("No %s found to delete (HTTP 404 response)", what)
+		return nil
+	}
+	if !resp.IsSuccess() {
+		return rest.NewRespErr(fmt.Sprintf("unable to delete %s (HTTP %d): %s", what, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodDelete, u)
+	}
+	return nil
+}
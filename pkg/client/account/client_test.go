@@ -0,0 +1,194 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account_test
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/account"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const accountUUID = "1d0a8a6c-3c3a-4ad9-a5c7-6d4c2f5ad6a1"
+
+func newClient(server *httptest.Server) *account.Client {
+	return account.NewClient(server.URL, accountUUID, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy()))
+}
+
+func TestGroups(t *testing.T) {
+	t.Run("lists groups", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/iam/v1/accounts/"+accountUUID+"/groups", r.URL.Path)
+			_, _ = w.Write([]byte(`{"count": 1, "items": [{"uuid": "g-1", "name": "devs", "description": "developers", "owner": "LOCAL"}]}`))
+		}))
+		defer server.Close()
+
+		groups, err := newClient(server).ListGroups(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, []account.Group{{UUID: "g-1", Name: "devs", Description: "developers"}}, groups)
+	})
+
+	t.Run("creates group", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/iam/v1/accounts/"+accountUUID+"/groups", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `[{"name": "devs", "description": "developers"}]`, string(body))
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`[{"uuid": "g-1", "name": "devs", "description": "developers"}]`))
+		}))
+		defer server.Close()
+
+		group, err := newClient(server).CreateGroup(context.TODO(), account.Group{Name: "devs", Description: "developers"})
+		require.NoError(t, err)
+		assert.Equal(t, "g-1", group.UUID)
+	})
+
+	t.Run("updates group", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/iam/v1/accounts/"+accountUUID+"/groups/g-1", r.URL.Path)
+		}))
+		defer server.Close()
+
+		err := newClient(server).UpdateGroup(context.TODO(), account.Group{UUID: "g-1", Name: "devs"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("ignores deleting missing groups", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		err := newClient(server).DeleteGroup(context.TODO(), "g-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns error responses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": "forbidden"}`))
+		}))
+		defer server.Close()
+
+		_, err := newClient(server).ListGroups(context.TODO())
+		var respErr rest.RespError
+		assert.ErrorAs(t, err, &respErr)
+		assert.Equal(t, http.StatusForbidden, respErr.StatusCode)
+	})
+}
+
+func TestPolicies(t *testing.T) {
+	t.Run("creates policy", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/iam/v1/repo/account/"+accountUUID+"/policies", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"name": "read", "statementQuery": "ALLOW settings:objects:read;"}`, string(body))
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"uuid": "p-1", "name": "read", "statementQuery": "ALLOW settings:objects:read;"}`))
+		}))
+		defer server.Close()
+
+		policy, err := newClient(server).CreatePolicy(context.TODO(), account.Policy{Name: "read", StatementQuery: "ALLOW settings:objects:read;"})
+		require.NoError(t, err)
+		assert.Equal(t, "p-1", policy.UUID)
+	})
+
+	t.Run("updates policy without sending its UUID", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/iam/v1/repo/account/"+accountUUID+"/policies/p-1", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"name": "read", "statementQuery": "ALLOW settings:objects:read;"}`, string(body))
+		}))
+		defer server.Close()
+
+		err := newClient(server).UpdatePolicy(context.TODO(), account.Policy{UUID: "p-1", Name: "read", StatementQuery: "ALLOW settings:objects:read;"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestEnvironmentBindings(t *testing.T) {
+	t.Run("binds policies on the environment", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/iam/v1/repo/environment/abc12345/bindings/groups/g-1", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"policyUuids": ["p-1", "p-2"]}`, string(body))
+		}))
+		defer server.Close()
+
+		err := newClient(server).WithEnvironment("abc12345").UpdateEnvironmentBindings(context.TODO(), "g-1", []string{"p-1", "p-2"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("lists bindings of the environment", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/iam/v1/repo/environment/abc12345/bindings", r.URL.Path)
+			_, _ = w.Write([]byte(`{"levelType": "environment", "levelId": "abc12345", "policyBindings": [{"policyUuid": "p-1", "groups": ["g-1"]}]}`))
+		}))
+		defer server.Close()
+
+		bindings, err := newClient(server).WithEnvironment("abc12345").ListEnvironmentBindings(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, []account.PolicyBinding{{PolicyUUID: "p-1", Groups: []string{"g-1"}}}, bindings)
+	})
+
+	t.Run("fails without environment", func(t *testing.T) {
+		err := account.NewClient("http://localhost", accountUUID, nil).UpdateEnvironmentBindings(context.TODO(), "g-1", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestGroupPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/iam/v1/accounts/"+accountUUID+"/groups/g-1/permissions", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"groupUuid": "g-1", "permissions": [{"permissionName": "tenant-viewer", "scope": "abc12345", "scopeType": "tenant"}]}`))
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `[]`, string(body))
+		}
+	}))
+	defer server.Close()
+
+	permissions, err := newClient(server).GetGroupPermissions(context.TODO(), "g-1")
+	require.NoError(t, err)
+	assert.Equal(t, []account.Permission{{PermissionName: "tenant-viewer", Scope: "abc12345", ScopeType: "tenant"}}, permissions)
+
+	err = newClient(server).UpdateGroupPermissions(context.TODO(), "g-1", nil)
+	assert.NoError(t, err)
+}
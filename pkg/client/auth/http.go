@@ -38,10 +38,16 @@ type OauthCredentials struct {
 
 // NewTokenAuthClient creates a new HTTP client that supports token based authorization
 func NewTokenAuthClient(token string) *http.Client {
+	return NewTokenAuthClientWithTransport(nil, token)
+}
+
+// NewTokenAuthClientWithTransport creates a new HTTP client that supports token based authorization and sends
+// requests using the given base transport. If baseTransport is nil, http.DefaultTransport is used.
+func NewTokenAuthClientWithTransport(baseTransport http.RoundTripper, token string) *http.Client {
 	if !isNewDynatraceTokenFormat(token) {
 		log.Warn("The supplied token does not match the expected format and may be invalid. If authentication fails, please check your manifest and environment variable configuration.\nIf you are using a token created before Dynatrace 1.205, please consider generating a new token: https://www.dynatrace.com/support/help/shortlink/api-authentication")
 	}
-	return &http.Client{Transport: NewTokenAuthTransport(nil, token)}
+	return &http.Client{Transport: NewTokenAuthTransport(baseTransport, token)}
 }
 
 // NewOAuthClient creates a new HTTP client that supports OAuth2 client credentials based authorization
@@ -18,18 +18,28 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/concurrency"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/trafficlogs"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/account"
 	clientAuth "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/auth"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/automation"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/document"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/extension"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/metadata"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
+	"golang.org/x/oauth2"
+	"net/http"
+	"net/url"
 	"runtime"
+	"time"
 )
 
 // ClientSet composes a "full" set of sub-clients to access Dynatrace APIs
@@ -42,6 +52,10 @@ type ClientSet struct {
 	autClient *automation.Client
 	// bucketClient is the client capable of updating or creating Grail Bucket configs
 	bucketClient *bucket.Client
+	// extensionClient is the client capable of uploading Extensions 2.0 and updating or creating their monitoring configurations
+	extensionClient *extension.Client
+	// documentClient is the client capable of updating or creating platform documents, like dashboards and notebooks
+	documentClient *document.Client
 }
 
 func (s ClientSet) Classic() *dtclient.DynatraceClient {
@@ -64,10 +78,36 @@ func (s ClientSet) Bucket() *bucket.Client {
 	return s.bucketClient
 }
 
+func (s ClientSet) Extension() *extension.Client {
+	return s.extensionClient
+}
+
+func (s ClientSet) Document() *document.Client {
+	return s.documentClient
+}
+
 type ClientOptions struct {
 	CustomUserAgent string
 	SupportArchive  bool
 	CachingDisabled bool
+
+	// ConcurrentRequests limits the number of parallel requests. If it is not positive, the limit defined by the
+	// environment.ConcurrentRequestsEnvKey environment variable is used.
+	ConcurrentRequests int
+	// RequestsPerSecond limits the number of requests sent per second. If it is not positive, no limit is applied.
+	RequestsPerSecond float64
+	// RetrySettings overrides rest.DefaultRetrySettings if set
+	RetrySettings *rest.RetrySettings
+	// ProxyURL is the URL of an HTTP proxy all requests are sent through. If empty, the proxy is taken from the
+	// HTTP_PROXY/HTTPS_PROXY environment variables.
+	ProxyURL string
+	// CACertificates are PEM encoded certificates of authorities to trust in addition to the system ones
+	CACertificates []byte
+	// RequestTimeout is the maximum duration of a single HTTP request. If it is 0, requests don't time out.
+	RequestTimeout time.Duration
+	// WrapTransport is an optional function wrapping the base transport of all HTTP clients, e.g. to record or replay
+	// all HTTP traffic.
+	WrapTransport func(base http.RoundTripper) http.RoundTripper
 }
 
 func (o ClientOptions) getUserAgentString() string {
@@ -77,22 +117,93 @@ func (o ClientOptions) getUserAgentString() string {
 	return o.CustomUserAgent
 }
 
+func (o ClientOptions) getConcurrentRequestLimit() int {
+	if o.ConcurrentRequests > 0 {
+		return o.ConcurrentRequests
+	}
+	return environment.GetEnvValueIntLog(environment.ConcurrentRequestsEnvKey)
+}
+
+func (o ClientOptions) getRetrySettings() rest.RetrySettings {
+	if o.RetrySettings != nil {
+		return *o.RetrySettings
+	}
+	return rest.DefaultRetrySettings
+}
+
+// createRateLimitStrategy creates the rate limiting strategy shared by all clients of a ClientSet
+func (o ClientOptions) createRateLimitStrategy() rest.RateLimitStrategy {
+	return rest.CreateRateLimitStrategyWithBudget(o.RequestsPerSecond)
+}
+
+// createTransport creates the base transport of all HTTP clients of a ClientSet, honoring the proxy and CA options.
+// If set, the transport is wrapped by WrapTransport.
+func (o ClientOptions) createTransport() (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if o.ProxyURL != "" {
+		proxyURL, err := url.Parse(o.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", o.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(o.CACertificates) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(o.CACertificates) {
+			return nil, errors.New("CA certificates do not contain any valid PEM encoded certificate")
+		}
+
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	if o.WrapTransport != nil {
+		return o.WrapTransport(transport), nil
+	}
+	return transport, nil
+}
+
+func (o ClientOptions) newTokenAuthClient(transport http.RoundTripper, token string) *http.Client {
+	c := clientAuth.NewTokenAuthClientWithTransport(transport, token)
+	c.Timeout = o.RequestTimeout
+	return c
+}
+
+func (o ClientOptions) newOAuthClient(transport http.RoundTripper, credentials clientAuth.OauthCredentials) *http.Client {
+	// the OAuth client uses the HTTP client stored in the context for fetching tokens, as well as for sending requests
+	ctx := context.WithValue(context.TODO(), oauth2.HTTPClient, &http.Client{Transport: transport, Timeout: o.RequestTimeout})
+	c := clientAuth.NewOAuthClient(ctx, credentials)
+	c.Timeout = o.RequestTimeout
+	return c
+}
+
 func CreateClassicClientSet(url string, token string, opts ClientOptions) (*ClientSet, error) {
-	concurrentRequestLimit := environment.GetEnvValueIntLog(environment.ConcurrentRequestsEnvKey)
+	transport, err := opts.createTransport()
+	if err != nil {
+		return nil, err
+	}
 
-	tokenClient := clientAuth.NewTokenAuthClient(token)
+	tokenClient := opts.newTokenAuthClient(transport, token)
 	var trafficLogger *trafficlogs.FileBasedLogger
 	if opts.SupportArchive {
 		trafficLogger = trafficlogs.NewFileBased()
 	}
 
-	restClient := rest.NewRestClient(tokenClient, trafficLogger, rest.CreateRateLimitStrategy())
+	restClient := rest.NewRestClient(tokenClient, trafficLogger, opts.createRateLimitStrategy())
 	dtClient, err := dtclient.NewClassicClient(
 		url,
 		restClient,
 		dtclient.WithCachingDisabled(opts.CachingDisabled),
 		dtclient.WithAutoServerVersion(),
-		dtclient.WithClientRequestLimiter(concurrency.NewLimiter(concurrentRequestLimit)),
+		dtclient.WithClientRequestLimiter(concurrency.NewLimiter(opts.getConcurrentRequestLimit())),
+		dtclient.WithRetrySettings(opts.getRetrySettings()),
 		dtclient.WithCustomUserAgentString(opts.getUserAgentString()),
 	)
 	if err != nil {
@@ -100,8 +211,9 @@ func CreateClassicClientSet(url string, token string, opts ClientOptions) (*Clie
 	}
 
 	return &ClientSet{
-		dtClient:  dtClient,
-		autClient: nil,
+		dtClient:        dtClient,
+		autClient:       nil,
+		extensionClient: extension.NewClient(url, restClient),
 	}, nil
 }
 
@@ -111,7 +223,12 @@ type PlatformAuth struct {
 }
 
 func CreatePlatformClientSet(url string, auth PlatformAuth, opts ClientOptions) (*ClientSet, error) {
-	concurrentRequestLimit := environment.GetEnvValueIntLog(environment.ConcurrentRequestsEnvKey)
+	concurrentRequestLimit := opts.getConcurrentRequestLimit()
+
+	transport, err := opts.createTransport()
+	if err != nil {
+		return nil, err
+	}
 
 	oauthCredentials := clientAuth.OauthCredentials{
 		ClientID:     auth.OauthClientID,
@@ -119,9 +236,10 @@ func CreatePlatformClientSet(url string, auth PlatformAuth, opts ClientOptions)
 		TokenURL:     auth.OauthTokenURL,
 	}
 
-	tokenClient := clientAuth.NewTokenAuthClient(auth.Token)
-	oauthClient := clientAuth.NewOAuthClient(context.TODO(), oauthCredentials)
-	classicURL, err := metadata.GetDynatraceClassicURL(context.TODO(), rest.NewRestClient(oauthClient, nil, rest.CreateRateLimitStrategy()), url) //this will send the default user-agent
+	rateLimitStrategy := opts.createRateLimitStrategy()
+	tokenClient := opts.newTokenAuthClient(transport, auth.Token)
+	oauthClient := opts.newOAuthClient(transport, oauthCredentials)
+	classicURL, err := metadata.GetDynatraceClassicURL(context.TODO(), rest.NewRestClient(oauthClient, nil, rateLimitStrategy), url) //this will send the default user-agent
 	if err != nil {
 		return nil, err
 	}
@@ -130,8 +248,8 @@ func CreatePlatformClientSet(url string, auth PlatformAuth, opts ClientOptions)
 	if opts.SupportArchive {
 		trafficLogger = trafficlogs.NewFileBased()
 	}
-	client := rest.NewRestClient(oauthClient, trafficLogger, rest.CreateRateLimitStrategy())
-	clientClassic := rest.NewRestClient(tokenClient, trafficLogger, rest.CreateRateLimitStrategy())
+	client := rest.NewRestClient(oauthClient, trafficLogger, rateLimitStrategy)
+	clientClassic := rest.NewRestClient(tokenClient, trafficLogger, rateLimitStrategy)
 
 	dtClient, err := dtclient.NewPlatformClient(
 		url,
@@ -141,9 +259,10 @@ func CreatePlatformClientSet(url string, auth PlatformAuth, opts ClientOptions)
 		dtclient.WithCachingDisabled(opts.CachingDisabled),
 		dtclient.WithAutoServerVersion(),
 		dtclient.WithClientRequestLimiter(concurrency.NewLimiter(concurrentRequestLimit)),
+		dtclient.WithRetrySettings(opts.getRetrySettings()),
 		dtclient.WithCustomUserAgentString(opts.getUserAgentString()),
 	)
-	platformClient := rest.NewRestClient(clientAuth.NewOAuthClient(context.TODO(), oauthCredentials), trafficLogger, rest.CreateRateLimitStrategy())
+	platformClient := rest.NewRestClient(opts.newOAuthClient(transport, oauthCredentials), trafficLogger, rateLimitStrategy)
 
 	autClient := automation.NewClient(
 		url,
@@ -159,8 +278,41 @@ func CreatePlatformClientSet(url string, auth PlatformAuth, opts ClientOptions)
 	}
 
 	return &ClientSet{
-		dtClient:     dtClient,
-		autClient:    autClient,
-		bucketClient: bucketClient,
+		dtClient:        dtClient,
+		autClient:       autClient,
+		bucketClient:    bucketClient,
+		extensionClient: extension.NewClient(classicURL, clientClassic),
+		documentClient:  document.NewClient(url, platformClient),
 	}, nil
 }
+
+// AccountAuth holds the OAuth client credentials used to access the account management API
+type AccountAuth struct {
+	OauthClientID, OauthClientSecret, OauthTokenURL string
+}
+
+// CreateAccountClient creates a client for the account management API of the given account. If url is empty, the API
+// of Dynatrace SaaS is used.
+func CreateAccountClient(url string, accountUUID string, auth AccountAuth, opts ClientOptions) (*account.Client, error) {
+	if url == "" {
+		url = account.DefaultURL
+	}
+
+	transport, err := opts.createTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	var trafficLogger *trafficlogs.FileBasedLogger
+	if opts.SupportArchive {
+		trafficLogger = trafficlogs.NewFileBased()
+	}
+
+	oauthClient := opts.newOAuthClient(transport, clientAuth.OauthCredentials{
+		ClientID:     auth.OauthClientID,
+		ClientSecret: auth.OauthClientSecret,
+		TokenURL:     auth.OauthTokenURL,
+	})
+
+	return account.NewClient(url, accountUUID, rest.NewRestClient(oauthClient, trafficLogger, opts.createRateLimitStrategy())), nil
+}
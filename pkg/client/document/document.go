@@ -0,0 +1,394 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/concurrency"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+)
+
+const (
+	endpoint      = "platform/document/v1/documents"
+	trashEndpoint = "platform/document/v1/trash/documents"
+	pageSize      = 1000
+)
+
+type (
+	// Metadata holds everything of a document except its content
+	Metadata struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		Type      string `json:"type"`
+		Version   int    `json:"version"`
+		Owner     string `json:"owner"`
+		IsPrivate bool   `json:"isPrivate"`
+	}
+
+	// Document is a document together with its content
+	Document struct {
+		Metadata
+		Content []byte
+	}
+
+	// OwnershipError is returned if a document can not be modified, as it is owned by another user
+	OwnershipError struct {
+		ID    string
+		Owner string
+		Err   error
+	}
+
+	listResponse struct {
+		Documents   []Metadata `json:"documents"`
+		NextPageKey string     `json:"nextPageKey"`
+	}
+
+	updateResponse struct {
+		DocumentMetadata Metadata `json:"documentMetadata"`
+	}
+
+	// Client abstracts the API access for platform documents, like dashboards and notebooks
+	Client struct {
+		url     string
+		client  *rest.Client
+		limiter *concurrency.Limiter
+	}
+)
+
+func (e OwnershipError) Error() string {
+	return fmt.Sprintf("document %q is owned by %q and can not be modified by the deploying user - either transfer its ownership or share it with write access: %v", e.ID, e.Owner, e.Err)
+}
+
+func (e OwnershipError) Unwrap() error {
+	return e.Err
+}
+
+// NewClient creates a new client to interact with the document API
+func NewClient(url string, client *rest.Client) *Client {
+	return &Client{
+		url:     url,
+		client:  client,
+		limiter: concurrency.NewLimiter(5),
+	}
+}
+
+// Upsert creates the document with the given ID, or updates it if it already exists. Private documents are only
+// visible to their owner, all others are shared with every user of the environment.
+func (c Client) Upsert(ctx context.Context, id, name, documentType string, private bool, content []byte) (result Metadata, err error) {
+	if id == "" {
+		return Metadata{}, fmt.Errorf("id must be non empty")
+	}
+	c.limiter.ExecuteBlocking(func() {
+		result, err = c.upsert(ctx, id, name, documentType, private, content)
+	})
+	return
+}
+
+func (c Client) upsert(ctx context.Context, id, name, documentType string, private bool, content []byte) (Metadata, error) {
+	existing, found, err := c.getMetadata(ctx, id)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	if !found {
+		return c.create(ctx, id, name, documentType, private, content)
+	}
+
+	if existing.Type != documentType {
+		return Metadata{}, fmt.Errorf("document %q is of type %q, and can not be updated to type %q", id, existing.Type, documentType)
+	}
+	return c.update(ctx, existing, name, private, content)
+}
+
+func (c Client) create(ctx context.Context, id, name, documentType string, private bool, content []byte) (Metadata, error) {
+	u, err := url.JoinPath(c.url, endpoint)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to create sound url: %w", err)
+	}
+
+	body, contentType, err := multipartBody(map[string]string{
+		"id":        id,
+		"name":      name,
+		"type":      documentType,
+		"isPrivate": strconv.FormatBool(private),
+	}, content)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to create document %q: %w", id, err)
+	}
+
+	resp, err := c.client.PostMultiPartFile(ctx, u, body, contentType)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to create document %q: %w", id, err)
+	}
+	if !resp.IsSuccess() {
+		return Metadata{}, rest.NewRespErr(fmt.Sprintf("failed to create document %q (HTTP %d): %s", id, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodPost, u)
+	}
+
+	var m Metadata
+	if err := json.Unmarshal(resp.Body, &m); err != nil {
+		return Metadata{}, fmt.Errorf("failed to unmarshal created document %q: %w", id, err)
+	}
+	log.WithCtxFields(ctx).Debug("Created document with ID %s", id)
+	return m, nil
+}
+
+// update updates the given existing document. Documents are updated using optimistic locking, so the version of the
+// existing document is sent along.
+func (c Client) update(ctx context.Context, existing Metadata, name string, private bool, content []byte) (Metadata, error) {
+	u, err := c.documentURL(existing.ID, existing.Version)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	body, contentType, err := multipartBody(map[string]string{
+		"name":      name,
+		"isPrivate": strconv.FormatBool(private),
+	}, content)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to update document %q: %w", existing.ID, err)
+	}
+
+	resp, err := c.client.PatchMultiPartFile(ctx, u, body, contentType)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to update document %q: %w", existing.ID, err)
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		return Metadata{}, OwnershipError{ID: existing.ID, Owner: existing.Owner, Err: rest.NewRespErr(fmt.Sprintf("failed to update document %q (HTTP %d): %s", existing.ID, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodPatch, u)}
+	}
+	if !resp.IsSuccess() {
+		return Metadata{}, rest.NewRespErr(fmt.Sprintf("failed to update document %q (HTTP %d): %s", existing.ID, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodPatch, u)
+	}
+
+	var r updateResponse
+	if err := json.Unmarshal(resp.Body, &r); err != nil {
+		return Metadata{}, fmt.Errorf("failed to unmarshal updated document %q: %w", existing.ID, err)
+	}
+	log.WithCtxFields(ctx).Debug("Updated document with ID %s", existing.ID)
+	return r.DocumentMetadata, nil
+}
+
+// Get returns the document with the given ID, including its content
+func (c Client) Get(ctx context.Context, id string) (result Document, err error) {
+	c.limiter.ExecuteBlocking(func() {
+		result, err = c.get(ctx, id)
+	})
+	return
+}
+
+func (c Client) get(ctx context.Context, id string) (Document, error) {
+	m, found, err := c.getMetadata(ctx, id)
+	if err != nil {
+		return Document{}, err
+	}
+	if !found {
+		return Document{}, fmt.Errorf("document %q does not exist", id)
+	}
+
+	u, err := url.JoinPath(c.url, endpoint, id, "content")
+	if err != nil {
+		return Document{}, fmt.Errorf("failed to create sound url: %w", err)
+	}
+
+	resp, err := c.client.Get(ctx, u)
+	if err != nil {
+		return Document{}, fmt.Errorf("failed to get content of document %q: %w", id, err)
+	}
+	if !resp.IsSuccess() {
+		return Document{}, rest.NewRespErr(fmt.Sprintf("failed to get content of document %q (HTTP %d): %s", id, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodGet, u)
+	}
+
+	return Document{Metadata: m, Content: resp.Body}, nil
+}
+
+// getMetadata returns the metadata of the document with the given ID. If the document does not exist, found is false.
+func (c Client) getMetadata(ctx context.Context, id string) (m Metadata, found bool, err error) {
+	u, err := url.JoinPath(c.url, endpoint, id, "metadata")
+	if err != nil {
+		return Metadata{}, false, fmt.Errorf("failed to create sound url: %w", err)
+	}
+
+	resp, err := c.client.Get(ctx, u)
+	if err != nil {
+		return Metadata{}, false, fmt.Errorf("failed to get document %q: %w", id, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return Metadata{}, false, nil
+	}
+	if !resp.IsSuccess() {
+		return Metadata{}, false, rest.NewRespErr(fmt.Sprintf("failed to get document %q (HTTP %d): %s", id, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodGet, u)
+	}
+
+	if err := json.Unmarshal(resp.Body, &m); err != nil {
+		return Metadata{}, false, fmt.Errorf("failed to unmarshal document %q: %w", id, err)
+	}
+	return m, true, nil
+}
+
+// List returns the metadata of all documents of the given type which are accessible by the user
+func (c Client) List(ctx context.Context, documentType string) (result []Metadata, err error) {
+	c.limiter.ExecuteBlocking(func() {
+		result, err = c.list(ctx, documentType)
+	})
+	return
+}
+
+func (c Client) list(ctx context.Context, documentType string) ([]Metadata, error) {
+	u, err := url.Parse(c.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+	u = u.JoinPath(endpoint)
+
+	var result []Metadata
+	pageKey := ""
+	for {
+		q := url.Values{}
+		if pageKey == "" {
+			q.Set("filter", fmt.Sprintf("type == '%s'", documentType))
+			q.Set("page-size", strconv.Itoa(pageSize))
+		} else {
+			// the page key holds all other query parameters of the first request
+			q.Set("page-key", pageKey)
+		}
+		u.RawQuery = q.Encode()
+
+		resp, err := c.client.Get(ctx, u.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list documents of type %q: %w", documentType, err)
+		}
+		if !resp.IsSuccess() {
+			return nil, rest.NewRespErr(fmt.Sprintf("failed to list documents of type %q (HTTP %d): %s", documentType, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodGet, u.String())
+		}
+
+		var r listResponse
+		if err := json.Unmarshal(resp.Body, &r); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal documents of type %q: %w", documentType, err)
+		}
+		result = append(result, r.Documents...)
+
+		if r.NextPageKey == "" {
+			return result, nil
+		}
+		pageKey = r.NextPageKey
+	}
+}
+
+// Delete removes the document with the given ID. Deleted documents are moved to the trash by the API, so they are
+// removed from the trash as well - otherwise no document with the same ID could be created again.
+func (c Client) Delete(ctx context.Context, id string) (err error) {
+	if id == "" {
+		return fmt.Errorf("id must be non empty")
+	}
+	c.limiter.ExecuteBlocking(func() {
+		err = c.delete(ctx, id)
+	})
+	return
+}
+
+func (c Client) delete(ctx context.Context, id string) error {
+	existing, found, err := c.getMetadata(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		log.Debug("No document with id '%s' found to delete (HTTP 404 response)", id)
+		return nil
+	}
+
+	u, err := c.documentURL(id, existing.Version)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Delete(ctx, u)
+	if err != nil {
+		return fmt.Errorf("unable to delete document %q: %w", id, err)
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		return OwnershipError{ID: id, Owner: existing.Owner, Err: rest.NewRespErr(fmt.Sprintf("unable to delete document %q (HTTP %d): %s", id, resp.StatusCode, resp.Body), resp).WithRequestInfo(http.MethodDelete, u)}
+	}
+	if !resp.IsSuccess() && resp.StatusCode != http.StatusNotFound {
+		return rest.NewRespErr(fmt.Sprintf("unable to delete document %q (HTTP %d): %s", id, resp.StatusCode, resp.Body), resp).WithRequestInfo(http.MethodDelete, u)
+	}
+
+	u, err = url.JoinPath(c.url, trashEndpoint, id)
+	if err != nil {
+		return fmt.Errorf("failed to create sound url: %w", err)
+	}
+
+	resp, err = c.client.Delete(ctx, u)
+	if err != nil {
+		return fmt.Errorf("unable to remove document %q from trash: %w", id, err)
+	}
+	if !resp.IsSuccess() && resp.StatusCode != http.StatusNotFound {
+		return rest.NewRespErr(fmt.Sprintf("unable to remove document %q from trash (HTTP %d): %s", id, resp.StatusCode, resp.Body), resp).WithRequestInfo(http.MethodDelete, u)
+	}
+	return nil
+}
+
+// documentURL returns the URL of the document with the given ID, using the given version for optimistic locking
+func (c Client) documentURL(id string, version int) (string, error) {
+	u, err := url.Parse(c.url)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse url: %w", err)
+	}
+	u = u.JoinPath(endpoint, id)
+
+	q := u.Query()
+	q.Set("optimistic-locking-version", strconv.Itoa(version))
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// multipartBody creates the multipart form used to create or update documents, holding the given fields and the
+// document's content
+func multipartBody(fields map[string]string, content []byte) (*bytes.Buffer, string, error) {
+	buffer := new(bytes.Buffer)
+	w := multipart.NewWriter(buffer)
+
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			return nil, "", err
+		}
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", `form-data; name="content"; filename="content"`)
+	h.Set("Content-Type", "application/json")
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := part.Write(content); err != nil {
+		return nil, "", err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buffer, w.FormDataContentType(), nil
+}
@@ -0,0 +1,205 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document_test
+
+import (
+	"context"
+	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/document"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const documentID = "b5a1b1b0-1a3e-4c5a-9e2b-1f1f1f1f1f1f"
+
+func newClient(server *httptest.Server) *document.Client {
+	return document.NewClient(server.URL, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy()))
+}
+
+func TestUpsert(t *testing.T) {
+	t.Run("creates missing document with given ID", func(t *testing.T) {
+		var requests []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			switch r.Method {
+			case http.MethodGet:
+				w.WriteHeader(http.StatusNotFound)
+			case http.MethodPost:
+				require.NoError(t, r.ParseMultipartForm(1024))
+				assert.Equal(t, documentID, r.FormValue("id"))
+				assert.Equal(t, "my dashboard", r.FormValue("name"))
+				assert.Equal(t, "dashboard", r.FormValue("type"))
+				assert.Equal(t, "true", r.FormValue("isPrivate"))
+
+				file, header, err := r.FormFile("content")
+				require.NoError(t, err)
+				assert.Equal(t, "application/json", header.Header.Get("Content-Type"))
+				content, err := io.ReadAll(file)
+				require.NoError(t, err)
+				assert.Equal(t, `{"tiles": {}}`, string(content))
+
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id": "` + documentID + `", "name": "my dashboard", "type": "dashboard", "version": 1, "owner": "me", "isPrivate": true}`))
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		m, err := newClient(server).Upsert(context.TODO(), documentID, "my dashboard", "dashboard", true, []byte(`{"tiles": {}}`))
+		assert.NoError(t, err)
+		assert.Equal(t, document.Metadata{ID: documentID, Name: "my dashboard", Type: "dashboard", Version: 1, Owner: "me", IsPrivate: true}, m)
+		assert.Equal(t, []string{
+			"GET /platform/document/v1/documents/" + documentID + "/metadata",
+			"POST /platform/document/v1/documents",
+		}, requests)
+	})
+
+	t.Run("updates existing document using its version", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				_, _ = w.Write([]byte(`{"id": "` + documentID + `", "type": "notebook", "version": 4, "owner": "me"}`))
+			case http.MethodPatch:
+				assert.Equal(t, "/platform/document/v1/documents/"+documentID, r.URL.Path)
+				assert.Equal(t, "4", r.URL.Query().Get("optimistic-locking-version"))
+				require.NoError(t, r.ParseMultipartForm(1024))
+				assert.Equal(t, "my notebook", r.FormValue("name"))
+				assert.Equal(t, "false", r.FormValue("isPrivate"))
+				assert.Empty(t, r.FormValue("type"))
+
+				_, _ = w.Write([]byte(`{"documentMetadata": {"id": "` + documentID + `", "name": "my notebook", "type": "notebook", "version": 5, "owner": "me"}}`))
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		m, err := newClient(server).Upsert(context.TODO(), documentID, "my notebook", "notebook", false, []byte(`{}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 5, m.Version)
+	})
+
+	t.Run("reports owner of document which can not be updated", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				_, _ = w.Write([]byte(`{"id": "` + documentID + `", "type": "dashboard", "version": 1, "owner": "someone-else"}`))
+			default:
+				w.WriteHeader(http.StatusForbidden)
+			}
+		}))
+		defer server.Close()
+
+		_, err := newClient(server).Upsert(context.TODO(), documentID, "my dashboard", "dashboard", false, []byte(`{}`))
+		var ownershipErr document.OwnershipError
+		require.True(t, errors.As(err, &ownershipErr))
+		assert.Equal(t, "someone-else", ownershipErr.Owner)
+	})
+
+	t.Run("does not change the type of an existing document", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			_, _ = w.Write([]byte(`{"id": "` + documentID + `", "type": "notebook", "version": 1}`))
+		}))
+		defer server.Close()
+
+		_, err := newClient(server).Upsert(context.TODO(), documentID, "my dashboard", "dashboard", false, []byte(`{}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page-key") == "" {
+			assert.Equal(t, "type == 'dashboard'", r.URL.Query().Get("filter"))
+			_, _ = w.Write([]byte(`{"documents": [{"id": "a"}], "nextPageKey": "next"}`))
+			return
+		}
+		assert.Equal(t, "next", r.URL.Query().Get("page-key"))
+		assert.Empty(t, r.URL.Query().Get("filter"))
+		_, _ = w.Write([]byte(`{"documents": [{"id": "b"}]}`))
+	}))
+	defer server.Close()
+
+	documents, err := newClient(server).List(context.TODO(), "dashboard")
+	assert.NoError(t, err)
+	assert.Equal(t, []document.Metadata{{ID: "a"}, {ID: "b"}}, documents)
+}
+
+func TestGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/platform/document/v1/documents/" + documentID + "/metadata":
+			_, _ = w.Write([]byte(`{"id": "` + documentID + `", "name": "my dashboard", "type": "dashboard"}`))
+		case "/platform/document/v1/documents/" + documentID + "/content":
+			_, _ = w.Write([]byte(`{"tiles": {}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	d, err := newClient(server).Get(context.TODO(), documentID)
+	assert.NoError(t, err)
+	assert.Equal(t, "my dashboard", d.Name)
+	assert.Equal(t, `{"tiles": {}}`, string(d.Content))
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("deletes document and removes it from trash", func(t *testing.T) {
+		var requests []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.String())
+			if r.Method == http.MethodGet {
+				_, _ = w.Write([]byte(`{"id": "` + documentID + `", "version": 2}`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		err := newClient(server).Delete(context.TODO(), documentID)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"GET /platform/document/v1/documents/" + documentID + "/metadata",
+			"DELETE /platform/document/v1/documents/" + documentID + "?optimistic-locking-version=2",
+			"DELETE /platform/document/v1/trash/documents/" + documentID,
+		}, requests)
+	})
+
+	t.Run("missing document is not an error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		assert.NoError(t, newClient(server).Delete(context.TODO(), documentID))
+	})
+}
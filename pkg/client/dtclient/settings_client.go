@@ -90,8 +90,63 @@ type (
 		SchemaId          string             `json:"schemaId"`
 		SchemaConstraints []schemaConstraint `json:"schemaConstraints"`
 	}
+
+	// Schema is the definition of a Settings 2.0 schema, as returned by the FetchSchema operation
+	Schema struct {
+		SchemaId      string                    `json:"schemaId"`
+		Version       string                    `json:"version"`
+		MultiObject   bool                      `json:"multiObject"`
+		AllowedScopes []string                  `json:"allowedScopes"`
+		Properties    map[string]SchemaProperty `json:"properties"`
+		Types         map[string]SchemaType     `json:"types"`
+		Enums         map[string]SchemaEnum     `json:"enums"`
+	}
+
+	// SchemaProperty is a property of a Settings 2.0 schema or of one of its types
+	SchemaProperty struct {
+		Type         SchemaPropertyType `json:"type"`
+		Items        *SchemaProperty    `json:"items,omitempty"`
+		Nullable     bool               `json:"nullable"`
+		Default      any                `json:"default,omitempty"`
+		Precondition any                `json:"precondition,omitempty"`
+	}
+
+	// SchemaPropertyType is the type of SchemaProperty. It is either the name of a primitive type like 'text' or
+	// 'boolean', or a reference to one of the types or enums of the schema, like '#/types/Rule'.
+	SchemaPropertyType struct {
+		Name string
+		Ref  string
+	}
+
+	// SchemaType is a complex type defined by a Settings 2.0 schema
+	SchemaType struct {
+		Properties map[string]SchemaProperty `json:"properties"`
+	}
+
+	// SchemaEnum is an enum defined by a Settings 2.0 schema
+	SchemaEnum struct {
+		Items []struct {
+			Value any `json:"value"`
+		} `json:"items"`
+	}
 )
 
+// UnmarshalJSON reads a SchemaPropertyType from either a plain type name or a '$ref' object
+func (t *SchemaPropertyType) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &t.Name); err == nil {
+		return nil
+	}
+
+	var ref struct {
+		Ref string `json:"$ref"`
+	}
+	if err := json.Unmarshal(b, &ref); err != nil {
+		return fmt.Errorf("property type is neither a type name nor a reference: %w", err)
+	}
+	t.Ref = ref.Ref
+	return nil
+}
+
 func (d *DynatraceClient) ListSchemas() (schemas SchemaList, err error) {
 	d.limiter.ExecuteBlocking(func() {
 		schemas, err = d.listSchemas(context.TODO())
@@ -166,6 +221,36 @@ func (d *DynatraceClient) fetchSchemasConstraints(ctx context.Context, schemaID
 	return ret, nil
 }
 
+// FetchSchema returns the full definition of the Settings 2.0 schema with the given ID
+func (d *DynatraceClient) FetchSchema(schemaID string) (schema Schema, err error) {
+	d.limiter.ExecuteBlocking(func() {
+		schema, err = d.fetchSchema(context.TODO(), schemaID)
+	})
+	return
+}
+
+func (d *DynatraceClient) fetchSchema(ctx context.Context, schemaID string) (Schema, error) {
+	u, err := url.JoinPath(d.environmentURL, d.settingsSchemaAPIPath, schemaID)
+	if err != nil {
+		return Schema{}, fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	r, err := d.platformClient.Get(ctx, u)
+	if err != nil {
+		return Schema{}, fmt.Errorf("failed to GET schema %q: %w", schemaID, err)
+	}
+
+	if !r.IsSuccess() {
+		return Schema{}, rest.NewRespErr(fmt.Sprintf("failed to GET schema %q (HTTP %d)!\n    Response was: %s", schemaID, r.StatusCode, string(r.Body)), r).WithRequestInfo(http.MethodGet, u)
+	}
+
+	var s Schema
+	if err := json.Unmarshal(r.Body, &s); err != nil {
+		return Schema{}, rest.NewRespErr("failed to unmarshal response", r).WithRequestInfo(http.MethodGet, u).WithErr(err)
+	}
+	return s, nil
+}
+
 func (d *DynatraceClient) UpsertSettings(ctx context.Context, obj SettingsObject) (result DynatraceEntity, err error) {
 	d.limiter.ExecuteBlocking(func() {
 		result, err = d.upsertSettings(ctx, obj)
@@ -113,6 +113,62 @@ func Test_FetchSchemaConstraintsUsesCache(t *testing.T) {
 	assert.Equal(t, 2, apiHits)
 }
 
+func TestFetchSchema(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case settingsSchemaAPIPathClassic + "/builtin:alerting.profile":
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(`
+{
+    "schemaId": "builtin:alerting.profile",
+    "version": "8.1",
+    "multiObject": true,
+    "allowedScopes": ["environment"],
+    "properties": {
+        "name": {"type": "text", "nullable": false},
+        "rules": {"type": "list", "nullable": false, "items": {"type": {"$ref": "#/types/Rule"}}},
+        "managementZone": {"type": "text", "nullable": true}
+    },
+    "types": {
+        "Rule": {"properties": {"severity": {"type": {"$ref": "#/enums/Severity"}, "nullable": false}}}
+    },
+    "enums": {
+        "Severity": {"items": [{"value": "AVAILABILITY"}, {"value": "ERRORS"}]}
+    }
+}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	restClient := rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy())
+	d, _ := NewClassicClient(server.URL, restClient, WithClientRequestLimiter(concurrency.NewLimiter(5)))
+
+	t.Run("unmarshalls schema", func(t *testing.T) {
+		schema, err := d.FetchSchema("builtin:alerting.profile")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "builtin:alerting.profile", schema.SchemaId)
+		assert.Equal(t, "8.1", schema.Version)
+		assert.Equal(t, []string{"environment"}, schema.AllowedScopes)
+		assert.Equal(t, SchemaPropertyType{Name: "text"}, schema.Properties["name"].Type)
+		assert.True(t, schema.Properties["managementZone"].Nullable)
+		assert.Equal(t, SchemaPropertyType{Name: "list"}, schema.Properties["rules"].Type)
+		assert.Equal(t, SchemaPropertyType{Ref: "#/types/Rule"}, schema.Properties["rules"].Items.Type)
+		assert.Equal(t, SchemaPropertyType{Ref: "#/enums/Severity"}, schema.Types["Rule"].Properties["severity"].Type)
+		assert.Len(t, schema.Enums["Severity"].Items, 2)
+	})
+
+	t.Run("unknown schema", func(t *testing.T) {
+		_, err := d.FetchSchema("builtin:unknown")
+
+		var respErr rest.RespError
+		assert.ErrorAs(t, err, &respErr)
+		assert.Equal(t, http.StatusNotFound, respErr.StatusCode)
+	})
+}
+
 func Test_findObjectWithSameConstraints(t *testing.T) {
 	type (
 		given struct {
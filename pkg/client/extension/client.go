@@ -0,0 +1,334 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+const endpoint = "api/v2/extensions"
+
+type (
+	// MonitoringConfiguration is a monitoring configuration of an extension
+	MonitoringConfiguration struct {
+		ObjectId string          `json:"objectId"`
+		Scope    string          `json:"scope"`
+		Value    json.RawMessage `json:"value"`
+	}
+
+	// Client abstracts the API access for Extensions 2.0
+	Client struct {
+		url           string
+		client        *rest.Client
+		retrySettings rest.RetrySettings
+
+		// lock guards extensionLocks and active
+		lock sync.Mutex
+		// extensionLocks serialize the activation of each extension, so that a version is only activated once
+		extensionLocks map[string]*sync.Mutex
+		// active holds the versions which have been activated by the client, per extension name
+		active map[string]string
+	}
+)
+
+// NewClient creates a new client to interact with the Extensions 2.0 API of the given (classic) environment URL
+func NewClient(url string, client *rest.Client) *Client {
+	return &Client{
+		url:            url,
+		client:         client,
+		retrySettings:  rest.DefaultRetrySettings,
+		extensionLocks: make(map[string]*sync.Mutex),
+		active:         make(map[string]string),
+	}
+}
+
+// Activate ensures that the given version of the extension is the active environment version. The package is
+// uploaded if the version does not exist on the environment yet. Each version is only checked once per client.
+// Different extensions are activated concurrently.
+func (c *Client) Activate(ctx context.Context, name, version string, archive []byte) error {
+	l := c.extensionLock(name)
+	l.Lock()
+	defer l.Unlock()
+
+	if c.activeVersion(name) == version {
+		return nil
+	}
+
+	exists, err := c.versionExists(ctx, name, version)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.WithCtxFields(ctx).Info("Uploading version %s of extension %q", version, name)
+		if err := c.upload(ctx, name, archive); err != nil {
+			return err
+		}
+	}
+
+	active, found, err := c.ActiveVersion(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !found || active != version {
+		log.WithCtxFields(ctx).Info("Activating version %s of extension %q", version, name)
+		if err := c.setActiveVersion(ctx, name, version, found); err != nil {
+			return err
+		}
+	}
+
+	c.lock.Lock()
+	c.active[name] = version
+	c.lock.Unlock()
+	return nil
+}
+
+func (c *Client) extensionLock(name string) *sync.Mutex {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	l, found := c.extensionLocks[name]
+	if !found {
+		l = &sync.Mutex{}
+		c.extensionLocks[name] = l
+	}
+	return l
+}
+
+func (c *Client) activeVersion(name string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.active[name]
+}
+
+// ActiveVersion returns the active environment version of the given extension, and whether a version is active
+func (c *Client) ActiveVersion(ctx context.Context, name string) (string, bool, error) {
+	u, err := url.JoinPath(c.url, endpoint, name, "environmentConfiguration")
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create URL: %w", err)
+	}
+
+	resp, err := c.client.Get(ctx, u)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get environment configuration of extension %q: %w", name, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return "", false, nil
+	}
+	if !resp.IsSuccess() {
+		return "", false, rest.NewRespErr(fmt.Sprintf("failed to get environment configuration of extension %q (HTTP %d): %s", name, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodGet, u)
+	}
+
+	var configuration struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(resp.Body, &configuration); err != nil {
+		return "", false, fmt.Errorf("failed to parse environment configuration of extension %q: %w", name, err)
+	}
+	return configuration.Version, true, nil
+}
+
+// DownloadPackage returns the signed package of the given version of the extension
+func (c *Client) DownloadPackage(ctx context.Context, name, version string) ([]byte, error) {
+	u, err := url.JoinPath(c.url, endpoint, name, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create URL: %w", err)
+	}
+
+	resp, err := c.client.GetFile(ctx, u, "application/octet-stream")
+	if err != nil {
+		return nil, fmt.Errorf("failed to download version %s of extension %q: %w", version, name, err)
+	}
+	if !resp.IsSuccess() {
+		return nil, rest.NewRespErr(fmt.Sprintf("failed to download version %s of extension %q (HTTP %d): %s", version, name, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodGet, u)
+	}
+	return resp.Body, nil
+}
+
+// ListMonitoringConfigurations returns all monitoring configurations of the given extension
+func (c *Client) ListMonitoringConfigurations(ctx context.Context, name string) ([]MonitoringConfiguration, error) {
+	u, err := url.Parse(c.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+	u = u.JoinPath(endpoint, name, "monitoringConfigurations")
+
+	var result []MonitoringConfiguration
+	addToResult := func(body []byte) (int, error) {
+		var page struct {
+			Items []MonitoringConfiguration `json:"items"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, fmt.Errorf("failed to parse monitoring configurations of extension %q: %w", name, err)
+		}
+		result = append(result, page.Items...)
+		return len(page.Items), nil
+	}
+
+	if _, err := rest.ListPaginated(ctx, c.client, c.retrySettings, u, name, addToResult); err != nil {
+		return nil, fmt.Errorf("failed to list monitoring configurations of extension %q: %w", name, err)
+	}
+	return result, nil
+}
+
+// UpsertMonitoringConfiguration creates a monitoring configuration of the given extension, or updates the one with
+// the given object ID if it is not empty. It returns the object ID of the monitoring configuration.
+func (c *Client) UpsertMonitoringConfiguration(ctx context.Context, name, objectID, scope string, value []byte) (string, error) {
+	configuration := struct {
+		Scope string          `json:"scope"`
+		Value json.RawMessage `json:"value"`
+	}{scope, value}
+
+	if objectID == "" {
+		return c.createMonitoringConfiguration(ctx, name, configuration)
+	}
+
+	u, err := url.JoinPath(c.url, endpoint, name, "monitoringConfigurations", objectID)
+	if err != nil {
+		return "", fmt.Errorf("failed to create URL: %w", err)
+	}
+
+	payload, err := json.Marshal(configuration)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal monitoring configuration: %w", err)
+	}
+
+	resp, err := c.client.Put(ctx, u, payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to update monitoring configuration %q of extension %q: %w", objectID, name, err)
+	}
+	if !resp.IsSuccess() {
+		return "", rest.NewRespErr(fmt.Sprintf("failed to update monitoring configuration %q of extension %q (HTTP %d): %s", objectID, name, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodPut, u)
+	}
+	return objectID, nil
+}
+
+func (c *Client) createMonitoringConfiguration(ctx context.Context, name string, configuration any) (string, error) {
+	u, err := url.JoinPath(c.url, endpoint, name, "monitoringConfigurations")
+	if err != nil {
+		return "", fmt.Errorf("failed to create URL: %w", err)
+	}
+
+	payload, err := json.Marshal([]any{configuration})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal monitoring configuration: %w", err)
+	}
+
+	resp, err := c.client.Post(ctx, u, payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to create monitoring configuration of extension %q: %w", name, err)
+	}
+	if !resp.IsSuccess() {
+		return "", rest.NewRespErr(fmt.Sprintf("failed to create monitoring configuration of extension %q (HTTP %d): %s", name, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodPost, u)
+	}
+
+	var created []struct {
+		ObjectId string `json:"objectId"`
+	}
+	if err := json.Unmarshal(resp.Body, &created); err != nil || len(created) != 1 {
+		return "", fmt.Errorf("failed to parse response of creating monitoring configuration of extension %q: %s", name, string(resp.Body))
+	}
+	return created[0].ObjectId, nil
+}
+
+func (c *Client) versionExists(ctx context.Context, name, version string) (bool, error) {
+	u, err := url.JoinPath(c.url, endpoint, name, version)
+	if err != nil {
+		return false, fmt.Errorf("failed to create URL: %w", err)
+	}
+
+	resp, err := c.client.Get(ctx, u)
+	if err != nil {
+		return false, fmt.Errorf("failed to get version %s of extension %q: %w", version, name, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if !resp.IsSuccess() {
+		return false, rest.NewRespErr(fmt.Sprintf("failed to get version %s of extension %q (HTTP %d): %s", version, name, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodGet, u)
+	}
+	return true, nil
+}
+
+func (c *Client) upload(ctx context.Context, name string, archive []byte) error {
+	u, err := url.JoinPath(c.url, endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to create URL: %w", err)
+	}
+
+	buffer := new(bytes.Buffer)
+	w := multipart.NewWriter(buffer)
+	file, err := w.CreateFormFile("file", name+".zip")
+	if err != nil {
+		return fmt.Errorf("failed to create upload of extension %q: %w", name, err)
+	}
+	if _, err := file.Write(archive); err != nil {
+		return fmt.Errorf("failed to create upload of extension %q: %w", name, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to create upload of extension %q: %w", name, err)
+	}
+
+	resp, err := c.client.PostMultiPartFile(ctx, u, buffer, w.FormDataContentType())
+	if err != nil {
+		return fmt.Errorf("failed to upload extension %q: %w", name, err)
+	}
+	if !resp.IsSuccess() {
+		return rest.NewRespErr(fmt.Sprintf("failed to upload extension %q (HTTP %d): %s", name, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodPost, u)
+	}
+	return nil
+}
+
+// setActiveVersion sets the active environment version of the extension. If exists is false, the environment
+// configuration of the extension is created.
+func (c *Client) setActiveVersion(ctx context.Context, name, version string, exists bool) error {
+	u, err := url.JoinPath(c.url, endpoint, name, "environmentConfiguration")
+	if err != nil {
+		return fmt.Errorf("failed to create URL: %w", err)
+	}
+
+	payload, err := json.Marshal(struct {
+		Version string `json:"version"`
+	}{version})
+	if err != nil {
+		return fmt.Errorf("failed to marshal environment configuration: %w", err)
+	}
+
+	method := http.MethodPut
+	send := c.client.Put
+	if !exists {
+		method = http.MethodPost
+		send = c.client.Post
+	}
+
+	resp, err := send(ctx, u, payload)
+	if err != nil {
+		return fmt.Errorf("failed to activate version %s of extension %q: %w", version, name, err)
+	}
+	if !resp.IsSuccess() {
+		return rest.NewRespErr(fmt.Sprintf("failed to activate version %s of extension %q (HTTP %d): %s", version, name, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(method, u)
+	}
+	return nil
+}
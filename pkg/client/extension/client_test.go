@@ -0,0 +1,231 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/extension"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const extensionName = "com.example.extension"
+
+func newClient(server *httptest.Server) *extension.Client {
+	return extension.NewClient(server.URL, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy()))
+}
+
+func TestActivate(t *testing.T) {
+	t.Run("uploads and activates missing version", func(t *testing.T) {
+		var requests []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			switch {
+			case r.Method == http.MethodGet:
+				w.WriteHeader(http.StatusNotFound)
+			case r.Method == http.MethodPost && r.URL.Path == "/api/v2/extensions":
+				file, _, err := r.FormFile("file")
+				require.NoError(t, err)
+				content, err := io.ReadAll(file)
+				require.NoError(t, err)
+				assert.Equal(t, "archive", string(content))
+				w.WriteHeader(http.StatusCreated)
+			case r.Method == http.MethodPost:
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"version": "1.0.0"}`, string(body))
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		client := newClient(server)
+		err := client.Activate(context.TODO(), extensionName, "1.0.0", []byte("archive"))
+		assert.NoError(t, err)
+
+		// the version is only activated once
+		err = client.Activate(context.TODO(), extensionName, "1.0.0", []byte("archive"))
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"GET /api/v2/extensions/" + extensionName + "/1.0.0",
+			"POST /api/v2/extensions",
+			"GET /api/v2/extensions/" + extensionName + "/environmentConfiguration",
+			"POST /api/v2/extensions/" + extensionName + "/environmentConfiguration",
+		}, requests)
+	})
+
+	t.Run("updates active version of existing version", func(t *testing.T) {
+		var requests []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/api/v2/extensions/"+extensionName+"/environmentConfiguration":
+				_, _ = w.Write([]byte(`{"version": "0.9.0"}`))
+			case r.Method == http.MethodGet:
+				_, _ = w.Write([]byte(`{"extensionName": "com.example.extension", "version": "1.0.0"}`))
+			case r.Method == http.MethodPut:
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		err := newClient(server).Activate(context.TODO(), extensionName, "1.0.0", []byte("archive"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"GET /api/v2/extensions/" + extensionName + "/1.0.0",
+			"GET /api/v2/extensions/" + extensionName + "/environmentConfiguration",
+			"PUT /api/v2/extensions/" + extensionName + "/environmentConfiguration",
+		}, requests)
+	})
+
+	t.Run("different extensions are activated concurrently", func(t *testing.T) {
+		var checked sync.WaitGroup
+		checked.Add(2)
+		bothChecked := make(chan struct{})
+		go func() {
+			checked.Wait()
+			close(bothChecked)
+		}()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/environmentConfiguration") {
+				_, _ = w.Write([]byte(`{"version": "1.0.0"}`))
+				return
+			}
+
+			// the version check of each extension only returns once both extensions are checked
+			checked.Done()
+			select {
+			case <-bothChecked:
+			case <-time.After(5 * time.Second):
+				t.Error("extensions were not activated concurrently")
+			}
+			_, _ = w.Write([]byte(`{"version": "1.0.0"}`))
+		}))
+		defer server.Close()
+
+		client := newClient(server)
+		var activated sync.WaitGroup
+		for _, name := range []string{"com.example.a", "com.example.b"} {
+			name := name
+			activated.Add(1)
+			go func() {
+				defer activated.Done()
+				assert.NoError(t, client.Activate(context.TODO(), name, "1.0.0", []byte("archive")))
+			}()
+		}
+		activated.Wait()
+	})
+
+	t.Run("returns upload errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"message": "invalid signature"}}`))
+		}))
+		defer server.Close()
+
+		err := newClient(server).Activate(context.TODO(), extensionName, "1.0.0", []byte("archive"))
+		var respErr rest.RespError
+		assert.ErrorAs(t, err, &respErr)
+		assert.Equal(t, http.StatusBadRequest, respErr.StatusCode)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+}
+
+func TestListMonitoringConfigurations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/extensions/"+extensionName+"/monitoringConfigurations", r.URL.Path)
+		if r.URL.Query().Get("nextPageKey") == "" {
+			_, _ = w.Write([]byte(`{"items": [{"objectId": "a", "scope": "environment", "value": {"description": "A"}}], "totalCount": 2, "nextPageKey": "next"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": [{"objectId": "b", "scope": "HOST-1", "value": {"description": "B"}}], "totalCount": 2}`))
+	}))
+	defer server.Close()
+
+	got, err := newClient(server).ListMonitoringConfigurations(context.TODO(), extensionName)
+	assert.NoError(t, err)
+	assert.Equal(t, []extension.MonitoringConfiguration{
+		{ObjectId: "a", Scope: "environment", Value: json.RawMessage(`{"description": "A"}`)},
+		{ObjectId: "b", Scope: "HOST-1", Value: json.RawMessage(`{"description": "B"}`)},
+	}, got)
+}
+
+func TestUpsertMonitoringConfiguration(t *testing.T) {
+	t.Run("creates new configuration", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v2/extensions/"+extensionName+"/monitoringConfigurations", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `[{"scope": "environment", "value": {"enabled": true}}]`, string(body))
+			_, _ = w.Write([]byte(`[{"objectId": "new-id", "code": 200}]`))
+		}))
+		defer server.Close()
+
+		id, err := newClient(server).UpsertMonitoringConfiguration(context.TODO(), extensionName, "", "environment", []byte(`{"enabled": true}`))
+		assert.NoError(t, err)
+		assert.Equal(t, "new-id", id)
+	})
+
+	t.Run("updates existing configuration", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v2/extensions/"+extensionName+"/monitoringConfigurations/existing-id", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"scope": "environment", "value": {"enabled": false}}`, string(body))
+			_, _ = w.Write([]byte(`{"objectId": "existing-id", "code": 200}`))
+		}))
+		defer server.Close()
+
+		id, err := newClient(server).UpsertMonitoringConfiguration(context.TODO(), extensionName, "existing-id", "environment", []byte(`{"enabled": false}`))
+		assert.NoError(t, err)
+		assert.Equal(t, "existing-id", id)
+	})
+}
+
+func TestDownloadPackage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/extensions/"+extensionName+"/1.0.0", r.URL.Path)
+		assert.Equal(t, "application/octet-stream", r.Header.Get("Accept"))
+		_, _ = w.Write([]byte("archive"))
+	}))
+	defer server.Close()
+
+	got, err := newClient(server).DownloadPackage(context.TODO(), extensionName, "1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("archive"), got)
+}
@@ -17,6 +17,7 @@
 package config
 
 import (
+	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	configErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/errors"
@@ -24,9 +25,13 @@ import (
 	compoundParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
 	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
 	listParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
+	pluginParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/plugin"
 	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	valuesParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/values"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"golang.org/x/exp/slices"
+	"strings"
 )
 
 const (
@@ -60,6 +65,9 @@ const (
 	EntityTypeId     TypeId = "entity"
 	AutomationTypeId TypeId = "automation"
 	BucketTypeId     TypeId = "bucket"
+	ExtensionTypeId  TypeId = "extension"
+	DocumentTypeId   TypeId = "document"
+	AccountTypeId    TypeId = "account"
 )
 
 type Type interface {
@@ -117,6 +125,71 @@ func (BucketType) ID() TypeId {
 	return BucketTypeId
 }
 
+// ExtensionType represents a monitoring configuration of an Extensions 2.0 extension. The extension package is
+// uploaded and activated as the environment's version before the monitoring configuration is deployed.
+type ExtensionType struct {
+	// Name of the extension, e.g. 'com.dynatrace.extension.sql-server'
+	Name string
+	// Version of the extension contained in the package
+	Version string
+	// Archive is the path of the signed extension package
+	Archive string
+	// ArchiveContent is the content of the signed extension package
+	ArchiveContent []byte
+}
+
+func (ExtensionType) ID() TypeId {
+	return ExtensionTypeId
+}
+
+// DocumentKind defines which kind of document a DocumentType is
+type DocumentKind string
+
+const (
+	DashboardKind DocumentKind = "dashboard"
+	NotebookKind  DocumentKind = "notebook"
+)
+
+// KnownDocumentKinds are all kinds of documents which can be deployed
+var KnownDocumentKinds = []DocumentKind{DashboardKind, NotebookKind}
+
+// DocumentType represents a Dynatrace Platform document, like a dashboard or a notebook
+type DocumentType struct {
+	// Kind of the document, either DashboardKind or NotebookKind
+	Kind DocumentKind
+	// Private documents are only visible to their owner, all others are shared with every user of the environment
+	Private bool
+}
+
+func (DocumentType) ID() TypeId {
+	return DocumentTypeId
+}
+
+// AccountResource defines which resource of the account management an AccountType is
+type AccountResource string
+
+const (
+	UserGroup       AccountResource = "user-group"
+	Policy          AccountResource = "policy"
+	PolicyBinding   AccountResource = "policy-binding"
+	GroupPermission AccountResource = "group-permission"
+)
+
+// KnownAccountResources are all account management resources which can be deployed
+var KnownAccountResources = []AccountResource{UserGroup, Policy, PolicyBinding, GroupPermission}
+
+// AccountType represents a resource of the Dynatrace account management. Account resources are deployed to the account
+// an environment belongs to, policy bindings are deployed to the environment itself.
+type AccountType struct {
+	// Resource identifies which account management resource is used in this config.
+	// Currently, this can be UserGroup, Policy, PolicyBinding, or GroupPermission.
+	Resource AccountResource
+}
+
+func (AccountType) ID() TypeId {
+	return AccountTypeId
+}
+
 // Config struct defining a configuration which can be deployed.
 type Config struct {
 	// template used to render the request send to the dynatrace api
@@ -142,6 +215,48 @@ type Config struct {
 
 	// OriginObjectId is the DT object ID of the object when it was downloaded from an environment
 	OriginObjectId string
+
+	// Description is a free-form description of the purpose of this configuration
+	Description string
+	// Owner is the team or person owning this configuration
+	Owner string
+	// Labels are free-form key-value pairs, which can be used to select configurations
+	Labels map[string]string
+
+	// DependsOn holds the coordinates of configurations which need to be deployed before this one, even though it does
+	// not reference them in its parameters
+	DependsOn []coordinate.Coordinate
+
+	// EnvironmentSpecific holds the names of parameters which hold environment-specific values. They are kept when
+	// configurations are promoted from one environment to another.
+	EnvironmentSpecific []string
+}
+
+// Describe returns the coordinate of the config together with its owner and labels, if set, so that reports about the
+// config point to the ones responsible for it
+func (c *Config) Describe() string {
+	var details []string
+	if c.Owner != "" {
+		details = append(details, "owner: "+c.Owner)
+	}
+	if len(c.Labels) > 0 {
+		details = append(details, "labels: "+c.LabelString())
+	}
+
+	if len(details) == 0 {
+		return c.Coordinate.String()
+	}
+	return fmt.Sprintf("%s (%s)", c.Coordinate, strings.Join(details, "; "))
+}
+
+// LabelString returns the labels of the config as a sorted, comma-separated list of 'key=value' pairs
+func (c *Config) LabelString() string {
+	labels := make([]string, 0, len(c.Labels))
+	for k, v := range c.Labels {
+		labels = append(labels, k+"="+v)
+	}
+	slices.Sort(labels)
+	return strings.Join(labels, ",")
 }
 
 func (c *Config) Render(properties map[string]interface{}) (string, error) {
@@ -196,6 +311,8 @@ var DefaultParameterParsers = map[string]parameter.ParameterSerDe{
 	envParam.EnvironmentVariableParameterType: envParam.EnvironmentVariableParameterSerde,
 	compoundParam.CompoundParameterType:       compoundParam.CompoundParameterSerde,
 	listParam.ListParameterType:               listParam.ListParameterSerde,
+	valuesParam.ValuesParameterType:           valuesParam.ValuesParameterSerde,
+	pluginParam.PluginParameterType:           pluginParam.PluginParameterSerde,
 }
 
 func (c *Config) References() []coordinate.Coordinate {
@@ -219,6 +336,16 @@ func (c *Config) References() []coordinate.Coordinate {
 	return refs
 }
 
+// Dependencies returns the coordinates of all configurations this config depends on. These are the ones it references,
+// followed by the ones it explicitly depends on.
+func (c *Config) Dependencies() []coordinate.Coordinate {
+	if c == nil {
+		return nil
+	}
+
+	return append(c.References(), c.DependsOn...)
+}
+
 // EntityLookup is used in parameter resolution to fetch the resolved entity of deployed configuration
 type EntityLookup interface {
 	parameter.PropertyResolver
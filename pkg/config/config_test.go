@@ -372,6 +372,14 @@ func TestConfigMethodsAreNilSafe(t *testing.T) {
 		})
 	})
 
+	t.Run("Dependencies", func(t *testing.T) {
+		var c *Config
+		c = nil
+		assert.NotPanics(t, func() {
+			_ = c.Dependencies()
+		})
+	})
+
 	t.Run("Render", func(t *testing.T) {
 		var c *Config
 		c = nil
@@ -395,3 +403,30 @@ func TestConfigMethodsAreNilSafe(t *testing.T) {
 		})
 	})
 }
+
+func TestDescribe(t *testing.T) {
+	c := Config{Coordinate: coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "d"}}
+	assert.Equal(t, "p:dashboard:d", c.Describe())
+
+	c.Owner = "payments"
+	assert.Equal(t, "p:dashboard:d (owner: payments)", c.Describe())
+
+	c.Labels = map[string]string{"tier": "critical", "app": "shop"}
+	assert.Equal(t, "p:dashboard:d (owner: payments; labels: app=shop,tier=critical)", c.Describe())
+}
+
+func TestDependencies(t *testing.T) {
+	referenced := coordinate.Coordinate{Project: "p", Type: "management-zone", ConfigId: "zone"}
+	dependedOn := coordinate.Coordinate{Project: "p", Type: "workflow", ConfigId: "wf"}
+
+	c := Config{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "d"},
+		Parameters: Parameters{
+			"zone": &parameter.DummyParameter{References: []parameter.ParameterReference{{Config: referenced, Property: "id"}}},
+		},
+		DependsOn: []coordinate.Coordinate{dependedOn},
+	}
+
+	assert.Equal(t, []coordinate.Coordinate{referenced}, c.References())
+	assert.Equal(t, []coordinate.Coordinate{referenced, dependedOn}, c.Dependencies())
+}
@@ -14,7 +14,10 @@
 
 package coordinate
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Coordinate struct used to specify the location of a certain configuration
 type Coordinate struct {
@@ -38,3 +41,23 @@ func (c Coordinate) Match(coordinate Coordinate) bool {
 		c.Type == coordinate.Type &&
 		c.ConfigId == coordinate.ConfigId
 }
+
+// Parse parses a coordinate in the form 'project:type:id', as returned by Coordinate.String.
+// As Settings schema IDs contain colons themselves, the type is everything between the first and the last colon.
+func Parse(s string) (Coordinate, error) {
+	first := strings.Index(s, ":")
+	last := strings.LastIndex(s, ":")
+	if first < 0 || first == last {
+		return Coordinate{}, fmt.Errorf("invalid coordinate %q: expected the form 'project:type:id'", s)
+	}
+
+	c := Coordinate{
+		Project:  s[:first],
+		Type:     s[first+1 : last],
+		ConfigId: s[last+1:],
+	}
+	if c.Project == "" || c.Type == "" || c.ConfigId == "" {
+		return Coordinate{}, fmt.Errorf("invalid coordinate %q: project, type and id must not be empty", s)
+	}
+	return c, nil
+}
@@ -68,3 +68,28 @@ func TestMatchShouldReturnFalseOnNonMatchingSameApi(t *testing.T) {
 
 	assert.Assert(t, !result, "shouldn't match")
 }
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Coordinate
+		wantErr bool
+	}{
+		{"project1:dashboard:dashboard1", Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard1"}, false},
+		{"project1:builtin:alerting.profile:profile", Coordinate{Project: "project1", Type: "builtin:alerting.profile", ConfigId: "profile"}, false},
+		{"dashboard:dashboard1", Coordinate{}, true},
+		{"dashboard1", Coordinate{}, true},
+		{"project1::dashboard1", Coordinate{}, true},
+		{"project1:dashboard:", Coordinate{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			assert.Equal(t, err != nil, tt.wantErr, "unexpected error: %v", err)
+			assert.Equal(t, got, tt.want)
+			if err == nil {
+				assert.Equal(t, got.String(), tt.input)
+			}
+		})
+	}
+}
@@ -64,6 +64,11 @@ func (p *CompoundParameter) GetReferences() []parameter.ParameterReference {
 	return p.referencedParameters
 }
 
+// GetFormatString returns the format string the parameter was created with
+func (p *CompoundParameter) GetFormatString() string {
+	return p.rawFormatString
+}
+
 func (p *CompoundParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
 	compoundData := make(map[string]interface{})
 
@@ -88,6 +88,9 @@ type ParameterParserContext struct {
 	ParameterName string
 	// current value to parse
 	Value map[string]interface{}
+	// Values holds the values of the environment the config is parsed for, as defined by the values files of the
+	// manifest. Nested values are maps with string keys.
+	Values map[string]interface{}
 }
 
 type ParameterParserError struct {
@@ -0,0 +1,176 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
+	strs "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+)
+
+// PluginParameterType specifies the type of the parameter used in config files
+const PluginParameterType = "plugin"
+
+// PluginParameterSerde is the serde used if no plugins are defined. Parsing plugin parameters with it fails, as every
+// plugin is unknown. Use NewSerDe to parse plugin parameters using the plugins defined in the manifest.
+var PluginParameterSerde = NewSerDe(nil)
+
+// NewSerDe returns the serde of plugin parameters, resolving them using the given plugins by their name
+func NewSerDe(plugins map[string]*Plugin) parameter.ParameterSerDe {
+	return parameter.ParameterSerDe{
+		Serializer: writePluginParameter,
+		Deserializer: func(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+			return parsePluginParameter(context, plugins)
+		},
+	}
+}
+
+// PluginParameter is a parameter whose value is resolved by an external Plugin
+type PluginParameter struct {
+	Plugin *Plugin
+
+	// Value is the `value` defined for the parameter, which is passed to the plugin
+	Value interface{}
+
+	// References are the references the plugin declared for the parameter
+	References []parameter.ParameterReference
+}
+
+// this forces the compiler to check if PluginParameter is of type Parameter
+var _ parameter.Parameter = (*PluginParameter)(nil)
+
+func (p *PluginParameter) GetType() string {
+	return PluginParameterType
+}
+
+func (p *PluginParameter) GetReferences() []parameter.ParameterReference {
+	return p.References
+}
+
+// ResolveValue resolves the references declared by the plugin, and asks the plugin for the value of the parameter
+func (p *PluginParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	resolvedReferences := make([]ResolvedReference, 0, len(p.References))
+	for _, ref := range p.References {
+		val, err := reference.NewWithCoordinate(ref.Config, ref.Property).ResolveValue(context)
+		if err != nil {
+			return nil, err
+		}
+
+		resolvedReferences = append(resolvedReferences, ResolvedReference{
+			Reference: Reference{Project: ref.Config.Project, Type: ref.Config.Type, ConfigId: ref.Config.ConfigId, Property: ref.Property},
+			Value:     val,
+		})
+	}
+
+	resp, err := p.Plugin.Call(Request{
+		Operation:   OperationResolve,
+		Value:       p.Value,
+		Coordinate:  toCoordinate(context.ConfigCoordinate),
+		Group:       context.Group,
+		Environment: context.Environment,
+		Parameter:   context.ParameterName,
+		References:  resolvedReferences,
+	})
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, err.Error())
+	}
+	if resp.Value == nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("plugin %q returned no value", p.Plugin.Name))
+	}
+
+	return template.EscapeSpecialCharactersInValue(resp.Value, template.FullStringEscapeFunction)
+}
+
+// parsePluginParameter parses a given context into an instance of PluginParameter.
+// the only required property is `plugin`, the name of the plugin as defined in the manifest. The optional `value` is
+// passed to the plugin, which is asked for the references of the parameter.
+func parsePluginParameter(context parameter.ParameterParserContext, plugins map[string]*Plugin) (parameter.Parameter, error) {
+	rawName, ok := context.Value["plugin"]
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, "missing property `plugin`")
+	}
+
+	name := strs.ToString(rawName)
+	p, found := plugins[name]
+	if !found {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("plugin %q is not defined in the manifest", name))
+	}
+
+	value := maps.NormalizeKeys(context.Value["value"])
+
+	resp, err := p.Call(Request{
+		Operation:   OperationReferences,
+		Value:       value,
+		Coordinate:  toCoordinate(context.Coordinate),
+		Group:       context.Group,
+		Environment: context.Environment,
+		Parameter:   context.ParameterName,
+	})
+	if err != nil {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("failed to get references: %s", err))
+	}
+
+	references := make([]parameter.ParameterReference, 0, len(resp.References))
+	for _, ref := range resp.References {
+		if ref.Property == "" {
+			return nil, parameter.NewParameterParserError(context, fmt.Sprintf("plugin %q declared a reference without property", name))
+		}
+		references = append(references, toParameterReference(context.Coordinate, ref))
+	}
+
+	return &PluginParameter{Plugin: p, Value: value, References: references}, nil
+}
+
+func writePluginParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	pluginParam, ok := context.Parameter.(*PluginParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `PluginParameter`")
+	}
+
+	result := map[string]interface{}{
+		"plugin": pluginParam.Plugin.Name,
+	}
+	if pluginParam.Value != nil {
+		result["value"] = pluginParam.Value
+	}
+	return result, nil
+}
+
+// toParameterReference returns the given reference, filling in missing parts of the coordinate from the given one
+func toParameterReference(current coordinate.Coordinate, ref Reference) parameter.ParameterReference {
+	c := current
+	if ref.Project != "" {
+		c.Project = ref.Project
+	}
+	if ref.Type != "" {
+		c.Type = ref.Type
+	}
+	if ref.ConfigId != "" {
+		c.ConfigId = ref.ConfigId
+	}
+	return parameter.ParameterReference{Config: c, Property: ref.Property}
+}
+
+func toCoordinate(c coordinate.Coordinate) Coordinate {
+	return Coordinate{Project: c.Project, Type: c.Type, ConfigId: c.ConfigId}
+}
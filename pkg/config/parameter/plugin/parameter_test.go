@@ -0,0 +1,129 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCoordinate = coordinate.Coordinate{Project: "project", Type: "dashboard", ConfigId: "board"}
+
+type propertyResolver map[parameter.ParameterReference]any
+
+func (r propertyResolver) GetResolvedProperty(c coordinate.Coordinate, propertyName string) (any, bool) {
+	v, found := r[parameter.ParameterReference{Config: c, Property: propertyName}]
+	return v, found
+}
+
+func TestParsePluginParameter(t *testing.T) {
+	p, _ := helperPlugin(t, "echo", time.Minute)
+
+	param, err := NewSerDe(map[string]*Plugin{"cmdb": p}).Deserializer(parameter.ParameterParserContext{
+		Coordinate:    testCoordinate,
+		Environment:   "dev",
+		ParameterName: "owner",
+		Value:         map[string]interface{}{"plugin": "cmdb", "value": map[interface{}]interface{}{"service": "checkout"}},
+	})
+	require.NoError(t, err)
+
+	other := coordinate.Coordinate{Project: "project", Type: "dashboard", ConfigId: "other"}
+	assert.Equal(t, []parameter.ParameterReference{{Config: other, Property: "name"}}, param.GetReferences())
+
+	t.Run("value is resolved by the plugin", func(t *testing.T) {
+		val, err := param.ResolveValue(parameter.ResolveContext{
+			PropertyResolver: propertyResolver{{Config: other, Property: "name"}: "Other"},
+			ConfigCoordinate: testCoordinate,
+			Environment:      "dev",
+			ParameterName:    "owner",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "map[service:checkout] in dev for board/owner: Other", val)
+	})
+
+	t.Run("unresolved references fail", func(t *testing.T) {
+		_, err := param.ResolveValue(parameter.ResolveContext{
+			PropertyResolver: propertyResolver{},
+			ConfigCoordinate: testCoordinate,
+			Environment:      "dev",
+			ParameterName:    "owner",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("parameter is written with plugin and value", func(t *testing.T) {
+		written, err := NewSerDe(nil).Serializer(parameter.ParameterWriterContext{Parameter: param})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"plugin": "helper", "value": map[string]interface{}{"service": "checkout"}}, written)
+	})
+}
+
+func TestParsePluginParameter_Errors(t *testing.T) {
+	p, _ := helperPlugin(t, "error", time.Minute)
+
+	tests := []struct {
+		name    string
+		value   map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "missing plugin",
+			value:   map[string]interface{}{},
+			wantErr: "missing property `plugin`",
+		},
+		{
+			name:    "unknown plugin",
+			value:   map[string]interface{}{"plugin": "unknown"},
+			wantErr: `plugin "unknown" is not defined in the manifest`,
+		},
+		{
+			name:    "failing plugin",
+			value:   map[string]interface{}{"plugin": "cmdb"},
+			wantErr: `failed to get references: plugin "helper" returned an error: lookup failed`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSerDe(map[string]*Plugin{"cmdb": p}).Deserializer(parameter.ParameterParserContext{
+				Coordinate: testCoordinate,
+				Value:      tt.value,
+			})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPluginParameter_ResolveValueFailsWithoutValue(t *testing.T) {
+	p, _ := helperPlugin(t, "empty", time.Minute)
+
+	param := &PluginParameter{Plugin: p}
+	_, err := param.ResolveValue(parameter.ResolveContext{
+		PropertyResolver: propertyResolver{},
+		ConfigCoordinate: testCoordinate,
+		Environment:      "dev",
+		ParameterName:    "owner",
+	})
+	assert.ErrorContains(t, err, `plugin "helper" returned no value`)
+}
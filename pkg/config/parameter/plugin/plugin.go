@@ -0,0 +1,182 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProtocolVersion is the version of the protocol sent with each Request
+const ProtocolVersion = 1
+
+// DefaultTimeout is the time a plugin may take to answer a single request, if no timeout is defined for it
+const DefaultTimeout = 30 * time.Second
+
+// Operation is the operation a plugin is asked to perform
+type Operation string
+
+const (
+	// OperationReferences asks the plugin for the properties of other configurations the value depends on. It is
+	// requested when configurations are loaded, so that referenced configurations are deployed first.
+	OperationReferences Operation = "references"
+	// OperationResolve asks the plugin for the value of the parameter. It is requested when the configuration is
+	// deployed, together with the values of the references the plugin declared.
+	OperationResolve Operation = "resolve"
+)
+
+// Coordinate identifies a configuration in the protocol
+type Coordinate struct {
+	Project  string `json:"project"`
+	Type     string `json:"type"`
+	ConfigId string `json:"configId"`
+}
+
+// Reference is a property of a configuration a plugin depends on. If project, type or config ID are empty, the ones
+// of the configuration the parameter belongs to are used.
+type Reference struct {
+	Project  string `json:"project,omitempty"`
+	Type     string `json:"type,omitempty"`
+	ConfigId string `json:"configId,omitempty"`
+	Property string `json:"property"`
+}
+
+// ResolvedReference is a Reference together with its value
+type ResolvedReference struct {
+	Reference
+	Value any `json:"value"`
+}
+
+// Request is written as JSON to the standard input of the plugin
+type Request struct {
+	Version   int       `json:"version"`
+	Operation Operation `json:"operation"`
+	// Value is the `value` of the parameter as defined in the config YAML
+	Value       any        `json:"value,omitempty"`
+	Coordinate  Coordinate `json:"coordinate"`
+	Group       string     `json:"group"`
+	Environment string     `json:"environment"`
+	Parameter   string     `json:"parameter"`
+	// References holds the values of the references the plugin declared. It is only sent with OperationResolve.
+	References []ResolvedReference `json:"references,omitempty"`
+}
+
+// Response is read as JSON from the standard output of the plugin
+type Response struct {
+	// Value is the resolved value of the parameter. It is only read for OperationResolve.
+	Value any `json:"value"`
+	// References are the references the value depends on. They are only read for OperationReferences.
+	References []Reference `json:"references,omitempty"`
+	// Error is set by the plugin if it fails to handle the request
+	Error string `json:"error,omitempty"`
+}
+
+// Plugin is an external executable resolving parameter values. It is invoked once per request, reading the Request
+// from its standard input and writing the Response to its standard output. Responses are cached, so equal requests
+// are sent to the executable only once. A Plugin is safe for concurrent use.
+type Plugin struct {
+	Name    string
+	Command string
+	Args    []string
+	Timeout time.Duration
+
+	lock  sync.Mutex
+	calls map[string]*call
+}
+
+// call is a single, possibly ongoing, invocation of a plugin
+type call struct {
+	once     sync.Once
+	response Response
+	err      error
+}
+
+// New creates a new Plugin. If timeout is not positive, DefaultTimeout is used.
+func New(name string, command string, args []string, timeout time.Duration) *Plugin {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Plugin{
+		Name:    name,
+		Command: command,
+		Args:    args,
+		Timeout: timeout,
+		calls:   make(map[string]*call),
+	}
+}
+
+// Call sends the given request to the plugin and returns its response. If the plugin was called with an equal request
+// before, the previous response is returned without invoking the plugin again.
+func (p *Plugin) Call(req Request) (Response, error) {
+	req.Version = ProtocolVersion
+	input, err := json.Marshal(req)
+	if err != nil {
+		return Response{}, fmt.Errorf("failed to marshal request for plugin %q: %w", p.Name, err)
+	}
+
+	p.lock.Lock()
+	c, found := p.calls[string(input)]
+	if !found {
+		c = &call{}
+		p.calls[string(input)] = c
+	}
+	p.lock.Unlock()
+
+	c.once.Do(func() {
+		c.response, c.err = p.run(input)
+	})
+	return c.response, c.err
+}
+
+func (p *Plugin) run(input []byte) (Response, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Command, p.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// processes started by the plugin may keep its output open, so waiting for it is limited as well
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return Response{}, fmt.Errorf("plugin %q timed out after %s", p.Name, p.Timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return Response{}, fmt.Errorf("plugin %q failed: %w: %s", p.Name, err, msg)
+		}
+		return Response{}, fmt.Errorf("plugin %q failed: %w", p.Name, err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return Response{}, fmt.Errorf("plugin %q returned an invalid response: %w", p.Name, err)
+	}
+	if resp.Error != "" {
+		return Response{}, fmt.Errorf("plugin %q returned an error: %s", p.Name, resp.Error)
+	}
+	return resp, nil
+}
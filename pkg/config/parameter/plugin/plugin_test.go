@@ -0,0 +1,139 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// helperEnv makes the test binary act as a plugin, see TestHelperPlugin
+const helperEnv = "MONACO_TEST_PLUGIN_MODE"
+
+// helperPlugin returns a plugin invoking the test binary, which answers requests according to the given mode. Each
+// invocation is logged as a line to the returned file.
+func helperPlugin(t *testing.T, mode string, timeout time.Duration) (*Plugin, string) {
+	invocations := filepath.Join(t.TempDir(), "invocations")
+	t.Setenv(helperEnv, mode)
+	t.Setenv("MONACO_TEST_PLUGIN_INVOCATIONS", invocations)
+	return New("helper", os.Args[0], []string{"-test.run=TestHelperPlugin"}, timeout), invocations
+}
+
+func countInvocations(t *testing.T, file string) int {
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	return strings.Count(string(content), "\n")
+}
+
+// TestHelperPlugin is not a real test, but the plugin executable used by the tests of this package
+func TestHelperPlugin(t *testing.T) {
+	mode := os.Getenv(helperEnv)
+	if mode == "" {
+		t.Skip("only run as plugin")
+	}
+
+	f, _ := os.OpenFile(os.Getenv("MONACO_TEST_PLUGIN_INVOCATIONS"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	_, _ = fmt.Fprintln(f, mode)
+	_ = f.Close()
+
+	var req Request
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "invalid request: %s", err)
+		os.Exit(2)
+	}
+
+	var resp Response
+	switch mode {
+	case "echo":
+		switch req.Operation {
+		case OperationReferences:
+			resp.References = []Reference{{ConfigId: "other", Property: "name"}}
+		case OperationResolve:
+			resp.Value = fmt.Sprintf("%v in %s for %s/%s: %v", req.Value, req.Environment, req.Coordinate.ConfigId, req.Parameter, req.References[0].Value)
+		}
+	case "error":
+		resp.Error = "lookup failed"
+	case "fail":
+		_, _ = fmt.Fprint(os.Stderr, "something broke")
+		os.Exit(1)
+	case "empty":
+		_, _ = fmt.Fprint(os.Stdout, "{}")
+		os.Exit(0)
+	case "invalid":
+		_, _ = fmt.Fprint(os.Stdout, "not json")
+		os.Exit(0)
+	case "sleep":
+		time.Sleep(10 * time.Second)
+	}
+
+	_ = json.NewEncoder(os.Stdout).Encode(resp)
+	os.Exit(0)
+}
+
+func TestPlugin_Call(t *testing.T) {
+	p, invocations := helperPlugin(t, "echo", time.Minute)
+
+	resp, err := p.Call(Request{Operation: OperationReferences, Environment: "dev"})
+	require.NoError(t, err)
+	assert.Equal(t, []Reference{{ConfigId: "other", Property: "name"}}, resp.References)
+
+	t.Run("equal requests are cached", func(t *testing.T) {
+		_, err := p.Call(Request{Operation: OperationReferences, Environment: "dev"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, countInvocations(t, invocations))
+	})
+
+	t.Run("different requests invoke the plugin", func(t *testing.T) {
+		_, err := p.Call(Request{Operation: OperationReferences, Environment: "prod"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, countInvocations(t, invocations))
+	})
+}
+
+func TestPlugin_CallErrors(t *testing.T) {
+	tests := []struct {
+		mode    string
+		wantErr string
+	}{
+		{mode: "error", wantErr: `plugin "helper" returned an error: lookup failed`},
+		{mode: "fail", wantErr: `plugin "helper" failed: exit status 1: something broke`},
+		{mode: "invalid", wantErr: `plugin "helper" returned an invalid response`},
+		{mode: "sleep", wantErr: `plugin "helper" timed out after 100ms`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			p, _ := helperPlugin(t, tt.mode, 100*time.Millisecond)
+			if tt.mode != "sleep" {
+				p.Timeout = time.Minute
+			}
+
+			_, err := p.Call(Request{Operation: OperationResolve})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package values
+
+import (
+	"fmt"
+	"strings"
+
+	strs "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// ValuesParameterType specifies the type of the parameter used in config files
+const ValuesParameterType = "values"
+
+var ValuesParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeValuesParameter,
+	Deserializer: parseValuesParameter,
+}
+
+// ValuesParameter references a value defined in the values files of an environment.
+// The value is looked up at config load time, so Value holds the value of the environment
+// the config has been loaded for.
+type ValuesParameter struct {
+	// Key is the dot separated path of the value in the values files, e.g. `alerting.threshold`
+	Key string
+
+	// Value is the value found for Key
+	Value interface{}
+}
+
+func New(key string, value interface{}) *ValuesParameter {
+	return &ValuesParameter{Key: key, Value: value}
+}
+
+// this forces the compiler to check if ValuesParameter is of type Parameter
+var _ parameter.Parameter = (*ValuesParameter)(nil)
+
+func (p *ValuesParameter) GetType() string {
+	return ValuesParameterType
+}
+
+func (p *ValuesParameter) GetReferences() []parameter.ParameterReference {
+	// values parameters cannot have references, as the value is resolved at load time
+	return []parameter.ParameterReference{}
+}
+
+func (p *ValuesParameter) ResolveValue(_ parameter.ResolveContext) (interface{}, error) {
+	return template.EscapeSpecialCharactersInValue(p.Value, template.FullStringEscapeFunction)
+}
+
+// parseValuesParameter parses a given context into an instance of ValuesParameter.
+// the only required property is `key`, which is looked up in the values of the environment.
+func parseValuesParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	rawKey, ok := context.Value["key"]
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, "missing property `key`")
+	}
+
+	key := strs.ToString(rawKey)
+	if key == "" {
+		return nil, parameter.NewParameterParserError(context, "property `key` must not be empty")
+	}
+
+	val, err := Lookup(context.Values, key)
+	if err != nil {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("failed to resolve values key %q for environment %q: %s", key, context.Environment, err))
+	}
+
+	return New(key, val), nil
+}
+
+// Lookup returns the value found at the given dot separated key. Every segment but the
+// last one has to reference a map.
+func Lookup(values map[string]interface{}, key string) (interface{}, error) {
+	var current interface{} = values
+	segments := strings.Split(key, ".")
+
+	for i, segment := range segments {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%q is not a map", strings.Join(segments[:i], "."))
+		}
+
+		current, ok = m[segment]
+		if !ok {
+			return nil, fmt.Errorf("%q is not defined", strings.Join(segments[:i+1], "."))
+		}
+	}
+
+	return current, nil
+}
+
+func writeValuesParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	valuesParam, ok := context.Parameter.(*ValuesParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `ValuesParameter`")
+	}
+
+	return map[string]interface{}{
+		"key": valuesParam.Key,
+	}, nil
+}
@@ -0,0 +1,99 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package values
+
+import (
+	"testing"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/stretchr/testify/assert"
+)
+
+var testValues = map[string]interface{}{
+	"alerting": map[string]interface{}{
+		"threshold": 10,
+		"message":   `say "hi"`,
+	},
+	"owner": "team-a",
+}
+
+func TestParseValuesParameter(t *testing.T) {
+	param, err := parseValuesParameter(parameter.ParameterParserContext{
+		Value:  map[string]interface{}{"key": "alerting.threshold"},
+		Values: testValues,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &ValuesParameter{Key: "alerting.threshold", Value: 10}, param)
+	assert.Empty(t, param.GetReferences())
+}
+
+func TestParseValuesParameter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "missing key",
+			value:   map[string]interface{}{},
+			wantErr: "missing property `key`",
+		},
+		{
+			name:    "empty key",
+			value:   map[string]interface{}{"key": ""},
+			wantErr: "property `key` must not be empty",
+		},
+		{
+			name:    "undefined value",
+			value:   map[string]interface{}{"key": "alerting.enabled"},
+			wantErr: `failed to resolve values key "alerting.enabled" for environment "dev": "alerting.enabled" is not defined`,
+		},
+		{
+			name:    "value is not a map",
+			value:   map[string]interface{}{"key": "owner.name"},
+			wantErr: `failed to resolve values key "owner.name" for environment "dev": "owner" is not a map`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseValuesParameter(parameter.ParameterParserContext{
+				Environment: "dev",
+				Value:       tt.value,
+				Values:      testValues,
+			})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestResolveValuesParameter(t *testing.T) {
+	result, err := New("alerting.message", `say "hi"`).ResolveValue(parameter.ResolveContext{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `say \"hi\"`, result)
+}
+
+func TestWriteValuesParameter(t *testing.T) {
+	result, err := writeValuesParameter(parameter.ParameterWriterContext{Parameter: New("alerting.threshold", 10)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"key": "alerting.threshold"}, result)
+}
@@ -0,0 +1,92 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrNotJSON is returned by FormatJSON for templates which are no valid JSON once their Go template actions are
+// replaced, e.g. as actions generate JSON structure
+var ErrNotJSON = errors.New("template is not valid JSON")
+
+// FormatJSON pretty-prints the given JSON template with an indentation of two spaces, keeping the order of properties
+// and the Go template actions it contains. As actions outside of JSON strings are invalid JSON, all actions are
+// replaced by placeholders for formatting.
+func FormatJSON(content string) (string, error) {
+	var inString, raw []string
+	var b strings.Builder
+
+	quoted, escaped := false, false
+	for i := 0; i < len(content); i++ {
+		if strings.HasPrefix(content[i:], "{{") {
+			end := strings.Index(content[i:], "}}")
+			if end < 0 {
+				return "", fmt.Errorf("unterminated template action at offset %d", i)
+			}
+			action := content[i : i+end+2]
+
+			if quoted {
+				b.WriteString(inStringPlaceholder(len(inString)))
+				inString = append(inString, action)
+			} else {
+				b.WriteString(`"` + rawPlaceholder(len(raw)) + `"`)
+				raw = append(raw, action)
+			}
+			i += end + 1
+			continue
+		}
+
+		c := content[i]
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		}
+		b.WriteByte(c)
+	}
+
+	var formatted bytes.Buffer
+	if err := json.Indent(&formatted, []byte(b.String()), "", "  "); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrNotJSON, err)
+	}
+
+	result := strings.TrimRightFunc(formatted.String(), unicode.IsSpace) + "\n"
+	for i, action := range raw {
+		result = strings.Replace(result, `"`+rawPlaceholder(i)+`"`, action, 1)
+	}
+	for i, action := range inString {
+		result = strings.Replace(result, inStringPlaceholder(i), action, 1)
+	}
+	return result, nil
+}
+
+func inStringPlaceholder(i int) string {
+	return fmt.Sprintf("__MONACO_ACTION_%d__", i)
+}
+
+func rawPlaceholder(i int) string {
+	return fmt.Sprintf("__MONACO_RAW_ACTION_%d__", i)
+}
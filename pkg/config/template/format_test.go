@@ -0,0 +1,90 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFormatJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		given string
+		want  string
+	}{
+		{
+			name:  "indents and keeps property order",
+			given: `{"z": 1, "a": {"list": [1,2], "empty": {}}, "number": 1.50}`,
+			want: `{
+  "z": 1,
+  "a": {
+    "list": [
+      1,
+      2
+    ],
+    "empty": {}
+  },
+  "number": 1.50
+}
+`,
+		},
+		{
+			name:  "keeps template actions",
+			given: `{"name": "{{.name}} ({{ .env }})", "enabled": {{ .enabled }}, "escaped": "a \"{{.quoted}}\" b", "list": [{{.first}}, 2]}`,
+			want: `{
+  "name": "{{.name}} ({{ .env }})",
+  "enabled": {{ .enabled }},
+  "escaped": "a \"{{.quoted}}\" b",
+  "list": [
+    {{.first}},
+    2
+  ]
+}
+`,
+		},
+		{
+			name: "formatted templates are unchanged",
+			given: `{
+  "name": "{{ .name }}"
+}
+`,
+			want: `{
+  "name": "{{ .name }}"
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatJSON(tt.given)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatJSON_Errors(t *testing.T) {
+	_, err := FormatJSON(`{"name": "{{.name}"}`)
+	assert.ErrorContains(t, err, "unterminated template action")
+
+	_, err = FormatJSON(`{"tags": [{{ range .tags }}"{{ . }}"{{ end }}]}`)
+	assert.ErrorIs(t, err, ErrNotJSON)
+}
@@ -24,10 +24,13 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/account"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/document"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"golang.org/x/exp/slices"
 	"reflect"
 )
 
@@ -61,6 +64,8 @@ type ClientSet struct {
 	Classic    dtclient.Client
 	Settings   dtclient.Client
 	Automation automationClient
+	Document   documentClient
+	Account    accountClient
 }
 
 type automationClient interface {
@@ -68,6 +73,20 @@ type automationClient interface {
 	List(ctx context.Context, resourceType automation.ResourceType) (result []automation.Response, err error)
 }
 
+type documentClient interface {
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, documentType string) ([]document.Metadata, error)
+}
+
+type accountClient interface {
+	ListGroups(ctx context.Context) ([]account.Group, error)
+	DeleteGroup(ctx context.Context, uuid string) error
+	ListPolicies(ctx context.Context) ([]account.Policy, error)
+	DeletePolicy(ctx context.Context, uuid string) error
+	UpdateEnvironmentBindings(ctx context.Context, groupUUID string, policyUUIDs []string) error
+	UpdateGroupPermissions(ctx context.Context, groupUUID string, permissions []account.Permission) error
+}
+
 // Configs removes all given entriesToDelete from the Dynatrace environment the given client connects to
 func Configs(ctx context.Context, clients ClientSet, apis api.APIs, automationResources map[string]config.AutomationResource, entriesToDelete map[string][]DeletePointer) []error {
 	errs := make([]error, 0)
@@ -84,6 +103,22 @@ func Configs(ctx context.Context, clients ClientSet, apis api.APIs, automationRe
 
 			deleteErrs := deleteAutomations(clients.Automation, targetAutomation, entries)
 			errs = append(errs, deleteErrs...)
+		} else if entryType == string(config.DocumentTypeId) {
+			if clients.Document == nil || reflect.ValueOf(clients.Document).IsNil() {
+				log.WithCtxFields(ctx).WithFields(field.Type(entryType)).Warn("Skipped deletion of %d document configurations as API client was unavailable.", len(entries))
+				continue
+			}
+
+			deleteErrs := deleteDocuments(ctx, clients.Document, entries)
+			errs = append(errs, deleteErrs...)
+		} else if resource := config.AccountResource(entryType); slices.Contains(config.KnownAccountResources, resource) {
+			if clients.Account == nil || reflect.ValueOf(clients.Account).IsNil() {
+				log.WithCtxFields(ctx).WithFields(field.Type(entryType)).Warn("Skipped deletion of %d %s configurations as the environment does not belong to an account.", len(entries), entryType)
+				continue
+			}
+
+			deleteErrs := deleteAccountResources(ctx, clients.Account, resource, entries)
+			errs = append(errs, deleteErrs...)
 		} else { // assume it's a Settings Schema
 			deleteErrs := deleteSettingsObject(ctx, clients.Settings, entries)
 			errs = append(errs, deleteErrs...)
@@ -186,6 +221,78 @@ func deleteAutomations(c automationClient, automationResource config.AutomationR
 	return errors
 }
 
+func deleteDocuments(ctx context.Context, c documentClient, entries []DeletePointer) []error {
+	errors := make([]error, 0)
+
+	for _, e := range entries {
+		id := idutils.GenerateUUIDFromCoordinate(e.asCoordinate())
+
+		log.WithCtxFields(ctx).Debug("Deleting document %s with ID %q.", e, id)
+		if err := c.Delete(ctx, id); err != nil {
+			errors = append(errors, fmt.Errorf("could not delete document %s with ID %q: %w", e, id, err))
+		}
+	}
+
+	return errors
+}
+
+// deleteAccountResources deletes the user groups and policies with the names of the given entries. For policy bindings
+// and group permissions, the entries name the user group whose policies are unbound from the environment, or whose
+// permissions are removed.
+func deleteAccountResources(ctx context.Context, c accountClient, resource config.AccountResource, entries []DeletePointer) []error {
+	errors := make([]error, 0)
+
+	if resource == config.Policy {
+		policies, err := c.ListPolicies(ctx)
+		if err != nil {
+			return []error{fmt.Errorf("failed to fetch existing policies. Skipping deletion of all policies. Reason: %w", err)}
+		}
+
+		for _, e := range entries {
+			idx := slices.IndexFunc(policies, func(p account.Policy) bool { return p.Name == e.Identifier })
+			if idx < 0 {
+				log.WithCtxFields(ctx).WithFields(field.Type(string(resource))).Debug("No policy with name %q found to delete.", e.Identifier)
+				continue
+			}
+
+			log.WithCtxFields(ctx).Debug("Deleting policy %s with ID %q.", e, policies[idx].UUID)
+			if err := c.DeletePolicy(ctx, policies[idx].UUID); err != nil {
+				errors = append(errors, fmt.Errorf("could not delete policy %s with ID %q: %w", e, policies[idx].UUID, err))
+			}
+		}
+		return errors
+	}
+
+	groups, err := c.ListGroups(ctx)
+	if err != nil {
+		return []error{fmt.Errorf("failed to fetch existing user groups. Skipping deletion of all %s configs. Reason: %w", resource, err)}
+	}
+
+	for _, e := range entries {
+		idx := slices.IndexFunc(groups, func(g account.Group) bool { return g.Name == e.Identifier })
+		if idx < 0 {
+			log.WithCtxFields(ctx).WithFields(field.Type(string(resource))).Debug("No user group with name %q found to delete %s of.", e.Identifier, resource)
+			continue
+		}
+		id := groups[idx].UUID
+
+		log.WithCtxFields(ctx).Debug("Deleting %s %s of user group with ID %q.", resource, e, id)
+		switch resource {
+		case config.UserGroup:
+			err = c.DeleteGroup(ctx, id)
+		case config.PolicyBinding:
+			err = c.UpdateEnvironmentBindings(ctx, id, nil)
+		case config.GroupPermission:
+			err = c.UpdateGroupPermissions(ctx, id, nil)
+		}
+		if err != nil {
+			errors = append(errors, fmt.Errorf("could not delete %s %s of user group with ID %q: %w", resource, e, id, err))
+		}
+	}
+
+	return errors
+}
+
 // filterValuesToDelete filters the given values for only values we want to delete.
 // We first search the names of the config-to-be-deleted, and if we find it, return them.
 // If we don't find it, we look if the name is actually an id, and if we find it, return them.
@@ -335,3 +442,27 @@ func AllAutomations(ctx context.Context, c automationClient) []error {
 
 	return errs
 }
+
+// AllDocuments deletes all documents it can find from the Dynatrace environment the given client connects to
+func AllDocuments(ctx context.Context, c documentClient) []error {
+	var errs []error
+
+	for _, kind := range config.KnownDocumentKinds {
+		log.WithCtxFields(ctx).WithFields(field.Type(string(kind))).Info("Collecting documents of type %s...", kind)
+		documents, err := c.List(ctx, string(kind))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		log.WithCtxFields(ctx).WithFields(field.Type(string(kind))).Info("Deleting %d documents of type %s...", len(documents), kind)
+		for _, d := range documents {
+			log.WithCtxFields(ctx).WithFields(field.Type(string(kind)), field.F("object", d)).Debug("Deleting document with id %q...", d.ID)
+			if err := c.Delete(ctx, d.ID); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errs
+}
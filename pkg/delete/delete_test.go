@@ -22,10 +22,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/account"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/document"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -393,6 +397,97 @@ func TestDeleteAutomations(t *testing.T) {
 
 }
 
+func TestDeleteDocuments(t *testing.T) {
+	t.Run("deletes document with ID generated from coordinate", func(t *testing.T) {
+		id := idutils.GenerateUUIDFromCoordinate(coordinate.Coordinate{Project: "project", Type: "document", ConfigId: "id1"})
+
+		var deleted bool
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			switch {
+			case req.Method == http.MethodGet && req.URL.Path == "/platform/document/v1/documents/"+id+"/metadata":
+				_, _ = rw.Write([]byte(`{"id": "` + id + `", "version": 1}`))
+			case req.Method == http.MethodDelete && req.URL.Path == "/platform/document/v1/documents/"+id:
+				deleted = true
+				rw.WriteHeader(http.StatusNoContent)
+			case req.Method == http.MethodDelete && req.URL.Path == "/platform/document/v1/trash/documents/"+id:
+				rw.WriteHeader(http.StatusNoContent)
+			default:
+				assert.Fail(t, "unexpected HTTP call")
+			}
+		}))
+		defer server.Close()
+
+		c := document.NewClient(server.URL, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy()))
+
+		entriesToDelete := map[string][]DeletePointer{
+			"document": {
+				{
+					Type:       "document",
+					Project:    "project",
+					Identifier: "id1",
+				},
+			},
+		}
+		errs := Configs(context.TODO(), ClientSet{Document: c}, api.NewAPIs(), automationTypes, entriesToDelete)
+		assert.Empty(t, errs, "errors should be empty")
+		assert.True(t, deleted, "expected document to be deleted but it was not")
+	})
+
+	t.Run("skips documents without client", func(t *testing.T) {
+		entriesToDelete := map[string][]DeletePointer{
+			"document": {
+				{
+					Type:       "document",
+					Project:    "project",
+					Identifier: "id1",
+				},
+			},
+		}
+		errs := Configs(context.TODO(), ClientSet{}, api.NewAPIs(), automationTypes, entriesToDelete)
+		assert.Empty(t, errs, "errors should be empty")
+	})
+}
+
+func TestDeleteAccountResources(t *testing.T) {
+	t.Run("deletes user groups and unbinds policies by group name", func(t *testing.T) {
+		var requests []string
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			switch {
+			case req.Method == http.MethodGet && req.URL.Path == "/iam/v1/accounts/account-uuid/groups":
+				_, _ = rw.Write([]byte(`{"items": [{"uuid": "g-1", "name": "devs"}, {"uuid": "g-2", "name": "ops"}]}`))
+			case req.Method == http.MethodDelete && req.URL.Path == "/iam/v1/accounts/account-uuid/groups/g-1":
+				rw.WriteHeader(http.StatusOK)
+			case req.Method == http.MethodPut && req.URL.Path == "/iam/v1/repo/environment/abc12345/bindings/groups/g-2":
+				rw.WriteHeader(http.StatusOK)
+			default:
+				assert.Fail(t, "unexpected HTTP call", "%s %s", req.Method, req.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		c := account.NewClient(server.URL, "account-uuid", rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy())).WithEnvironment("abc12345")
+
+		errs := Configs(context.TODO(), ClientSet{Account: c}, api.NewAPIs(), automationTypes, map[string][]DeletePointer{
+			"user-group": {{Type: "user-group", Identifier: "devs"}},
+		})
+		assert.Empty(t, errs)
+		errs = Configs(context.TODO(), ClientSet{Account: c}, api.NewAPIs(), automationTypes, map[string][]DeletePointer{
+			"policy-binding": {{Type: "policy-binding", Identifier: "ops"}, {Type: "policy-binding", Identifier: "unknown"}},
+		})
+		assert.Empty(t, errs)
+		assert.Contains(t, requests, "DELETE /iam/v1/accounts/account-uuid/groups/g-1")
+		assert.Contains(t, requests, "PUT /iam/v1/repo/environment/abc12345/bindings/groups/g-2")
+	})
+
+	t.Run("skips account resources without client", func(t *testing.T) {
+		errs := Configs(context.TODO(), ClientSet{}, api.NewAPIs(), automationTypes, map[string][]DeletePointer{
+			"policy": {{Type: "policy", Identifier: "read"}},
+		})
+		assert.Empty(t, errs)
+	})
+}
+
 func TestSplitConfigsForDeletion(t *testing.T) {
 	type expect struct {
 		ids     []string
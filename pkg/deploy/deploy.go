@@ -21,18 +21,26 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/tracing"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	errors2 "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/account"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/automation"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/bucket"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/document"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/entitymap"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extension"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/setting"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/unchanged"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
 	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
 	clientErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"go.opentelemetry.io/otel/trace"
 	graph2 "gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/simple"
 	"sync"
@@ -46,6 +54,15 @@ type DeployConfigsOptions struct {
 	// DryRun states that the deployment shall just run in dry-run mode, meaning
 	// that actual deployment of the configuration to a tenant will be skipped
 	DryRun bool
+	// ReferencedProjects are projects which are not deployed, but whose configurations may be referenced by the deployed
+	// ones. References to them are resolved by looking up the objects they were previously deployed as.
+	ReferencedProjects []project.Project
+	// State records the objects configurations are deployed as. If set, recorded objects are used to resolve references
+	// to configurations which are not deployed, and to update renamed configurations instead of creating new objects.
+	State *state.State
+	// OnlyChanged states that configurations are only deployed if they changed since their last deployment, compared
+	// to the State, or to the objects on the environment if they are not recorded in the State
+	OnlyChanged bool
 }
 
 type ClientSet struct {
@@ -53,6 +70,9 @@ type ClientSet struct {
 	Settings   dtclient.Client
 	Automation automation.Client
 	Bucket     bucket.Client
+	Extension  extension.Client
+	Document   document.Client
+	Account    account.Client
 }
 
 var DummyClientSet = ClientSet{
@@ -60,6 +80,9 @@ var DummyClientSet = ClientSet{
 	Settings:   &dtclient.DummyClient{},
 	Automation: &automation.DummyClient{},
 	Bucket:     &bucket.DummyClient{},
+	Extension:  &extension.DummyClient{},
+	Document:   &document.DummyClient{},
+	Account:    &account.DummyClient{},
 }
 
 type EnvironmentInfo struct {
@@ -76,7 +99,14 @@ func (e EnvironmentClients) Names() []string {
 	return n
 }
 
-func DeployConfigGraph(projects []project.Project, environmentClients EnvironmentClients, opts DeployConfigsOptions) error {
+// ValidateUniqueConfigNames checks that no two classic configurations of the same API share a name in an environment.
+// Classic configurations are identified by their name, so DeployConfigGraph refuses to deploy projects failing this check.
+// Errors are returned as errors.EnvironmentDeploymentErrors holding one errors.ConfigDeployErr per affected configuration.
+func ValidateUniqueConfigNames(projects []project.Project) error {
+	return classic.ValidateUniqueConfigNames(projects)
+}
+
+func DeployConfigGraph(ctx context.Context, projects []project.Project, environmentClients EnvironmentClients, opts DeployConfigsOptions) error {
 
 	apis := api.NewAPIs()
 	g := graph.New(projects, environmentClients.Names())
@@ -89,7 +119,7 @@ func DeployConfigGraph(projects []project.Project, environmentClients Environmen
 	}
 
 	for env, clients := range environmentClients {
-		envErrs := deployComponentsToEnvironment(g, env, clients, apis, opts)
+		envErrs := deployComponentsToEnvironment(ctx, g, env, clients, apis, opts)
 		if len(envErrs) > 0 {
 			errs[env.Name] = envErrs
 
@@ -106,9 +136,13 @@ func DeployConfigGraph(projects []project.Project, environmentClients Environmen
 	return nil
 }
 
-func deployComponentsToEnvironment(g graph.ConfigGraphPerEnvironment, env EnvironmentInfo, clientSet ClientSet, apis api.APIs, opts DeployConfigsOptions) []error {
+func deployComponentsToEnvironment(ctx context.Context, g graph.ConfigGraphPerEnvironment, env EnvironmentInfo, clientSet ClientSet, apis api.APIs, opts DeployConfigsOptions) (errs []error) {
 
-	ctx := context.WithValue(context.TODO(), log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+	ctx = context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+	ctx, span := tracing.Tracer().Start(ctx, "deploy environment", trace.WithAttributes(tracing.Environment.String(env.Name), tracing.EnvironmentGroup.String(env.Group)))
+	defer func() {
+		tracing.EndSpan(span, errors.Join(errs...))
+	}()
 
 	log.WithCtxFields(ctx).Info("Deploying configurations to environment %q...", env.Name)
 
@@ -117,11 +151,13 @@ func deployComponentsToEnvironment(g graph.ConfigGraphPerEnvironment, env Enviro
 		return []error{fmt.Errorf("failed to get independently sorted configs for environment %q: %w", env.Name, err)}
 	}
 
+	envOpts := NewEnvironmentOptions(clientSet, apis, opts)
+
 	var deployErrs []error
 	if featureflags.DependencyGraphBasedDeployParallel().Enabled() {
-		deployErrs = deployComponentsParallel(ctx, sortedConfigs, clientSet, apis, opts)
+		deployErrs = deployComponentsParallel(ctx, sortedConfigs, clientSet, apis, envOpts, opts)
 	} else {
-		deployErrs = deployComponents(ctx, sortedConfigs, clientSet, apis, opts)
+		deployErrs = deployComponents(ctx, sortedConfigs, clientSet, apis, envOpts, opts)
 	}
 
 	if len(deployErrs) > 0 {
@@ -131,17 +167,45 @@ func deployComponentsToEnvironment(g graph.ConfigGraphPerEnvironment, env Enviro
 	return nil
 }
 
+// EnvironmentOptions are the options for deploying configurations to a single environment. They are derived from the
+// DeployConfigsOptions and the clients of the environment once per environment, using NewEnvironmentOptions.
+type EnvironmentOptions struct {
+	// Remote resolves references to configurations of the DeployConfigsOptions.ReferencedProjects by looking them up
+	// on the environment. It is nil if no referenced projects are set.
+	Remote *remote.Resolver
+	// Unchanged detects configurations which need not to be deployed, by comparing them to the State or to the objects
+	// on the environment. It is nil if DeployConfigsOptions.OnlyChanged is not set.
+	Unchanged *unchanged.Detector
+	// State is the DeployConfigsOptions.State
+	State *state.State
+}
+
+// NewEnvironmentOptions returns the EnvironmentOptions for deploying to the environment of the given clients
+func NewEnvironmentOptions(clientSet ClientSet, apis api.APIs, opts DeployConfigsOptions) EnvironmentOptions {
+	clients := remote.Clients{Classic: clientSet.Classic, Settings: clientSet.Settings, Extension: clientSet.Extension, Account: clientSet.Account}
+
+	envOpts := EnvironmentOptions{State: opts.State}
+	if len(opts.ReferencedProjects) > 0 {
+		envOpts.Remote = remote.New(clients, apis, opts.ReferencedProjects, opts.DryRun)
+	}
+	if opts.OnlyChanged {
+		envOpts.Unchanged = unchanged.New(clients, apis, opts.State)
+	}
+	return envOpts
+}
+
 var skipError = errors.New("skip error")
 
-func deployComponents(ctx context.Context, components []graph.SortedComponent, clientSet ClientSet, apis api.APIs, opts DeployConfigsOptions) []error {
+func deployComponents(ctx context.Context, components []graph.SortedComponent, clientSet ClientSet, apis api.APIs, envOpts EnvironmentOptions, opts DeployConfigsOptions) []error {
 
 	var errs []error
 
 	log.WithCtxFields(ctx).Info("Deploying %d independent configuration sets...", len(components))
 
 	for i := range components {
-		ctx = context.WithValue(ctx, log.CtxGraphComponentId{}, log.CtxValGraphComponentId(i))
-		componentDeployErrs := deployComponent(ctx, components[i], clientSet, apis, opts)
+		componentCtx, span := startComponentSpan(ctx, i, components[i])
+		componentDeployErrs := deployComponent(componentCtx, components[i], clientSet, apis, envOpts, opts)
+		tracing.EndSpan(span, errors.Join(componentDeployErrs...))
 
 		if len(componentDeployErrs) > 0 && !opts.ContinueOnErr && !opts.DryRun {
 			return componentDeployErrs
@@ -153,7 +217,7 @@ func deployComponents(ctx context.Context, components []graph.SortedComponent, c
 	return errs
 }
 
-func deployComponentsParallel(ctx context.Context, components []graph.SortedComponent, clientSet ClientSet, apis api.APIs, opts DeployConfigsOptions) []error {
+func deployComponentsParallel(ctx context.Context, components []graph.SortedComponent, clientSet ClientSet, apis api.APIs, envOpts EnvironmentOptions, opts DeployConfigsOptions) []error {
 	var errs []error
 	log.WithCtxFields(ctx).Info("Deploying %d independent configuration sets in parallel...", len(components))
 
@@ -161,11 +225,12 @@ func deployComponentsParallel(ctx context.Context, components []graph.SortedComp
 
 	// Iterate over components and launch a goroutine for each component deployment.
 	for i := range components {
-		c := context.WithValue(ctx, log.CtxGraphComponentId{}, log.CtxValGraphComponentId(i))
-		go func(ctx context.Context, component graph.SortedComponent) {
-			componentDeployErrs := deployComponent(ctx, component, clientSet, apis, opts)
+		c, span := startComponentSpan(ctx, i, components[i])
+		go func(ctx context.Context, span trace.Span, component graph.SortedComponent) {
+			componentDeployErrs := deployComponent(ctx, component, clientSet, apis, envOpts, opts)
+			tracing.EndSpan(span, errors.Join(componentDeployErrs...))
 			errChan <- componentDeployErrs
-		}(c, components[i])
+		}(c, span, components[i])
 	}
 
 	// Collect errors from goroutines and append to the 'errs' slice.
@@ -180,11 +245,21 @@ func deployComponentsParallel(ctx context.Context, components []graph.SortedComp
 	return errs
 }
 
+// startComponentSpan adds the ID of the graph component to the context, and starts a span for deploying the component
+func startComponentSpan(ctx context.Context, id int, component graph.SortedComponent) (context.Context, trace.Span) {
+	ctx = context.WithValue(ctx, log.CtxGraphComponentId{}, log.CtxValGraphComponentId(id))
+	return tracing.Tracer().Start(ctx, "deploy component", trace.WithAttributes(
+		tracing.GraphComponentID.Int(id),
+		tracing.ConfigCount.Int(len(component.SortedNodes)),
+	))
+}
+
 type componentDeployer struct {
 	lock             sync.Mutex
 	graph            graph.ConfigGraph
 	clients          ClientSet
 	resolvedEntities entitymap.EntityMap
+	envOpts          EnvironmentOptions
 	apis             api.APIs
 }
 
@@ -223,7 +298,14 @@ func (c *componentDeployer) deploy(ctx context.Context) []error {
 }
 
 func (c *componentDeployer) deployNode(ctx context.Context, n graph.ConfigNode) error {
-	entity, err := deploy(ctx, n.Config, c.clients, c.apis, &c.resolvedEntities)
+	ctx, span := tracing.Tracer().Start(ctx, "deploy config", trace.WithAttributes(tracing.CoordinateAttributes(n.Config.Coordinate)...))
+	entity, err := deploy(ctx, n.Config, c.clients, c.apis, &c.resolvedEntities, c.envOpts)
+	if errors.Is(err, skipError) {
+		span.SetAttributes(tracing.Skipped.Bool(true))
+		tracing.EndSpan(span, nil)
+	} else {
+		tracing.EndSpan(span, err)
+	}
 
 	// lock changes we will make to shared variables. Writing them is trivial compared to any http request
 	c.lock.Lock()
@@ -270,7 +352,7 @@ func (c *componentDeployer) removeChildren(ctx context.Context, parent, root gra
 		c.graph.RemoveNode(child.ID())
 	}
 }
-func deployComponent(ctx context.Context, component graph.SortedComponent, clientSet ClientSet, apis api.APIs, _ DeployConfigsOptions) []error {
+func deployComponent(ctx context.Context, component graph.SortedComponent, clientSet ClientSet, apis api.APIs, envOpts EnvironmentOptions, opts DeployConfigsOptions) []error {
 	g := simple.NewDirectedGraph()
 	graph2.Copy(g, component.Graph)
 
@@ -279,17 +361,23 @@ func deployComponent(ctx context.Context, component graph.SortedComponent, clien
 		graph:            g,
 		clients:          clientSet,
 		resolvedEntities: *entitymap.New(),
+		envOpts:          envOpts,
 		apis:             apis,
 	}
 	return deployer.deploy(ctx)
 }
 
-func deploy(ctx context.Context, c *config.Config, clientSet ClientSet, apis api.APIs, entityMap *entitymap.EntityMap) (config.ResolvedEntity, error) {
+func deploy(ctx context.Context, c *config.Config, clientSet ClientSet, apis api.APIs, entityMap *entitymap.EntityMap, envOpts EnvironmentOptions) (config.ResolvedEntity, error) {
 	if c.Skip {
 		log.WithCtxFields(ctx).Info("Skipping deployment of config %s", c.Coordinate)
 		return config.ResolvedEntity{}, skipError //fake resolved entity that "old" deploy creates is never needed, as we don't even try to deploy dependencies of skipped configs (so no reference will ever be attempted to resolve)
 	}
 
+	envOpts.State.ResolveReferences(c, entityMap)
+	if err := envOpts.Remote.ResolveReferences(ctx, c, entityMap); err != nil {
+		return config.ResolvedEntity{}, fmt.Errorf("failed to resolve references of config %s: %w", c.Coordinate, err)
+	}
+
 	properties, errs := c.ResolveParameterValues(entityMap)
 	if len(errs) > 0 {
 		return config.ResolvedEntity{}, fmt.Errorf("failed to resolve parameter properties of config %s: %w", c.Coordinate, errors.Join(errs...))
@@ -300,15 +388,31 @@ func deploy(ctx context.Context, c *config.Config, clientSet ClientSet, apis api
 		return config.ResolvedEntity{}, fmt.Errorf("failed to render JSON template of config %s: %w", c.Coordinate, err)
 	}
 
+	hash, err := state.Hash(renderedConfig, properties)
+	if err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err)).Debug("Failed to hash config: %s", err)
+	}
+
+	if entity, unchanged := envOpts.Unchanged.Unchanged(ctx, c, properties, renderedConfig, hash); unchanged {
+		log.WithCtxFields(ctx).Info("Skipping deployment of unchanged config")
+		trace.SpanFromContext(ctx).SetAttributes(tracing.Unchanged.Bool(true))
+		return entity, nil
+	}
+
 	log.WithCtxFields(ctx).Info("Deploying config")
 	var entity config.ResolvedEntity
 	var deployErr error
-	switch c.Type.(type) {
+	switch t := c.Type.(type) {
 	case config.SettingsType:
 		entity, deployErr = setting.Deploy(ctx, clientSet.Settings, properties, renderedConfig, c)
 
 	case config.ClassicApiType:
-		entity, deployErr = classic.Deploy(ctx, clientSet.Classic, apis, properties, renderedConfig, c)
+		trace.SpanFromContext(ctx).SetAttributes(tracing.API.String(t.Api))
+		if previous, renamed := classic.Renamed(envOpts.State, c, properties); renamed {
+			entity, deployErr = classic.DeployRenamed(ctx, clientSet.Classic, apis, properties, renderedConfig, c, previous.ID)
+		} else {
+			entity, deployErr = classic.Deploy(ctx, clientSet.Classic, apis, properties, renderedConfig, c)
+		}
 
 	case config.AutomationType:
 		entity, deployErr = automation.Deploy(ctx, clientSet.Automation, properties, renderedConfig, c)
@@ -316,6 +420,15 @@ func deploy(ctx context.Context, c *config.Config, clientSet ClientSet, apis api
 	case config.BucketType:
 		entity, deployErr = bucket.Deploy(ctx, clientSet.Bucket, properties, renderedConfig, c)
 
+	case config.ExtensionType:
+		entity, deployErr = extension.Deploy(ctx, clientSet.Extension, properties, renderedConfig, c)
+
+	case config.DocumentType:
+		entity, deployErr = document.Deploy(ctx, clientSet.Document, properties, renderedConfig, c)
+
+	case config.AccountType:
+		entity, deployErr = account.Deploy(ctx, clientSet.Account, properties, renderedConfig, c)
+
 	default:
 		deployErr = fmt.Errorf("unknown config-type (ID: %q)", c.Type.ID())
 	}
@@ -323,11 +436,13 @@ func deploy(ctx context.Context, c *config.Config, clientSet ClientSet, apis api
 	if deployErr != nil {
 		var responseErr clientErrors.RespError
 		if errors.As(deployErr, &responseErr) {
-			log.WithCtxFields(ctx).WithFields(field.Error(responseErr)).Error("Failed to deploy config %s: %s", c.Coordinate, responseErr.Reason)
+			log.WithCtxFields(ctx).WithFields(field.Error(responseErr)).Error("Failed to deploy config %s: %s", c.Describe(), responseErr.Reason)
 		} else {
-			log.WithCtxFields(ctx).WithFields(field.Error(deployErr)).Error("Failed to deploy config %s: %s", c.Coordinate, deployErr.Error())
+			log.WithCtxFields(ctx).WithFields(field.Error(deployErr)).Error("Failed to deploy config %s: %s", c.Describe(), deployErr.Error())
 		}
-		return config.ResolvedEntity{}, fmt.Errorf("failed to deploy config %s: %w", c.Coordinate, deployErr)
+		return config.ResolvedEntity{}, fmt.Errorf("failed to deploy config %s: %w", c.Describe(), deployErr)
 	}
+
+	envOpts.State.Record(c, entity, hash)
 	return entity, nil
 }
@@ -428,7 +428,7 @@ func TestDeployConfigGraph_SingleConfig(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: clientSet,
 	}
 
-	errors := deploy.DeployConfigGraph(p, c, deploy.DeployConfigsOptions{})
+	errors := deploy.DeployConfigGraph(context.TODO(), p, c, deploy.DeployConfigsOptions{})
 
 	assert.Emptyf(t, errors, "errors: %v", errors)
 
@@ -491,7 +491,7 @@ func TestDeployConfigGraph_SettingShouldFailUpsert(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c},
 	}
 
-	errors := deploy.DeployConfigGraph(p, clients, deploy.DeployConfigsOptions{})
+	errors := deploy.DeployConfigGraph(context.TODO(), p, clients, deploy.DeployConfigsOptions{})
 	assert.NotEmpty(t, errors)
 }
 
@@ -512,7 +512,7 @@ func TestDeployConfigGraph_DoesNotFailOnEmptyConfigs(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: deploy.DummyClientSet,
 	}
 
-	errors := deploy.DeployConfigGraph(p, c, deploy.DeployConfigsOptions{})
+	errors := deploy.DeployConfigGraph(context.TODO(), p, c, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -524,7 +524,7 @@ func TestDeployConfigGraph_DoesNotFailOnEmptyProject(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: deploy.DummyClientSet,
 	}
 
-	errors := deploy.DeployConfigGraph(p, c, deploy.DeployConfigsOptions{})
+	errors := deploy.DeployConfigGraph(context.TODO(), p, c, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -534,7 +534,7 @@ func TestDeployConfigGraph_DoesNotFailNilProject(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: deploy.DummyClientSet,
 	}
 
-	errors := deploy.DeployConfigGraph(nil, c, deploy.DeployConfigsOptions{})
+	errors := deploy.DeployConfigGraph(context.TODO(), nil, c, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -560,7 +560,7 @@ func TestDeployConfigGraph_DoesNotDeploySkippedConfig(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: clientSet,
 	}
 
-	errors := deploy.DeployConfigGraph(p, c, deploy.DeployConfigsOptions{})
+	errors := deploy.DeployConfigGraph(context.TODO(), p, c, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 	createdEntities, found := dummyClient.GetEntries(api.NewAPIs()["dashboard"])
 	assert.False(t, found, "expected NO entries for dashboard API to exist")
@@ -607,7 +607,7 @@ func TestDeployConfigGraph_DeploysSetting(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c},
 	}
 
-	errors := deploy.DeployConfigGraph(p, clients, deploy.DeployConfigsOptions{})
+	errors := deploy.DeployConfigGraph(context.TODO(), p, clients, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -652,7 +652,7 @@ func TestDeployConfigsTargetingClassicConfigUnique(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Classic: client},
 	}
 
-	errors := deploy.DeployConfigGraph(p, clients, deploy.DeployConfigsOptions{})
+	errors := deploy.DeployConfigGraph(context.TODO(), p, clients, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -697,7 +697,7 @@ func TestDeployConfigsTargetingClassicConfigNonUniqueWithExistingCfgsOfSameName(
 		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Classic: client},
 	}
 
-	errors := deploy.DeployConfigGraph(p, clients, deploy.DeployConfigsOptions{})
+	errors := deploy.DeployConfigGraph(context.TODO(), p, clients, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -748,7 +748,7 @@ func TestDeployConfigsWithDeploymentErrors(t *testing.T) {
 	}
 
 	t.Run("deployment error - stop on error", func(t *testing.T) {
-		err := deploy.DeployConfigGraph(p, c, deploy.DeployConfigsOptions{})
+		err := deploy.DeployConfigGraph(context.TODO(), p, c, deploy.DeployConfigsOptions{})
 		assert.Error(t, err)
 
 		envErrs := make(errors.EnvironmentDeploymentErrors)
@@ -758,7 +758,7 @@ func TestDeployConfigsWithDeploymentErrors(t *testing.T) {
 	})
 
 	t.Run("deployment error - continue on error", func(t *testing.T) {
-		err := deploy.DeployConfigGraph(p, c, deploy.DeployConfigsOptions{ContinueOnErr: true})
+		err := deploy.DeployConfigGraph(context.TODO(), p, c, deploy.DeployConfigsOptions{ContinueOnErr: true})
 		assert.Error(t, err)
 
 		envErrs := make(errors.EnvironmentDeploymentErrors)
@@ -883,7 +883,7 @@ func TestDeployConfigGraph_DoesNotDeployConfigsDependingOnSkippedConfigs(t *test
 		deploy.EnvironmentInfo{Name: environmentName}: clientSet,
 	}
 
-	errs := deploy.DeployConfigGraph(projects, clients, deploy.DeployConfigsOptions{})
+	errs := deploy.DeployConfigGraph(context.TODO(), projects, clients, deploy.DeployConfigsOptions{})
 	assert.NoError(t, errs)
 	assert.Zero(t, dummyClient.CreatedObjects())
 }
@@ -1001,7 +1001,7 @@ func TestDeployConfigGraph_DeploysIndependentConfigurations(t *testing.T) {
 		deploy.EnvironmentInfo{Name: environmentName}: clientSet,
 	}
 
-	errs := deploy.DeployConfigGraph(projects, clients, deploy.DeployConfigsOptions{})
+	errs := deploy.DeployConfigGraph(context.TODO(), projects, clients, deploy.DeployConfigsOptions{})
 	assert.NoError(t, errs)
 
 	dashboards, found := dummyClient.GetEntries(api.NewAPIs()["dashboard"])
@@ -1123,7 +1123,7 @@ func TestDeployConfigGraph_DeploysIndependentConfigurations_IfContinuingAfterFai
 		deploy.EnvironmentInfo{Name: environmentName}: clientSet,
 	}
 
-	errs := deploy.DeployConfigGraph(projects, clients, deploy.DeployConfigsOptions{ContinueOnErr: true})
+	errs := deploy.DeployConfigGraph(context.TODO(), projects, clients, deploy.DeployConfigsOptions{ContinueOnErr: true})
 	assert.Len(t, errs, 1)
 
 	dashboards, found := dummyClient.GetEntries(api.NewAPIs()["dashboard"])
@@ -1505,7 +1505,7 @@ func TestDeployConfigsValidatesClassicAPINames(t *testing.T) {
 				deploy.EnvironmentInfo{Name: "env2"}: deploy.DummyClientSet,
 			}
 
-			err := deploy.DeployConfigGraph(tc.given, c, deploy.DeployConfigsOptions{})
+			err := deploy.DeployConfigGraph(context.TODO(), tc.given, c, deploy.DeployConfigsOptions{})
 			if len(tc.wantErrsContain) == 0 {
 				assert.NoError(t, err)
 			} else {
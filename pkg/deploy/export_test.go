@@ -18,4 +18,14 @@
 
 package deploy
 
-var TestDeploy = deploy
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/entitymap"
+)
+
+// TestDeploy deploys the given config with the default EnvironmentOptions
+var TestDeploy = func(ctx context.Context, c *config.Config, clientSet ClientSet, apis api.APIs, entityMap *entitymap.EntityMap) (config.ResolvedEntity, error) {
+	return deploy(ctx, c, clientSet, apis, entityMap, EnvironmentOptions{})
+}
@@ -0,0 +1,287 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/account"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+)
+
+//go:generate mockgen -source=account.go -destination=account_mock.go -package=account accountClient
+type Client interface {
+	ListGroups(ctx context.Context) ([]account.Group, error)
+	CreateGroup(ctx context.Context, group account.Group) (account.Group, error)
+	UpdateGroup(ctx context.Context, group account.Group) error
+	ListPolicies(ctx context.Context) ([]account.Policy, error)
+	CreatePolicy(ctx context.Context, policy account.Policy) (account.Policy, error)
+	UpdatePolicy(ctx context.Context, policy account.Policy) error
+	UpdateEnvironmentBindings(ctx context.Context, groupUUID string, policyUUIDs []string) error
+	UpdateGroupPermissions(ctx context.Context, groupUUID string, permissions []account.Permission) error
+}
+
+var _ Client = (*DummyClient)(nil)
+
+// DummyClient is used during dry-runs. It does not know any objects and returns all objects as created.
+type DummyClient struct{}
+
+func (c *DummyClient) ListGroups(context.Context) ([]account.Group, error) {
+	return nil, nil
+}
+
+func (c *DummyClient) CreateGroup(_ context.Context, group account.Group) (account.Group, error) {
+	group.UUID = idutils.GenerateUUIDFromString(group.Name)
+	return group, nil
+}
+
+func (c *DummyClient) UpdateGroup(context.Context, account.Group) error {
+	return nil
+}
+
+func (c *DummyClient) ListPolicies(context.Context) ([]account.Policy, error) {
+	return nil, nil
+}
+
+func (c *DummyClient) CreatePolicy(_ context.Context, policy account.Policy) (account.Policy, error) {
+	policy.UUID = idutils.GenerateUUIDFromString(policy.Name)
+	return policy, nil
+}
+
+func (c *DummyClient) UpdatePolicy(context.Context, account.Policy) error {
+	return nil
+}
+
+func (c *DummyClient) UpdateEnvironmentBindings(context.Context, string, []string) error {
+	return nil
+}
+
+func (c *DummyClient) UpdateGroupPermissions(context.Context, string, []account.Permission) error {
+	return nil
+}
+
+// bindingPayload is the payload of a policy binding config. Group and policies are usually references to user group
+// and policy configs.
+type bindingPayload struct {
+	Group    string   `json:"group"`
+	Policies []string `json:"policies"`
+}
+
+// permissionPayload is the payload of a group permission config. The group is usually a reference to a user group
+// config.
+type permissionPayload struct {
+	Group       string               `json:"group"`
+	Permissions []account.Permission `json:"permissions"`
+}
+
+// Deploy deploys the given account config. User groups and policies are identified by their name, policy bindings and
+// group permissions replace all bindings and permissions of the group they are defined for.
+func Deploy(ctx context.Context, client Client, properties parameter.Properties, renderedConfig string, c *config.Config) (config.ResolvedEntity, error) {
+	t, ok := c.Type.(config.AccountType)
+	if !ok {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.AccountTypeId, c.Type.ID()))
+	}
+
+	switch t.Resource {
+	case config.UserGroup:
+		return deployGroup(ctx, client, properties, renderedConfig, c)
+	case config.Policy:
+		return deployPolicy(ctx, client, properties, renderedConfig, c)
+	case config.PolicyBinding:
+		return deployBinding(ctx, client, properties, renderedConfig, c)
+	case config.GroupPermission:
+		return deployPermission(ctx, client, properties, renderedConfig, c)
+	default:
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("unknown account resource %q", t.Resource))
+	}
+}
+
+func deployGroup(ctx context.Context, client Client, properties parameter.Properties, renderedConfig string, c *config.Config) (config.ResolvedEntity, error) {
+	name, err := extract.ConfigName(c, properties)
+	if err != nil {
+		return config.ResolvedEntity{}, err
+	}
+
+	var group account.Group
+	if err := json.Unmarshal([]byte(renderedConfig), &group); err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, "failed to unmarshal user group").WithError(err)
+	}
+	group.Name = name
+
+	groups, err := client.ListGroups(ctx)
+	if err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, "failed to list user groups").WithError(err)
+	}
+
+	if existing, found := findGroup(c, name, groups); found {
+		group.UUID = existing.UUID
+		if err := client.UpdateGroup(ctx, group); err != nil {
+			return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("failed to update user group %q", name)).WithError(err)
+		}
+	} else {
+		created, err := client.CreateGroup(ctx, group)
+		if err != nil {
+			return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("failed to create user group %q", name)).WithError(err)
+		}
+		group.UUID = created.UUID
+	}
+
+	return entity(c, properties, name, group.UUID), nil
+}
+
+func deployPolicy(ctx context.Context, client Client, properties parameter.Properties, renderedConfig string, c *config.Config) (config.ResolvedEntity, error) {
+	name, err := extract.ConfigName(c, properties)
+	if err != nil {
+		return config.ResolvedEntity{}, err
+	}
+
+	var policy account.Policy
+	if err := json.Unmarshal([]byte(renderedConfig), &policy); err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, "failed to unmarshal policy").WithError(err)
+	}
+	policy.Name = name
+
+	policies, err := client.ListPolicies(ctx)
+	if err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, "failed to list policies").WithError(err)
+	}
+
+	if existing, found := findPolicy(c, name, policies); found {
+		policy.UUID = existing.UUID
+		if err := client.UpdatePolicy(ctx, policy); err != nil {
+			return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("failed to update policy %q", name)).WithError(err)
+		}
+	} else {
+		created, err := client.CreatePolicy(ctx, policy)
+		if err != nil {
+			return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("failed to create policy %q", name)).WithError(err)
+		}
+		policy.UUID = created.UUID
+	}
+
+	return entity(c, properties, name, policy.UUID), nil
+}
+
+func deployBinding(ctx context.Context, client Client, properties parameter.Properties, renderedConfig string, c *config.Config) (config.ResolvedEntity, error) {
+	var binding bindingPayload
+	if err := json.Unmarshal([]byte(renderedConfig), &binding); err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, "failed to unmarshal policy binding").WithError(err)
+	}
+	if binding.Group == "" {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, "policy binding does not define a 'group'")
+	}
+
+	if err := client.UpdateEnvironmentBindings(ctx, binding.Group, binding.Policies); err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("failed to bind policies to user group %q", binding.Group)).WithError(err)
+	}
+
+	return entity(c, properties, c.Coordinate.ConfigId, binding.Group), nil
+}
+
+func deployPermission(ctx context.Context, client Client, properties parameter.Properties, renderedConfig string, c *config.Config) (config.ResolvedEntity, error) {
+	var permission permissionPayload
+	if err := json.Unmarshal([]byte(renderedConfig), &permission); err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, "failed to unmarshal group permissions").WithError(err)
+	}
+	if permission.Group == "" {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, "group permissions do not define a 'group'")
+	}
+
+	if err := client.UpdateGroupPermissions(ctx, permission.Group, permission.Permissions); err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("failed to update permissions of user group %q", permission.Group)).WithError(err)
+	}
+
+	return entity(c, properties, c.Coordinate.ConfigId, permission.Group), nil
+}
+
+// Lookup returns the entity the given config was deployed as. Only user groups and policies can be looked up, by their
+// name.
+func Lookup(ctx context.Context, client Client, properties parameter.Properties, c *config.Config) (config.ResolvedEntity, error) {
+	t, ok := c.Type.(config.AccountType)
+	if !ok {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.AccountTypeId, c.Type.ID()))
+	}
+
+	name, err := extract.ConfigName(c, properties)
+	if err != nil {
+		return config.ResolvedEntity{}, err
+	}
+
+	switch t.Resource {
+	case config.UserGroup:
+		groups, err := client.ListGroups(ctx)
+		if err != nil {
+			return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, "failed to list user groups").WithError(err)
+		}
+		existing, found := findGroup(c, name, groups)
+		if !found {
+			return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("no user group with name %q found", name))
+		}
+		return entity(c, properties, name, existing.UUID), nil
+
+	case config.Policy:
+		policies, err := client.ListPolicies(ctx)
+		if err != nil {
+			return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, "failed to list policies").WithError(err)
+		}
+		existing, found := findPolicy(c, name, policies)
+		if !found {
+			return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("no policy with name %q found", name))
+		}
+		return entity(c, properties, name, existing.UUID), nil
+
+	default:
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("%s configs can not be looked up", t.Resource))
+	}
+}
+
+// findGroup returns the group the given config was deployed as. The group is identified by the config's origin object
+// ID if set, otherwise by its name.
+func findGroup(c *config.Config, name string, groups []account.Group) (account.Group, bool) {
+	for _, g := range groups {
+		if (c.OriginObjectId != "" && g.UUID == c.OriginObjectId) || (c.OriginObjectId == "" && g.Name == name) {
+			return g, true
+		}
+	}
+	return account.Group{}, false
+}
+
+// findPolicy returns the policy the given config was deployed as. The policy is identified by the config's origin
+// object ID if set, otherwise by its name.
+func findPolicy(c *config.Config, name string, policies []account.Policy) (account.Policy, bool) {
+	for _, p := range policies {
+		if (c.OriginObjectId != "" && p.UUID == c.OriginObjectId) || (c.OriginObjectId == "" && p.Name == name) {
+			return p, true
+		}
+	}
+	return account.Policy{}, false
+}
+
+func entity(c *config.Config, properties parameter.Properties, name, id string) config.ResolvedEntity {
+	properties[config.IdParameter] = id
+	return config.ResolvedEntity{
+		EntityName: name,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+		Skip:       false,
+	}
+}
@@ -0,0 +1,146 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"context"
+	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/account"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func newConfig(t *testing.T, resource config.AccountResource) *config.Config {
+	return &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: string(resource), ConfigId: "my-" + string(resource)},
+		Type:       config.AccountType{Resource: resource},
+		Template:   testutils.GenerateDummyTemplate(t),
+	}
+}
+
+func TestDeploy_UserGroup(t *testing.T) {
+	t.Run("creates missing group", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().ListGroups(gomock.Any()).Return([]account.Group{{UUID: "other", Name: "other"}}, nil)
+		client.EXPECT().CreateGroup(gomock.Any(), account.Group{Name: "devs", Description: "developers"}).Return(account.Group{UUID: "g-1", Name: "devs"}, nil)
+
+		entity, err := Deploy(context.TODO(), client, parameter.Properties{config.NameParameter: "devs"}, `{"description": "developers"}`, newConfig(t, config.UserGroup))
+		require.NoError(t, err)
+		assert.Equal(t, "devs", entity.EntityName)
+		assert.Equal(t, "g-1", entity.Properties[config.IdParameter])
+	})
+
+	t.Run("updates group with same name", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().ListGroups(gomock.Any()).Return([]account.Group{{UUID: "g-1", Name: "devs"}}, nil)
+		client.EXPECT().UpdateGroup(gomock.Any(), account.Group{UUID: "g-1", Name: "devs", Description: "developers"}).Return(nil)
+
+		entity, err := Deploy(context.TODO(), client, parameter.Properties{config.NameParameter: "devs"}, `{"description": "developers"}`, newConfig(t, config.UserGroup))
+		require.NoError(t, err)
+		assert.Equal(t, "g-1", entity.Properties[config.IdParameter])
+	})
+
+	t.Run("updates group with origin object ID", func(t *testing.T) {
+		c := newConfig(t, config.UserGroup)
+		c.OriginObjectId = "g-1"
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().ListGroups(gomock.Any()).Return([]account.Group{{UUID: "g-1", Name: "old name"}}, nil)
+		client.EXPECT().UpdateGroup(gomock.Any(), account.Group{UUID: "g-1", Name: "devs"}).Return(nil)
+
+		_, err := Deploy(context.TODO(), client, parameter.Properties{config.NameParameter: "devs"}, `{}`, c)
+		require.NoError(t, err)
+	})
+
+	t.Run("fails if listing groups fails", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().ListGroups(gomock.Any()).Return(nil, errors.New("LIST_FAIL"))
+
+		_, err := Deploy(context.TODO(), client, parameter.Properties{config.NameParameter: "devs"}, `{}`, newConfig(t, config.UserGroup))
+		assert.ErrorContains(t, err, "LIST_FAIL")
+	})
+}
+
+func TestDeploy_Policy(t *testing.T) {
+	client := NewMockClient(gomock.NewController(t))
+	client.EXPECT().ListPolicies(gomock.Any()).Return(nil, nil)
+	client.EXPECT().CreatePolicy(gomock.Any(), account.Policy{Name: "read", StatementQuery: "ALLOW settings:objects:read;"}).Return(account.Policy{UUID: "p-1"}, nil)
+
+	entity, err := Deploy(context.TODO(), client, parameter.Properties{config.NameParameter: "read"}, `{"statementQuery": "ALLOW settings:objects:read;"}`, newConfig(t, config.Policy))
+	require.NoError(t, err)
+	assert.Equal(t, "p-1", entity.Properties[config.IdParameter])
+}
+
+func TestDeploy_PolicyBinding(t *testing.T) {
+	t.Run("binds policies to group", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().UpdateEnvironmentBindings(gomock.Any(), "g-1", []string{"p-1", "p-2"}).Return(nil)
+
+		entity, err := Deploy(context.TODO(), client, parameter.Properties{}, `{"group": "g-1", "policies": ["p-1", "p-2"]}`, newConfig(t, config.PolicyBinding))
+		require.NoError(t, err)
+		assert.Equal(t, "my-policy-binding", entity.EntityName)
+		assert.Equal(t, "g-1", entity.Properties[config.IdParameter])
+	})
+
+	t.Run("fails without group", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+
+		_, err := Deploy(context.TODO(), client, parameter.Properties{}, `{"policies": ["p-1"]}`, newConfig(t, config.PolicyBinding))
+		assert.ErrorContains(t, err, "group")
+	})
+}
+
+func TestDeploy_GroupPermission(t *testing.T) {
+	client := NewMockClient(gomock.NewController(t))
+	client.EXPECT().UpdateGroupPermissions(gomock.Any(), "g-1", []account.Permission{{PermissionName: "tenant-viewer", Scope: "abc12345", ScopeType: "tenant"}}).Return(nil)
+
+	_, err := Deploy(context.TODO(), client, parameter.Properties{}, `{"group": "g-1", "permissions": [{"permissionName": "tenant-viewer", "scope": "abc12345", "scopeType": "tenant"}]}`, newConfig(t, config.GroupPermission))
+	require.NoError(t, err)
+}
+
+func TestLookup(t *testing.T) {
+	t.Run("looks up policy by name", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().ListPolicies(gomock.Any()).Return([]account.Policy{{UUID: "p-1", Name: "read"}}, nil)
+
+		entity, err := Lookup(context.TODO(), client, parameter.Properties{config.NameParameter: "read"}, newConfig(t, config.Policy))
+		require.NoError(t, err)
+		assert.Equal(t, "p-1", entity.Properties[config.IdParameter])
+	})
+
+	t.Run("fails for missing group", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().ListGroups(gomock.Any()).Return(nil, nil)
+
+		_, err := Lookup(context.TODO(), client, parameter.Properties{config.NameParameter: "devs"}, newConfig(t, config.UserGroup))
+		assert.ErrorContains(t, err, "no user group")
+	})
+
+	t.Run("fails for policy bindings", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+
+		_, err := Lookup(context.TODO(), client, parameter.Properties{config.NameParameter: "binding"}, newConfig(t, config.PolicyBinding))
+		assert.Error(t, err)
+	})
+}
@@ -49,13 +49,7 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.AutomationType{}.ID(), c.Type.ID()))
 	}
 
-	var id string
-
-	if c.OriginObjectId != "" {
-		id = c.OriginObjectId
-	} else {
-		id = idutils.GenerateUUIDFromCoordinate(c.Coordinate)
-	}
+	id := objectID(c)
 
 	resourceType, err := automationutils.ClientResourceTypeFromConfigType(t.Resource)
 	if err != nil {
@@ -85,3 +79,33 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 	return resolved, nil
 
 }
+
+// Lookup returns the entity the given config was deployed as. Automation objects are deployed with an ID generated
+// from the config's coordinate, so they can be referenced without querying the environment.
+func Lookup(properties parameter.Properties, c *config.Config) (config.ResolvedEntity, error) {
+	if _, ok := c.Type.(config.AutomationType); !ok {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.AutomationType{}.ID(), c.Type.ID()))
+	}
+
+	id := objectID(c)
+	name := fmt.Sprintf("[UNKNOWN NAME]%s", id)
+	if configName, err := extract.ConfigName(c, properties); err == nil {
+		name = configName
+	}
+
+	properties[config.IdParameter] = id
+	return config.ResolvedEntity{
+		EntityName: name,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+		Skip:       false,
+	}, nil
+}
+
+// objectID returns the ID of the automation object of the given config
+func objectID(c *config.Config) string {
+	if c.OriginObjectId != "" {
+		return c.OriginObjectId
+	}
+	return idutils.GenerateUUIDFromCoordinate(c.Coordinate)
+}
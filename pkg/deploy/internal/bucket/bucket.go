@@ -58,6 +58,19 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 	}, nil
 }
 
+// Lookup returns the entity the given config was deployed as. Buckets are named after the config's coordinate, so they
+// can be referenced without querying the environment.
+func Lookup(properties parameter.Properties, c *config.Config) (config.ResolvedEntity, error) {
+	bucketName := BucketId(c.Coordinate)
+	properties[config.IdParameter] = bucketName
+
+	return config.ResolvedEntity{
+		EntityName: bucketName,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+	}, nil
+}
+
 // BucketId returns the ID for a bucket based on the coordinate.
 // Since the bucket API does not support colons, we concatenate them using underscores.
 func BucketId(c coordinate.Coordinate) string {
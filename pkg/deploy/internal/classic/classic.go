@@ -27,9 +27,29 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 )
 
 func Deploy(ctx context.Context, configClient dtclient.ConfigClient, apis api.APIs, properties parameter.Properties, renderedConfig string, conf *config.Config) (config.ResolvedEntity, error) {
+	return deploy(ctx, configClient, apis, properties, renderedConfig, conf, "")
+}
+
+// DeployRenamed deploys the given config like Deploy, but updates the object with the given previousID, as the name of
+// the config changed since it was deployed as that object. Otherwise, a new object would be created for the new name.
+func DeployRenamed(ctx context.Context, configClient dtclient.ConfigClient, apis api.APIs, properties parameter.Properties, renderedConfig string, conf *config.Config, previousID string) (config.ResolvedEntity, error) {
+	return deploy(ctx, configClient, apis, properties, renderedConfig, conf, previousID)
+}
+
+// Renamed returns the recorded state of the given config, if it was deployed with a different name before
+func Renamed(st *state.State, c *config.Config, properties parameter.Properties) (state.Entry, bool) {
+	name, err := extract.ConfigName(c, properties)
+	if err != nil {
+		return state.Entry{}, false
+	}
+	return st.Renamed(c, name)
+}
+
+func deploy(ctx context.Context, configClient dtclient.ConfigClient, apis api.APIs, properties parameter.Properties, renderedConfig string, conf *config.Config, previousID string) (config.ResolvedEntity, error) {
 	t, ok := conf.Type.(config.ClassicApiType)
 	if !ok {
 		return config.ResolvedEntity{}, fmt.Errorf("config was not of expected type %q, but %q", config.ClassicApiTypeId, conf.Type.ID())
@@ -50,7 +70,10 @@ func Deploy(ctx context.Context, configClient dtclient.ConfigClient, apis api.AP
 	}
 
 	var entity dtclient.DynatraceEntity
-	if apiToDeploy.NonUniqueName {
+	if previousID != "" && !apiToDeploy.NonUniqueName && !apiToDeploy.SingleConfiguration {
+		log.WithCtxFields(ctx).Info("Config was renamed to %q since its last deployment - updating previously deployed object %q", configName, previousID)
+		entity, err = configClient.UpsertConfigByNonUniqueNameAndId(ctx, apiToDeploy, previousID, configName, []byte(renderedConfig))
+	} else if apiToDeploy.NonUniqueName {
 		entity, err = upsertNonUniqueNameConfig(ctx, configClient, apiToDeploy, conf, configName, renderedConfig)
 	} else {
 		entity, err = configClient.UpsertConfigByName(ctx, apiToDeploy, configName, []byte(renderedConfig))
@@ -72,6 +95,13 @@ func Deploy(ctx context.Context, configClient dtclient.ConfigClient, apis api.AP
 }
 
 func upsertNonUniqueNameConfig(ctx context.Context, client dtclient.ConfigClient, apiToDeploy api.API, conf *config.Config, configName string, renderedConfig string) (dtclient.DynatraceEntity, error) {
+	entityUuid := nonUniqueNameConfigID(conf)
+	return client.UpsertConfigByNonUniqueNameAndId(ctx, apiToDeploy, entityUuid, configName, []byte(renderedConfig))
+}
+
+// nonUniqueNameConfigID returns the ID a config of an API with non-unique names is deployed with. This is the config ID
+// itself, if it is already a UUID or Dynatrace entity ID, or a UUID generated from it otherwise.
+func nonUniqueNameConfigID(conf *config.Config) string {
 	configID := conf.Coordinate.ConfigId
 	projectId := conf.Coordinate.Project
 
@@ -81,6 +111,5 @@ func upsertNonUniqueNameConfig(ctx context.Context, client dtclient.ConfigClient
 	if !isUUIDOrMeID {
 		entityUuid = idutils.GenerateUUIDFromConfigId(projectId, configID)
 	}
-
-	return client.UpsertConfigByNonUniqueNameAndId(ctx, apiToDeploy, entityUuid, configName, []byte(renderedConfig))
+	return entityUuid
 }
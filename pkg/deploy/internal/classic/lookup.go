@@ -0,0 +1,100 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package classic
+
+import (
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+)
+
+// Lookup finds the object the given config was deployed as on the environment, without changing it. Like Deploy, objects
+// are identified by their name, or for APIs with non-unique names, by the ID generated for the config if several
+// objects share the same name.
+func Lookup(ctx context.Context, configClient dtclient.ConfigClient, apis api.APIs, properties parameter.Properties, conf *config.Config) (config.ResolvedEntity, error) {
+	t, ok := conf.Type.(config.ClassicApiType)
+	if !ok {
+		return config.ResolvedEntity{}, fmt.Errorf("config was not of expected type %q, but %q", config.ClassicApiTypeId, conf.Type.ID())
+	}
+
+	a, found := apis[t.Api]
+	if !found {
+		return config.ResolvedEntity{}, fmt.Errorf("unknown api `%s`. this is most likely a bug", t.Api)
+	}
+
+	configName, err := extract.ConfigName(conf, properties)
+	if err != nil {
+		return config.ResolvedEntity{}, err
+	}
+
+	// single configuration APIs have exactly one object without an ID, which always exists
+	id := ""
+	if !a.SingleConfiguration {
+		values, err := configClient.ListConfigs(ctx, a)
+		if err != nil {
+			return config.ResolvedEntity{}, errors.NewConfigDeployErr(conf, err.Error()).WithError(err)
+		}
+
+		id, err = FindConfigID(a, conf, configName, values)
+		if err != nil {
+			return config.ResolvedEntity{}, errors.NewConfigDeployErr(conf, err.Error()).WithError(err)
+		}
+	}
+
+	properties[config.IdParameter] = id
+	properties[config.NameParameter] = configName
+
+	return config.ResolvedEntity{
+		EntityName: configName,
+		Coordinate: conf.Coordinate,
+		Properties: properties,
+		Skip:       false,
+	}, nil
+}
+
+// FindConfigID returns the ID of the object with the given name from the given objects of the API. Like Deploy, objects
+// of APIs with non-unique names are identified by the ID generated for the config if several objects share the same name.
+func FindConfigID(a api.API, conf *config.Config, name string, values []dtclient.Value) (string, error) {
+	var matches []dtclient.Value
+	for _, v := range values {
+		if v.Name == name {
+			matches = append(matches, v)
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0].Id, nil
+	case len(matches) > 1 && a.NonUniqueName:
+		id := nonUniqueNameConfigID(conf)
+		for _, m := range matches {
+			if m.Id == id {
+				return id, nil
+			}
+		}
+		return "", fmt.Errorf("found %d %s objects named %q, but none with ID %q", len(matches), a.ID, name, id)
+	case len(matches) > 1:
+		return "", fmt.Errorf("found %d %s objects named %q", len(matches), a.ID, name)
+	default:
+		return "", fmt.Errorf("no %s object named %q found", a.ID, name)
+	}
+}
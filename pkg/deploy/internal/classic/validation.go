@@ -52,12 +52,12 @@ func ValidateUniqueConfigNames(projects []project.Project) error {
 		for _, c2 := range uniqueList[c.Environment][a.Api] {
 			n1, err := getNameForConfig(c)
 			if err != nil {
-				errs = errs.Append(c.Environment, err)
+				errs = errs.Append(c.Environment, errors.NewConfigDeployErr(&c, err.Error()).WithError(err))
 				return
 			}
 			n2, err := getNameForConfig(c2)
 			if err != nil {
-				errs = errs.Append(c.Environment, err)
+				errs = errs.Append(c.Environment, errors.NewConfigDeployErr(&c2, err.Error()).WithError(err))
 				return
 			}
 
@@ -67,7 +67,7 @@ func ValidateUniqueConfigNames(projects []project.Project) error {
 					nameDetails = fmt.Sprintf(": %s", s)
 				}
 
-				errs = errs.Append(c.Environment, fmt.Errorf("duplicated config name found: configurations %s and %s define the same 'name' %q", c.Coordinate, c2.Coordinate, nameDetails))
+				errs = errs.Append(c.Environment, errors.NewConfigDeployErr(&c, fmt.Sprintf("duplicated config name found: configurations %s and %s define the same 'name' %q", c.Coordinate, c2.Coordinate, nameDetails)))
 				return
 			}
 		}
@@ -17,14 +17,17 @@
 package classic
 
 import (
+	"errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
 	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -394,3 +397,34 @@ func TestValidateUniqueConfigNames(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUniqueConfigNames_ErrorsLocateConfigs(t *testing.T) {
+	first := coordinate.Coordinate{Project: "p", Type: "app-detection-rule", ConfigId: "first"}
+	second := coordinate.Coordinate{Project: "p", Type: "app-detection-rule", ConfigId: "second"}
+	newConfig := func(c coordinate.Coordinate) config.Config {
+		return config.Config{
+			Type:        config.ClassicApiType{Api: "app-detection-rule"},
+			Environment: "env",
+			Coordinate:  c,
+			Parameters:  config.Parameters{config.NameParameter: &value.ValueParameter{Value: "name"}},
+		}
+	}
+
+	err := ValidateUniqueConfigNames([]project.Project{
+		{
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": {"app-detection-rule": {newConfig(first), newConfig(second)}},
+			},
+		},
+	})
+
+	var envErrs deployErrors.EnvironmentDeploymentErrors
+	require.True(t, errors.As(err, &envErrs))
+	require.Len(t, envErrs["env"], 1)
+
+	var configErr deployErrors.ConfigDeployErr
+	require.True(t, errors.As(envErrs["env"][0], &configErr))
+	assert.Contains(t, []coordinate.Coordinate{first, second}, configErr.Location)
+	assert.Equal(t, "env", configErr.EnvironmentDetails.Environment)
+	assert.ErrorContains(t, configErr, "duplicated config name found")
+}
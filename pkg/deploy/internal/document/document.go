@@ -0,0 +1,96 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/document"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+)
+
+//go:generate mockgen -source=document.go -destination=document_mock.go -package=document documentClient
+type Client interface {
+	Upsert(ctx context.Context, id, name, documentType string, private bool, content []byte) (document.Metadata, error)
+}
+
+var _ Client = (*DummyClient)(nil)
+
+type DummyClient struct{}
+
+func (c *DummyClient) Upsert(_ context.Context, id, name, documentType string, private bool, _ []byte) (document.Metadata, error) {
+	return document.Metadata{ID: id, Name: name, Type: documentType, IsPrivate: private}, nil
+}
+
+func Deploy(ctx context.Context, client Client, properties parameter.Properties, renderedConfig string, c *config.Config) (config.ResolvedEntity, error) {
+	t, ok := c.Type.(config.DocumentType)
+	if !ok {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.DocumentTypeId, c.Type.ID()))
+	}
+
+	name, err := extract.ConfigName(c, properties)
+	if err != nil {
+		return config.ResolvedEntity{}, err
+	}
+
+	id := objectID(c)
+	if _, err := client.Upsert(ctx, id, name, string(t.Kind), t.Private, []byte(renderedConfig)); err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("failed to upsert %s document with id %s", t.Kind, id)).WithError(err)
+	}
+
+	properties[config.IdParameter] = id
+	return config.ResolvedEntity{
+		EntityName: name,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+		Skip:       false,
+	}, nil
+}
+
+// Lookup returns the entity the given config was deployed as. Documents are deployed with an ID generated from the
+// config's coordinate, so they can be referenced without querying the environment.
+func Lookup(properties parameter.Properties, c *config.Config) (config.ResolvedEntity, error) {
+	if _, ok := c.Type.(config.DocumentType); !ok {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.DocumentTypeId, c.Type.ID()))
+	}
+
+	id := objectID(c)
+	name := fmt.Sprintf("[UNKNOWN NAME]%s", id)
+	if configName, err := extract.ConfigName(c, properties); err == nil {
+		name = configName
+	}
+
+	properties[config.IdParameter] = id
+	return config.ResolvedEntity{
+		EntityName: name,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+		Skip:       false,
+	}, nil
+}
+
+// objectID returns the ID of the document of the given config
+func objectID(c *config.Config) string {
+	if c.OriginObjectId != "" {
+		return c.OriginObjectId
+	}
+	return idutils.GenerateUUIDFromCoordinate(c.Coordinate)
+}
@@ -0,0 +1,101 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"context"
+	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/document"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestDeploy(t *testing.T) {
+	coord := coordinate.Coordinate{Project: "project", Type: "document", ConfigId: "my-dashboard"}
+	newConfig := func(originObjectId string) *config.Config {
+		return &config.Config{
+			Coordinate:     coord,
+			Type:           config.DocumentType{Kind: config.DashboardKind, Private: true},
+			Template:       testutils.GenerateDummyTemplate(t),
+			OriginObjectId: originObjectId,
+		}
+	}
+
+	t.Run("deploys with ID generated from coordinate", func(t *testing.T) {
+		id := idutils.GenerateUUIDFromCoordinate(coord)
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().Upsert(gomock.Any(), id, "My Dashboard", "dashboard", true, []byte(`{"tiles": {}}`)).Return(document.Metadata{ID: id}, nil)
+
+		entity, err := Deploy(context.TODO(), client, parameter.Properties{config.NameParameter: "My Dashboard"}, `{"tiles": {}}`, newConfig(""))
+		require.NoError(t, err)
+		assert.Equal(t, "My Dashboard", entity.EntityName)
+		assert.Equal(t, id, entity.Properties[config.IdParameter])
+	})
+
+	t.Run("deploys with origin object ID", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().Upsert(gomock.Any(), "origin-id", "My Dashboard", "dashboard", true, gomock.Any()).Return(document.Metadata{ID: "origin-id"}, nil)
+
+		entity, err := Deploy(context.TODO(), client, parameter.Properties{config.NameParameter: "My Dashboard"}, `{}`, newConfig("origin-id"))
+		require.NoError(t, err)
+		assert.Equal(t, "origin-id", entity.Properties[config.IdParameter])
+	})
+
+	t.Run("fails if upsert fails", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(document.Metadata{}, errors.New("UPSERT_FAIL"))
+
+		_, err := Deploy(context.TODO(), client, parameter.Properties{config.NameParameter: "My Dashboard"}, `{}`, newConfig(""))
+		assert.ErrorContains(t, err, "UPSERT_FAIL")
+	})
+
+	t.Run("fails without name", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+
+		_, err := Deploy(context.TODO(), client, parameter.Properties{}, `{}`, newConfig(""))
+		assert.Error(t, err)
+	})
+
+	t.Run("fails for wrong type", func(t *testing.T) {
+		c := newConfig("")
+		c.Type = config.ClassicApiType{}
+
+		_, err := Deploy(context.TODO(), &DummyClient{}, parameter.Properties{}, `{}`, c)
+		assert.Error(t, err)
+	})
+}
+
+func TestLookup(t *testing.T) {
+	c := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "document", ConfigId: "my-notebook"},
+		Type:       config.DocumentType{Kind: config.NotebookKind},
+	}
+
+	entity, err := Lookup(parameter.Properties{config.NameParameter: "My Notebook"}, c)
+	require.NoError(t, err)
+	assert.Equal(t, "My Notebook", entity.EntityName)
+	assert.Equal(t, idutils.GenerateUUIDFromCoordinate(c.Coordinate), entity.Properties[config.IdParameter])
+}
@@ -0,0 +1,168 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package extension deploys monitoring configurations of Extensions 2.0 extensions.
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/extension"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+)
+
+const (
+	// descriptionProperty is the property of monitoring configurations holding their name, by which they are identified
+	descriptionProperty = "description"
+	// versionProperty is the property of monitoring configurations holding the version of the extension they use
+	versionProperty = "version"
+)
+
+//go:generate mockgen -source=extension.go -destination=extension_mock.go -package=extension extensionClient
+type Client interface {
+	Activate(ctx context.Context, name, version string, archive []byte) error
+	ListMonitoringConfigurations(ctx context.Context, name string) ([]extension.MonitoringConfiguration, error)
+	UpsertMonitoringConfiguration(ctx context.Context, name, objectID, scope string, value []byte) (string, error)
+}
+
+var _ Client = (*extension.Client)(nil)
+var _ Client = (*DummyClient)(nil)
+
+type DummyClient struct{}
+
+func (DummyClient) Activate(context.Context, string, string, []byte) error {
+	return nil
+}
+
+func (DummyClient) ListMonitoringConfigurations(context.Context, string) ([]extension.MonitoringConfiguration, error) {
+	return nil, nil
+}
+
+func (DummyClient) UpsertMonitoringConfiguration(_ context.Context, _, objectID, _ string, _ []byte) (string, error) {
+	if objectID == "" {
+		return "dummy-monitoring-configuration-id", nil
+	}
+	return objectID, nil
+}
+
+// Deploy activates the extension package of the given config, and creates or updates its monitoring configuration.
+// Monitoring configurations are identified by their description, which is set to the config's name, or by the
+// config's origin object ID. The version of the package is used, unless the rendered config defines a version.
+func Deploy(ctx context.Context, client Client, properties parameter.Properties, renderedConfig string, c *config.Config) (config.ResolvedEntity, error) {
+	t, ok := c.Type.(config.ExtensionType)
+	if !ok {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.ExtensionTypeId, c.Type.ID()))
+	}
+
+	name, err := extract.ConfigName(c, properties)
+	if err != nil {
+		return config.ResolvedEntity{}, err
+	}
+
+	scope, found := properties[config.ScopeParameter]
+	if !found || scope == "" {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("property '%s' not found, this is most likely a bug", config.ScopeParameter))
+	}
+
+	var value map[string]any
+	if err := json.Unmarshal([]byte(renderedConfig), &value); err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, "monitoring configuration is not a JSON object").WithError(err)
+	}
+	value[descriptionProperty] = name
+	if _, found := value[versionProperty]; !found {
+		value[versionProperty] = t.Version
+	}
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, "failed to marshal monitoring configuration").WithError(err)
+	}
+
+	if err := client.Activate(ctx, t.Name, t.Version, t.ArchiveContent); err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, err.Error()).WithError(err)
+	}
+
+	configurations, err := client.ListMonitoringConfigurations(ctx, t.Name)
+	if err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, err.Error()).WithError(err)
+	}
+
+	existing, _ := Find(c, name, configurations)
+	id, err := client.UpsertMonitoringConfiguration(ctx, t.Name, existing.ObjectId, fmt.Sprint(scope), payload)
+	if err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, err.Error()).WithError(err)
+	}
+
+	return entity(c, properties, name, id), nil
+}
+
+// Lookup finds the monitoring configuration the given config was deployed as on the environment, without changing it
+func Lookup(ctx context.Context, client Client, properties parameter.Properties, c *config.Config) (config.ResolvedEntity, error) {
+	t, ok := c.Type.(config.ExtensionType)
+	if !ok {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.ExtensionTypeId, c.Type.ID()))
+	}
+
+	name, err := extract.ConfigName(c, properties)
+	if err != nil {
+		return config.ResolvedEntity{}, err
+	}
+
+	configurations, err := client.ListMonitoringConfigurations(ctx, t.Name)
+	if err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, err.Error()).WithError(err)
+	}
+
+	existing, found := Find(c, name, configurations)
+	if !found {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("no monitoring configuration of extension %q with description %q found", t.Name, name))
+	}
+	return entity(c, properties, name, existing.ObjectId), nil
+}
+
+// Find returns the monitoring configuration with the given name from the given ones. Configurations are identified by
+// their description, and by the config's origin object ID if none has a matching description.
+func Find(c *config.Config, name string, configurations []extension.MonitoringConfiguration) (extension.MonitoringConfiguration, bool) {
+	for _, mc := range configurations {
+		var value struct {
+			Description string `json:"description"`
+		}
+		if err := json.Unmarshal(mc.Value, &value); err == nil && value.Description == name {
+			return mc, true
+		}
+	}
+
+	for _, mc := range configurations {
+		if c.OriginObjectId != "" && mc.ObjectId == c.OriginObjectId {
+			return mc, true
+		}
+	}
+	return extension.MonitoringConfiguration{}, false
+}
+
+func entity(c *config.Config, properties parameter.Properties, name, id string) config.ResolvedEntity {
+	properties[config.IdParameter] = id
+	properties[config.NameParameter] = name
+
+	return config.ResolvedEntity{
+		EntityName: name,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+	}
+}
@@ -0,0 +1,139 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/extension"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+var extensionType = config.ExtensionType{
+	Name:           "com.example.extension",
+	Version:        "1.0.0",
+	Archive:        "project/com.example.extension/extension.zip",
+	ArchiveContent: []byte("archive"),
+}
+
+func newConfig() *config.Config {
+	return &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "com.example.extension", ConfigId: "config-id"},
+		Type:       extensionType,
+	}
+}
+
+func TestDeploy(t *testing.T) {
+	t.Run("creates monitoring configuration", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().Activate(gomock.Any(), "com.example.extension", "1.0.0", []byte("archive")).Return(nil)
+		client.EXPECT().ListMonitoringConfigurations(gomock.Any(), "com.example.extension").Return([]extension.MonitoringConfiguration{
+			{ObjectId: "other-id", Value: json.RawMessage(`{"description": "other"}`)},
+		}, nil)
+		client.EXPECT().UpsertMonitoringConfiguration(gomock.Any(), "com.example.extension", "", "HOST-1", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, _, _ string, value []byte) (string, error) {
+				assert.JSONEq(t, `{"enabled": true, "description": "my config", "version": "1.0.0"}`, string(value))
+				return "new-id", nil
+			})
+
+		properties := parameter.Properties{config.NameParameter: "my config", config.ScopeParameter: "HOST-1"}
+		entity, err := Deploy(context.TODO(), client, properties, `{"enabled": true}`, newConfig())
+		assert.NoError(t, err)
+		assert.Equal(t, "my config", entity.EntityName)
+		assert.Equal(t, "new-id", entity.Properties[config.IdParameter])
+	})
+
+	t.Run("updates monitoring configuration with same description", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().Activate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		client.EXPECT().ListMonitoringConfigurations(gomock.Any(), gomock.Any()).Return([]extension.MonitoringConfiguration{
+			{ObjectId: "existing-id", Value: json.RawMessage(`{"description": "my config"}`)},
+		}, nil)
+		client.EXPECT().UpsertMonitoringConfiguration(gomock.Any(), "com.example.extension", "existing-id", "environment", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, _, _ string, value []byte) (string, error) {
+				assert.JSONEq(t, `{"description": "my config", "version": "0.9.0"}`, string(value))
+				return "existing-id", nil
+			})
+
+		properties := parameter.Properties{config.NameParameter: "my config", config.ScopeParameter: "environment"}
+		entity, err := Deploy(context.TODO(), client, properties, `{"description": "something else", "version": "0.9.0"}`, newConfig())
+		assert.NoError(t, err)
+		assert.Equal(t, "existing-id", entity.Properties[config.IdParameter])
+	})
+
+	t.Run("updates monitoring configuration with origin object ID", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().Activate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		client.EXPECT().ListMonitoringConfigurations(gomock.Any(), gomock.Any()).Return([]extension.MonitoringConfiguration{
+			{ObjectId: "origin-id", Value: json.RawMessage(`{"description": "old name"}`)},
+		}, nil)
+		client.EXPECT().UpsertMonitoringConfiguration(gomock.Any(), gomock.Any(), "origin-id", gomock.Any(), gomock.Any()).Return("origin-id", nil)
+
+		c := newConfig()
+		c.OriginObjectId = "origin-id"
+		properties := parameter.Properties{config.NameParameter: "my config", config.ScopeParameter: "environment"}
+		_, err := Deploy(context.TODO(), client, properties, `{}`, c)
+		assert.NoError(t, err)
+	})
+
+	t.Run("fails if extension can't be activated", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+		client.EXPECT().Activate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("upload failed"))
+
+		properties := parameter.Properties{config.NameParameter: "my config", config.ScopeParameter: "environment"}
+		_, err := Deploy(context.TODO(), client, properties, `{}`, newConfig())
+		assert.ErrorContains(t, err, "upload failed")
+	})
+
+	t.Run("fails on invalid payload", func(t *testing.T) {
+		client := NewMockClient(gomock.NewController(t))
+
+		properties := parameter.Properties{config.NameParameter: "my config", config.ScopeParameter: "environment"}
+		_, err := Deploy(context.TODO(), client, properties, `[]`, newConfig())
+		assert.Error(t, err)
+	})
+
+	t.Run("fails on wrong type", func(t *testing.T) {
+		c := newConfig()
+		c.Type = config.ClassicApiType{Api: "dashboard"}
+
+		_, err := Deploy(context.TODO(), &DummyClient{}, parameter.Properties{}, `{}`, c)
+		assert.Error(t, err)
+	})
+}
+
+func TestLookup(t *testing.T) {
+	client := NewMockClient(gomock.NewController(t))
+	client.EXPECT().ListMonitoringConfigurations(gomock.Any(), "com.example.extension").Return([]extension.MonitoringConfiguration{
+		{ObjectId: "existing-id", Value: json.RawMessage(`{"description": "my config"}`)},
+	}, nil).Times(2)
+
+	entity, err := Lookup(context.TODO(), client, parameter.Properties{config.NameParameter: "my config"}, newConfig())
+	assert.NoError(t, err)
+	assert.Equal(t, "existing-id", entity.Properties[config.IdParameter])
+
+	_, err = Lookup(context.TODO(), client, parameter.Properties{config.NameParameter: "unknown"}, newConfig())
+	assert.ErrorContains(t, err, "no monitoring configuration")
+}
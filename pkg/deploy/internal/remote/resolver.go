@@ -0,0 +1,282 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package remote resolves references to configurations which are not deployed in the current run, by looking up the
+// objects they were previously deployed as on the environment.
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/account"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/document"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/entitymap"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extension"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/setting"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"sync"
+)
+
+// Clients are the clients used by a Resolver to look up objects
+type Clients struct {
+	Classic   dtclient.ConfigClient
+	Settings  dtclient.SettingsClient
+	Extension extension.Client
+	Account   account.Client
+}
+
+// Resolver looks up the objects of configurations that are defined in projects which are not deployed. It is bound to
+// the environment its clients target, and caches all objects it found. A Resolver is safe for concurrent use, and looks
+// up each configuration only once, even if it is referenced by configurations deployed concurrently.
+type Resolver struct {
+	clients Clients
+	apis    api.APIs
+	dryRun  bool
+
+	// configs are the configurations which can be resolved, per environment name
+	configs map[string]map[coordinate.Coordinate]*config.Config
+
+	// lock guards resolved and inFlight. It is not held while looking up objects.
+	lock     sync.Mutex
+	resolved map[coordinate.Coordinate]config.ResolvedEntity
+	inFlight map[coordinate.Coordinate]*lookupCall
+}
+
+// lookupCall is the resolution of a configuration in progress. Its entity and err are set once done is closed.
+type lookupCall struct {
+	done   chan struct{}
+	entity config.ResolvedEntity
+	err    error
+}
+
+// New creates a Resolver for the configurations of the given projects. If dryRun is set, objects are not looked up,
+// but IDs are generated from the coordinates of the configurations instead.
+func New(clients Clients, apis api.APIs, projects []project.Project, dryRun bool) *Resolver {
+	configs := make(map[string]map[coordinate.Coordinate]*config.Config)
+	for _, p := range projects {
+		for env, cfgsPerType := range p.Configs {
+			if _, found := configs[env]; !found {
+				configs[env] = make(map[coordinate.Coordinate]*config.Config)
+			}
+			for _, cfgs := range cfgsPerType {
+				for i := range cfgs {
+					configs[env][cfgs[i].Coordinate] = &cfgs[i]
+				}
+			}
+		}
+	}
+
+	return &Resolver{
+		clients:    clients,
+		apis:       apis,
+		dryRun:     dryRun,
+		configs:    configs,
+		resolved: make(map[coordinate.Coordinate]config.ResolvedEntity),
+		inFlight: make(map[coordinate.Coordinate]*lookupCall),
+	}
+}
+
+// ResolveReferences looks up all configurations referenced by c that are neither contained in the given entities, nor
+// deployed in the current run, and adds them to the entities. References which are not known to the Resolver are left
+// for parameter resolution to report. Calling ResolveReferences on a nil Resolver does nothing.
+func (r *Resolver) ResolveReferences(ctx context.Context, c *config.Config, entities *entitymap.EntityMap) error {
+	if r == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, ref := range c.References() {
+		if _, found := entities.GetResolvedEntity(ref); found || ref == c.Coordinate {
+			continue
+		}
+		if _, found := r.configs[c.Environment][ref]; !found {
+			continue
+		}
+
+		if err := r.checkAcyclic(c.Environment, ref, map[coordinate.Coordinate]struct{}{}); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		entity, err := r.resolve(ctx, c.Environment, ref, entities)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		entities.Put(entity)
+	}
+	return errors.Join(errs...)
+}
+
+// checkAcyclic returns an error if the configuration with the given coordinate references itself via other
+// configurations of the Resolver. Resolving such configurations would wait for their own resolution.
+func (r *Resolver) checkAcyclic(environment string, coord coordinate.Coordinate, visiting map[coordinate.Coordinate]struct{}) error {
+	if _, found := visiting[coord]; found {
+		return fmt.Errorf("failed to resolve config %s: cyclic references", coord)
+	}
+	visiting[coord] = struct{}{}
+	defer delete(visiting, coord)
+
+	for _, ref := range r.configs[environment][coord].References() {
+		if _, found := r.configs[environment][ref]; !found || ref == coord {
+			continue
+		}
+		if err := r.checkAcyclic(environment, ref, visiting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve returns the entity of the configuration with the given coordinate. It is taken from the cache, or from the
+// resolution in progress by a concurrent call, or else resolved by this call and cached. The configuration must not
+// be part of a reference cycle.
+func (r *Resolver) resolve(ctx context.Context, environment string, coord coordinate.Coordinate, entities *entitymap.EntityMap) (config.ResolvedEntity, error) {
+	r.lock.Lock()
+	if entity, found := r.resolved[coord]; found {
+		r.lock.Unlock()
+		return entity, nil
+	}
+	if call, found := r.inFlight[coord]; found {
+		r.lock.Unlock()
+		<-call.done
+		return call.entity, call.err
+	}
+	call := &lookupCall{done: make(chan struct{})}
+	r.inFlight[coord] = call
+	r.lock.Unlock()
+
+	call.entity, call.err = r.resolveUncached(ctx, environment, coord, entities)
+
+	r.lock.Lock()
+	if call.err == nil {
+		r.resolved[coord] = call.entity
+	}
+	delete(r.inFlight, coord)
+	r.lock.Unlock()
+	close(call.done)
+
+	return call.entity, call.err
+}
+
+// resolveUncached looks up the configuration with the given coordinate, after resolving the configurations it
+// references itself
+func (r *Resolver) resolveUncached(ctx context.Context, environment string, coord coordinate.Coordinate, entities *entitymap.EntityMap) (config.ResolvedEntity, error) {
+	c := r.configs[environment][coord]
+	if c.Skip {
+		return config.ResolvedEntity{}, fmt.Errorf("failed to resolve config %s: config is skipped", coord)
+	}
+
+	dependencies := entitymap.New()
+	for _, ref := range c.References() {
+		if ref == coord {
+			continue
+		}
+
+		if _, found := r.configs[environment][ref]; found {
+			entity, err := r.resolve(ctx, environment, ref, entities)
+			if err != nil {
+				return config.ResolvedEntity{}, fmt.Errorf("failed to resolve config %s: %w", coord, err)
+			}
+			dependencies.Put(entity)
+		} else if entity, found := entities.GetResolvedEntity(ref); found {
+			dependencies.Put(entity)
+		}
+	}
+
+	properties, errs := c.ResolveParameterValues(dependencies)
+	if len(errs) > 0 {
+		return config.ResolvedEntity{}, fmt.Errorf("failed to resolve parameter properties of config %s: %w", coord, errors.Join(errs...))
+	}
+
+	entity, err := r.lookup(ctx, c, properties)
+	if err != nil {
+		return config.ResolvedEntity{}, fmt.Errorf("failed to look up config %s: %w", coord, err)
+	}
+
+	log.WithCtxFields(ctx).Debug("Resolved config %s, which is not deployed, as %q", coord, entity.Properties[config.IdParameter])
+	return entity, nil
+}
+
+func (r *Resolver) lookup(ctx context.Context, c *config.Config, properties parameter.Properties) (config.ResolvedEntity, error) {
+	switch c.Type.(type) {
+	case config.SettingsType:
+		if r.dryRun {
+			return dryRunEntity(c, properties), nil
+		}
+		return setting.Lookup(ctx, r.clients.Settings, properties, c)
+
+	case config.ClassicApiType:
+		if r.dryRun {
+			return dryRunEntity(c, properties), nil
+		}
+		return classic.Lookup(ctx, r.clients.Classic, r.apis, properties, c)
+
+	case config.AutomationType:
+		return automation.Lookup(properties, c)
+
+	case config.BucketType:
+		return bucket.Lookup(properties, c)
+
+	case config.DocumentType:
+		return document.Lookup(properties, c)
+
+	case config.ExtensionType:
+		if r.dryRun {
+			return dryRunEntity(c, properties), nil
+		}
+		return extension.Lookup(ctx, r.clients.Extension, properties, c)
+
+	case config.AccountType:
+		if r.dryRun {
+			return dryRunEntity(c, properties), nil
+		}
+		return account.Lookup(ctx, r.clients.Account, properties, c)
+
+	default:
+		return config.ResolvedEntity{}, fmt.Errorf("unknown config-type (ID: %q)", c.Type.ID())
+	}
+}
+
+// dryRunEntity returns an entity with an ID generated from the coordinate of the config, as objects are not looked up
+// during dry-runs.
+func dryRunEntity(c *config.Config, properties parameter.Properties) config.ResolvedEntity {
+	name, err := extract.ConfigName(c, properties)
+	if err != nil {
+		name = c.Coordinate.ConfigId
+	}
+
+	properties[config.IdParameter] = idutils.GenerateUUIDFromCoordinate(c.Coordinate)
+	properties[config.NameParameter] = name
+
+	return config.ResolvedEntity{
+		EntityName: name,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+	}
+}
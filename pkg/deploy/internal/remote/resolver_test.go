@@ -0,0 +1,233 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/entitymap"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"sync"
+	"testing"
+	"time"
+)
+
+const env = "env"
+
+var (
+	profile = config.Config{
+		Type:        config.ClassicApiType{Api: "alerting-profile"},
+		Coordinate:  coordinate.Coordinate{Project: "shared", Type: "alerting-profile", ConfigId: "profile"},
+		Environment: env,
+		Parameters: config.Parameters{
+			config.NameParameter: &value.ValueParameter{Value: "Shared profile"},
+		},
+	}
+	zone = config.Config{
+		Type:        config.SettingsType{SchemaId: "builtin:tags.auto-tagging"},
+		Coordinate:  coordinate.Coordinate{Project: "shared", Type: "builtin:tags.auto-tagging", ConfigId: "zone"},
+		Environment: env,
+		Parameters: config.Parameters{
+			config.NameParameter:  &value.ValueParameter{Value: "Shared tag"},
+			config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+			"profile":             reference.NewWithCoordinate(profile.Coordinate, config.IdParameter),
+		},
+	}
+	workflow = config.Config{
+		Type:        config.AutomationType{Resource: config.Workflow},
+		Coordinate:  coordinate.Coordinate{Project: "shared", Type: "workflow", ConfigId: "workflow"},
+		Environment: env,
+		Parameters:  config.Parameters{},
+	}
+)
+
+func sharedProject() []project.Project {
+	return []project.Project{{
+		Id: "shared",
+		Configs: project.ConfigsPerTypePerEnvironments{
+			env: {
+				"alerting-profile":          {profile},
+				"builtin:tags.auto-tagging": {zone},
+				"workflow":                  {workflow},
+			},
+		},
+	}}
+}
+
+func referencing(refs ...coordinate.Coordinate) *config.Config {
+	params := config.Parameters{}
+	for _, r := range refs {
+		params[r.ConfigId] = reference.NewWithCoordinate(r, config.IdParameter)
+	}
+	return &config.Config{
+		Type:        config.ClassicApiType{Api: "dashboard"},
+		Coordinate:  coordinate.Coordinate{Project: "team", Type: "dashboard", ConfigId: "dashboard"},
+		Environment: env,
+		Parameters:  params,
+	}
+}
+
+func TestResolveReferences_ClassicConfigsAreFoundByName(t *testing.T) {
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListConfigs(gomock.Any(), gomock.Any()).Return([]dtclient.Value{{Id: "other-id", Name: "Other"}, {Id: "profile-id", Name: "Shared profile"}}, nil).Times(1)
+
+	r := New(Clients{Classic: c, Settings: c}, api.NewAPIs(), sharedProject(), false)
+
+	entities := entitymap.New()
+	require.NoError(t, r.ResolveReferences(context.TODO(), referencing(profile.Coordinate), entities))
+
+	id, found := entities.GetResolvedProperty(profile.Coordinate, config.IdParameter)
+	assert.True(t, found)
+	assert.Equal(t, "profile-id", id)
+
+	// found entities are cached
+	require.NoError(t, r.ResolveReferences(context.TODO(), referencing(profile.Coordinate), entitymap.New()))
+}
+
+func TestResolveReferences_SettingsAreFoundByExternalIDAfterResolvingTheirReferences(t *testing.T) {
+	externalID, err := idutils.GenerateExternalID(zone.Coordinate)
+	require.NoError(t, err)
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListConfigs(gomock.Any(), gomock.Any()).Return([]dtclient.Value{{Id: "profile-id", Name: "Shared profile"}}, nil)
+	c.EXPECT().ListSettings(gomock.Any(), "builtin:tags.auto-tagging", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+		var result []dtclient.DownloadSettingsObject
+		for _, o := range []dtclient.DownloadSettingsObject{{ExternalId: "other", ObjectId: "other-id"}, {ExternalId: externalID, ObjectId: "zone-id"}} {
+			if opts.Filter(o) {
+				result = append(result, o)
+			}
+		}
+		return result, nil
+	})
+
+	r := New(Clients{Classic: c, Settings: c}, api.NewAPIs(), sharedProject(), false)
+
+	entities := entitymap.New()
+	require.NoError(t, r.ResolveReferences(context.TODO(), referencing(zone.Coordinate), entities))
+
+	entity, found := entities.GetResolvedEntity(zone.Coordinate)
+	require.True(t, found)
+	assert.Equal(t, parameter.Properties{
+		config.IdParameter:    "zone-id",
+		config.NameParameter:  "Shared tag",
+		config.ScopeParameter: "environment",
+		"profile":             "profile-id",
+	}, entity.Properties)
+}
+
+func TestResolveReferences_AutomationsUseGeneratedIDs(t *testing.T) {
+	r := New(Clients{}, api.NewAPIs(), sharedProject(), false)
+
+	entities := entitymap.New()
+	require.NoError(t, r.ResolveReferences(context.TODO(), referencing(workflow.Coordinate), entities))
+
+	id, _ := entities.GetResolvedProperty(workflow.Coordinate, config.IdParameter)
+	assert.Equal(t, idutils.GenerateUUIDFromCoordinate(workflow.Coordinate), id)
+}
+
+func TestResolveReferences_DryRunDoesNotQueryTheEnvironment(t *testing.T) {
+	r := New(Clients{}, api.NewAPIs(), sharedProject(), true)
+
+	entities := entitymap.New()
+	require.NoError(t, r.ResolveReferences(context.TODO(), referencing(profile.Coordinate, zone.Coordinate), entities))
+
+	id, _ := entities.GetResolvedProperty(zone.Coordinate, config.IdParameter)
+	assert.Equal(t, idutils.GenerateUUIDFromCoordinate(zone.Coordinate), id)
+}
+
+func TestResolveReferences_ReturnsErrorIfObjectIsNotFound(t *testing.T) {
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListConfigs(gomock.Any(), gomock.Any()).Return([]dtclient.Value{{Id: "other-id", Name: "Other"}}, nil)
+
+	r := New(Clients{Classic: c, Settings: c}, api.NewAPIs(), sharedProject(), false)
+
+	err := r.ResolveReferences(context.TODO(), referencing(profile.Coordinate), entitymap.New())
+	assert.ErrorContains(t, err, `no alerting-profile object named "Shared profile" found`)
+}
+
+func TestResolveReferences_IgnoresKnownAndUnknownReferences(t *testing.T) {
+	r := New(Clients{}, api.NewAPIs(), sharedProject(), false)
+
+	unknown := coordinate.Coordinate{Project: "unknown", Type: "alerting-profile", ConfigId: "profile"}
+	entities := entitymap.New()
+	entities.Put(config.ResolvedEntity{Coordinate: profile.Coordinate, Properties: parameter.Properties{config.IdParameter: "deployed-id"}})
+
+	require.NoError(t, r.ResolveReferences(context.TODO(), referencing(profile.Coordinate, unknown), entities))
+
+	id, _ := entities.GetResolvedProperty(profile.Coordinate, config.IdParameter)
+	assert.Equal(t, "deployed-id", id)
+	_, found := entities.GetResolvedEntity(unknown)
+	assert.False(t, found)
+}
+
+func TestResolveReferences_ConcurrentReferencesAreLookedUpOnce(t *testing.T) {
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListConfigs(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, api.API) ([]dtclient.Value, error) {
+		time.Sleep(10 * time.Millisecond) // keep the lookup in flight while the other calls arrive
+		return []dtclient.Value{{Id: "profile-id", Name: "Shared profile"}}, nil
+	}).Times(1)
+
+	r := New(Clients{Classic: c, Settings: c}, api.NewAPIs(), sharedProject(), false)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entities := entitymap.New()
+			assert.NoError(t, r.ResolveReferences(context.TODO(), referencing(profile.Coordinate), entities))
+
+			id, _ := entities.GetResolvedProperty(profile.Coordinate, config.IdParameter)
+			assert.Equal(t, "profile-id", id)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestResolveReferences_ReturnsErrorForCyclicReferences(t *testing.T) {
+	a := workflow
+	a.Coordinate.ConfigId = "a"
+	b := workflow
+	b.Coordinate.ConfigId = "b"
+	a.Parameters = config.Parameters{"b": reference.NewWithCoordinate(b.Coordinate, config.IdParameter)}
+	b.Parameters = config.Parameters{"a": reference.NewWithCoordinate(a.Coordinate, config.IdParameter)}
+
+	r := New(Clients{}, api.NewAPIs(), []project.Project{{
+		Id:      "shared",
+		Configs: project.ConfigsPerTypePerEnvironments{env: {"workflow": {a, b}}},
+	}}, false)
+
+	err := r.ResolveReferences(context.TODO(), referencing(a.Coordinate), entitymap.New())
+	assert.ErrorContains(t, err, "cyclic references")
+}
+
+func TestResolveReferences_NilResolverDoesNothing(t *testing.T) {
+	var r *Resolver
+	assert.NoError(t, r.ResolveReferences(context.TODO(), referencing(profile.Coordinate), entitymap.New()))
+}
@@ -0,0 +1,132 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package setting
+
+import (
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+)
+
+// Lookup finds the Settings 2.0 object the given config was deployed as on the environment, without changing it.
+// Objects are identified by the externalId generated from the config's coordinate, the legacy externalId without the
+// project, or by the config's origin object ID, in this order.
+func Lookup(ctx context.Context, settingsClient dtclient.SettingsClient, properties parameter.Properties, c *config.Config) (config.ResolvedEntity, error) {
+	t, ok := c.Type.(config.SettingsType)
+	if !ok {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.SettingsTypeId, c.Type.ID()))
+	}
+
+	externalID, legacyExternalID, err := externalIDs(c)
+	if err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, err.Error()).WithError(err)
+	}
+
+	objects, err := settingsClient.ListSettings(ctx, t.SchemaId, dtclient.ListSettingsOptions{
+		DiscardValue: true,
+		Filter: func(o dtclient.DownloadSettingsObject) bool {
+			return o.ExternalId == externalID || o.ExternalId == legacyExternalID || (c.OriginObjectId != "" && o.ObjectId == c.OriginObjectId)
+		},
+	})
+	if err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, err.Error()).WithError(err)
+	}
+
+	object, found := findObject(objects, externalID, legacyExternalID, c.OriginObjectId)
+	if !found {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("no Settings 2.0 object of schema %q with externalId %q found", t.SchemaId, externalID))
+	}
+
+	entity, err := Entity(c, properties, object)
+	if err != nil {
+		return config.ResolvedEntity{}, errors.NewConfigDeployErr(c, err.Error()).WithError(err)
+	}
+	return entity, nil
+}
+
+// Find returns the object the given config was deployed as from the given objects of its schema, identified like
+// objects are by Lookup.
+func Find(c *config.Config, objects []dtclient.DownloadSettingsObject) (dtclient.DownloadSettingsObject, bool, error) {
+	externalID, legacyExternalID, err := externalIDs(c)
+	if err != nil {
+		return dtclient.DownloadSettingsObject{}, false, err
+	}
+
+	object, found := findObject(objects, externalID, legacyExternalID, c.OriginObjectId)
+	return object, found, nil
+}
+
+// Entity returns the entity of the given config, which was deployed as the given object
+func Entity(c *config.Config, properties parameter.Properties, object dtclient.DownloadSettingsObject) (config.ResolvedEntity, error) {
+	name := fmt.Sprintf("[UNKNOWN NAME]%s", object.ObjectId)
+	if configName, err := extract.ConfigName(c, properties); err == nil {
+		name = configName
+	}
+
+	id, err := getEntityID(c, dtclient.DynatraceEntity{Id: object.ObjectId})
+	if err != nil {
+		return config.ResolvedEntity{}, err
+	}
+	properties[config.IdParameter] = id
+	properties[config.NameParameter] = name
+
+	return config.ResolvedEntity{
+		EntityName: name,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+		Skip:       false,
+	}, nil
+}
+
+// externalIDs returns the externalId generated for the given config, and the legacy externalId generated without its project
+func externalIDs(c *config.Config) (string, string, error) {
+	externalID, err := idutils.GenerateExternalID(c.Coordinate)
+	if err != nil {
+		return "", "", err
+	}
+	legacyExternalID, err := idutils.GenerateExternalID(coordinate.Coordinate{Type: c.Coordinate.Type, ConfigId: c.Coordinate.ConfigId})
+	if err != nil {
+		return "", "", err
+	}
+	return externalID, legacyExternalID, nil
+}
+
+// findObject returns the first object matching the given IDs, checking all objects for one ID before moving to the next
+func findObject(objects []dtclient.DownloadSettingsObject, externalID, legacyExternalID, originObjectID string) (dtclient.DownloadSettingsObject, bool) {
+	matchers := []func(dtclient.DownloadSettingsObject) bool{
+		func(o dtclient.DownloadSettingsObject) bool { return o.ExternalId == externalID },
+		func(o dtclient.DownloadSettingsObject) bool { return o.ExternalId == legacyExternalID },
+		func(o dtclient.DownloadSettingsObject) bool {
+			return originObjectID != "" && o.ObjectId == originObjectID
+		},
+	}
+
+	for _, matches := range matchers {
+		for _, o := range objects {
+			if matches(o) {
+				return o, true
+			}
+		}
+	}
+	return dtclient.DownloadSettingsObject{}, false
+}
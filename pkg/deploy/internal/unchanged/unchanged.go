@@ -0,0 +1,328 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package unchanged detects configurations which do not need to be deployed, as neither they nor the objects they were
+// deployed as changed since their last deployment.
+package unchanged
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/extension"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/setting"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	"golang.org/x/exp/slices"
+	"reflect"
+	"sync"
+)
+
+// Detector detects unchanged configurations. Configurations recorded in the state are unchanged if the hash of their
+// rendered payload and resolved parameters equals the recorded one, and the recorded object still exists. All other
+// Settings 2.0 and classic configurations are unchanged if their rendered payload is contained in the object they were
+// deployed as. Settings 2.0 objects are listed once per schema including their values. Classic objects are listed once
+// per API to find their IDs, but their payloads are read one by one, as lists only hold their names.
+//
+// Only Settings 2.0 objects, classic objects and monitoring configurations of extensions are checked for existence.
+// Recorded configurations of other types are always reported as changed.
+//
+// A Detector is bound to the environment its clients target, and is safe for concurrent use.
+type Detector struct {
+	clients remote.Clients
+	apis    api.APIs
+	state   *state.State
+
+	lock       sync.Mutex
+	settings   map[string][]dtclient.DownloadSettingsObject
+	classic    map[string][]dtclient.Value
+	extensions map[string][]extension.MonitoringConfiguration
+}
+
+// New creates a Detector comparing configurations against the given state, or against the objects fetched using the
+// given clients if they are not recorded in the state.
+func New(clients remote.Clients, apis api.APIs, st *state.State) *Detector {
+	return &Detector{
+		clients:  clients,
+		apis:     apis,
+		state:    st,
+		settings:   make(map[string][]dtclient.DownloadSettingsObject),
+		classic:    make(map[string][]dtclient.Value),
+		extensions: make(map[string][]extension.MonitoringConfiguration),
+	}
+}
+
+// Unchanged returns the entity of the given config, and whether the config is unchanged and needs not to be deployed.
+// The hash is the one returned by state.Hash for the rendered config and properties. Configurations which are unchanged
+// compared to the objects on the environment are recorded in the state. Calling Unchanged on a nil Detector always
+// reports configurations as changed.
+func (d *Detector) Unchanged(ctx context.Context, c *config.Config, properties parameter.Properties, renderedConfig string, hash string) (config.ResolvedEntity, bool) {
+	if d == nil || hash == "" {
+		return config.ResolvedEntity{}, false
+	}
+
+	if e, found := d.state.Get(c.Environment, c.Coordinate); found {
+		if e.Hash != hash {
+			return config.ResolvedEntity{}, false
+		}
+
+		exists, err := d.exists(ctx, c, e.ID)
+		if err != nil {
+			log.WithCtxFields(ctx).WithFields(field.Error(err)).Debug("Failed to check whether the recorded object %q exists: %s", e.ID, err)
+			return config.ResolvedEntity{}, false
+		}
+		if !exists {
+			log.WithCtxFields(ctx).Debug("Recorded object %q does not exist anymore", e.ID)
+			return config.ResolvedEntity{}, false
+		}
+
+		properties[config.IdParameter] = e.ID
+		properties[config.NameParameter] = e.Name
+		return config.ResolvedEntity{
+			EntityName: e.Name,
+			Coordinate: c.Coordinate,
+			Properties: properties,
+		}, true
+	}
+
+	entity, unchanged, err := d.compare(ctx, c, properties, renderedConfig)
+	if err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err)).Debug("Failed to compare config to the object on the environment: %s", err)
+		return config.ResolvedEntity{}, false
+	}
+	if unchanged {
+		d.state.Record(c, entity, hash)
+	}
+	return entity, unchanged
+}
+
+// exists returns whether the object with the given ID, which the config was recorded as, exists on the environment.
+// Objects of types which are not checked are reported as not existing.
+func (d *Detector) exists(ctx context.Context, c *config.Config, id string) (bool, error) {
+	switch t := c.Type.(type) {
+	case config.SettingsType:
+		objects, err := d.settingsObjects(ctx, t.SchemaId)
+		if err != nil {
+			return false, err
+		}
+		return slices.ContainsFunc(objects, func(o dtclient.DownloadSettingsObject) bool { return o.ObjectId == id }), nil
+
+	case config.ClassicApiType:
+		a, found := d.apis[t.Api]
+		if !found {
+			return false, fmt.Errorf("unknown api `%s`. this is most likely a bug", t.Api)
+		}
+		if a.SingleConfiguration {
+			// single configurations always exist
+			return true, nil
+		}
+
+		values, err := d.classicValues(ctx, a)
+		if err != nil {
+			return false, err
+		}
+		return slices.ContainsFunc(values, func(v dtclient.Value) bool { return v.Id == id }), nil
+
+	case config.ExtensionType:
+		configurations, err := d.monitoringConfigurations(ctx, t.Name)
+		if err != nil {
+			return false, err
+		}
+		return slices.ContainsFunc(configurations, func(m extension.MonitoringConfiguration) bool { return m.ObjectId == id }), nil
+
+	default:
+		return false, nil
+	}
+}
+
+// compare checks whether the rendered config is contained in the object the config was deployed as. Configurations
+// of types which can't be fetched in bulk are always reported as changed.
+func (d *Detector) compare(ctx context.Context, c *config.Config, properties parameter.Properties, renderedConfig string) (config.ResolvedEntity, bool, error) {
+	switch t := c.Type.(type) {
+	case config.SettingsType:
+		objects, err := d.settingsObjects(ctx, t.SchemaId)
+		if err != nil {
+			return config.ResolvedEntity{}, false, err
+		}
+
+		object, found, err := setting.Find(c, objects)
+		if err != nil || !found {
+			return config.ResolvedEntity{}, false, err
+		}
+		if scope, found := properties[config.ScopeParameter]; !found || fmt.Sprint(scope) != object.Scope {
+			return config.ResolvedEntity{}, false, nil
+		}
+		if same, err := contained(renderedConfig, object.Value); err != nil || !same {
+			return config.ResolvedEntity{}, false, err
+		}
+
+		entity, err := setting.Entity(c, properties, object)
+		return entity, err == nil, err
+
+	case config.ClassicApiType:
+		a, found := d.apis[t.Api]
+		if !found {
+			return config.ResolvedEntity{}, false, fmt.Errorf("unknown api `%s`. this is most likely a bug", t.Api)
+		}
+
+		name, err := extract.ConfigName(c, properties)
+		if err != nil {
+			return config.ResolvedEntity{}, false, err
+		}
+
+		id := ""
+		if !a.SingleConfiguration {
+			values, err := d.classicValues(ctx, a)
+			if err != nil {
+				return config.ResolvedEntity{}, false, err
+			}
+			if id, err = classic.FindConfigID(a, c, name, values); err != nil {
+				// the object does not exist yet, or can't be identified without deploying it
+				return config.ResolvedEntity{}, false, nil
+			}
+		}
+
+		payload, err := d.clients.Classic.ReadConfigById(a, id)
+		if err != nil {
+			return config.ResolvedEntity{}, false, err
+		}
+		if same, err := contained(renderedConfig, payload); err != nil || !same {
+			return config.ResolvedEntity{}, false, err
+		}
+
+		properties[config.IdParameter] = id
+		properties[config.NameParameter] = name
+		return config.ResolvedEntity{
+			EntityName: name,
+			Coordinate: c.Coordinate,
+			Properties: properties,
+		}, true, nil
+
+	default:
+		return config.ResolvedEntity{}, false, nil
+	}
+}
+
+// settingsObjects returns all objects of the given schema, which are fetched once
+func (d *Detector) settingsObjects(ctx context.Context, schemaID string) ([]dtclient.DownloadSettingsObject, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if objects, found := d.settings[schemaID]; found {
+		return objects, nil
+	}
+
+	objects, err := d.clients.Settings.ListSettings(ctx, schemaID, dtclient.ListSettingsOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Settings 2.0 objects of schema %q: %w", schemaID, err)
+	}
+	d.settings[schemaID] = objects
+	return objects, nil
+}
+
+// classicValues returns all objects of the given API, which are fetched once
+func (d *Detector) classicValues(ctx context.Context, a api.API) ([]dtclient.Value, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if values, found := d.classic[a.ID]; found {
+		return values, nil
+	}
+
+	values, err := d.clients.Classic.ListConfigs(ctx, a)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s objects: %w", a.ID, err)
+	}
+	d.classic[a.ID] = values
+	return values, nil
+}
+
+// monitoringConfigurations returns all monitoring configurations of the given extension, which are fetched once
+func (d *Detector) monitoringConfigurations(ctx context.Context, name string) ([]extension.MonitoringConfiguration, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if configurations, found := d.extensions[name]; found {
+		return configurations, nil
+	}
+
+	configurations, err := d.clients.Extension.ListMonitoringConfigurations(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monitoring configurations of extension %q: %w", name, err)
+	}
+	d.extensions[name] = configurations
+	return configurations, nil
+}
+
+// contained returns whether the rendered JSON is contained in the remote JSON. Remote objects usually hold additional
+// fields like IDs, metadata, or defaults, so only the fields of the rendered JSON are compared.
+func contained(rendered string, remote []byte) (bool, error) {
+	var expected, actual any
+	if err := json.Unmarshal([]byte(rendered), &expected); err != nil {
+		return false, fmt.Errorf("failed to unmarshal rendered config: %w", err)
+	}
+	if err := json.Unmarshal(remote, &actual); err != nil {
+		return false, fmt.Errorf("failed to unmarshal remote object: %w", err)
+	}
+	return containedIn(expected, actual), nil
+}
+
+func containedIn(expected, actual any) bool {
+	switch e := expected.(type) {
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, v := range e {
+			av, found := a[k]
+			if !found {
+				// null fields are usually omitted by the API
+				if v == nil {
+					continue
+				}
+				return false
+			}
+			if !containedIn(v, av) {
+				return false
+			}
+		}
+		return true
+
+	case []any:
+		a, ok := actual.([]any)
+		if !ok || len(e) != len(a) {
+			return false
+		}
+		for i := range e {
+			if !containedIn(e[i], a[i]) {
+				return false
+			}
+		}
+		return true
+
+	default:
+		return reflect.DeepEqual(expected, actual)
+	}
+}
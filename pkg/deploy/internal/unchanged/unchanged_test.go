@@ -0,0 +1,217 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package unchanged
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+const env = "env"
+
+var (
+	profile = config.Config{
+		Type:        config.ClassicApiType{Api: "alerting-profile"},
+		Coordinate:  coordinate.Coordinate{Project: "project", Type: "alerting-profile", ConfigId: "profile"},
+		Environment: env,
+		Parameters: config.Parameters{
+			config.NameParameter: &value.ValueParameter{Value: "Profile"},
+		},
+	}
+	tag = config.Config{
+		Type:        config.SettingsType{SchemaId: "builtin:tags.auto-tagging"},
+		Coordinate:  coordinate.Coordinate{Project: "project", Type: "builtin:tags.auto-tagging", ConfigId: "tag"},
+		Environment: env,
+		Parameters: config.Parameters{
+			config.NameParameter:  &value.ValueParameter{Value: "Tag"},
+			config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+		},
+	}
+)
+
+func TestUnchanged_NilDetectorReportsChanged(t *testing.T) {
+	var d *Detector
+	_, unchanged := d.Unchanged(context.TODO(), &profile, parameter.Properties{}, "{}", "hash")
+	assert.False(t, unchanged)
+}
+
+func TestUnchanged_ComparesHashOfRecordedConfigs(t *testing.T) {
+	st := state.New()
+	st.Put(env, profile.Coordinate, state.Entry{ID: "profile-id", Name: "Profile", Hash: "hash"})
+
+	// recorded configs are not compared to the objects on the environment, but the objects must still exist
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListConfigs(gomock.Any(), gomock.Any()).Return([]dtclient.Value{{Id: "profile-id", Name: "Profile"}}, nil).Times(1)
+	d := New(remote.Clients{Classic: c, Settings: c}, api.NewAPIs(), st)
+
+	entity, unchanged := d.Unchanged(context.TODO(), &profile, parameter.Properties{config.NameParameter: "Profile"}, "{}", "hash")
+	assert.True(t, unchanged)
+	assert.Equal(t, config.ResolvedEntity{
+		EntityName: "Profile",
+		Coordinate: profile.Coordinate,
+		Properties: parameter.Properties{config.IdParameter: "profile-id", config.NameParameter: "Profile"},
+	}, entity)
+
+	_, unchanged = d.Unchanged(context.TODO(), &profile, parameter.Properties{config.NameParameter: "Profile"}, "{}", "other-hash")
+	assert.False(t, unchanged)
+}
+
+func TestUnchanged_RecordedConfigsWithoutObjectAreChanged(t *testing.T) {
+	st := state.New()
+	st.Put(env, profile.Coordinate, state.Entry{ID: "profile-id", Name: "Profile", Hash: "hash"})
+	st.Put(env, tag.Coordinate, state.Entry{ID: "tag-id", Name: "Tag", Hash: "hash"})
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListConfigs(gomock.Any(), gomock.Any()).Return([]dtclient.Value{{Id: "other-id", Name: "Profile"}}, nil)
+	c.EXPECT().ListSettings(gomock.Any(), "builtin:tags.auto-tagging", gomock.Any()).Return([]dtclient.DownloadSettingsObject{{ObjectId: "other-id"}}, nil)
+
+	d := New(remote.Clients{Classic: c, Settings: c}, api.NewAPIs(), st)
+
+	_, unchanged := d.Unchanged(context.TODO(), &profile, parameter.Properties{config.NameParameter: "Profile"}, "{}", "hash")
+	assert.False(t, unchanged, "expected classic config whose object was deleted to be changed")
+
+	_, unchanged = d.Unchanged(context.TODO(), &tag, parameter.Properties{config.NameParameter: "Tag"}, "{}", "hash")
+	assert.False(t, unchanged, "expected setting whose object was deleted to be changed")
+}
+
+func TestUnchanged_ComparesClassicConfigsToObjects(t *testing.T) {
+	tests := []struct {
+		name      string
+		rendered  string
+		unchanged bool
+	}{
+		{"equal payload", `{"name": "Profile", "rules": [{"severity": "ERROR"}]}`, true},
+		{"changed field", `{"name": "Profile", "rules": [{"severity": "INFO"}]}`, false},
+		{"added field", `{"name": "Profile", "rules": [{"severity": "ERROR"}], "added": true}`, false},
+		{"removed list item", `{"name": "Profile", "rules": []}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dtclient.NewMockClient(gomock.NewController(t))
+			c.EXPECT().ListConfigs(gomock.Any(), gomock.Any()).Return([]dtclient.Value{{Id: "other-id", Name: "Other"}, {Id: "profile-id", Name: "Profile"}}, nil).Times(1)
+			c.EXPECT().ReadConfigById(gomock.Any(), "profile-id").Return([]byte(`{"id": "profile-id", "metadata": {}, "name": "Profile", "rules": [{"severity": "ERROR", "tagFilter": null}]}`), nil)
+
+			st := state.New()
+			d := New(remote.Clients{Classic: c, Settings: c}, api.NewAPIs(), st)
+
+			entity, unchanged := d.Unchanged(context.TODO(), &profile, parameter.Properties{config.NameParameter: "Profile"}, tt.rendered, "hash")
+			assert.Equal(t, tt.unchanged, unchanged)
+
+			e, recorded := st.Get(env, profile.Coordinate)
+			assert.Equal(t, tt.unchanged, recorded, "expected unchanged configs to be recorded")
+			if tt.unchanged {
+				assert.Equal(t, "profile-id", entity.Properties[config.IdParameter])
+				assert.Equal(t, "hash", e.Hash)
+			}
+		})
+	}
+}
+
+func TestUnchanged_ClassicConfigsWithoutObjectAreChanged(t *testing.T) {
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListConfigs(gomock.Any(), gomock.Any()).Return([]dtclient.Value{{Id: "other-id", Name: "Other"}}, nil)
+
+	d := New(remote.Clients{Classic: c, Settings: c}, api.NewAPIs(), nil)
+
+	_, unchanged := d.Unchanged(context.TODO(), &profile, parameter.Properties{config.NameParameter: "Profile"}, `{"name": "Profile"}`, "hash")
+	assert.False(t, unchanged)
+}
+
+func TestUnchanged_SettingsAreFetchedOncePerSchema(t *testing.T) {
+	externalID, err := idutils.GenerateExternalID(tag.Coordinate)
+	require.NoError(t, err)
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListSettings(gomock.Any(), "builtin:tags.auto-tagging", gomock.Any()).Return([]dtclient.DownloadSettingsObject{
+		{ExternalId: "other", ObjectId: "other-id", Scope: "environment", Value: []byte(`{"name": "Other"}`)},
+		{ExternalId: externalID, ObjectId: "tag-id", Scope: "environment", Value: []byte(`{"name": "Tag", "rules": []}`)},
+	}, nil).Times(1)
+
+	d := New(remote.Clients{Classic: c, Settings: c}, api.NewAPIs(), nil)
+
+	entity, unchanged := d.Unchanged(context.TODO(), &tag, parameter.Properties{config.NameParameter: "Tag", config.ScopeParameter: "environment"}, `{"name": "Tag", "rules": []}`, "hash")
+	assert.True(t, unchanged)
+	assert.Equal(t, "tag-id", entity.Properties[config.IdParameter])
+
+	_, unchanged = d.Unchanged(context.TODO(), &tag, parameter.Properties{config.NameParameter: "Tag", config.ScopeParameter: "environment"}, `{"name": "Tag", "rules": [{}]}`, "hash")
+	assert.False(t, unchanged)
+
+	_, unchanged = d.Unchanged(context.TODO(), &tag, parameter.Properties{config.NameParameter: "Tag", config.ScopeParameter: "HOST-1"}, `{"name": "Tag", "rules": []}`, "hash")
+	assert.False(t, unchanged, "expected config with changed scope to be changed")
+}
+
+func TestUnchanged_OtherTypesAreChanged(t *testing.T) {
+	workflow := config.Config{
+		Type:        config.AutomationType{Resource: config.Workflow},
+		Coordinate:  coordinate.Coordinate{Project: "project", Type: "workflow", ConfigId: "workflow"},
+		Environment: env,
+	}
+
+	d := New(remote.Clients{}, api.NewAPIs(), nil)
+
+	_, unchanged := d.Unchanged(context.TODO(), &workflow, parameter.Properties{}, `{}`, "hash")
+	assert.False(t, unchanged)
+
+	// whether the recorded object still exists can't be checked
+	st := state.New()
+	st.Put(env, workflow.Coordinate, state.Entry{ID: "workflow-id", Name: "workflow", Hash: "hash"})
+	d = New(remote.Clients{}, api.NewAPIs(), st)
+
+	_, unchanged = d.Unchanged(context.TODO(), &workflow, parameter.Properties{}, `{}`, "hash")
+	assert.False(t, unchanged)
+}
+
+func TestContained(t *testing.T) {
+	tests := []struct {
+		name     string
+		rendered string
+		remote   string
+		want     bool
+	}{
+		{"equal", `{"a": 1}`, `{"a": 1}`, true},
+		{"additional remote fields", `{"a": 1}`, `{"a": 1, "b": "x"}`, true},
+		{"missing remote field", `{"a": 1, "b": "x"}`, `{"a": 1}`, false},
+		{"omitted null field", `{"a": 1, "b": null}`, `{"a": 1}`, true},
+		{"different number", `{"a": 1}`, `{"a": 1.5}`, false},
+		{"nested objects", `{"a": {"b": [1, {"c": true}]}}`, `{"a": {"b": [1, {"c": true, "d": 2}]}}`, true},
+		{"different list order", `{"a": [1, 2]}`, `{"a": [2, 1]}`, false},
+		{"different types", `{"a": "1"}`, `{"a": 1}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := contained(tt.rendered, []byte(tt.remote))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
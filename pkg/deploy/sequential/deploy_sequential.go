@@ -21,18 +21,24 @@ import (
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/tracing"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
 	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/account"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/automation"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/bucket"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/document"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/entitymap"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extension"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/setting"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 	clientErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/net/context"
 )
 
@@ -71,23 +77,30 @@ func (r *entityMapWithNames) contains(entityType string, entityName string) bool
 	return found
 }
 
-// DeployConfigs sequentially deploys the given configs with the given apis to a single environment via the given client
+// DeployConfigs sequentially deploys the given configs with the given apis to a single environment via the given client.
+// The deployment of each config is traced as a child span of the given context.
 // NOTE: the given configs need to be sorted, otherwise deployment will probably fail, as references cannot be resolved.
-func DeployConfigs(clientSet deploy.ClientSet, apis api.APIs, sortedConfigs []config.Config, opts deploy.DeployConfigsOptions) []error {
+func DeployConfigs(ctx context.Context, clientSet deploy.ClientSet, apis api.APIs, sortedConfigs []config.Config, opts deploy.DeployConfigsOptions) []error {
 	entityMapWithNames := newEntityMapWithNames()
+	envOpts := deploy.NewEnvironmentOptions(clientSet, apis, opts)
 	var errs []error
 
 	for i := range sortedConfigs {
 		c := &sortedConfigs[i] // avoid implicit memory aliasing (gosec G601)
 
-		ctx := context.WithValue(context.TODO(), log.CtxKeyCoord{}, c.Coordinate)
+		ctx := context.WithValue(ctx, log.CtxKeyCoord{}, c.Coordinate)
 		ctx = context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: c.Environment, Group: c.Group})
 
-		entity, deploymentErrors := deployConfig(ctx, clientSet, apis, entityMapWithNames, c)
+		ctx, span := tracing.Tracer().Start(ctx, "deploy config", trace.WithAttributes(tracing.CoordinateAttributes(c.Coordinate)...))
+		if c.Skip {
+			span.SetAttributes(tracing.Skipped.Bool(true))
+		}
+		entity, deploymentErrors := deployConfigWithOptions(ctx, clientSet, apis, entityMapWithNames, envOpts, c)
+		tracing.EndSpan(span, errors.Join(deploymentErrors...))
 
 		if len(deploymentErrors) > 0 {
 			for _, err := range deploymentErrors {
-				errs = append(errs, fmt.Errorf("failed to deploy config %s: %w", c.Coordinate, err))
+				errs = append(errs, fmt.Errorf("failed to deploy config %s: %w", c.Describe(), err))
 			}
 
 			if !opts.ContinueOnErr && !opts.DryRun {
@@ -101,12 +114,17 @@ func DeployConfigs(clientSet deploy.ClientSet, apis api.APIs, sortedConfigs []co
 	return errs
 }
 
-func deployConfig(ctx context.Context, clientSet deploy.ClientSet, apis api.APIs, em *entityMapWithNames, c *config.Config) (config.ResolvedEntity, []error) {
+func deployConfigWithOptions(ctx context.Context, clientSet deploy.ClientSet, apis api.APIs, em *entityMapWithNames, envOpts deploy.EnvironmentOptions, c *config.Config) (config.ResolvedEntity, []error) {
 	if c.Skip {
 		log.WithCtxFields(ctx).Info("Skipping deployment of config %s", c.Coordinate)
 		return config.ResolvedEntity{EntityName: c.Coordinate.ConfigId, Coordinate: c.Coordinate, Properties: parameter.Properties{}, Skip: true}, nil
 	}
 
+	envOpts.State.ResolveReferences(c, em.entityMap)
+	if err := envOpts.Remote.ResolveReferences(ctx, c, em.entityMap); err != nil {
+		return config.ResolvedEntity{}, []error{err}
+	}
+
 	properties, errs := c.ResolveParameterValues(em.entityMap)
 	if len(errs) > 0 {
 		return config.ResolvedEntity{}, errs
@@ -117,21 +135,37 @@ func deployConfig(ctx context.Context, clientSet deploy.ClientSet, apis api.APIs
 		return config.ResolvedEntity{}, []error{err}
 	}
 
+	hash, err := state.Hash(renderedConfig, properties)
+	if err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err)).Debug("Failed to hash config: %s", err)
+	}
+
+	if entity, unchanged := envOpts.Unchanged.Unchanged(ctx, c, properties, renderedConfig, hash); unchanged {
+		log.WithCtxFields(ctx).Info("Skipping deployment of unchanged config")
+		trace.SpanFromContext(ctx).SetAttributes(tracing.Unchanged.Bool(true))
+		return entity, nil
+	}
+
 	log.WithCtxFields(ctx).Info("Deploying config")
 	var res config.ResolvedEntity
 	var deployErr error
-	switch c.Type.(type) {
+	switch t := c.Type.(type) {
 	case config.SettingsType:
 		res, deployErr = setting.Deploy(ctx, clientSet.Settings, properties, renderedConfig, c)
 
 	case config.ClassicApiType:
+		trace.SpanFromContext(ctx).SetAttributes(tracing.API.String(t.Api))
 		validationErr := validateConfigNameIsUnique(c, apis, properties, em)
 		if validationErr != nil {
 			deployErr = validationErr
 			break
 		}
 
-		res, deployErr = classic.Deploy(ctx, clientSet.Classic, apis, properties, renderedConfig, c)
+		if previous, renamed := classic.Renamed(envOpts.State, c, properties); renamed {
+			res, deployErr = classic.DeployRenamed(ctx, clientSet.Classic, apis, properties, renderedConfig, c, previous.ID)
+		} else {
+			res, deployErr = classic.Deploy(ctx, clientSet.Classic, apis, properties, renderedConfig, c)
+		}
 
 	case config.AutomationType:
 		res, deployErr = automation.Deploy(ctx, clientSet.Automation, properties, renderedConfig, c)
@@ -139,6 +173,15 @@ func deployConfig(ctx context.Context, clientSet deploy.ClientSet, apis api.APIs
 	case config.BucketType:
 		res, deployErr = bucket.Deploy(ctx, clientSet.Bucket, properties, renderedConfig, c)
 
+	case config.ExtensionType:
+		res, deployErr = extension.Deploy(ctx, clientSet.Extension, properties, renderedConfig, c)
+
+	case config.DocumentType:
+		res, deployErr = document.Deploy(ctx, clientSet.Document, properties, renderedConfig, c)
+
+	case config.AccountType:
+		res, deployErr = account.Deploy(ctx, clientSet.Account, properties, renderedConfig, c)
+
 	default:
 		deployErr = fmt.Errorf("unknown config-type (ID: %q)", c.Type.ID())
 	}
@@ -146,12 +189,14 @@ func deployConfig(ctx context.Context, clientSet deploy.ClientSet, apis api.APIs
 	if deployErr != nil {
 		var responseErr clientErrors.RespError
 		if errors.As(deployErr, &responseErr) {
-			log.WithCtxFields(ctx).WithFields(field.Error(responseErr)).Error("Failed to deploy config %s: %s", c.Coordinate, responseErr.Reason)
+			log.WithCtxFields(ctx).WithFields(field.Error(responseErr)).Error("Failed to deploy config %s: %s", c.Describe(), responseErr.Reason)
 		} else {
-			log.WithCtxFields(ctx).WithFields(field.Error(deployErr)).Error("Failed to deploy config %s: %s", c.Coordinate, deployErr.Error())
+			log.WithCtxFields(ctx).WithFields(field.Error(deployErr)).Error("Failed to deploy config %s: %s", c.Describe(), deployErr.Error())
 		}
 		return config.ResolvedEntity{}, []error{deployErr}
 	}
+
+	envOpts.State.Record(c, res, hash)
 	return res, nil
 
 }
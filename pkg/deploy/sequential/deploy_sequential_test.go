@@ -34,6 +34,11 @@ import (
 	"testing"
 )
 
+// deployConfig deploys the given config with the default deploy.EnvironmentOptions
+func deployConfig(ctx context.Context, clientSet deploy.ClientSet, apis api.APIs, em *entityMapWithNames, c *config.Config) (config.ResolvedEntity, []error) {
+	return deployConfigWithOptions(ctx, clientSet, apis, em, deploy.EnvironmentOptions{}, c)
+}
+
 var dashboardApi = api.API{ID: "dashboard", URLPath: "dashboard", DeprecatedBy: "dashboard-v2"}
 var testApiMap = api.APIs{"dashboard": dashboardApi}
 
@@ -370,7 +375,7 @@ func TestDeployConfigsWithNoConfigs(t *testing.T) {
 	var apis api.APIs
 	var sortedConfigs []config.Config
 
-	errors := DeployConfigs(deploy.DummyClientSet, apis, sortedConfigs, deploy.DeployConfigsOptions{})
+	errors := DeployConfigs(context.TODO(), deploy.DummyClientSet, apis, sortedConfigs, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -379,7 +384,7 @@ func TestDeployConfigsWithOneConfigToSkip(t *testing.T) {
 	sortedConfigs := []config.Config{
 		{Skip: true},
 	}
-	errors := DeployConfigs(deploy.DummyClientSet, apis, sortedConfigs, deploy.DeployConfigsOptions{})
+	errors := DeployConfigs(context.TODO(), deploy.DummyClientSet, apis, sortedConfigs, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -407,7 +412,7 @@ func TestDeployConfigsTargetingSettings(t *testing.T) {
 		Id:   "42",
 		Name: "Super Special Settings Object",
 	}, nil)
-	errors := DeployConfigs(deploy.ClientSet{Settings: c}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
+	errors := DeployConfigs(context.TODO(), deploy.ClientSet{Settings: c}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -440,7 +445,7 @@ func TestDeployConfigsTargetingClassicConfigUnique(t *testing.T) {
 		},
 	}
 
-	errors := DeployConfigs(deploy.ClientSet{Classic: client}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
+	errors := DeployConfigs(context.TODO(), deploy.ClientSet{Classic: client}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -473,7 +478,7 @@ func TestDeployConfigsTargetingClassicConfigNonUniqueWithExistingCfgsOfSameName(
 		},
 	}
 
-	errors := DeployConfigs(deploy.ClientSet{Classic: client}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
+	errors := DeployConfigs(context.TODO(), deploy.ClientSet{Classic: client}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -512,12 +517,12 @@ func TestDeployConfigsNoApi(t *testing.T) {
 	}
 
 	t.Run("missing api - continue on error", func(t *testing.T) {
-		errors := DeployConfigs(deploy.ClientSet{Classic: client}, apis, sortedConfigs, deploy.DeployConfigsOptions{ContinueOnErr: true})
+		errors := DeployConfigs(context.TODO(), deploy.ClientSet{Classic: client}, apis, sortedConfigs, deploy.DeployConfigsOptions{ContinueOnErr: true})
 		assert.Equal(t, 2, len(errors), fmt.Sprintf("Expected 2 errors, but just got %d", len(errors)))
 	})
 
 	t.Run("missing api - stop on error", func(t *testing.T) {
-		errors := DeployConfigs(deploy.ClientSet{Classic: client}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
+		errors := DeployConfigs(context.TODO(), deploy.ClientSet{Classic: client}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
 		assert.Equal(t, 1, len(errors), fmt.Sprintf("Expected 1 error, but just got %d", len(errors)))
 	})
 	// test continue on error
@@ -548,12 +553,12 @@ func TestDeployConfigsWithDeploymentErrors(t *testing.T) {
 	}
 
 	t.Run("deployment error - stop on error", func(t *testing.T) {
-		errors := DeployConfigs(deploy.DummyClientSet, apis, sortedConfigs, deploy.DeployConfigsOptions{})
+		errors := DeployConfigs(context.TODO(), deploy.DummyClientSet, apis, sortedConfigs, deploy.DeployConfigsOptions{})
 		assert.Equal(t, 1, len(errors), fmt.Sprintf("Expected 1 error, but just got %d", len(errors)))
 	})
 
 	t.Run("deployment error - stop on error", func(t *testing.T) {
-		errors := DeployConfigs(deploy.DummyClientSet, apis, sortedConfigs, deploy.DeployConfigsOptions{ContinueOnErr: true})
+		errors := DeployConfigs(context.TODO(), deploy.DummyClientSet, apis, sortedConfigs, deploy.DeployConfigsOptions{ContinueOnErr: true})
 		assert.Equal(t, 2, len(errors), fmt.Sprintf("Expected 1 error, but just got %d", len(errors)))
 	})
 
@@ -599,7 +604,7 @@ func TestDeployConfigsWithDuplicateNameCausesError(t *testing.T) {
 		Name: theConfigName,
 	}, nil)
 
-	errors := DeployConfigs(deploy.ClientSet{Classic: c}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
+	errors := DeployConfigs(context.TODO(), deploy.ClientSet{Classic: c}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
 	assert.NotEmpty(t, errors, "two configs using the same name should cause validation errors - but got none")
 }
 
@@ -644,7 +649,7 @@ func TestDeploySkippedConfigsWithDuplicateNameNoError(t *testing.T) {
 		Name: theConfigName,
 	}, nil)
 
-	errors := DeployConfigs(deploy.ClientSet{Classic: c}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
+	errors := DeployConfigs(context.TODO(), deploy.ClientSet{Classic: c}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
 	assert.Empty(t, errors, "skipped and deployed config having the same name should deploy without errors (errors: %v)", errors)
 }
 
@@ -688,6 +693,6 @@ func TestDeployNonUniqueConfigsWithDuplicateNameNoError(t *testing.T) {
 		Name: theConfigName,
 	}, nil).Times(2)
 
-	errors := DeployConfigs(deploy.ClientSet{Classic: c}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
+	errors := DeployConfigs(context.TODO(), deploy.ClientSet{Classic: c}, apis, sortedConfigs, deploy.DeployConfigsOptions{})
 	assert.Empty(t, errors, "two non-unique-name configs with the same name should deploy without errors (errors: %v)", errors)
 }
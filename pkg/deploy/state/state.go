@@ -0,0 +1,213 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package state records the objects configurations were deployed as. It allows deployments to resolve references to
+// configurations which are not deployed in the current run, to detect renamed configurations, and to know which
+// payload was deployed last.
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"sync"
+	"time"
+)
+
+// Entry is the recorded state of a single deployed configuration
+type Entry struct {
+	// ID is the ID of the object the configuration was deployed as
+	ID string
+	// Name is the name of the object
+	Name string
+	// Hash is the hash of the rendered payload and resolved parameters of the last deployment, as returned by Hash
+	Hash string
+	// DeployedAt is the time of the last deployment
+	DeployedAt time.Time
+}
+
+// State holds the Entry of each deployed configuration per environment. A State is safe for concurrent use, and all of
+// its methods may be called on a nil State, which behaves like an empty State which does not record anything.
+type State struct {
+	lock         sync.RWMutex
+	environments map[string]map[coordinate.Coordinate]Entry
+}
+
+// New creates a new, empty State
+func New() *State {
+	return &State{environments: make(map[string]map[coordinate.Coordinate]Entry)}
+}
+
+// Get returns the entry of the configuration with the given coordinate in the given environment
+func (s *State) Get(environment string, c coordinate.Coordinate) (Entry, bool) {
+	if s == nil {
+		return Entry{}, false
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	e, found := s.environments[environment][c]
+	return e, found
+}
+
+// Put records the entry of the configuration with the given coordinate in the given environment
+func (s *State) Put(environment string, c coordinate.Coordinate, e Entry) {
+	if s == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, found := s.environments[environment]; !found {
+		s.environments[environment] = make(map[coordinate.Coordinate]Entry)
+	}
+	s.environments[environment][c] = e
+}
+
+// Environments returns the names of all environments the State holds entries for
+func (s *State) Environments() []string {
+	if s == nil {
+		return nil
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	names := maps.Keys(s.environments)
+	slices.Sort(names)
+	return names
+}
+
+// EntityStore holds the entities of deployed configurations
+type EntityStore interface {
+	GetResolvedEntity(coordinate.Coordinate) (config.ResolvedEntity, bool)
+	Put(config.ResolvedEntity)
+}
+
+// ResolveReferences adds the recorded entities of all configurations referenced by c, which are not contained in the
+// given entities yet, to them. The entities only contain the recorded ID and name of the configurations.
+func (s *State) ResolveReferences(c *config.Config, entities EntityStore) {
+	for _, ref := range c.References() {
+		if _, found := entities.GetResolvedEntity(ref); found || ref == c.Coordinate {
+			continue
+		}
+
+		if e, found := s.Get(c.Environment, ref); found {
+			entities.Put(config.ResolvedEntity{
+				EntityName: e.Name,
+				Coordinate: ref,
+				Properties: parameter.Properties{
+					config.IdParameter:   e.ID,
+					config.NameParameter: e.Name,
+				},
+			})
+		}
+	}
+}
+
+// Renamed returns the entry of the given configuration, if it was last deployed with a name other than the given one
+func (s *State) Renamed(c *config.Config, name string) (Entry, bool) {
+	e, found := s.Get(c.Environment, c.Coordinate)
+	if !found || e.ID == "" || e.Name == name {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// Record records the given entity the configuration was deployed as, together with the hash of its payload
+func (s *State) Record(c *config.Config, entity config.ResolvedEntity, hash string) {
+	s.Put(c.Environment, c.Coordinate, Entry{
+		ID:         fmt.Sprint(entity.Properties[config.IdParameter]),
+		Name:       entity.EntityName,
+		Hash:       hash,
+		DeployedAt: time.Now().UTC(),
+	})
+}
+
+// Hash returns the hash of the given rendered payload and resolved parameters of a configuration
+func Hash(renderedConfig string, properties parameter.Properties) (string, error) {
+	// maps are marshalled with sorted keys, so equal properties always result in the same hash
+	p, err := json.Marshal(properties)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal properties: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(renderedConfig))
+	h.Write([]byte{0})
+	h.Write(p)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// fileEntry is the persisted form of an Entry, including the coordinate of its configuration
+type fileEntry struct {
+	Project    string    `json:"project"`
+	Type       string    `json:"type"`
+	ConfigID   string    `json:"configId"`
+	ID         string    `json:"id"`
+	Name       string    `json:"name,omitempty"`
+	Hash       string    `json:"hash,omitempty"`
+	DeployedAt time.Time `json:"deployedAt"`
+}
+
+// entries returns the entries of the given environment, sorted by coordinate so that persisted states are stable
+func (s *State) entries(environment string) []fileEntry {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	result := make([]fileEntry, 0, len(s.environments[environment]))
+	for c, e := range s.environments[environment] {
+		result = append(result, fileEntry{
+			Project:    c.Project,
+			Type:       c.Type,
+			ConfigID:   c.ConfigId,
+			ID:         e.ID,
+			Name:       e.Name,
+			Hash:       e.Hash,
+			DeployedAt: e.DeployedAt,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b fileEntry) bool {
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.ConfigID < b.ConfigID
+	})
+	return result
+}
+
+func (s *State) putEntries(environment string, entries []fileEntry) {
+	for _, e := range entries {
+		s.Put(environment, coordinate.Coordinate{Project: e.Project, Type: e.Type, ConfigId: e.ConfigID}, Entry{
+			ID:         e.ID,
+			Name:       e.Name,
+			Hash:       e.Hash,
+			DeployedAt: e.DeployedAt,
+		})
+	}
+}
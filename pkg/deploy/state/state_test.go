@@ -0,0 +1,173 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/entitymap"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"single file", "state/deployments.json"},
+		{"directory", "state"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			deployedAt := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+
+			st := state.New()
+			st.Put("dev", coordinate.Coordinate{Project: "p", Type: "alerting-profile", ConfigId: "a"}, state.Entry{ID: "id-a", Name: "A", Hash: "hash-a", DeployedAt: deployedAt})
+			st.Put("prod", coordinate.Coordinate{Project: "p", Type: "builtin:tags.auto-tagging", ConfigId: "b"}, state.Entry{ID: "id-b", Name: "B", DeployedAt: deployedAt})
+
+			require.NoError(t, state.NewStore(fs, tt.path).Save(st))
+
+			loaded, err := state.NewStore(fs, tt.path).Load()
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{"dev", "prod"}, loaded.Environments())
+			e, found := loaded.Get("dev", coordinate.Coordinate{Project: "p", Type: "alerting-profile", ConfigId: "a"})
+			assert.True(t, found)
+			assert.Equal(t, state.Entry{ID: "id-a", Name: "A", Hash: "hash-a", DeployedAt: deployedAt}, e)
+			e, found = loaded.Get("prod", coordinate.Coordinate{Project: "p", Type: "builtin:tags.auto-tagging", ConfigId: "b"})
+			assert.True(t, found)
+			assert.Equal(t, state.Entry{ID: "id-b", Name: "B", DeployedAt: deployedAt}, e)
+		})
+	}
+}
+
+func TestStore_DirectoryContainsFilePerEnvironment(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	st := state.New()
+	st.Put("dev", coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}, state.Entry{ID: "id-a"})
+	st.Put("prod", coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}, state.Entry{ID: "id-b"})
+	require.NoError(t, state.NewStore(fs, "state").Save(st))
+
+	files, err := afero.Glob(fs, filepath.Join("state", "*.json"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{filepath.Join("state", "dev.json"), filepath.Join("state", "prod.json")}, files)
+}
+
+func TestStore_LoadMissingStateReturnsEmptyState(t *testing.T) {
+	for _, path := range []string{"missing.json", "missing"} {
+		t.Run(path, func(t *testing.T) {
+			st, err := state.NewStore(afero.NewMemMapFs(), path).Load()
+			require.NoError(t, err)
+			assert.Empty(t, st.Environments())
+		})
+	}
+}
+
+func TestStore_LoadInvalidFileReturnsError(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "state.json", []byte("{not json"), 0644))
+
+	_, err := state.NewStore(fs, "state.json").Load()
+	assert.Error(t, err)
+}
+
+func TestHash(t *testing.T) {
+	h1, err := state.Hash(`{"name": "a"}`, parameter.Properties{"name": "a", "id": "1"})
+	require.NoError(t, err)
+	h2, err := state.Hash(`{"name": "a"}`, parameter.Properties{"id": "1", "name": "a"})
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2, "hash must not depend on the order of properties")
+
+	h3, err := state.Hash(`{"name": "b"}`, parameter.Properties{"name": "a", "id": "1"})
+	require.NoError(t, err)
+	assert.NotEqual(t, h1, h3)
+
+	h4, err := state.Hash(`{"name": "a"}`, parameter.Properties{"name": "b", "id": "1"})
+	require.NoError(t, err)
+	assert.NotEqual(t, h1, h4)
+}
+
+func TestState_ResolveReferences(t *testing.T) {
+	deployed := coordinate.Coordinate{Project: "p", Type: "management-zone", ConfigId: "deployed"}
+	recorded := coordinate.Coordinate{Project: "p", Type: "management-zone", ConfigId: "recorded"}
+	unknown := coordinate.Coordinate{Project: "p", Type: "management-zone", ConfigId: "unknown"}
+
+	st := state.New()
+	st.Put("dev", deployed, state.Entry{ID: "recorded-id-of-deployed", Name: "Deployed"})
+	st.Put("dev", recorded, state.Entry{ID: "id-recorded", Name: "Recorded"})
+
+	entities := entitymap.New()
+	entities.Put(config.ResolvedEntity{Coordinate: deployed, EntityName: "Deployed", Properties: parameter.Properties{config.IdParameter: "id-deployed"}})
+
+	c := config.Config{
+		Coordinate:  coordinate.Coordinate{Project: "p", Type: "alerting-profile", ConfigId: "c"},
+		Environment: "dev",
+		Parameters: config.Parameters{
+			"a": refParam.New("p", "management-zone", "deployed", "id"),
+			"b": refParam.New("p", "management-zone", "recorded", "id"),
+			"c": refParam.New("p", "management-zone", "unknown", "id"),
+		},
+	}
+
+	st.ResolveReferences(&c, entities)
+
+	e, found := entities.GetResolvedEntity(deployed)
+	assert.True(t, found)
+	assert.Equal(t, "id-deployed", e.Properties[config.IdParameter], "entities deployed in this run must not be overwritten")
+
+	e, found = entities.GetResolvedEntity(recorded)
+	assert.True(t, found)
+	assert.Equal(t, "id-recorded", e.Properties[config.IdParameter])
+	assert.Equal(t, "Recorded", e.Properties[config.NameParameter])
+
+	_, found = entities.GetResolvedEntity(unknown)
+	assert.False(t, found)
+}
+
+func TestState_Renamed(t *testing.T) {
+	c := config.Config{
+		Coordinate:  coordinate.Coordinate{Project: "p", Type: "alerting-profile", ConfigId: "c"},
+		Environment: "dev",
+	}
+
+	st := state.New()
+	st.Record(&c, config.ResolvedEntity{EntityName: "Old", Properties: parameter.Properties{config.IdParameter: "id"}}, "")
+
+	e, renamed := st.Renamed(&c, "New")
+	assert.True(t, renamed)
+	assert.Equal(t, "id", e.ID)
+
+	_, renamed = st.Renamed(&c, "Old")
+	assert.False(t, renamed)
+
+	var nilState *state.State
+	_, renamed = nilState.Renamed(&c, "New")
+	assert.False(t, renamed)
+}
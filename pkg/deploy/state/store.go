@@ -0,0 +1,160 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/spf13/afero"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+const fileExtension = ".json"
+
+// Store loads and saves a State
+type Store interface {
+	// Load returns the stored State, or an empty State if nothing was stored yet
+	Load() (*State, error)
+	// Save stores the given State
+	Save(*State) error
+}
+
+// NewStore returns the Store persisting a State at the given path. Paths with a '.json' extension are stored as a single
+// file containing all environments. All other paths are treated as directory, containing one '<environment>.json' file
+// per environment, which keeps the changes of each environment separate when the state is checked into a repository.
+func NewStore(fs afero.Fs, path string) Store {
+	if strings.EqualFold(filepath.Ext(path), fileExtension) {
+		return &fileStore{fs: fs, path: path}
+	}
+	return &directoryStore{fs: fs, path: path}
+}
+
+type stateFile struct {
+	Environments map[string][]fileEntry `json:"environments"`
+}
+
+type environmentFile struct {
+	Entries []fileEntry `json:"entries"`
+}
+
+// fileStore stores the State of all environments in a single file
+type fileStore struct {
+	fs   afero.Fs
+	path string
+}
+
+func (s *fileStore) Load() (*State, error) {
+	var f stateFile
+	if found, err := readJSON(s.fs, s.path, &f); err != nil || !found {
+		return New(), err
+	}
+
+	st := New()
+	for env, entries := range f.Environments {
+		st.putEntries(env, entries)
+	}
+	return st, nil
+}
+
+func (s *fileStore) Save(st *State) error {
+	f := stateFile{Environments: make(map[string][]fileEntry)}
+	for _, env := range st.Environments() {
+		f.Environments[env] = st.entries(env)
+	}
+
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := s.fs.MkdirAll(dir, 0777); err != nil {
+			return fmt.Errorf("failed to create directory for state file %q: %w", s.path, err)
+		}
+	}
+	return writeJSON(s.fs, s.path, f)
+}
+
+// directoryStore stores the State of each environment in a separate file within a directory
+type directoryStore struct {
+	fs   afero.Fs
+	path string
+}
+
+func (s *directoryStore) Load() (*State, error) {
+	st := New()
+
+	files, err := afero.ReadDir(s.fs, s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state directory %q: %w", s.path, err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != fileExtension {
+			continue
+		}
+
+		var f environmentFile
+		if _, err := readJSON(s.fs, filepath.Join(s.path, file.Name()), &f); err != nil {
+			return nil, err
+		}
+		st.putEntries(strings.TrimSuffix(file.Name(), fileExtension), f.Entries)
+	}
+	return st, nil
+}
+
+func (s *directoryStore) Save(st *State) error {
+	if err := s.fs.MkdirAll(s.path, 0777); err != nil {
+		return fmt.Errorf("failed to create state directory %q: %w", s.path, err)
+	}
+
+	for _, env := range st.Environments() {
+		if err := writeJSON(s.fs, filepath.Join(s.path, env+fileExtension), environmentFile{Entries: st.entries(env)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readJSON unmarshalls the given file into v. It returns false if the file does not exist.
+func readJSON(fs afero.Fs, path string, v any) (bool, error) {
+	data, err := afero.ReadFile(fs, path)
+	if errors.Is(err, afero.ErrFileNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read state file %q: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("failed to parse state file %q: %w", path, err)
+	}
+	return true, nil
+}
+
+func writeJSON(fs afero.Fs, path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	if err := afero.WriteFile(fs, path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write state file %q: %w", path, err)
+	}
+	return nil
+}
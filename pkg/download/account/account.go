@@ -0,0 +1,229 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/account"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	v2 "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"sort"
+)
+
+// Client is the client used to download account resources
+type Client interface {
+	ListGroups(ctx context.Context) ([]account.Group, error)
+	ListPolicies(ctx context.Context) ([]account.Policy, error)
+	GetPolicy(ctx context.Context, uuid string) (account.Policy, error)
+	ListEnvironmentBindings(ctx context.Context) ([]account.PolicyBinding, error)
+	GetGroupPermissions(ctx context.Context, groupUUID string) ([]account.Permission, error)
+}
+
+// Downloader can be used to download the user groups, policies, policy bindings and group permissions of an account
+type Downloader struct {
+	client Client
+}
+
+// NewDownloader creates a new [Downloader] for account resources
+func NewDownloader(client Client) *Downloader {
+	return &Downloader{
+		client: client,
+	}
+}
+
+// Download downloads the account resources of the given types. If no types are given, all known resources are
+// downloaded. Policy bindings are downloaded for the environment of the client. Groups and policies are referenced by
+// their UUID, which are replaced by references during dependency resolution.
+func (d *Downloader) Download(ctx context.Context, projectName string, accountTypes ...config.AccountType) (v2.ConfigsPerType, error) {
+	resources := config.KnownAccountResources
+	if len(accountTypes) > 0 {
+		resources = nil
+		for _, t := range accountTypes {
+			resources = append(resources, t.Resource)
+		}
+	}
+
+	groups, err := d.client.ListGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user groups: %w", err)
+	}
+
+	result := v2.ConfigsPerType{}
+	for _, r := range resources {
+		var cfgs []config.Config
+		var err error
+		switch r {
+		case config.UserGroup:
+			cfgs = d.groups(projectName, groups)
+		case config.Policy:
+			cfgs, err = d.policies(ctx, projectName)
+		case config.PolicyBinding:
+			cfgs, err = d.bindings(ctx, projectName)
+		case config.GroupPermission:
+			cfgs = d.permissions(ctx, projectName, groups)
+		}
+		if err != nil {
+			log.WithFields(field.Type(string(r)), field.Error(err)).Error("Failed to download %s configs: %v", r, err)
+			continue
+		}
+
+		log.WithFields(field.Type(string(r)), field.F("configsDownloaded", len(cfgs))).Info("Downloaded %d %s configs", len(cfgs), r)
+		if len(cfgs) > 0 {
+			result[string(r)] = cfgs
+		}
+	}
+	return result, nil
+}
+
+func (d *Downloader) groups(projectName string, groups []account.Group) []config.Config {
+	var configs []config.Config
+	for _, g := range groups {
+		content := struct {
+			Description string `json:"description,omitempty"`
+		}{g.Description}
+		configs = append(configs, toConfig(projectName, config.UserGroup, g.UUID, g.Name, content))
+	}
+	return configs
+}
+
+func (d *Downloader) policies(ctx context.Context, projectName string) ([]config.Config, error) {
+	policies, err := d.client.ListPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []config.Config
+	for _, p := range policies {
+		// listed policies do not contain their statements
+		policy, err := d.client.GetPolicy(ctx, p.UUID)
+		if err != nil {
+			log.WithFields(field.Type(string(config.Policy)), field.Error(err)).Warn("Failed to download policy %q (%s): %v", p.Name, p.UUID, err)
+			continue
+		}
+
+		content := struct {
+			Description    string   `json:"description,omitempty"`
+			Tags           []string `json:"tags,omitempty"`
+			StatementQuery string   `json:"statementQuery"`
+		}{policy.Description, policy.Tags, policy.StatementQuery}
+		configs = append(configs, toConfig(projectName, config.Policy, policy.UUID, policy.Name, content))
+	}
+	return configs, nil
+}
+
+func (d *Downloader) bindings(ctx context.Context, projectName string) ([]config.Config, error) {
+	bindings, err := d.client.ListEnvironmentBindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the API returns the groups per policy, but bindings are deployed per group
+	policiesPerGroup := make(map[string][]string)
+	for _, b := range bindings {
+		for _, g := range b.Groups {
+			policiesPerGroup[g] = append(policiesPerGroup[g], b.PolicyUUID)
+		}
+	}
+
+	groups := make([]string, 0, len(policiesPerGroup))
+	for g := range policiesPerGroup {
+		groups = append(groups, g)
+	}
+	sort.Strings(groups)
+
+	var configs []config.Config
+	for _, g := range groups {
+		policies := policiesPerGroup[g]
+		sort.Strings(policies)
+		content := struct {
+			Group    string   `json:"group"`
+			Policies []string `json:"policies"`
+		}{g, policies}
+		configs = append(configs, toConfig(projectName, config.PolicyBinding, g+"-"+string(config.PolicyBinding), "", content))
+	}
+	return configs, nil
+}
+
+func (d *Downloader) permissions(ctx context.Context, projectName string, groups []account.Group) []config.Config {
+	var configs []config.Config
+	for _, g := range groups {
+		permissions, err := d.client.GetGroupPermissions(ctx, g.UUID)
+		if err != nil {
+			log.WithFields(field.Type(string(config.GroupPermission)), field.Error(err)).Warn("Failed to download permissions of user group %q (%s): %v", g.Name, g.UUID, err)
+			continue
+		}
+		if len(permissions) == 0 {
+			continue
+		}
+
+		content := struct {
+			Group       string               `json:"group"`
+			Permissions []account.Permission `json:"permissions"`
+		}{g.UUID, permissions}
+		configs = append(configs, toConfig(projectName, config.GroupPermission, g.UUID+"-"+string(config.GroupPermission), "", content))
+	}
+	return configs
+}
+
+// toConfig converts the given account resource to a config. User groups and policies keep their UUID as origin object
+// ID, so that deploying the config to the same account updates the downloaded object. Policy bindings and group
+// permissions have no name, as they are identified by their group.
+func toConfig(projectName string, resource config.AccountResource, id, name string, content any) config.Config {
+	data, err := json.Marshal(content)
+	if err != nil {
+		// marshalling plain structs can't fail
+		panic(err)
+	}
+
+	templateName := id
+	if name != "" {
+		templateName = name
+	}
+
+	c := config.Config{
+		Template: template.NewDownloadTemplate(id, templateName, string(jsonutils.MarshalIndent(data))),
+		Coordinate: coordinate.Coordinate{
+			Project:  projectName,
+			Type:     string(resource),
+			ConfigId: id,
+		},
+		Type:       config.AccountType{Resource: resource},
+		Parameters: map[string]parameter.Parameter{},
+	}
+	if name != "" {
+		c.Parameters[config.NameParameter] = &value.ValueParameter{Value: name}
+		c.OriginObjectId = id
+	}
+	return c
+}
+
+// NoopAccountDownloader is used if no account resources can be downloaded, e.g. if the environment does not belong to
+// an account
+type NoopAccountDownloader struct{}
+
+func (NoopAccountDownloader) Download(context.Context, string, ...config.AccountType) (v2.ConfigsPerType, error) {
+	return nil, nil
+}
@@ -0,0 +1,114 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"context"
+	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/account"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type fakeClient struct {
+	groups      []account.Group
+	policies    map[string]account.Policy
+	bindings    []account.PolicyBinding
+	permissions map[string][]account.Permission
+}
+
+func (c fakeClient) ListGroups(context.Context) ([]account.Group, error) {
+	return c.groups, nil
+}
+
+func (c fakeClient) ListPolicies(context.Context) ([]account.Policy, error) {
+	var result []account.Policy
+	for _, p := range c.policies {
+		result = append(result, account.Policy{UUID: p.UUID, Name: p.Name})
+	}
+	return result, nil
+}
+
+func (c fakeClient) GetPolicy(_ context.Context, uuid string) (account.Policy, error) {
+	p, found := c.policies[uuid]
+	if !found {
+		return account.Policy{}, errors.New("not found")
+	}
+	return p, nil
+}
+
+func (c fakeClient) ListEnvironmentBindings(context.Context) ([]account.PolicyBinding, error) {
+	return c.bindings, nil
+}
+
+func (c fakeClient) GetGroupPermissions(_ context.Context, groupUUID string) ([]account.Permission, error) {
+	return c.permissions[groupUUID], nil
+}
+
+func TestDownload(t *testing.T) {
+	client := fakeClient{
+		groups: []account.Group{{UUID: "g-1", Name: "devs", Description: "developers"}, {UUID: "g-2", Name: "ops"}},
+		policies: map[string]account.Policy{
+			"p-1": {UUID: "p-1", Name: "read", StatementQuery: "ALLOW settings:objects:read;"},
+		},
+		bindings: []account.PolicyBinding{
+			{PolicyUUID: "p-1", Groups: []string{"g-1", "g-2"}},
+			{PolicyUUID: "global-policy", Groups: []string{"g-1"}},
+		},
+		permissions: map[string][]account.Permission{
+			"g-1": {{PermissionName: "tenant-viewer", Scope: "abc12345", ScopeType: "tenant"}},
+		},
+	}
+
+	t.Run("downloads all resources", func(t *testing.T) {
+		got, err := NewDownloader(client).Download(context.TODO(), "project")
+		require.NoError(t, err)
+
+		require.Len(t, got["user-group"], 2)
+		g := got["user-group"][0]
+		assert.Equal(t, coordinate.Coordinate{Project: "project", Type: "user-group", ConfigId: "g-1"}, g.Coordinate)
+		assert.Equal(t, config.AccountType{Resource: config.UserGroup}, g.Type)
+		assert.Equal(t, config.Parameters{config.NameParameter: &value.ValueParameter{Value: "devs"}}, g.Parameters)
+		assert.Equal(t, "g-1", g.OriginObjectId)
+		assert.JSONEq(t, `{"description": "developers"}`, g.Template.Content())
+
+		require.Len(t, got["policy"], 1)
+		assert.JSONEq(t, `{"statementQuery": "ALLOW settings:objects:read;"}`, got["policy"][0].Template.Content())
+
+		require.Len(t, got["policy-binding"], 2)
+		b := got["policy-binding"][0]
+		assert.Equal(t, "g-1-policy-binding", b.Coordinate.ConfigId)
+		assert.Empty(t, b.OriginObjectId)
+		assert.JSONEq(t, `{"group": "g-1", "policies": ["global-policy", "p-1"]}`, b.Template.Content())
+
+		require.Len(t, got["group-permission"], 1)
+		assert.JSONEq(t, `{"group": "g-1", "permissions": [{"permissionName": "tenant-viewer", "scope": "abc12345", "scopeType": "tenant"}]}`, got["group-permission"][0].Template.Content())
+	})
+
+	t.Run("downloads only given resources", func(t *testing.T) {
+		got, err := NewDownloader(client).Download(context.TODO(), "project", config.AccountType{Resource: config.Policy})
+		require.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Len(t, got["policy"], 1)
+	})
+}
@@ -17,6 +17,7 @@
 package automation
 
 import (
+	"context"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/automation"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
@@ -48,7 +49,7 @@ func TestDownloader_Download(t *testing.T) {
 		defer server.Close()
 		httpClient := automation.NewClient(server.URL, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy()))
 		downloader := NewDownloader(httpClient)
-		result, err := downloader.Download("projectName")
+		result, err := downloader.Download(context.TODO(), "projectName")
 		assert.Len(t, result, 3)
 		assert.Len(t, result[string(config.Workflow)], 3)
 		assert.Len(t, result[string(config.SchedulingRule)], 6)
@@ -75,7 +76,7 @@ func TestDownloader_Download(t *testing.T) {
 		defer server.Close()
 		httpClient := automation.NewClient(server.URL, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy()))
 		downloader := NewDownloader(httpClient)
-		result, err := downloader.Download("projectName",
+		result, err := downloader.Download(context.TODO(), "projectName",
 			config.AutomationType{Resource: config.Workflow}, config.AutomationType{Resource: config.BusinessCalendar})
 		assert.Len(t, result, 2)
 		assert.Len(t, result[string(config.Workflow)], 3)
@@ -99,7 +100,7 @@ func TestDownloader_Download(t *testing.T) {
 		httpClient := automation.NewClient(server.URL, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy()))
 
 		downloader := NewDownloader(httpClient)
-		result, err := downloader.Download("projectName", config.AutomationType{Resource: config.Workflow})
+		result, err := downloader.Download(context.TODO(), "projectName", config.AutomationType{Resource: config.Workflow})
 
 		assert.Len(t, result, 1)
 		assert.Len(t, result[string(config.Workflow)], 1)
@@ -129,7 +130,7 @@ func TestDownloader_Download_FailsToDownloadSpecificResource(t *testing.T) {
 	defer server.Close()
 	httpClient := automation.NewClient(server.URL, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy()))
 	downloader := NewDownloader(httpClient)
-	result, err := downloader.Download("projectName")
+	result, err := downloader.Download(context.TODO(), "projectName")
 	assert.Len(t, result, 2)
 	assert.Len(t, result[string(config.Workflow)], 3)
 	assert.Len(t, result[string(config.SchedulingRule)], 6)
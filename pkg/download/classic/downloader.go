@@ -88,14 +88,14 @@ func WithFiltering(b bool) Option {
 	}
 }
 
-func (d *Downloader) Download(projectName string, _ ...config.ClassicApiType) (project.ConfigsPerType, error) {
+func (d *Downloader) Download(ctx context.Context, projectName string, _ ...config.ClassicApiType) (project.ConfigsPerType, error) {
 	log.Info("Downloading configuration APIs from %d endpoints", len(d.apisToDownload))
-	configs := d.downloadAPIs(d.apisToDownload, projectName)
+	configs := d.downloadAPIs(ctx, d.apisToDownload, projectName)
 	log.Info("downloaded %d configurations from classic Config API endpoints", len(configs))
 	return configs, nil
 }
 
-func (d *Downloader) downloadAPIs(apisToDownload api.APIs, projectName string) project.ConfigsPerType {
+func (d *Downloader) downloadAPIs(ctx context.Context, apisToDownload api.APIs, projectName string) project.ConfigsPerType {
 	log.Debug("APIs to download: \n - %v", strings.Join(maps.Keys(apisToDownload), "\n - "))
 	results := make(project.ConfigsPerType, len(apisToDownload))
 	mutex := sync.Mutex{}
@@ -108,7 +108,7 @@ func (d *Downloader) downloadAPIs(apisToDownload api.APIs, projectName string) p
 		currentApi := currentApi // prevent data race
 		go func() {
 			defer wg.Done()
-			configsToDownload, err := d.findConfigsToDownload(currentApi)
+			configsToDownload, err := d.findConfigsToDownload(ctx, currentApi)
 			if err != nil {
 				log.WithFields(field.Type(currentApi.ID), field.Error(err)).Error("\tFailed to fetch configs of type '%v', skipping download of this type. Reason: %v", currentApi.ID, err)
 				return
@@ -229,7 +229,7 @@ func (d *Downloader) createTemplate(mappedJson map[string]interface{}, value dtc
 	return templ, nil
 }
 
-func (d *Downloader) findConfigsToDownload(currentApi api.API) ([]dtclient.Value, error) {
+func (d *Downloader) findConfigsToDownload(ctx context.Context, currentApi api.API) ([]dtclient.Value, error) {
 	if currentApi.SingleConfiguration {
 		log.WithFields(field.Type(currentApi.ID)).Debug("\tFetching singleton-configuration '%v'", currentApi.ID)
 
@@ -238,7 +238,7 @@ func (d *Downloader) findConfigsToDownload(currentApi api.API) ([]dtclient.Value
 		return []dtclient.Value{singletonConfigToDownload}, nil
 	}
 	log.WithFields(field.Type(currentApi.ID)).Debug("\tFetching all '%v' configs", currentApi.ID)
-	return d.client.ListConfigs(context.TODO(), currentApi)
+	return d.client.ListConfigs(ctx, currentApi)
 }
 
 func (d *Downloader) shouldPersist(a api.API, json map[string]interface{}) bool {
@@ -36,7 +36,7 @@ func TestDownloadConfigs_FailedToFindConfigsToDownload(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 0)
 }
@@ -51,7 +51,7 @@ func TestDownload_NoConfigsToDownloadFound(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 0)
 }
@@ -76,7 +76,7 @@ func TestDownload_ConfigsDownloaded(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 2)
 }
@@ -90,7 +90,7 @@ func TestDownload_SingleConfigurationAPI(t *testing.T) {
 
 	downloader := classic.NewDownloader(client, classic.WithAPIs(apiMap))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 1)
 }
@@ -118,7 +118,7 @@ func TestDownload_ErrorFetchingConfig(t *testing.T) {
 	apiMap := api.APIs{"API_ID_1": testAPI1, "API_ID_2": testAPI2}
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap))
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 1)
 }
@@ -143,7 +143,7 @@ func TestDownload_ConfigsDownloaded_WithEmptyFilter(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap), classic.WithAPIContentFilters(map[string]classic.ContentFilter{}))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 2)
 }
@@ -173,7 +173,7 @@ func TestDownload_SkipConfigThatShouldNotBePersisted(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap), classic.WithAPIContentFilters(apiFilters))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 1)
 }
@@ -229,7 +229,7 @@ func TestDownload_SkipConfigBeforeDownload(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap), classic.WithAPIContentFilters(apiFilters), classic.WithFiltering(tt.withFiltering))
-			configurations, err := downloader.Download("project")
+			configurations, err := downloader.Download(context.TODO(), "project")
 			assert.NoError(t, err)
 			assert.Len(t, configurations, tt.wantDownloadedConfigs)
 		})
@@ -261,7 +261,7 @@ func TestDownload_FilteringCanBeTurnedOffViaFeatureFlags(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap), classic.WithAPIContentFilters(apiFilters))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 1)
 }
@@ -285,7 +285,7 @@ func TestDownload_APIWithoutAnyConfigAvailableAreNotDownloaded(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 1)
 }
@@ -309,7 +309,7 @@ func TestDownload_MalformedResponseFromAnAPI(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 1)
 }
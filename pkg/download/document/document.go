@@ -0,0 +1,125 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"context"
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/document"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	v2 "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+// Client is the client used to download documents
+type Client interface {
+	List(ctx context.Context, documentType string) ([]document.Metadata, error)
+	Get(ctx context.Context, id string) (document.Document, error)
+}
+
+// Downloader can be used to download documents, like dashboards and notebooks
+type Downloader struct {
+	client Client
+}
+
+// NewDownloader creates a new [Downloader] for documents
+func NewDownloader(client Client) *Downloader {
+	return &Downloader{
+		client: client,
+	}
+}
+
+// Download downloads all documents of the given kinds. If no kinds are given, documents of all known kinds are
+// downloaded. All documents are stored as configs of type 'document'.
+func (d *Downloader) Download(ctx context.Context, projectName string, documentTypes ...config.DocumentType) (v2.ConfigsPerType, error) {
+	kinds := config.KnownDocumentKinds
+	if len(documentTypes) > 0 {
+		kinds = nil
+		for _, t := range documentTypes {
+			kinds = append(kinds, t.Kind)
+		}
+	}
+
+	var configs []config.Config
+	for _, kind := range kinds {
+		cfgs, err := d.download(ctx, projectName, kind)
+		if err != nil {
+			log.WithFields(field.Type(string(kind)), field.Error(err)).Error("Failed to download documents of type %q: %v", kind, err)
+			continue
+		}
+
+		log.WithFields(field.Type(string(kind)), field.F("configsDownloaded", len(cfgs))).Info("Downloaded %d documents of type %q", len(cfgs), kind)
+		configs = append(configs, cfgs...)
+	}
+
+	if len(configs) == 0 {
+		return v2.ConfigsPerType{}, nil
+	}
+	return v2.ConfigsPerType{string(config.DocumentTypeId): configs}, nil
+}
+
+func (d *Downloader) download(ctx context.Context, projectName string, kind config.DocumentKind) ([]config.Config, error) {
+	documents, err := d.client.List(ctx, string(kind))
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []config.Config
+	for _, m := range documents {
+		doc, err := d.client.Get(ctx, m.ID)
+		if err != nil {
+			log.WithFields(field.Type(string(kind)), field.Error(err)).Warn("Failed to download content of document %q (%s): %v", m.Name, m.ID, err)
+			continue
+		}
+		configs = append(configs, toConfig(projectName, kind, doc))
+	}
+	return configs, nil
+}
+
+// toConfig converts the given document to a config. The ID of the document is kept as origin object ID, so that
+// deploying the config to the same environment updates the downloaded document.
+func toConfig(projectName string, kind config.DocumentKind, doc document.Document) config.Config {
+	return config.Config{
+		Template: template.NewDownloadTemplate(doc.ID, doc.Name, string(jsonutils.MarshalIndent(doc.Content))),
+		Coordinate: coordinate.Coordinate{
+			Project:  projectName,
+			Type:     string(config.DocumentTypeId),
+			ConfigId: doc.ID,
+		},
+		Type: config.DocumentType{
+			Kind:    kind,
+			Private: doc.IsPrivate,
+		},
+		Parameters: map[string]parameter.Parameter{
+			config.NameParameter: &value.ValueParameter{Value: doc.Name},
+		},
+		OriginObjectId: doc.ID,
+	}
+}
+
+// NoopDocumentDownloader is used if no documents can be downloaded, e.g. if the environment is not a platform
+// environment
+type NoopDocumentDownloader struct{}
+
+func (NoopDocumentDownloader) Download(context.Context, string, ...config.DocumentType) (v2.ConfigsPerType, error) {
+	return nil, nil
+}
@@ -0,0 +1,87 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"context"
+	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/document"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type fakeClient struct {
+	documents map[string]document.Document
+}
+
+func (c fakeClient) List(_ context.Context, documentType string) ([]document.Metadata, error) {
+	var result []document.Metadata
+	for _, d := range c.documents {
+		if d.Type == documentType {
+			result = append(result, d.Metadata)
+		}
+	}
+	return result, nil
+}
+
+func (c fakeClient) Get(_ context.Context, id string) (document.Document, error) {
+	d, found := c.documents[id]
+	if !found || d.Content == nil {
+		return document.Document{}, errors.New("not found")
+	}
+	return d, nil
+}
+
+func TestDownload(t *testing.T) {
+	client := fakeClient{
+		documents: map[string]document.Document{
+			"dashboard-id": {Metadata: document.Metadata{ID: "dashboard-id", Name: "My Dashboard", Type: "dashboard", IsPrivate: true}, Content: []byte(`{"tiles":{}}`)},
+			"notebook-id":  {Metadata: document.Metadata{ID: "notebook-id", Name: "My Notebook", Type: "notebook"}, Content: []byte(`{"sections":[]}`)},
+			"broken-id":    {Metadata: document.Metadata{ID: "broken-id", Name: "Broken", Type: "notebook"}},
+			"launchpad-id": {Metadata: document.Metadata{ID: "launchpad-id", Name: "My Launchpad", Type: "launchpad"}, Content: []byte(`{}`)},
+		},
+	}
+
+	t.Run("downloads all known kinds", func(t *testing.T) {
+		got, err := NewDownloader(client).Download(context.TODO(), "project")
+		assert.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Len(t, got["document"], 2)
+
+		c := got["document"][0]
+		assert.Equal(t, coordinate.Coordinate{Project: "project", Type: "document", ConfigId: "dashboard-id"}, c.Coordinate)
+		assert.Equal(t, config.DocumentType{Kind: config.DashboardKind, Private: true}, c.Type)
+		assert.Equal(t, config.Parameters{config.NameParameter: &value.ValueParameter{Value: "My Dashboard"}}, c.Parameters)
+		assert.Equal(t, "dashboard-id", c.OriginObjectId)
+		assert.JSONEq(t, `{"tiles": {}}`, c.Template.Content())
+
+		assert.Equal(t, config.DocumentType{Kind: config.NotebookKind}, got["document"][1].Type)
+	})
+
+	t.Run("downloads only given kinds", func(t *testing.T) {
+		got, err := NewDownloader(client).Download(context.TODO(), "project", config.DocumentType{Kind: config.NotebookKind})
+		assert.NoError(t, err)
+		require.Len(t, got["document"], 1)
+		assert.Equal(t, "notebook-id", got["document"][0].Coordinate.ConfigId)
+	})
+}
@@ -31,11 +31,13 @@ import (
 )
 
 type WriterContext struct {
-	EnvironmentUrl  string
-	ProjectToWrite  project.Project
-	Auth            manifest.Auth
-	OutputFolder    string
-	ForceOverwrite  bool
+	EnvironmentUrl string
+	ProjectToWrite project.Project
+	Auth           manifest.Auth
+	OutputFolder   string
+	ForceOverwrite bool
+	// Account the environment belongs to, if account resources were downloaded
+	Account         *manifest.Account
 	timestampString string
 }
 
@@ -66,8 +68,16 @@ func writeToDisk(fs afero.Fs, writerContext WriterContext) error {
 		},
 	}
 
+	var accounts map[string]manifest.Account
+	var accountName string
+	if writerContext.Account != nil {
+		accountName = writerContext.Account.Name
+		accounts = map[string]manifest.Account{accountName: *writerContext.Account}
+	}
+
 	manifest := manifest.Manifest{
 		Projects: projectDefinition,
+		Accounts: accounts,
 		Environments: map[string]manifest.EnvironmentDefinition{
 			writerContext.ProjectToWrite.Id: {
 				Name: writerContext.ProjectToWrite.Id,
@@ -75,8 +85,9 @@ func writeToDisk(fs afero.Fs, writerContext WriterContext) error {
 					Type:  manifest.ValueURLType,
 					Value: writerContext.EnvironmentUrl,
 				},
-				Group: "default",
-				Auth:  writerContext.Auth,
+				Group:   "default",
+				Auth:    writerContext.Auth,
+				Account: accountName,
 			},
 		},
 	}
@@ -17,6 +17,7 @@
 package download
 
 import (
+	"context"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	projectv2 "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
 )
@@ -27,5 +28,5 @@ type Downloader[T config.Type] interface {
 	// Download downloads configurations from a Dynatrace environment.
 	// If only projectName is given, it will download all configuration.
 	// If additionally specific configuration names/types are given, then it will only download those
-	Download(projectName string, specificConfigs ...T) (projectv2.ConfigsPerType, error)
+	Download(ctx context.Context, projectName string, specificConfigs ...T) (projectv2.ConfigsPerType, error)
 }
@@ -0,0 +1,157 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/extension"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	v2 "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"strings"
+)
+
+// Client is the client used to download extensions and their monitoring configurations
+type Client interface {
+	ActiveVersion(ctx context.Context, name string) (string, bool, error)
+	DownloadPackage(ctx context.Context, name, version string) ([]byte, error)
+	ListMonitoringConfigurations(ctx context.Context, name string) ([]extension.MonitoringConfiguration, error)
+}
+
+// Downloader can be used to download the monitoring configurations of Extensions 2.0 extensions
+type Downloader struct {
+	client Client
+}
+
+// NewDownloader creates a new [Downloader] for monitoring configurations of extensions
+func NewDownloader(client Client) *Downloader {
+	return &Downloader{
+		client: client,
+	}
+}
+
+// Download downloads the monitoring configurations of the given extensions, together with the package of their
+// active environment version. Extensions have to be given explicitly, as their packages are downloaded as well.
+func (d *Downloader) Download(ctx context.Context, projectName string, extensions ...config.ExtensionType) (v2.ConfigsPerType, error) {
+	configsPerType := make(v2.ConfigsPerType)
+	for _, e := range extensions {
+		configs, err := d.download(ctx, projectName, e.Name)
+		if err != nil {
+			log.WithFields(field.Type(e.Name), field.Error(err)).Error("Failed to download monitoring configurations of extension %q: %v", e.Name, err)
+			continue
+		}
+
+		log.WithFields(field.Type(e.Name), field.F("configsDownloaded", len(configs))).Info("Downloaded %d monitoring configurations of extension %q", len(configs), e.Name)
+		if len(configs) > 0 {
+			configsPerType[e.Name] = configs
+		}
+	}
+	return configsPerType, nil
+}
+
+func (d *Downloader) download(ctx context.Context, projectName, name string) ([]config.Config, error) {
+	version, found, err := d.client.ActiveVersion(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		log.WithFields(field.Type(name)).Warn("Extension %q has no active environment version and is not downloaded", name)
+		return nil, nil
+	}
+
+	configurations, err := d.client.ListMonitoringConfigurations(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(configurations) == 0 {
+		return nil, nil
+	}
+
+	archive, err := d.client.DownloadPackage(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	t := config.ExtensionType{
+		Name:           name,
+		Version:        version,
+		Archive:        fmt.Sprintf("%s-%s.zip", strings.ReplaceAll(name, ":", "_"), version),
+		ArchiveContent: archive,
+	}
+
+	var configs []config.Config
+	for _, mc := range configurations {
+		c, err := toConfig(projectName, t, mc)
+		if err != nil {
+			log.WithFields(field.Type(name), field.Error(err)).Warn("Failed to convert monitoring configuration %q of extension %q: %v", mc.ObjectId, name, err)
+			continue
+		}
+		configs = append(configs, c)
+	}
+	return configs, nil
+}
+
+// toConfig converts the given monitoring configuration to a config. Its description is extracted as the config's name,
+// and its version is removed, so that it uses the version of the package.
+func toConfig(projectName string, t config.ExtensionType, mc extension.MonitoringConfiguration) (config.Config, error) {
+	var v map[string]any
+	if err := json.Unmarshal(mc.Value, &v); err != nil {
+		return config.Config{}, err
+	}
+
+	name := mc.ObjectId
+	if description, ok := v["description"].(string); ok && description != "" {
+		name = description
+	}
+	v["description"] = "{{.name}}"
+	delete(v, "version")
+
+	content, err := json.Marshal(v)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	return config.Config{
+		Template: template.NewDownloadTemplate(mc.ObjectId, name, string(jsonutils.MarshalIndent(content))),
+		Coordinate: coordinate.Coordinate{
+			Project:  projectName,
+			Type:     t.Name,
+			ConfigId: mc.ObjectId,
+		},
+		Type: t,
+		Parameters: map[string]parameter.Parameter{
+			config.NameParameter:  &value.ValueParameter{Value: name},
+			config.ScopeParameter: &value.ValueParameter{Value: mc.Scope},
+		},
+		OriginObjectId: mc.ObjectId,
+	}, nil
+}
+
+// NoopExtensionDownloader is used if no extensions are downloaded
+type NoopExtensionDownloader struct{}
+
+func (NoopExtensionDownloader) Download(context.Context, string, ...config.ExtensionType) (v2.ConfigsPerType, error) {
+	return nil, nil
+}
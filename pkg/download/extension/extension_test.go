@@ -0,0 +1,90 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/extension"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type fakeClient struct {
+	versions       map[string]string
+	configurations map[string][]extension.MonitoringConfiguration
+}
+
+func (c fakeClient) ActiveVersion(_ context.Context, name string) (string, bool, error) {
+	v, found := c.versions[name]
+	return v, found, nil
+}
+
+func (c fakeClient) DownloadPackage(_ context.Context, name, version string) ([]byte, error) {
+	if c.versions[name] != version {
+		return nil, errors.New("unknown version")
+	}
+	return []byte(name + "@" + version), nil
+}
+
+func (c fakeClient) ListMonitoringConfigurations(_ context.Context, name string) ([]extension.MonitoringConfiguration, error) {
+	return c.configurations[name], nil
+}
+
+func TestDownload(t *testing.T) {
+	client := fakeClient{
+		versions: map[string]string{
+			"com.example.extension": "1.2.3",
+			"custom:unconfigured":   "1.0.0",
+		},
+		configurations: map[string][]extension.MonitoringConfiguration{
+			"com.example.extension": {
+				{ObjectId: "object-id", Scope: "HOST-1", Value: json.RawMessage(`{"enabled": true, "description": "SQL Server", "version": "1.2.3"}`)},
+			},
+		},
+	}
+
+	got, err := NewDownloader(client).Download(context.TODO(), "project",
+		config.ExtensionType{Name: "com.example.extension"},
+		config.ExtensionType{Name: "custom:unconfigured"},
+		config.ExtensionType{Name: "com.example.inactive"})
+	assert.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Len(t, got["com.example.extension"], 1)
+
+	c := got["com.example.extension"][0]
+	assert.Equal(t, coordinate.Coordinate{Project: "project", Type: "com.example.extension", ConfigId: "object-id"}, c.Coordinate)
+	assert.Equal(t, config.ExtensionType{
+		Name:           "com.example.extension",
+		Version:        "1.2.3",
+		Archive:        "com.example.extension-1.2.3.zip",
+		ArchiveContent: []byte("com.example.extension@1.2.3"),
+	}, c.Type)
+	assert.Equal(t, config.Parameters{
+		config.NameParameter:  &value.ValueParameter{Value: "SQL Server"},
+		config.ScopeParameter: &value.ValueParameter{Value: "HOST-1"},
+	}, c.Parameters)
+	assert.Equal(t, "object-id", c.OriginObjectId)
+	assert.JSONEq(t, `{"enabled": true, "description": "{{.name}}"}`, c.Template.Content())
+}
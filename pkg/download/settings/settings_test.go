@@ -19,6 +19,7 @@
 package settings
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
@@ -225,7 +226,7 @@ func TestDownloadAll(t *testing.T) {
 			c.EXPECT().ListSchemas().Times(tt.mockValues.ListSchemasCalls).Return(schemas, err)
 			settings, err := tt.mockValues.Settings()
 			c.EXPECT().ListSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(tt.mockValues.ListSettingsCalls).Return(settings, err)
-			res, _ := NewDownloader(c, WithFilters(tt.filters)).Download("projectName")
+			res, _ := NewDownloader(c, WithFilters(tt.filters)).Download(context.TODO(), "projectName")
 			assert.Equal(t, tt.want, res)
 		})
 	}
@@ -306,7 +307,7 @@ func TestDownload(t *testing.T) {
 			settings, err2 := tt.mockValues.Settings()
 			c.EXPECT().ListSchemas().Times(tt.mockValues.ListSchemasCalls).Return(schemas, err1)
 			c.EXPECT().ListSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(tt.mockValues.ListSettingsCalls).Return(settings, err2)
-			res, _ := NewDownloader(c).Download("projectName", tt.Schemas...)
+			res, _ := NewDownloader(c).Download(context.TODO(), "projectName", tt.Schemas...)
 			assert.Equal(t, tt.want, res)
 		})
 	}
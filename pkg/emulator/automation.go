@@ -0,0 +1,118 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/automation"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"net/http"
+	"strconv"
+)
+
+var automationPaths = map[automation.ResourceType]string{
+	automation.Workflows:         "/platform/automation/v1/workflows",
+	automation.BusinessCalendars: "/platform/automation/v1/business-calendars",
+	automation.SchedulingRules:   "/platform/automation/v1/scheduling-rules",
+}
+
+// serveAutomation handles requests to the Automation API. It returns false if the path is not an Automation API path.
+func (e *Emulator) serveAutomation(rw http.ResponseWriter, req *http.Request, path string, body []byte) bool {
+	for resourceType, resourcePath := range automationPaths {
+		if id, ok := splitID(path, resourcePath); ok {
+			e.serveAutomationResource(rw, req, resourceType, id, body)
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Emulator) serveAutomationResource(rw http.ResponseWriter, req *http.Request, resourceType automation.ResourceType, id string, body []byte) {
+	objects, ok := e.automations[resourceType]
+	if !ok {
+		objects = make(map[string]map[string]any)
+		e.automations[resourceType] = objects
+	}
+
+	switch {
+	case id == "" && req.Method == http.MethodGet:
+		e.listAutomations(rw, req, objects)
+	case id == "" && req.Method == http.MethodPost:
+		obj, err := unmarshalObject(body)
+		if err != nil {
+			writeError(rw, http.StatusBadRequest, "%s", err)
+			return
+		}
+		objectID, _ := obj["id"].(string)
+		if objectID == "" {
+			objectID = newID()
+			obj["id"] = objectID
+		}
+		if _, exists := objects[objectID]; exists {
+			writeError(rw, http.StatusConflict, "object with id %q already exists", objectID)
+			return
+		}
+		objects[objectID] = obj
+		writeJSON(rw, http.StatusCreated, obj)
+	case id != "" && req.Method == http.MethodGet:
+		obj, exists := objects[id]
+		if !exists {
+			writeError(rw, http.StatusNotFound, "object with id %q not found", id)
+			return
+		}
+		writeJSON(rw, http.StatusOK, obj)
+	case id != "" && req.Method == http.MethodPut:
+		if _, exists := objects[id]; !exists {
+			writeError(rw, http.StatusNotFound, "object with id %q not found", id)
+			return
+		}
+		obj, err := unmarshalObject(body)
+		if err != nil {
+			writeError(rw, http.StatusBadRequest, "%s", err)
+			return
+		}
+		obj["id"] = id
+		objects[id] = obj
+		writeJSON(rw, http.StatusOK, obj)
+	case id != "" && req.Method == http.MethodDelete:
+		if _, exists := objects[id]; !exists {
+			writeError(rw, http.StatusNotFound, "object with id %q not found", id)
+			return
+		}
+		delete(objects, id)
+		rw.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(rw, http.StatusMethodNotAllowed, "%s is not supported for automation resources", req.Method)
+	}
+}
+
+// listAutomations returns all objects starting at the 'offset' query parameter, ordered by ID
+func (e *Emulator) listAutomations(rw http.ResponseWriter, req *http.Request, objects map[string]map[string]any) {
+	ids := maps.Keys(objects)
+	slices.Sort(ids)
+
+	offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+	if offset < 0 || offset > len(ids) {
+		offset = len(ids)
+	}
+
+	results := make([]map[string]any, 0, len(ids)-offset)
+	for _, id := range ids[offset:] {
+		results = append(results, objects[id])
+	}
+	writeJSON(rw, http.StatusOK, map[string]any{"count": len(ids), "results": results})
+}
@@ -0,0 +1,109 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"net/http"
+	"strconv"
+)
+
+const bucketPath = "/platform/storage/management/v1/bucket-definitions"
+
+// serveBuckets handles requests to the Grail bucket definitions API. Like the real API, updates require the current
+// version of the bucket to be passed as 'optimistic-locking-version' and increase the version.
+func (e *Emulator) serveBuckets(rw http.ResponseWriter, req *http.Request, path string, body []byte) {
+	name, ok := splitID(path, bucketPath)
+	if !ok {
+		writeError(rw, http.StatusNotFound, "%s %s is not supported by the emulator", req.Method, req.URL.Path)
+		return
+	}
+
+	switch {
+	case name == "" && req.Method == http.MethodGet:
+		names := maps.Keys(e.buckets)
+		slices.Sort(names)
+		buckets := make([]map[string]any, 0, len(names))
+		for _, n := range names {
+			buckets = append(buckets, e.buckets[n])
+		}
+		writeJSON(rw, http.StatusOK, map[string]any{"buckets": buckets})
+	case name == "" && req.Method == http.MethodPost:
+		obj, err := unmarshalObject(body)
+		if err != nil {
+			writeError(rw, http.StatusBadRequest, "%s", err)
+			return
+		}
+		bucketName, _ := obj["bucketName"].(string)
+		if bucketName == "" {
+			writeError(rw, http.StatusBadRequest, "bucketName must be set")
+			return
+		}
+		if _, exists := e.buckets[bucketName]; exists {
+			writeError(rw, http.StatusConflict, "bucket %q already exists", bucketName)
+			return
+		}
+		obj["status"] = "active"
+		obj["version"] = 1
+		e.buckets[bucketName] = obj
+		writeJSON(rw, http.StatusCreated, obj)
+	case name != "" && req.Method == http.MethodGet:
+		obj, exists := e.buckets[name]
+		if !exists {
+			writeError(rw, http.StatusNotFound, "bucket %q not found", name)
+			return
+		}
+		writeJSON(rw, http.StatusOK, obj)
+	case name != "" && req.Method == http.MethodPut:
+		e.updateBucket(rw, req, name, body)
+	case name != "" && req.Method == http.MethodDelete:
+		if _, exists := e.buckets[name]; !exists {
+			writeError(rw, http.StatusNotFound, "bucket %q not found", name)
+			return
+		}
+		delete(e.buckets, name)
+		rw.WriteHeader(http.StatusAccepted)
+	default:
+		writeError(rw, http.StatusMethodNotAllowed, "%s is not supported for bucket definitions", req.Method)
+	}
+}
+
+func (e *Emulator) updateBucket(rw http.ResponseWriter, req *http.Request, name string, body []byte) {
+	existing, exists := e.buckets[name]
+	if !exists {
+		writeError(rw, http.StatusNotFound, "bucket %q not found", name)
+		return
+	}
+
+	version := existing["version"].(int)
+	if v := req.URL.Query().Get("optimistic-locking-version"); v != strconv.Itoa(version) {
+		writeError(rw, http.StatusConflict, "bucket %q has version %d, but version %q was given", name, version, v)
+		return
+	}
+
+	obj, err := unmarshalObject(body)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, "%s", err)
+		return
+	}
+	obj["bucketName"] = name
+	obj["status"] = existing["status"]
+	obj["version"] = version + 1
+	e.buckets[name] = obj
+	writeJSON(rw, http.StatusOK, obj)
+}
@@ -0,0 +1,241 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"net/http"
+	"strings"
+)
+
+// classicStore holds all objects of a single classic configuration API, in the order they were created
+type classicStore struct {
+	ids     []string
+	objects map[string]map[string]any
+	// single is the configuration of a single configuration API
+	single map[string]any
+}
+
+func (s *classicStore) put(id string, obj map[string]any) (created bool) {
+	if _, exists := s.objects[id]; !exists {
+		s.ids = append(s.ids, id)
+		created = true
+	}
+	s.objects[id] = obj
+	return created
+}
+
+func (s *classicStore) delete(id string) bool {
+	if _, exists := s.objects[id]; !exists {
+		return false
+	}
+	delete(s.objects, id)
+	for i, v := range s.ids {
+		if v == id {
+			s.ids = append(s.ids[:i], s.ids[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (e *Emulator) classicStoreOf(a api.API) *classicStore {
+	s, ok := e.classic[a.ID]
+	if !ok {
+		s = &classicStore{objects: make(map[string]map[string]any)}
+		e.classic[a.ID] = s
+	}
+	return s
+}
+
+// findClassicAPI returns the API addressed by the given path, and the object ID if the path addresses a single object.
+// APIs whose URL path matches exactly are preferred, as some single configuration APIs are nested in other APIs.
+func (e *Emulator) findClassicAPI(path string) (api.API, string, bool) {
+	var match api.API
+	var matchID string
+	found := false
+	for _, a := range e.apis {
+		id, ok := splitID(path, a.URLPath)
+		if !ok {
+			continue
+		}
+		if id == "" {
+			return a, "", true
+		}
+		if !found || len(a.URLPath) > len(match.URLPath) {
+			match, matchID, found = a, id, true
+		}
+	}
+	return match, matchID, found
+}
+
+func (e *Emulator) serveClassic(rw http.ResponseWriter, req *http.Request, path string, body []byte) bool {
+	a, id, found := e.findClassicAPI(path)
+	if !found {
+		return false
+	}
+
+	store := e.classicStoreOf(a)
+
+	if a.SingleConfiguration {
+		switch req.Method {
+		case http.MethodGet:
+			if store.single == nil {
+				writeJSON(rw, http.StatusOK, map[string]any{})
+				return true
+			}
+			writeJSON(rw, http.StatusOK, store.single)
+		case http.MethodPut:
+			obj, err := unmarshalObject(body)
+			if err != nil {
+				writeError(rw, http.StatusBadRequest, "%s", err)
+				return true
+			}
+			store.single = obj
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(rw, http.StatusMethodNotAllowed, "%s is not supported for single configuration API %q", req.Method, a.ID)
+		}
+		return true
+	}
+
+	switch {
+	case id == "" && req.Method == http.MethodGet:
+		writeJSON(rw, http.StatusOK, listResponse(a, store))
+	case id == "" && req.Method == http.MethodPost:
+		e.createClassic(rw, a, store, body)
+	case id != "" && req.Method == http.MethodGet:
+		obj, exists := store.objects[id]
+		if !exists {
+			writeError(rw, http.StatusNotFound, "%s with id %q not found", a.ID, id)
+			return true
+		}
+		writeJSON(rw, http.StatusOK, obj)
+	case id != "" && req.Method == http.MethodPut:
+		obj, err := unmarshalObject(body)
+		if err != nil {
+			writeError(rw, http.StatusBadRequest, "%s", err)
+			return true
+		}
+		setClassicID(a, obj, id)
+		if store.put(id, obj) {
+			writeJSON(rw, http.StatusCreated, map[string]string{"id": id, "name": classicName(obj, id)})
+			return true
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	case id != "" && req.Method == http.MethodDelete:
+		if !store.delete(id) {
+			writeError(rw, http.StatusNotFound, "%s with id %q not found", a.ID, id)
+			return true
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(rw, http.StatusMethodNotAllowed, "%s is not supported for API %q", req.Method, a.ID)
+	}
+	return true
+}
+
+func (e *Emulator) createClassic(rw http.ResponseWriter, a api.API, store *classicStore, body []byte) {
+	obj, err := unmarshalObject(body)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, "%s", err)
+		return
+	}
+
+	id := newID()
+	setClassicID(a, obj, id)
+	store.put(id, obj)
+
+	switch {
+	case isSyntheticAPI(a):
+		writeJSON(rw, http.StatusOK, map[string]string{"entityId": id})
+	case a.ID == "slo":
+		// the SLO API only returns the location of the created object
+		rw.Header().Set("Location", a.URLPath+"/"+id)
+		rw.WriteHeader(http.StatusCreated)
+	default:
+		writeJSON(rw, http.StatusCreated, map[string]string{"id": id, "name": classicName(obj, id)})
+	}
+}
+
+// listResponse builds the response of listing all objects of an API, in the API specific format
+func listResponse(a api.API, store *classicStore) any {
+	values := make([]map[string]any, 0, len(store.ids))
+	for _, id := range store.ids {
+		obj := store.objects[id]
+		v := make(map[string]any)
+		if name, ok := objectName(obj); ok {
+			v["name"] = name
+		}
+		if isSyntheticAPI(a) {
+			v["entityId"] = id
+			v["type"] = "PRIVATE"
+		} else {
+			v["id"] = id
+		}
+		for _, key := range []string{"owner", "dashboardId"} {
+			if s, ok := obj[key].(string); ok {
+				v[key] = s
+			}
+		}
+		values = append(values, v)
+	}
+
+	switch a.ID {
+	case "aws-credentials":
+		return values
+	case "synthetic-location":
+		return map[string]any{"locations": values}
+	case "synthetic-monitor":
+		return map[string]any{"monitors": values}
+	default:
+		return map[string]any{a.PropertyNameOfGetAllResponse: values, "totalCount": len(values)}
+	}
+}
+
+func isSyntheticAPI(a api.API) bool {
+	return strings.HasPrefix(a.ID, "synthetic-")
+}
+
+func setClassicID(a api.API, obj map[string]any, id string) {
+	if isSyntheticAPI(a) {
+		obj["entityId"] = id
+		return
+	}
+	obj["id"] = id
+}
+
+// classicName returns the name of a classic configuration object, falling back to its ID for unnamed objects
+func classicName(obj map[string]any, id string) string {
+	if name, ok := objectName(obj); ok {
+		return name
+	}
+	return id
+}
+
+// objectName returns the name of a classic configuration object. Dashboards define their name in their metadata.
+func objectName(obj map[string]any) (string, bool) {
+	if name, ok := obj["name"].(string); ok {
+		return name, true
+	}
+	if metadata, ok := obj["dashboardMetadata"].(map[string]any); ok {
+		if name, ok := metadata["name"].(string); ok {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,204 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package emulator provides an in-memory implementation of the subset of the Dynatrace APIs used by monaco.
+// It is meant for testing deployments and downloads without access to a real Dynatrace environment, and stores
+// everything it is sent until it is discarded.
+package emulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/automation"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DefaultVersion is the Dynatrace version reported by the emulator if none is set using WithVersion
+const DefaultVersion = "1.280.0.20231013-120000"
+
+const (
+	versionPath       = "/api/v1/config/clusterversion"
+	classicEnvURLPath = "/platform/metadata/v1/classic-environment-domain"
+	oauthTokenPath    = "/sso/oauth2/token"
+	// platformSettingsPath is the path of the Settings 2.0 API on platform environments
+	platformSettingsPath = "/platform/classic/environment-api/v2/settings"
+)
+
+// Emulator is an in-memory Dynatrace environment. It implements http.Handler and can be served using any HTTP server,
+// e.g. httptest.NewServer. An Emulator is safe for concurrent use.
+//
+// The following APIs are emulated:
+//   - all classic configuration APIs defined by api.NewAPIs
+//   - Settings 2.0 objects and schemas, including externalIds and unique constraints of schemas
+//   - Automation workflows, business calendars and scheduling rules
+//   - Grail bucket definitions
+//   - the version, classic environment URL and OAuth token endpoints
+//
+// The emulator neither authenticates requests nor validates payloads beyond what is needed to store them.
+type Emulator struct {
+	version string
+	apis    []api.API
+
+	mutex       sync.Mutex
+	classic     map[string]*classicStore
+	schemas     map[string]Schema
+	settings    []*settingsObject
+	automations map[automation.ResourceType]map[string]map[string]any
+	buckets     map[string]map[string]any
+}
+
+// Option configures an Emulator created by New
+type Option func(*Emulator)
+
+// WithVersion sets the Dynatrace version reported by the emulator. It must follow the MAJOR.MINOR.PATCH.DATE format
+// of Dynatrace versions.
+func WithVersion(version string) Option {
+	return func(e *Emulator) {
+		e.version = version
+	}
+}
+
+// WithSchemas registers the given Settings 2.0 schemas. Objects of other schemas are accepted as well, but they
+// don't have any constraints.
+func WithSchemas(schemas ...Schema) Option {
+	return func(e *Emulator) {
+		for _, s := range schemas {
+			e.schemas[s.SchemaId] = s
+		}
+	}
+}
+
+// New creates a new, empty Emulator
+func New(opts ...Option) *Emulator {
+	e := &Emulator{
+		version:     DefaultVersion,
+		classic:     make(map[string]*classicStore),
+		schemas:     make(map[string]Schema),
+		automations: make(map[automation.ResourceType]map[string]map[string]any),
+		buckets:     make(map[string]map[string]any),
+	}
+
+	for _, a := range api.NewAPIs() {
+		e.apis = append(e.apis, a)
+	}
+
+	for _, o := range opts {
+		o(e)
+	}
+	return e
+}
+
+// ServeHTTP implements http.Handler
+func (e *Emulator) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, "failed to read request body: %s", err)
+		return
+	}
+
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	log.Debug("Emulator: %s %s", req.Method, req.URL)
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	switch {
+	case path == versionPath && req.Method == http.MethodGet:
+		writeJSON(rw, http.StatusOK, map[string]string{"version": e.version})
+	case path == classicEnvURLPath && req.Method == http.MethodGet:
+		writeJSON(rw, http.StatusOK, map[string]string{"domain": baseURL(req)})
+	case path == oauthTokenPath && req.Method == http.MethodPost:
+		writeJSON(rw, http.StatusOK, map[string]any{"access_token": "emulated-token", "token_type": "Bearer", "expires_in": 3600})
+	case strings.HasPrefix(path, settingsPath):
+		e.serveSettings(rw, req, path, body)
+	case strings.HasPrefix(path, platformSettingsPath):
+		e.serveSettings(rw, req, settingsPath+strings.TrimPrefix(path, platformSettingsPath), body)
+	case strings.HasPrefix(path, bucketPath):
+		e.serveBuckets(rw, req, path, body)
+	default:
+		if e.serveAutomation(rw, req, path, body) || e.serveClassic(rw, req, path, body) {
+			return
+		}
+		writeError(rw, http.StatusNotFound, "%s %s is not supported by the emulator", req.Method, req.URL.Path)
+	}
+}
+
+// baseURL returns the URL the emulator was reached with by the given request
+func baseURL(req *http.Request) string {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + req.Host
+}
+
+// splitID splits the given path into the part matching the collection path and a single trailing ID segment.
+// It returns false if the path does not address the collection or one of its elements.
+func splitID(path, collection string) (id string, ok bool) {
+	if path == collection {
+		return "", true
+	}
+	rest, found := strings.CutPrefix(path, collection+"/")
+	if !found || rest == "" || strings.Contains(rest, "/") {
+		return "", false
+	}
+	return rest, true
+}
+
+func newID() string {
+	return uuid.NewString()
+}
+
+func unmarshalObject(body []byte) (map[string]any, error) {
+	var m map[string]any
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, fmt.Errorf("request body is not a JSON object: %w", err)
+	}
+	if m == nil {
+		m = make(map[string]any)
+	}
+	return m, nil
+}
+
+func writeJSON(rw http.ResponseWriter, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, "failed to marshal response: %s", err)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_, _ = rw.Write(data)
+}
+
+// writeError writes an error response in the format returned by Dynatrace APIs
+func writeError(rw http.ResponseWriter, status int, format string, args ...any) {
+	data, _ := json.Marshal(map[string]any{
+		"error": map[string]any{
+			"code":    status,
+			"message": fmt.Sprintf(format, args...),
+		},
+	})
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_, _ = rw.Write(data)
+}
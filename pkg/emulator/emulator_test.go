@@ -0,0 +1,200 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/version"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/metadata"
+	dtversion "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/version"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, opts ...Option) (*httptest.Server, *rest.Client) {
+	server := httptest.NewServer(New(opts...))
+	t.Cleanup(server.Close)
+	return server, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy())
+}
+
+func newTestDTClient(t *testing.T, opts ...Option) *dtclient.DynatraceClient {
+	server, restClient := newTestServer(t, opts...)
+	c, err := dtclient.NewClassicClient(server.URL, restClient, dtclient.WithServerVersion(version.Version{Major: 1, Minor: 280}), dtclient.WithCachingDisabled(true))
+	require.NoError(t, err)
+	return c
+}
+
+func TestClassicAPIs(t *testing.T) {
+	c := newTestDTClient(t)
+	a := api.NewAPIs()["alerting-profile"]
+
+	created, err := c.UpsertConfigByName(context.TODO(), a, "my-profile", []byte(`{"name":"my-profile","rules":[]}`))
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.Id)
+
+	updated, err := c.UpsertConfigByName(context.TODO(), a, "my-profile", []byte(`{"name":"my-profile","rules":["x"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, created.Id, updated.Id, "expected existing object to be updated")
+
+	values, err := c.ListConfigs(context.TODO(), a)
+	require.NoError(t, err)
+	assert.Equal(t, []dtclient.Value{{Id: created.Id, Name: "my-profile"}}, values)
+
+	payload, err := c.ReadConfigById(a, created.Id)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"`+created.Id+`","name":"my-profile","rules":["x"]}`, string(payload))
+
+	require.NoError(t, c.DeleteConfigById(a, created.Id))
+	values, err = c.ListConfigs(context.TODO(), a)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+func TestClassicAPIs_NestedSingleConfiguration(t *testing.T) {
+	c := newTestDTClient(t)
+	apis := api.NewAPIs()
+
+	_, err := c.UpsertConfigByName(context.TODO(), apis["app-detection-rule-host"], "host", []byte(`{"hostDetectionHeaders":["a"]}`))
+	require.NoError(t, err)
+
+	payload, err := c.ReadConfigById(apis["app-detection-rule-host"], "")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"hostDetectionHeaders":["a"]}`, string(payload))
+
+	values, err := c.ListConfigs(context.TODO(), apis["app-detection-rule"])
+	require.NoError(t, err)
+	assert.Empty(t, values, "expected single configuration not to be stored as object of the parent API")
+}
+
+func TestSettings(t *testing.T) {
+	c := newTestDTClient(t, WithSchemas(Schema{SchemaId: "builtin:unique", UniqueProperties: [][]string{{"key"}}}))
+
+	obj := dtclient.SettingsObject{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "builtin:unique", ConfigId: "a"},
+		SchemaId:   "builtin:unique",
+		Scope:      "environment",
+		Content:    []byte(`{"key":"k","value":1}`),
+	}
+
+	created, err := c.UpsertSettings(context.TODO(), obj)
+	require.NoError(t, err)
+
+	obj.Content = []byte(`{"key":"k","value":2}`)
+	updated, err := c.UpsertSettings(context.TODO(), obj)
+	require.NoError(t, err)
+	assert.Equal(t, created.Id, updated.Id, "expected object with same externalId to be updated")
+
+	// a different config with the same unique key is found by the client and updates the existing object
+	obj.Coordinate.ConfigId = "b"
+	other, err := c.UpsertSettings(context.TODO(), obj)
+	require.NoError(t, err)
+	assert.Equal(t, created.Id, other.Id)
+
+	schemas, err := c.ListSchemas()
+	require.NoError(t, err)
+	assert.Len(t, schemas, 1)
+
+	objects, err := c.ListSettings(context.TODO(), "builtin:unique", dtclient.ListSettingsOptions{})
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.JSONEq(t, `{"key":"k","value":2}`, string(objects[0].Value))
+	assert.NotEmpty(t, objects[0].ExternalId)
+
+	require.NoError(t, c.DeleteSettings(created.Id))
+	_, err = c.GetSettingById(created.Id)
+	assert.ErrorIs(t, err, dtclient.ErrSettingNotFound)
+}
+
+func TestSettings_UniqueConstraintViolation(t *testing.T) {
+	server, restClient := newTestServer(t, WithSchemas(Schema{SchemaId: "builtin:unique", UniqueProperties: [][]string{{"key"}}}))
+
+	for i, expected := range []int{http.StatusOK, http.StatusBadRequest} {
+		resp, err := restClient.Post(context.TODO(), server.URL+settingsObjectsPath, []byte(`[{"schemaId":"builtin:unique","scope":"environment","externalId":"`+string(rune('a'+i))+`","value":{"key":"k"}}]`))
+		require.NoError(t, err)
+		assert.Equal(t, expected, resp.StatusCode)
+	}
+}
+
+func TestAutomation(t *testing.T) {
+	server, restClient := newTestServer(t)
+	c := automation.NewClient(server.URL, restClient)
+
+	_, err := c.Upsert(context.TODO(), automation.Workflows, "my-workflow", []byte(`{"title":"a"}`))
+	require.NoError(t, err)
+	_, err = c.Upsert(context.TODO(), automation.Workflows, "my-workflow", []byte(`{"title":"b"}`))
+	require.NoError(t, err)
+
+	resp, err := c.Get(context.TODO(), automation.Workflows, "my-workflow")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"my-workflow","title":"b"}`, string(resp.Data))
+
+	list, err := c.List(context.TODO(), automation.Workflows)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	list, err = c.List(context.TODO(), automation.BusinessCalendars)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	require.NoError(t, c.Delete(automation.Workflows, "my-workflow"))
+	_, err = c.Get(context.TODO(), automation.Workflows, "my-workflow")
+	assert.Error(t, err)
+}
+
+func TestBuckets(t *testing.T) {
+	server, restClient := newTestServer(t)
+	c := bucket.NewClient(server.URL, restClient)
+
+	_, err := c.Upsert(context.TODO(), "my-bucket", []byte(`{"displayName":"a"}`))
+	require.NoError(t, err)
+	_, err = c.Upsert(context.TODO(), "my-bucket", []byte(`{"displayName":"b"}`))
+	require.NoError(t, err)
+
+	b, err := c.Get(context.TODO(), "my-bucket")
+	require.NoError(t, err)
+	assert.Equal(t, 2, b.Version)
+	assert.JSONEq(t, `{"bucketName":"my-bucket","displayName":"b","status":"active","version":2}`, string(b.Data))
+}
+
+func TestEnvironmentEndpoints(t *testing.T) {
+	server, restClient := newTestServer(t, WithVersion("1.275.3.20230101-000000"))
+
+	v, err := dtversion.GetDynatraceVersion(context.TODO(), restClient, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, version.Version{Major: 1, Minor: 275, Patch: 3}, v)
+
+	classicURL, err := metadata.GetDynatraceClassicURL(context.TODO(), restClient, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL, classicURL)
+
+	c, err := dtclient.NewPlatformClient(server.URL, classicURL, restClient, restClient)
+	require.NoError(t, err)
+	_, err = c.ListSchemas()
+	assert.NoError(t, err, "expected settings to be available using the platform path")
+}
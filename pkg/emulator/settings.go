@@ -0,0 +1,270 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"net/http"
+	"strings"
+)
+
+const (
+	settingsPath        = "/api/v2/settings"
+	settingsObjectsPath = settingsPath + "/objects"
+	settingsSchemasPath = settingsPath + "/schemas"
+)
+
+// Schema defines a Settings 2.0 schema known to the emulator
+type Schema struct {
+	SchemaId string
+	// UniqueProperties are the unique constraints of the schema. Each entry lists the properties whose combined values
+	// must be unique among all objects of the schema.
+	UniqueProperties [][]string
+}
+
+type settingsObject struct {
+	ObjectId      string          `json:"objectId"`
+	ExternalId    string          `json:"externalId,omitempty"`
+	SchemaId      string          `json:"schemaId"`
+	SchemaVersion string          `json:"schemaVersion,omitempty"`
+	Scope         string          `json:"scope"`
+	Value         json.RawMessage `json:"value"`
+}
+
+type settingsRequest struct {
+	SchemaId      string          `json:"schemaId"`
+	ExternalId    string          `json:"externalId"`
+	Scope         string          `json:"scope"`
+	Value         json.RawMessage `json:"value"`
+	SchemaVersion string          `json:"schemaVersion"`
+	ObjectId      string          `json:"objectId"`
+}
+
+type settingsResponse struct {
+	Code     int    `json:"code"`
+	ObjectId string `json:"objectId,omitempty"`
+	Error    any    `json:"error,omitempty"`
+}
+
+func (e *Emulator) serveSettings(rw http.ResponseWriter, req *http.Request, path string, body []byte) {
+	if id, ok := splitID(path, settingsSchemasPath); ok && req.Method == http.MethodGet {
+		if id == "" {
+			e.listSchemas(rw)
+			return
+		}
+		e.getSchema(rw, id)
+		return
+	}
+
+	id, ok := splitID(path, settingsObjectsPath)
+	if !ok {
+		writeError(rw, http.StatusNotFound, "%s %s is not supported by the emulator", req.Method, req.URL.Path)
+		return
+	}
+
+	switch {
+	case id == "" && req.Method == http.MethodGet:
+		e.listSettings(rw, req)
+	case id == "" && req.Method == http.MethodPost:
+		e.upsertSettings(rw, body)
+	case id != "" && req.Method == http.MethodGet:
+		if i := e.findSettings(id); i >= 0 {
+			writeJSON(rw, http.StatusOK, e.settings[i])
+			return
+		}
+		writeError(rw, http.StatusNotFound, "Settings not found")
+	case id != "" && req.Method == http.MethodDelete:
+		if i := e.findSettings(id); i >= 0 {
+			e.settings = slices.Delete(e.settings, i, i+1)
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeError(rw, http.StatusNotFound, "Settings not found")
+	default:
+		writeError(rw, http.StatusMethodNotAllowed, "%s is not supported for settings objects", req.Method)
+	}
+}
+
+func (e *Emulator) listSchemas(rw http.ResponseWriter) {
+	ids := make(map[string]struct{})
+	for id := range e.schemas {
+		ids[id] = struct{}{}
+	}
+	for _, o := range e.settings {
+		ids[o.SchemaId] = struct{}{}
+	}
+
+	sorted := maps.Keys(ids)
+	slices.Sort(sorted)
+
+	items := make([]map[string]string, 0, len(sorted))
+	for _, id := range sorted {
+		items = append(items, map[string]string{"schemaId": id})
+	}
+	writeJSON(rw, http.StatusOK, map[string]any{"items": items, "totalCount": len(items)})
+}
+
+func (e *Emulator) getSchema(rw http.ResponseWriter, id string) {
+	var constraints []map[string]any
+	for _, props := range e.schemas[id].UniqueProperties {
+		constraints = append(constraints, map[string]any{"type": "UNIQUE", "uniqueProperties": props})
+	}
+	writeJSON(rw, http.StatusOK, map[string]any{"schemaId": id, "schemaConstraints": constraints})
+}
+
+// listSettings returns all objects of the schemas given by the 'schemaIds' query parameter in a single page.
+// If the parameter is not set, all objects are returned.
+func (e *Emulator) listSettings(rw http.ResponseWriter, req *http.Request) {
+	var schemaIDs []string
+	if v := req.URL.Query().Get("schemaIds"); v != "" {
+		schemaIDs = strings.Split(v, ",")
+	}
+
+	items := make([]*settingsObject, 0)
+	for _, o := range e.settings {
+		if len(schemaIDs) == 0 || slices.Contains(schemaIDs, o.SchemaId) {
+			items = append(items, o)
+		}
+	}
+	writeJSON(rw, http.StatusOK, map[string]any{"items": items, "totalCount": len(items), "pageSize": len(items)})
+}
+
+// upsertSettings creates or updates all objects of the request. An object is updated if it has the objectId or
+// externalId of an existing object, otherwise a new object is created. Objects violating unique constraints of their
+// schema are rejected.
+func (e *Emulator) upsertSettings(rw http.ResponseWriter, body []byte) {
+	var requests []settingsRequest
+	if err := json.Unmarshal(body, &requests); err != nil {
+		writeError(rw, http.StatusBadRequest, "request body is not a list of settings objects: %s", err)
+		return
+	}
+
+	responses := make([]settingsResponse, 0, len(requests))
+	status := http.StatusOK
+	for _, r := range requests {
+		objectID, err := e.upsertSettingsObject(r)
+		if err != nil {
+			status = http.StatusBadRequest
+			responses = append(responses, settingsResponse{
+				Code:  http.StatusBadRequest,
+				Error: map[string]any{"code": http.StatusBadRequest, "message": err.Error()},
+			})
+			continue
+		}
+		responses = append(responses, settingsResponse{Code: http.StatusOK, ObjectId: objectID})
+	}
+	writeJSON(rw, status, responses)
+}
+
+func (e *Emulator) upsertSettingsObject(r settingsRequest) (string, error) {
+	if r.SchemaId == "" || r.Scope == "" {
+		return "", fmt.Errorf("schemaId and scope must be set")
+	}
+
+	index := -1
+	if r.ObjectId != "" {
+		index = e.findSettings(r.ObjectId)
+	}
+	if index < 0 && r.ExternalId != "" {
+		for i, o := range e.settings {
+			if o.ExternalId == r.ExternalId {
+				index = i
+				break
+			}
+		}
+	}
+
+	obj := &settingsObject{
+		ObjectId:      r.ObjectId,
+		ExternalId:    r.ExternalId,
+		SchemaId:      r.SchemaId,
+		SchemaVersion: r.SchemaVersion,
+		Scope:         r.Scope,
+		Value:         r.Value,
+	}
+
+	if index >= 0 {
+		obj.ObjectId = e.settings[index].ObjectId
+	} else if obj.ObjectId == "" {
+		obj.ObjectId = newID()
+	}
+
+	if err := e.checkUniqueConstraints(obj); err != nil {
+		return "", err
+	}
+
+	if index >= 0 {
+		e.settings[index] = obj
+	} else {
+		e.settings = append(e.settings, obj)
+	}
+	return obj.ObjectId, nil
+}
+
+// checkUniqueConstraints returns an error if another object of the same schema has the same values for all
+// properties of any unique constraint of the schema.
+func (e *Emulator) checkUniqueConstraints(obj *settingsObject) error {
+	constraints := e.schemas[obj.SchemaId].UniqueProperties
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	var value map[string]any
+	if err := json.Unmarshal(obj.Value, &value); err != nil {
+		return fmt.Errorf("value is not a JSON object: %w", err)
+	}
+
+	for _, other := range e.settings {
+		if other.SchemaId != obj.SchemaId || other.ObjectId == obj.ObjectId {
+			continue
+		}
+
+		var otherValue map[string]any
+		if err := json.Unmarshal(other.Value, &otherValue); err != nil {
+			continue
+		}
+
+		for _, props := range constraints {
+			if sameValues(props, value, otherValue) {
+				return fmt.Errorf("unique constraint violation: object %q has the same values for properties %v", other.ObjectId, props)
+			}
+		}
+	}
+	return nil
+}
+
+func sameValues(props []string, a, b map[string]any) bool {
+	for _, p := range props {
+		if !cmp.Equal(a[p], b[p]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *Emulator) findSettings(objectID string) int {
+	for i, o := range e.settings {
+		if o.ObjectId == objectID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,94 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package extension reads Extensions 2.0 packages.
+package extension
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io"
+)
+
+const (
+	// archiveName is the name of the extension archive within a signed extension package
+	archiveName = "extension.zip"
+	// manifestName is the name of the extension manifest within an extension archive
+	manifestName = "extension.yaml"
+)
+
+// Package describes an Extensions 2.0 package
+type Package struct {
+	// Name of the extension, e.g. 'com.dynatrace.extension.sql-server'
+	Name string `yaml:"name"`
+	// Version of the extension
+	Version string `yaml:"version"`
+}
+
+// ReadPackage reads the name and version of the extension contained in the given package. Packages are signed zip
+// archives, containing the extension archive and its signature. Unsigned extension archives are accepted as well, as
+// they can be uploaded to environments which allow them.
+func ReadPackage(content []byte) (Package, error) {
+	r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return Package{}, fmt.Errorf("extension package is not a zip archive: %w", err)
+	}
+
+	if m, err := readFile(r, manifestName); err == nil {
+		return parseManifest(m)
+	}
+
+	archive, err := readFile(r, archiveName)
+	if err != nil {
+		return Package{}, fmt.Errorf("extension package contains neither %q nor %q", archiveName, manifestName)
+	}
+
+	r, err = zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return Package{}, fmt.Errorf("%q of extension package is not a zip archive: %w", archiveName, err)
+	}
+
+	m, err := readFile(r, manifestName)
+	if err != nil {
+		return Package{}, fmt.Errorf("%q of extension package does not contain %q", archiveName, manifestName)
+	}
+	return parseManifest(m)
+}
+
+func readFile(r *zip.Reader, name string) ([]byte, error) {
+	f, err := r.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
+
+func parseManifest(content []byte) (Package, error) {
+	var p Package
+	if err := yaml.Unmarshal(content, &p); err != nil {
+		return Package{}, fmt.Errorf("failed to parse %q: %w", manifestName, err)
+	}
+
+	if p.Name == "" || p.Version == "" {
+		return Package{}, errors.New(manifestName + " must define the name and version of the extension")
+	}
+	return p, nil
+}
@@ -0,0 +1,100 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/extension"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReadPackage(t *testing.T) {
+	manifest := "name: com.example.extension\nversion: 1.2.3\nminDynatraceVersion: \"1.260\"\n"
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    extension.Package
+		wantErr string
+	}{
+		{
+			name: "signed package",
+			content: createZip(t, map[string][]byte{
+				"extension.zip":     createZip(t, map[string][]byte{"extension.yaml": []byte(manifest)}),
+				"extension.zip.sig": []byte("signature"),
+			}),
+			want: extension.Package{Name: "com.example.extension", Version: "1.2.3"},
+		},
+		{
+			name:    "unsigned archive",
+			content: createZip(t, map[string][]byte{"extension.yaml": []byte(manifest)}),
+			want:    extension.Package{Name: "com.example.extension", Version: "1.2.3"},
+		},
+		{
+			name:    "not a zip archive",
+			content: []byte("{}"),
+			wantErr: "not a zip archive",
+		},
+		{
+			name:    "missing extension archive",
+			content: createZip(t, map[string][]byte{"extension.zip.sig": []byte("signature")}),
+			wantErr: `contains neither "extension.zip" nor "extension.yaml"`,
+		},
+		{
+			name: "missing manifest",
+			content: createZip(t, map[string][]byte{
+				"extension.zip": createZip(t, map[string][]byte{"README.md": []byte("")}),
+			}),
+			wantErr: `does not contain "extension.yaml"`,
+		},
+		{
+			name:    "missing version",
+			content: createZip(t, map[string][]byte{"extension.yaml": []byte("name: com.example.extension")}),
+			wantErr: "must define the name and version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extension.ReadPackage(tt.content)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func createZip(t *testing.T, files map[string][]byte) []byte {
+	buffer := new(bytes.Buffer)
+	w := zip.NewWriter(buffer)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buffer.Bytes()
+}
@@ -56,7 +56,7 @@ func (e CyclicDependencyError) Error() string {
 	b := strings.Builder{}
 	_, _ = b.WriteString(fmt.Sprintf("There are %d dependency cycles between the configurations.\n", len(e.ConfigsInDependencyCycle)))
 	for _, cycle := range e.ConfigsInDependencyCycle {
-		_, _ = b.WriteString("Please check the following configuration's references and break the cycle:\n")
+		_, _ = b.WriteString("Please check the following configuration's references and dependsOn entries and break the cycle:\n")
 
 		for _, c := range cycle {
 			_, _ = b.WriteString(fmt.Sprintf("%q", c.Coordinate))
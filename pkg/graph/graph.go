@@ -24,6 +24,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
 	"gonum.org/v1/gonum/graph/encoding/dot"
 	"gonum.org/v1/gonum/graph/simple"
 	"gonum.org/v1/gonum/graph/topo"
@@ -58,6 +59,23 @@ func (n ConfigNode) DOTID() string {
 	return n.Config.Coordinate.String()
 }
 
+// Attributes returns the DOT attributes of the node, which hold the owner, description and labels of the Config if
+// they are set. Labels are joined into a single sorted 'key=value' list, as DOT's 'label' attribute sets the displayed
+// text of a node.
+func (n ConfigNode) Attributes() []encoding.Attribute {
+	var attributes []encoding.Attribute
+	if n.Config.Owner != "" {
+		attributes = append(attributes, encoding.Attribute{Key: "owner", Value: n.Config.Owner})
+	}
+	if n.Config.Description != "" {
+		attributes = append(attributes, encoding.Attribute{Key: "description", Value: n.Config.Description})
+	}
+	if len(n.Config.Labels) > 0 {
+		attributes = append(attributes, encoding.Attribute{Key: "labels", Value: n.Config.LabelString()})
+	}
+	return attributes
+}
+
 func (n ConfigNode) String() string {
 	return fmt.Sprintf("ConfigNode{ id=%d, configCoordinate=%v }", n.NodeID, n.Config.Coordinate)
 }
@@ -226,7 +244,7 @@ func buildDependencyGraph(projects []project.Project, environment string) *simpl
 
 		configReferences[c.Coordinate] = map[coordinate.Coordinate]struct{}{}
 
-		for _, ref := range c.References() {
+		for _, ref := range c.Dependencies() {
 			configReferences[c.Coordinate][ref] = struct{}{}
 		}
 	}
@@ -234,6 +234,87 @@ func TestGraphExport(t *testing.T) {
 	assert.Equal(t, string(dot), "strict digraph dev_dependency_graph {\n  // Node definitions.\n  \"project1:dashboard:sample dashboard\";\n  \"project1:dashboard:Random Dashboard\";\n  \"project2:auto-tag:tag\";\n\n  // Edge definitions.\n  \"project2:auto-tag:tag\" -> \"project1:dashboard:sample dashboard\";\n}")
 }
 
+func TestGraphExportContainsMetadata(t *testing.T) {
+	projects := []project.Project{
+		{
+			Id: "project",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"dev": {
+					"dashboard": []config.Config{
+						{
+							Coordinate:  coordinate.Coordinate{Project: "project", Type: "dashboard", ConfigId: "dashboard"},
+							Environment: "dev",
+							Owner:       "payments",
+							Labels:      map[string]string{"tier": "critical", "app": "shop"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	dot, err := graph.New(projects, []string{"dev"}).EncodeToDOT("dev")
+	assert.NoError(t, err)
+	assert.Contains(t, string(dot), `"project:dashboard:dashboard" [
+    owner=payments
+    labels="app=shop,tier=critical"
+  ];`)
+}
+
+func TestGraphContainsDependsOn(t *testing.T) {
+	workflow := coordinate.Coordinate{Project: "project", Type: "workflow", ConfigId: "workflow"}
+	trigger := coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "trigger"}
+
+	projects := []project.Project{
+		{
+			Id: "project",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"dev": {
+					"builtin:alerting.profile": []config.Config{{Coordinate: trigger, Environment: "dev", DependsOn: []coordinate.Coordinate{workflow}}},
+					"workflow":                 []config.Config{{Coordinate: workflow, Environment: "dev"}},
+				},
+			},
+		},
+	}
+
+	graphs := graph.New(projects, []string{"dev"})
+
+	dot, err := graphs.EncodeToDOT("dev")
+	assert.NoError(t, err)
+	assert.Contains(t, string(dot), `"project:workflow:workflow" -> "project:builtin:alerting.profile:trigger";`)
+
+	sorted, err := graphs.SortConfigs("dev")
+	assert.NoError(t, err)
+	assert.Len(t, sorted, 2)
+	assert.Equal(t, workflow, sorted[0].Coordinate)
+	assert.Equal(t, trigger, sorted[1].Coordinate)
+}
+
+func TestGraphCycleErrors_DependsOn(t *testing.T) {
+	a := coordinate.Coordinate{Project: "project", Type: "workflow", ConfigId: "a"}
+	b := coordinate.Coordinate{Project: "project", Type: "workflow", ConfigId: "b"}
+
+	projects := []project.Project{
+		{
+			Id: "project",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"dev": {
+					"workflow": []config.Config{
+						{Coordinate: a, Environment: "dev", DependsOn: []coordinate.Coordinate{b}},
+						{Coordinate: b, Environment: "dev", DependsOn: []coordinate.Coordinate{a}},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := graph.New(projects, []string{"dev"}).SortConfigs("dev")
+	var cycleErr graph.CyclicDependencyError
+	assert.ErrorAs(t, err, &cycleErr)
+	assert.Len(t, cycleErr.ConfigsInDependencyCycle, 1)
+	assert.ElementsMatch(t, []graph.DependencyLocation{{Coordinate: a}, {Coordinate: b}}, cycleErr.ConfigsInDependencyCycle[0])
+}
+
 func TestGraphCycleErrors(t *testing.T) {
 	projectId := "project1"
 	referencedProjectId := "project2"
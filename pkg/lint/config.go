@@ -0,0 +1,99 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/afero"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultConfigFileName is the name of the lint configuration file looked up next to the manifest
+const DefaultConfigFileName = ".monaco-lint.yaml"
+
+// Config defines which rules are run, and with which severity. Rules not configured run with their default severity.
+//
+// Example:
+//
+//	rules:
+//	  unused-parameter:
+//	    enabled: false
+//	  deprecated-api:
+//	    severity: error
+type Config struct {
+	Rules map[string]RuleConfig `yaml:"rules"`
+}
+
+// RuleConfig overrides the defaults of a single rule
+type RuleConfig struct {
+	// Enabled disables the rule if set to false
+	Enabled *bool `yaml:"enabled,omitempty"`
+	// Severity replaces the default severity of the rule if set
+	Severity Severity `yaml:"severity,omitempty"`
+}
+
+// LoadConfig reads a lint Config from the given YAML file
+func LoadConfig(fs afero.Fs, path string) (Config, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read lint configuration %q: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("failed to parse lint configuration %q: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid lint configuration %q: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate returns an error if the Config refers to unknown rules or severities
+func (c Config) Validate() error {
+	var errs []error
+	ids := maps.Keys(c.Rules)
+	slices.Sort(ids)
+	for _, id := range ids {
+		r := c.Rules[id]
+		if _, found := ruleByID(id); !found {
+			errs = append(errs, fmt.Errorf("unknown rule %q", id))
+		}
+		if r.Severity != "" && !r.Severity.valid() {
+			errs = append(errs, fmt.Errorf("rule %q: unknown severity %q, expected one of %q, %q or %q", id, r.Severity, SeverityError, SeverityWarning, SeverityNote))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) severity(r Rule) (Severity, bool) {
+	rc, found := c.Rules[r.ID]
+	if !found {
+		return r.DefaultSeverity, true
+	}
+	if rc.Enabled != nil && !*rc.Enabled {
+		return "", false
+	}
+	if rc.Severity != "" {
+		return rc.Severity, true
+	}
+	return r.DefaultSeverity, true
+}
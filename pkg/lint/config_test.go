@@ -0,0 +1,89 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "lint.yaml", []byte(`
+rules:
+  unused-parameter:
+    enabled: false
+  deprecated-api:
+    severity: error
+  plaintext-secret:
+    enabled: true
+`), 0644))
+
+	cfg, err := LoadConfig(fs, "lint.yaml")
+	require.NoError(t, err)
+
+	for _, r := range Rules {
+		severity, enabled := cfg.severity(r)
+		switch r.ID {
+		case RuleUnusedParameter:
+			assert.False(t, enabled)
+		case RuleDeprecatedAPI:
+			assert.True(t, enabled)
+			assert.Equal(t, SeverityError, severity)
+		default:
+			assert.True(t, enabled, r.ID)
+			assert.Equal(t, r.DefaultSeverity, severity, r.ID)
+		}
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown rule",
+			content: "rules:\n  no-such-rule:\n    enabled: false\n",
+			wantErr: `unknown rule "no-such-rule"`,
+		},
+		{
+			name:    "unknown severity",
+			content: "rules:\n  unused-parameter:\n    severity: fatal\n",
+			wantErr: `rule "unused-parameter": unknown severity "fatal"`,
+		},
+		{
+			name:    "unknown property",
+			content: "rules:\n  unused-parameter:\n    disabled: true\n",
+			wantErr: "failed to parse lint configuration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "lint.yaml", []byte(tt.content), 0644))
+
+			_, err := LoadConfig(fs, "lint.yaml")
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
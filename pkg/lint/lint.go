@@ -0,0 +1,166 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package lint checks monaco projects for common mistakes which are not detected by loading them, or only when deploying.
+package lint
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/spf13/afero"
+	"golang.org/x/exp/slices"
+	"sort"
+)
+
+// Severity of a Finding. The values match the levels of SARIF results.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+func (s Severity) valid() bool {
+	return s == SeverityError || s == SeverityWarning || s == SeverityNote
+}
+
+// Finding is a problem reported by a Rule
+type Finding struct {
+	// RuleID is the ID of the rule reporting the finding
+	RuleID string `json:"rule"`
+	// Severity is the severity of the rule, as configured
+	Severity Severity `json:"severity"`
+	// Message describes the problem
+	Message string `json:"message"`
+	// Config is the configuration the problem was found in. It is empty for problems not tied to a loaded configuration.
+	Config *coordinate.Coordinate `json:"config,omitempty"`
+	// File is the file the problem was found in, if known
+	File string `json:"file,omitempty"`
+	// Environments are the environments the problem was found for. It is empty for problems independent of environments.
+	Environments []string `json:"environments,omitempty"`
+}
+
+// Input holds the loaded manifest and projects to lint
+type Input struct {
+	Fs afero.Fs
+	// WorkingDir is the directory of the manifest, which project paths are relative to
+	WorkingDir string
+	Manifest   manifest.Manifest
+	// Projects are all loaded projects, including the ones only referenced by linted projects
+	Projects []project.Project
+	// LintedProjects are the IDs of the projects to report findings for. If empty, findings of all projects are reported.
+	LintedProjects []string
+	APIs           api.APIs
+}
+
+func (in Input) lints(projectID string) bool {
+	return len(in.LintedProjects) == 0 || slices.Contains(in.LintedProjects, projectID)
+}
+
+// Lint runs all rules enabled by the given Config and returns their findings.
+// Findings are returned once per problem, listing all environments the problem was found for, and are sorted by file,
+// configuration and rule.
+func Lint(in Input, cfg Config) ([]Finding, error) {
+	ctx, err := newCheckContext(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, r := range Rules {
+		severity, enabled := cfg.severity(r)
+		if !enabled {
+			continue
+		}
+		for _, f := range r.check(ctx) {
+			if f.Config != nil && !in.lints(f.Config.Project) {
+				continue
+			}
+			f.RuleID = r.ID
+			f.Severity = severity
+			if f.File == "" && f.Config != nil {
+				f.File = ctx.configFiles[configFileKey(*f.Config)]
+			}
+			findings = append(findings, f)
+		}
+	}
+
+	return merge(findings), nil
+}
+
+// merge combines findings which only differ in their environments
+func merge(findings []Finding) []Finding {
+	var merged []Finding
+	index := make(map[string]int)
+	for _, f := range findings {
+		key := fmt.Sprintf("%s|%s|%s|%s", f.RuleID, f.File, configString(f.Config), f.Message)
+		i, found := index[key]
+		if !found {
+			index[key] = len(merged)
+			f.Environments = slices.Clone(f.Environments)
+			merged = append(merged, f)
+			continue
+		}
+		for _, env := range f.Environments {
+			if !slices.Contains(merged[i].Environments, env) {
+				merged[i].Environments = append(merged[i].Environments, env)
+			}
+		}
+	}
+
+	for i := range merged {
+		slices.Sort(merged[i].Environments)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if configString(a.Config) != configString(b.Config) {
+			return configString(a.Config) < configString(b.Config)
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		return a.Message < b.Message
+	})
+	return merged
+}
+
+func configString(c *coordinate.Coordinate) string {
+	if c == nil {
+		return ""
+	}
+	return c.String()
+}
+
+// HasErrors returns whether any of the findings has SeverityError
+func HasErrors(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool { return f.Severity == SeverityError })
+}
+
+func configFinding(c config.Config, message string, args ...any) Finding {
+	return Finding{
+		Message:      fmt.Sprintf(message, args...),
+		Config:       &c.Coordinate,
+		Environments: []string{c.Environment},
+	}
+}
@@ -0,0 +1,313 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var (
+	zone      = coordinate.Coordinate{Project: "p", Type: "management-zone", ConfigId: "zone"}
+	dashboard = coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "dashboard"}
+	setting   = coordinate.Coordinate{Project: "p", Type: "builtin:alerting.profile", ConfigId: "profile"}
+)
+
+func lintConfigs(t *testing.T, configs ...config.Config) []Finding {
+	p := project.Project{Id: "p", Configs: project.ConfigsPerTypePerEnvironments{}}
+	for _, c := range configs {
+		if p.Configs[c.Environment] == nil {
+			p.Configs[c.Environment] = project.ConfigsPerType{}
+		}
+		p.Configs[c.Environment][c.Coordinate.Type] = append(p.Configs[c.Environment][c.Coordinate.Type], c)
+	}
+
+	findings, err := Lint(Input{
+		Fs:       afero.NewMemMapFs(),
+		Manifest: manifest.Manifest{Environments: manifest.Environments{"dev": {Name: "dev"}, "prod": {Name: "prod"}}},
+		Projects: []project.Project{p},
+		APIs:     api.NewAPIs(),
+	}, Config{})
+	require.NoError(t, err)
+	return findings
+}
+
+func ruleFindings(findings []Finding, ruleID string) []Finding {
+	var result []Finding
+	for _, f := range findings {
+		if f.RuleID == ruleID {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func TestLint_UnusedParameter(t *testing.T) {
+	findings := lintConfigs(t,
+		config.Config{
+			Coordinate:  setting,
+			Type:        config.SettingsType{SchemaId: setting.Type},
+			Environment: "dev",
+			Template:    template.CreateTemplateFromString("p/profile.json", `{"name": "{{ .name }}", "zone": "{{ .zoneId }}"}`),
+			Parameters: config.Parameters{
+				config.NameParameter:  valueParam.New("profile"),
+				config.ScopeParameter: valueParam.New("environment"),
+				"zoneId":              refParam.NewWithCoordinate(zone, "id"),
+				"referencedOnly":      valueParam.New("used by the dashboard"),
+				"unused":              valueParam.New("unused"),
+			},
+		},
+		config.Config{
+			Coordinate:  dashboard,
+			Type:        config.ClassicApiType{Api: "dashboard"},
+			Environment: "dev",
+			Template:    template.CreateTemplateFromString("p/dashboard.json", `{"name": "{{ .name }}"}`),
+			Parameters: config.Parameters{
+				config.NameParameter: valueParam.New("dashboard"),
+				"profile":            refParam.NewWithCoordinate(setting, "referencedOnly"),
+			},
+		},
+	)
+
+	unused := ruleFindings(findings, RuleUnusedParameter)
+	require.Len(t, unused, 2)
+	assert.Equal(t, &setting, unused[0].Config)
+	assert.Contains(t, unused[0].Message, `parameter "unused"`)
+	assert.Equal(t, SeverityWarning, unused[0].Severity)
+	assert.Equal(t, &dashboard, unused[1].Config)
+	assert.Contains(t, unused[1].Message, `parameter "profile"`)
+}
+
+func TestLint_UndefinedTemplateVariable(t *testing.T) {
+	c := config.Config{
+		Coordinate:  dashboard,
+		Type:        config.ClassicApiType{Api: "dashboard"},
+		Template:    template.CreateTemplateFromString("p/dashboard.json", `{"name": "{{ .name }}", "owner": "{{ .owner }}"}`),
+		Parameters:  config.Parameters{config.NameParameter: valueParam.New("dashboard")},
+		Environment: "dev",
+	}
+	prod := c
+	prod.Environment = "prod"
+
+	undefined := ruleFindings(lintConfigs(t, c, prod), RuleUndefinedTemplateVariable)
+	require.Len(t, undefined, 1, "findings of all environments are merged")
+	assert.Equal(t, `template "p/dashboard.json" uses variable "owner", but no parameter of that name is defined`, undefined[0].Message)
+	assert.Equal(t, []string{"dev", "prod"}, undefined[0].Environments)
+	assert.Equal(t, SeverityError, undefined[0].Severity)
+}
+
+func TestLint_DeprecatedAPI(t *testing.T) {
+	findings := lintConfigs(t,
+		config.Config{
+			Coordinate:  zone,
+			Type:        config.ClassicApiType{Api: "management-zone"},
+			Environment: "dev",
+			Template:    template.CreateTemplateFromString("p/zone.json", `{"name": "{{ .name }}"}`),
+			Parameters:  config.Parameters{config.NameParameter: valueParam.New("zone")},
+		},
+	)
+
+	deprecated := ruleFindings(findings, RuleDeprecatedAPI)
+	require.Len(t, deprecated, 1)
+	assert.Contains(t, deprecated[0].Message, `"builtin:management-zones"`)
+}
+
+func TestLint_DuplicateClassicName(t *testing.T) {
+	other := zone
+	other.ConfigId = "other"
+	findings := lintConfigs(t,
+		config.Config{
+			Coordinate:  zone,
+			Type:        config.ClassicApiType{Api: "management-zone"},
+			Environment: "dev",
+			Template:    template.CreateTemplateFromString("p/zone.json", `{"name": "{{ .name }}"}`),
+			Parameters:  config.Parameters{config.NameParameter: valueParam.New("zone")},
+		},
+		config.Config{
+			Coordinate:  other,
+			Type:        config.ClassicApiType{Api: "management-zone"},
+			Environment: "dev",
+			Template:    template.CreateTemplateFromString("p/zone.json", `{"name": "{{ .name }}"}`),
+			Parameters:  config.Parameters{config.NameParameter: valueParam.New("zone")},
+		},
+	)
+
+	duplicates := ruleFindings(findings, RuleDuplicateClassicName)
+	require.Len(t, duplicates, 1)
+	assert.Contains(t, duplicates[0].Message, "duplicated config name found")
+	assert.NotNil(t, duplicates[0].Config)
+	assert.Equal(t, []string{"dev"}, duplicates[0].Environments)
+}
+
+func TestLint_PlaintextSecret(t *testing.T) {
+	findings := lintConfigs(t,
+		config.Config{
+			Coordinate:  setting,
+			Type:        config.SettingsType{SchemaId: setting.Type},
+			Environment: "dev",
+			Template:    template.CreateTemplateFromString("p/profile.json", `{{ .name }}{{ .apiToken }}{{ .webhook }}{{ .fromEnv }}{{ .tokenName }}`),
+			Parameters: config.Parameters{
+				config.NameParameter: valueParam.New("profile"),
+				"apiToken":           valueParam.New("dt0c01.ABCDEFGHIJKLMNOPQRSTUVWX.ABCDEFGHIJKLMNOPQRSTUVWXYZABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789AB"),
+				"webhook":            valueParam.New(map[any]any{"url": "https://example.com", "headers": []any{map[any]any{"secret": "s3cr3t"}}}),
+				"fromEnv":            envParam.New("SECRET"),
+				"tokenName":          valueParam.New(""),
+			},
+		},
+	)
+
+	secrets := ruleFindings(findings, RulePlaintextSecret)
+	require.Len(t, secrets, 2)
+	assert.Contains(t, secrets[0].Message, `value parameter "apiToken"`)
+	assert.Contains(t, secrets[1].Message, `at "webhook.headers[0].secret"`)
+	assert.NotContains(t, secrets[1].Message, "s3cr3t", "secrets are not reported")
+}
+
+func TestLint_ReferenceToSkippedConfig(t *testing.T) {
+	findings := lintConfigs(t,
+		config.Config{
+			Coordinate:  zone,
+			Type:        config.ClassicApiType{Api: "management-zone"},
+			Environment: "dev",
+			Template:    template.CreateTemplateFromString("p/zone.json", `{"name": "{{ .name }}"}`),
+			Parameters:  config.Parameters{config.NameParameter: valueParam.New("zone")},
+			Skip:        true,
+		},
+		config.Config{
+			Coordinate:  zone,
+			Type:        config.ClassicApiType{Api: "management-zone"},
+			Environment: "prod",
+			Template:    template.CreateTemplateFromString("p/zone.json", `{"name": "{{ .name }}"}`),
+			Parameters:  config.Parameters{config.NameParameter: valueParam.New("zone")},
+		},
+		config.Config{
+			Coordinate:  dashboard,
+			Type:        config.ClassicApiType{Api: "dashboard"},
+			Environment: "dev",
+			Template:    template.CreateTemplateFromString("p/dashboard.json", `{"name": "{{ .name }}", "zone": "{{ .zoneId }}"}`),
+			Parameters: config.Parameters{
+				config.NameParameter: valueParam.New("dashboard"),
+				"zoneId":             refParam.NewWithCoordinate(zone, "id"),
+			},
+			DependsOn: []coordinate.Coordinate{zone},
+		},
+		config.Config{
+			Coordinate:  dashboard,
+			Type:        config.ClassicApiType{Api: "dashboard"},
+			Environment: "prod",
+			Template:    template.CreateTemplateFromString("p/dashboard.json", `{"name": "{{ .name }}", "zone": "{{ .zoneId }}"}`),
+			Parameters: config.Parameters{
+				config.NameParameter: valueParam.New("dashboard"),
+				"zoneId":             refParam.NewWithCoordinate(zone, "id"),
+			},
+		},
+	)
+
+	skipped := ruleFindings(findings, RuleReferenceToSkippedConfig)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, &dashboard, skipped[0].Config)
+	assert.Equal(t, []string{"dev"}, skipped[0].Environments)
+}
+
+func TestLint_UndefinedEnvironmentOverride(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "manifest/project/config.yaml", []byte(`
+configs:
+- id: dashboard
+  type: dashboard
+  config:
+    name: dashboard
+    template: dashboard.json
+  environmentOverrides:
+  - environment: dev
+    override:
+      skip: true
+  - environment: staging
+    override:
+      skip: true
+`), 0644))
+	require.NoError(t, afero.WriteFile(fs, "manifest/other/config.yaml", []byte(`
+configs:
+- id: other
+  type: dashboard
+  config:
+    template: dashboard.json
+  environmentOverrides:
+  - environment: staging
+    override:
+      skip: true
+`), 0644))
+
+	findings, err := Lint(Input{
+		Fs:         fs,
+		WorkingDir: "manifest",
+		Manifest: manifest.Manifest{
+			Projects:     manifest.ProjectDefinitionByProjectID{"project": {Name: "project", Path: "project"}, "other": {Name: "other", Path: "other"}},
+			Environments: manifest.Environments{"dev": {Name: "dev"}},
+		},
+		LintedProjects: []string{"project"},
+		APIs:           api.NewAPIs(),
+	}, Config{})
+	require.NoError(t, err)
+
+	require.Len(t, findings, 1)
+	assert.Equal(t, Finding{
+		RuleID:   RuleUndefinedEnvironmentOverride,
+		Severity: SeverityWarning,
+		Message:  `configuration "dashboard" overrides environment "staging", which is not defined in the manifest`,
+		File:     "manifest/project/config.yaml",
+	}, findings[0])
+}
+
+func TestLint_Config(t *testing.T) {
+	disabled := false
+	c := config.Config{
+		Coordinate:  zone,
+		Type:        config.ClassicApiType{Api: "management-zone"},
+		Environment: "dev",
+		Template:    template.CreateTemplateFromString("p/zone.json", `{"name": "{{ .name }}", "rules": {{ .rules }}}`),
+		Parameters:  config.Parameters{config.NameParameter: valueParam.New("zone")},
+	}
+
+	findings, err := Lint(Input{
+		Fs:       afero.NewMemMapFs(),
+		Projects: []project.Project{{Id: "p", Configs: project.ConfigsPerTypePerEnvironments{"dev": {zone.Type: {c}}}}},
+		APIs:     api.NewAPIs(),
+	}, Config{Rules: map[string]RuleConfig{
+		RuleDeprecatedAPI:             {Enabled: &disabled},
+		RuleUndefinedTemplateVariable: {Severity: SeverityNote},
+	}})
+	require.NoError(t, err)
+
+	require.Len(t, findings, 1)
+	assert.Equal(t, RuleUndefinedTemplateVariable, findings[0].RuleID)
+	assert.Equal(t, SeverityNote, findings[0].Severity)
+	assert.False(t, HasErrors(findings))
+}
@@ -0,0 +1,433 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/spf13/afero"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v2"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IDs of all rules
+const (
+	RuleUnusedParameter              = "unused-parameter"
+	RuleUndefinedTemplateVariable    = "undefined-template-variable"
+	RuleDeprecatedAPI                = "deprecated-api"
+	RuleDuplicateClassicName         = "duplicate-classic-name"
+	RulePlaintextSecret              = "plaintext-secret"
+	RuleReferenceToSkippedConfig     = "reference-to-skipped-config"
+	RuleUndefinedEnvironmentOverride = "undefined-environment-override"
+)
+
+// Rule is a single lint check
+type Rule struct {
+	ID              string
+	Description     string
+	DefaultSeverity Severity
+	check           func(ctx *checkContext) []Finding
+}
+
+// Rules holds all available rules
+var Rules = []Rule{
+	{
+		ID:              RuleUnusedParameter,
+		Description:     "Parameters which are neither used in the template, nor referenced by other parameters or configurations",
+		DefaultSeverity: SeverityWarning,
+		check:           checkUnusedParameters,
+	},
+	{
+		ID:              RuleUndefinedTemplateVariable,
+		Description:     "Template variables without a parameter of the same name, which fail rendering the template on deployment",
+		DefaultSeverity: SeverityError,
+		check:           checkUndefinedTemplateVariables,
+	},
+	{
+		ID:              RuleDeprecatedAPI,
+		Description:     "Configurations of deprecated classic APIs, which should be migrated to the Settings 2.0 schema replacing them",
+		DefaultSeverity: SeverityWarning,
+		check:           checkDeprecatedAPIs,
+	},
+	{
+		ID:              RuleDuplicateClassicName,
+		Description:     "Classic configurations of the same API sharing a name, which are rejected on deployment",
+		DefaultSeverity: SeverityError,
+		check:           checkDuplicateClassicNames,
+	},
+	{
+		ID:              RulePlaintextSecret,
+		Description:     "Value parameters holding what looks like a plaintext secret, which should be an environment parameter instead",
+		DefaultSeverity: SeverityError,
+		check:           checkPlaintextSecrets,
+	},
+	{
+		ID:              RuleReferenceToSkippedConfig,
+		Description:     "Configurations referencing or depending on skipped configurations, which causes them to be skipped as well",
+		DefaultSeverity: SeverityWarning,
+		check:           checkReferencesToSkippedConfigs,
+	},
+	{
+		ID:              RuleUndefinedEnvironmentOverride,
+		Description:     "Environment overrides for environments not defined in the manifest, which are silently ignored",
+		DefaultSeverity: SeverityWarning,
+		check:           checkUndefinedEnvironmentOverrides,
+	},
+}
+
+func ruleByID(id string) (Rule, bool) {
+	i := slices.IndexFunc(Rules, func(r Rule) bool { return r.ID == id })
+	if i < 0 {
+		return Rule{}, false
+	}
+	return Rules[i], true
+}
+
+// checkContext holds the input of all rules
+type checkContext struct {
+	Input
+	configs []config.Config
+	// configFiles maps configFileKey of each configuration to the file defining it
+	configFiles map[string]string
+	// overrides maps files to the environments overridden by the configurations defined in them
+	overrides map[string][]environmentOverride
+}
+
+type environmentOverride struct {
+	configID    string
+	environment string
+}
+
+// rawConfigFile holds the parts of config files not available in loaded configurations
+type rawConfigFile struct {
+	Configs []struct {
+		Id                   string `yaml:"id"`
+		EnvironmentOverrides []struct {
+			Environment string `yaml:"environment"`
+		} `yaml:"environmentOverrides"`
+	} `yaml:"configs"`
+}
+
+func newCheckContext(in Input) (*checkContext, error) {
+	ctx := &checkContext{
+		Input:       in,
+		configFiles: make(map[string]string),
+		overrides:   make(map[string][]environmentOverride),
+	}
+
+	for _, p := range in.Projects {
+		p.ForEveryConfigDo(func(c config.Config) {
+			ctx.configs = append(ctx.configs, c)
+		})
+	}
+
+	fs := in.Fs
+	if in.WorkingDir != "" && in.WorkingDir != "." {
+		fs = afero.NewBasePathFs(in.Fs, in.WorkingDir)
+	}
+	for _, p := range in.Manifest.Projects {
+		if !in.lints(p.Name) {
+			continue
+		}
+		configFiles, err := project.FindConfigFiles(fs, p.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find config files of project %q: %w", p.Name, err)
+		}
+
+		for _, file := range configFiles {
+			data, err := afero.ReadFile(fs, file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read config file %q: %w", file, err)
+			}
+			var raw rawConfigFile
+			if err := yaml.Unmarshal(data, &raw); err != nil {
+				continue // loading the projects would have failed for invalid config files
+			}
+
+			file = filepath.Join(in.WorkingDir, file)
+			for _, c := range raw.Configs {
+				key := configFileKey(coordinate.Coordinate{Project: p.Name, ConfigId: c.Id})
+				if _, exists := ctx.configFiles[key]; !exists {
+					ctx.configFiles[key] = file
+				}
+				for _, o := range c.EnvironmentOverrides {
+					ctx.overrides[file] = append(ctx.overrides[file], environmentOverride{configID: c.Id, environment: o.Environment})
+				}
+			}
+		}
+	}
+
+	return ctx, nil
+}
+
+// configFileKey identifies the file of a configuration. As determining the type of configuration requires to fully
+// parse it, configurations are identified by project and ID only.
+func configFileKey(c coordinate.Coordinate) string {
+	return c.Project + ":" + c.ConfigId
+}
+
+func (ctx *checkContext) templateFile(c config.Config) string {
+	if t, ok := c.Template.(template.FileBasedTemplate); ok {
+		return filepath.Join(ctx.WorkingDir, t.FilePath())
+	}
+	return ""
+}
+
+func checkUnusedParameters(ctx *checkContext) []Finding {
+	type envProperty struct {
+		environment string
+		property    string
+	}
+	referenced := make(map[coordinate.Coordinate][]envProperty)
+	for _, c := range ctx.configs {
+		for _, p := range c.Parameters {
+			for _, ref := range p.GetReferences() {
+				referenced[ref.Config] = append(referenced[ref.Config], envProperty{c.Environment, ref.Property})
+			}
+		}
+	}
+
+	var findings []Finding
+	for _, c := range ctx.configs {
+		if c.Template == nil {
+			continue
+		}
+		vars, err := templateVariables(c.Template.Content())
+		if err != nil {
+			continue // invalid templates fail on deployment
+		}
+
+		names := maps.Keys(c.Parameters)
+		slices.Sort(names)
+		for _, name := range names {
+			if slices.Contains(config.ReservedParameterNames, name) || slices.Contains(vars, name) ||
+				slices.Contains(referenced[c.Coordinate], envProperty{c.Environment, name}) {
+				continue
+			}
+			findings = append(findings, configFinding(c, "parameter %q is neither used in template %q nor referenced", name, c.Template.Name()))
+		}
+	}
+	return findings
+}
+
+func checkUndefinedTemplateVariables(ctx *checkContext) []Finding {
+	var findings []Finding
+	for _, c := range ctx.configs {
+		if c.Template == nil {
+			continue
+		}
+		vars, err := templateVariables(c.Template.Content())
+		if err != nil {
+			continue // invalid templates fail on deployment
+		}
+
+		for _, v := range vars {
+			if _, found := c.Parameters[v]; found {
+				continue
+			}
+			f := configFinding(c, "template %q uses variable %q, but no parameter of that name is defined", c.Template.Name(), v)
+			f.File = ctx.templateFile(c)
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+func checkDeprecatedAPIs(ctx *checkContext) []Finding {
+	var findings []Finding
+	for _, c := range ctx.configs {
+		t, ok := c.Type.(config.ClassicApiType)
+		if !ok {
+			continue
+		}
+		if a, found := ctx.APIs[t.Api]; found && a.DeprecatedBy != "" {
+			findings = append(findings, configFinding(c, "API %q is deprecated, configurations should use the Settings 2.0 schema %q instead - see 'monaco migrate'", t.Api, a.DeprecatedBy))
+		}
+	}
+	return findings
+}
+
+func checkDuplicateClassicNames(ctx *checkContext) []Finding {
+	err := deploy.ValidateUniqueConfigNames(ctx.Projects)
+	if err == nil {
+		return nil
+	}
+
+	var envErrs deployErrors.EnvironmentDeploymentErrors
+	if !errors.As(err, &envErrs) {
+		return []Finding{{Message: err.Error()}}
+	}
+
+	var findings []Finding
+	for env, errs := range envErrs {
+		for _, e := range errs {
+			f := Finding{Message: e.Error(), Environments: []string{env}}
+			var deployErr deployErrors.ConfigDeployErr
+			if errors.As(e, &deployErr) {
+				c := deployErr.Location
+				f.Config = &c
+			}
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+var (
+	secretParameterName = regexp.MustCompile(`(?i)(passw(or)?d|secret|token|api[-_]?key|credential|private[-_]?key)`)
+	secretValues        = []*regexp.Regexp{
+		regexp.MustCompile(`\bdt0[a-z][0-9]{2}\.[A-Za-z0-9]{24}\.[A-Za-z0-9]{64}\b`), // Dynatrace tokens
+		regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),
+		regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`), // AWS access keys
+	}
+)
+
+func checkPlaintextSecrets(ctx *checkContext) []Finding {
+	var findings []Finding
+	for _, c := range ctx.configs {
+		names := maps.Keys(c.Parameters)
+		slices.Sort(names)
+		for _, name := range names {
+			v, ok := c.Parameters[name].(*valueParam.ValueParameter)
+			if !ok || name == config.NameParameter {
+				continue
+			}
+			if key, found := findSecret(name, v.Value); found {
+				findings = append(findings, configFinding(c, "value parameter %q holds a plaintext value looking like a secret at %q - use an environment parameter instead", name, key))
+			}
+		}
+	}
+	return findings
+}
+
+// findSecret returns the path of the first string within v which either looks like a secret, or is stored under a
+// key indicating a secret
+func findSecret(key string, v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return "", false
+		}
+		if secretParameterName.MatchString(key[strings.LastIndex(key, ".")+1:]) {
+			return key, true
+		}
+		for _, r := range secretValues {
+			if r.MatchString(v) {
+				return key, true
+			}
+		}
+	case map[any]any:
+		keys := make([]string, 0, len(v))
+		values := make(map[string]any, len(v))
+		for k, e := range v {
+			keys = append(keys, fmt.Sprint(k))
+			values[fmt.Sprint(k)] = e
+		}
+		return findSecretInMap(key, keys, values)
+	case map[string]any:
+		return findSecretInMap(key, maps.Keys(v), v)
+	case []any:
+		for i, e := range v {
+			if k, found := findSecret(fmt.Sprintf("%s[%d]", key, i), e); found {
+				return k, true
+			}
+		}
+	}
+	return "", false
+}
+
+func findSecretInMap(key string, keys []string, values map[string]any) (string, bool) {
+	slices.Sort(keys)
+	for _, k := range keys {
+		if p, found := findSecret(key+"."+k, values[k]); found {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+func checkReferencesToSkippedConfigs(ctx *checkContext) []Finding {
+	type envCoordinate struct {
+		environment string
+		coordinate  coordinate.Coordinate
+	}
+	skipped := make(map[envCoordinate]bool)
+	for _, c := range ctx.configs {
+		if c.Skip {
+			skipped[envCoordinate{c.Environment, c.Coordinate}] = true
+		}
+	}
+
+	var findings []Finding
+	for _, c := range ctx.configs {
+		if c.Skip {
+			continue
+		}
+
+		var refs []coordinate.Coordinate
+		for _, p := range c.Parameters {
+			refs = append(refs, referencedConfigs(p)...)
+		}
+		refs = append(refs, c.DependsOn...)
+
+		reported := make(map[coordinate.Coordinate]bool)
+		for _, r := range refs {
+			if r == c.Coordinate || reported[r] || !skipped[envCoordinate{c.Environment, r}] {
+				continue
+			}
+			reported[r] = true
+			findings = append(findings, configFinding(c, "configuration depends on %s, which is skipped - it will be skipped as well", r))
+		}
+	}
+	return findings
+}
+
+func referencedConfigs(p parameter.Parameter) []coordinate.Coordinate {
+	var refs []coordinate.Coordinate
+	for _, r := range p.GetReferences() {
+		refs = append(refs, r.Config)
+	}
+	return refs
+}
+
+func checkUndefinedEnvironmentOverrides(ctx *checkContext) []Finding {
+	var findings []Finding
+	for file, overrides := range ctx.overrides {
+		for _, o := range overrides {
+			if _, found := ctx.Manifest.Environments[o.environment]; found {
+				continue
+			}
+			findings = append(findings, Finding{
+				Message: fmt.Sprintf("configuration %q overrides environment %q, which is not defined in the manifest", o.configID, o.environment),
+				File:    file,
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,89 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"text/template/parse"
+)
+
+// templateVariables returns the names of all top-level properties a template accesses, e.g. 'name' for '{{ .name }}'.
+// Fields accessed within 'range' and 'with' blocks refer to the current element instead of the properties and are
+// only considered if they are accessed via '$'.
+func templateVariables(content string) ([]string, error) {
+	t, err := template.ParseTemplate("lint", content)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := make(map[string]struct{})
+	var walk func(n parse.Node, dotIsRoot bool)
+	walkBranch := func(b parse.BranchNode, listDotIsRoot, dotIsRoot bool) {
+		walk(b.Pipe, dotIsRoot)
+		walk(b.List, listDotIsRoot)
+		walk(b.ElseList, dotIsRoot)
+	}
+	walk = func(n parse.Node, dotIsRoot bool) {
+		switch n := n.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Nodes {
+				walk(c, dotIsRoot)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe, dotIsRoot)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Cmds {
+				walk(c, dotIsRoot)
+			}
+		case *parse.CommandNode:
+			for _, a := range n.Args {
+				walk(a, dotIsRoot)
+			}
+		case *parse.ChainNode:
+			walk(n.Node, dotIsRoot)
+		case *parse.FieldNode:
+			if dotIsRoot {
+				vars[n.Ident[0]] = struct{}{}
+			}
+		case *parse.VariableNode:
+			if n.Ident[0] == "$" && len(n.Ident) > 1 {
+				vars[n.Ident[1]] = struct{}{}
+			}
+		case *parse.IfNode:
+			walkBranch(n.BranchNode, dotIsRoot, dotIsRoot)
+		case *parse.RangeNode:
+			walkBranch(n.BranchNode, false, dotIsRoot)
+		case *parse.WithNode:
+			walkBranch(n.BranchNode, false, dotIsRoot)
+		case *parse.TemplateNode:
+			walk(n.Pipe, dotIsRoot)
+		}
+	}
+	walk(t.Tree.Root, true)
+
+	names := maps.Keys(vars)
+	slices.Sort(names)
+	return names, nil
+}
@@ -0,0 +1,76 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTemplateVariables(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     []string
+	}{
+		{
+			name:     "plain fields",
+			template: `{"name": "{{ .name }}", "zone": "{{.zone}}", "again": "{{ .name }}"}`,
+			want:     []string{"name", "zone"},
+		},
+		{
+			name:     "nested fields only report top-level property",
+			template: `{"user": "{{ .credentials.user }}"}`,
+			want:     []string{"credentials"},
+		},
+		{
+			name:     "functions and pipelines",
+			template: `{"tags": {{ printf "%q" .tag | html }}, "len": {{ len .list }}}`,
+			want:     []string{"list", "tag"},
+		},
+		{
+			name:     "range and with change the dot",
+			template: `{{ range .items }}{{ .id }}{{ $.owner }}{{ else }}{{ .fallback }}{{ end }}{{ with .zone }}{{ .name }}{{ end }}`,
+			want:     []string{"fallback", "items", "owner", "zone"},
+		},
+		{
+			name:     "if keeps the dot",
+			template: `{{ if .enabled }}{{ .value }}{{ else }}{{ .other }}{{ end }}`,
+			want:     []string{"enabled", "other", "value"},
+		},
+		{
+			name:     "no variables",
+			template: `{"name": "static"}`,
+			want:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := templateVariables(tt.template)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTemplateVariables_InvalidTemplate(t *testing.T) {
+	_, err := templateVariables(`{{ .name `)
+	assert.Error(t, err)
+}
@@ -17,6 +17,9 @@ package manifest
 import (
 	"fmt"
 	"golang.org/x/exp/maps"
+	neturl "net/url"
+	"strings"
+	"time"
 )
 
 type ProjectDefinition struct {
@@ -53,10 +56,95 @@ type Auth struct {
 
 // EnvironmentDefinition holds all information about a Dynatrace environment
 type EnvironmentDefinition struct {
-	Name  string
-	Group string
-	URL   URLDefinition
-	Auth  Auth
+	Name    string
+	Group   string
+	URL     URLDefinition
+	Auth    Auth
+	Options EnvironmentOptions
+	// Account is the name of the Account the environment belongs to. It is empty if no account is defined.
+	Account string
+	// Values holds the merged content of all values files of the environment. Nested values are maps with string keys.
+	Values map[string]interface{}
+	// ValuesFiles lists the values files of the environment, relative to the manifest.
+	ValuesFiles []string
+	// GroupValuesFiles lists the values files shared by all environments of the Group, relative to the manifest.
+	GroupValuesFiles []string
+}
+
+// EnvironmentID returns the ID of the environment, which is the first label of its host for SaaS environments, or
+// the path segment after '/e/' for Managed environments.
+func (e EnvironmentDefinition) EnvironmentID() string {
+	u, err := neturl.Parse(e.URL.Value)
+	if err != nil {
+		return ""
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == "e" {
+			return segments[i+1]
+		}
+	}
+
+	id, _, _ := strings.Cut(u.Hostname(), ".")
+	return id
+}
+
+// Account holds all information about a Dynatrace account, which is used to manage account-level resources like
+// user groups and IAM policies
+type Account struct {
+	Name string
+	// AccountUUID is the UUID of the account
+	AccountUUID string
+	// ApiURL is the URL of the account management API. If it is nil, the default API of Dynatrace SaaS is used.
+	ApiURL *URLDefinition
+	OAuth  OAuth
+}
+
+// GetApiURLValue returns the defined API URL or an empty string if it's not set.
+func (a Account) GetApiURLValue() string {
+	if a.ApiURL == nil {
+		return ""
+	}
+	return a.ApiURL.Value
+}
+
+// EnvironmentOptions holds optional settings for the HTTP clients used to access a Dynatrace environment.
+// Zero values mean that the respective default of monaco is used.
+type EnvironmentOptions struct {
+	// ConcurrentRequests limits the number of requests that are sent to the environment in parallel.
+	// If it is not set, the limit defined by the MONACO_CONCURRENT_REQUESTS environment variable is used.
+	ConcurrentRequests int
+
+	// RequestsPerSecond limits the number of requests that are sent to the environment per second.
+	// If it is not set, requests are not limited proactively.
+	RequestsPerSecond float64
+
+	// Retry overrides the default settings for retrying failed requests.
+	Retry *RetryOptions
+
+	// ProxyURL is the URL of the HTTP proxy all requests to the environment are sent through.
+	ProxyURL string
+
+	// CAFile is the path to a PEM encoded file of certificate authorities which are trusted in addition to the system ones.
+	// Relative paths in the manifest are resolved against the manifest's directory when it is loaded, and made relative
+	// to the written manifest again when it is written.
+	CAFile string
+
+	// CACertificates holds the PEM encoded content of CAFile, read when the manifest is loaded.
+	CACertificates []byte
+
+	// RequestTimeout is the maximum duration of a single HTTP request.
+	RequestTimeout time.Duration
+}
+
+// RetryOptions define how failed requests are retried.
+type RetryOptions struct {
+	// WaitTime is the duration waited between retries
+	WaitTime time.Duration
+
+	// MaxRetries is the number of retries for regular requests. Long-running operations are retried a multiple of this.
+	MaxRetries int
 }
 
 // URLType describes from where the url is loaded.
@@ -111,4 +199,25 @@ type Manifest struct {
 
 	// Environments defined in the manifest, split by environment-name
 	Environments Environments
+
+	// Accounts defined in the manifest, split by account-name
+	Accounts map[string]Account
+
+	// Plugins defined in the manifest, split by plugin-name
+	Plugins map[string]PluginDefinition
+}
+
+// PluginDefinition defines an external executable resolving the values of plugin parameters
+type PluginDefinition struct {
+	Name string
+
+	// Command is the executable of the plugin. Paths containing a separator are relative to the manifest, other
+	// commands are looked up in the PATH.
+	Command string
+
+	// Args are passed to the executable on each invocation
+	Args []string
+
+	// Timeout is the time the plugin may take to answer a single request. If it is 0, a default is used.
+	Timeout time.Duration
 }
@@ -23,11 +23,14 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/slices"
 	version2 "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/version"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
+	"github.com/google/uuid"
 	"github.com/spf13/afero"
 	"gopkg.in/yaml.v2"
+	neturl "net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // LoaderContext holds all information for [LoadManifest]
@@ -64,6 +67,10 @@ type projectLoaderContext struct {
 // LoaderOptions are optional configuration for LoadManifest
 type LoaderOptions struct {
 	DontResolveEnvVars bool
+
+	// ValuesFiles are additional values files applied on top of the values files defined in the manifest.
+	// Paths are resolved relative to the current working directory.
+	ValuesFiles []string
 }
 
 type ManifestLoaderError struct {
@@ -162,7 +169,12 @@ func LoadManifest(context *LoaderContext) (Manifest, []error) {
 		errs = append(errs, newManifestLoaderError(context.ManifestPath, "no projects defined in manifest"))
 	}
 
-	environmentDefinitions, manifestErrors := toEnvironments(context, manifestYAML.EnvironmentGroups)
+	values, err := newValuesLoader(workingDirFs, context.Fs, context.Opts.ValuesFiles)
+	if err != nil {
+		return Manifest{}, []error{newManifestLoaderError(context.ManifestPath, err.Error())}
+	}
+
+	environmentDefinitions, manifestErrors := toEnvironments(context, manifestYAML.EnvironmentGroups, values)
 
 	if manifestErrors != nil {
 		errs = append(errs, manifestErrors...)
@@ -170,6 +182,18 @@ func LoadManifest(context *LoaderContext) (Manifest, []error) {
 		errs = append(errs, newManifestLoaderError(context.ManifestPath, "no environments defined in manifest"))
 	}
 
+	accounts, accountErrors := toAccounts(context, manifestYAML.Accounts)
+	errs = append(errs, accountErrors...)
+
+	for _, env := range environmentDefinitions {
+		if _, found := accounts[env.Account]; env.Account != "" && !found && accountErrors == nil {
+			errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, env.Group, env.Name, fmt.Sprintf("unknown account %q", env.Account)))
+		}
+	}
+
+	plugins, pluginErrors := toPlugins(context, manifestYAML.Plugins)
+	errs = append(errs, pluginErrors...)
+
 	if errs != nil {
 		return Manifest{}, errs
 	}
@@ -177,9 +201,119 @@ func LoadManifest(context *LoaderContext) (Manifest, []error) {
 	return Manifest{
 		Projects:     projectDefinitions,
 		Environments: environmentDefinitions,
+		Accounts:     accounts,
+		Plugins:      plugins,
 	}, nil
 }
 
+func toPlugins(context *LoaderContext, plugins []plugin) (map[string]PluginDefinition, []error) {
+	if len(plugins) == 0 {
+		return nil, nil
+	}
+
+	var errs []error
+	result := make(map[string]PluginDefinition, len(plugins))
+
+	for i, p := range plugins {
+		if p.Name == "" {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("missing plugin name on index `%d`", i)))
+			continue
+		}
+
+		if _, exists := result[p.Name]; exists {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("duplicated plugin name %q", p.Name)))
+			continue
+		}
+
+		if p.Command == "" {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("missing command of plugin %q", p.Name)))
+			continue
+		}
+
+		definition := PluginDefinition{
+			Name:    p.Name,
+			Command: p.Command,
+			Args:    p.Args,
+		}
+
+		if p.Timeout != "" {
+			d, err := time.ParseDuration(p.Timeout)
+			if err != nil || d <= 0 {
+				errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("`timeout` %q of plugin %q is not a valid positive duration", p.Timeout, p.Name)))
+				continue
+			}
+			definition.Timeout = d
+		}
+
+		result[p.Name] = definition
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+	return result, nil
+}
+
+func toAccounts(context *LoaderContext, accounts []account) (map[string]Account, []error) {
+	if len(accounts) == 0 {
+		return nil, nil
+	}
+
+	var errs []error
+	result := make(map[string]Account, len(accounts))
+
+	for i, a := range accounts {
+		if a.Name == "" {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("missing account name on index `%d`", i)))
+			continue
+		}
+
+		if _, exists := result[a.Name]; exists {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("duplicated account name %q", a.Name)))
+			continue
+		}
+
+		parsed, err := parseAccount(context, a)
+		if err != nil {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("failed to parse account %q: %s", a.Name, err)))
+			continue
+		}
+		result[a.Name] = parsed
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+	return result, nil
+}
+
+func parseAccount(context *LoaderContext, a account) (Account, error) {
+	if _, err := uuid.Parse(a.AccountUUID); err != nil {
+		return Account{}, fmt.Errorf("invalid accountUUID %q: %w", a.AccountUUID, err)
+	}
+
+	o, err := parseOAuth(context, a.OAuth)
+	if err != nil {
+		return Account{}, fmt.Errorf("failed to parse OAuth credentials: %w", err)
+	}
+
+	result := Account{
+		Name:        a.Name,
+		AccountUUID: a.AccountUUID,
+		OAuth:       o,
+	}
+
+	if a.ApiURL != nil {
+		u, err := parseURLDefinition(context, *a.ApiURL)
+		if err != nil {
+			return Account{}, fmt.Errorf(`failed to parse "apiUrl": %w`, err)
+		}
+		result.ApiURL = &u
+	}
+
+	return result, nil
+}
+
 func parseAuth(context *LoaderContext, a auth) (Auth, error) {
 	token, err := parseAuthSecret(context, a.Token)
 	if err != nil {
@@ -334,7 +468,7 @@ func validateManifestVersion(manifestVersion string) error {
 	return nil
 }
 
-func toEnvironments(context *LoaderContext, groups []group) (map[string]EnvironmentDefinition, []error) { // nolint:gocognit
+func toEnvironments(context *LoaderContext, groups []group, values *valuesLoader) (map[string]EnvironmentDefinition, []error) { // nolint:gocognit
 	var errors []error
 	environments := make(map[string]EnvironmentDefinition)
 
@@ -378,6 +512,15 @@ func toEnvironments(context *LoaderContext, groups []group) (map[string]Environm
 				continue
 			}
 
+			envValues, err := values.valuesFor(group, env)
+			if err != nil {
+				errors = append(errors, newManifestEnvironmentLoaderError(context.ManifestPath, group.Name, env.Name, err.Error()))
+				continue
+			}
+			parsedEnv.Values = envValues
+			parsedEnv.ValuesFiles = env.Values
+			parsedEnv.GroupValuesFiles = group.Values
+
 			environments[parsedEnv.Name] = parsedEnv
 		}
 	}
@@ -432,18 +575,117 @@ func parseEnvironment(context *LoaderContext, config environment, group string)
 		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, err.Error()))
 	}
 
+	opts, err := parseEnvironmentOptions(context, config.Options)
+	if err != nil {
+		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, fmt.Sprintf("failed to parse options section: %s", err)))
+	}
+
 	if len(errs) > 0 {
 		return EnvironmentDefinition{}, errs
 	}
 
 	return EnvironmentDefinition{
-		Name:  config.Name,
-		URL:   urlDef,
-		Auth:  a,
-		Group: group,
+		Name:    config.Name,
+		URL:     urlDef,
+		Auth:    a,
+		Group:   group,
+		Options: opts,
+		Account: config.Account,
 	}, nil
 }
 
+func parseEnvironmentOptions(context *LoaderContext, o *environmentOptions) (EnvironmentOptions, error) {
+	if o == nil {
+		return EnvironmentOptions{}, nil
+	}
+
+	if o.ConcurrentRequests < 0 {
+		return EnvironmentOptions{}, errors.New("`concurrentRequests` must not be negative")
+	}
+
+	if o.RequestsPerSecond < 0 {
+		return EnvironmentOptions{}, errors.New("`requestsPerSecond` must not be negative")
+	}
+
+	opts := EnvironmentOptions{
+		ConcurrentRequests: o.ConcurrentRequests,
+		RequestsPerSecond:  o.RequestsPerSecond,
+		ProxyURL:           o.Proxy,
+	}
+
+	if o.Proxy != "" {
+		if u, err := neturl.Parse(o.Proxy); err != nil || u.Host == "" {
+			return EnvironmentOptions{}, fmt.Errorf("`proxy` %q is not a valid URL", o.Proxy)
+		}
+	}
+
+	if o.RequestTimeout != "" {
+		d, err := time.ParseDuration(o.RequestTimeout)
+		if err != nil || d <= 0 {
+			return EnvironmentOptions{}, fmt.Errorf("`requestTimeout` %q is not a valid positive duration", o.RequestTimeout)
+		}
+		opts.RequestTimeout = d
+	}
+
+	if o.Retry != nil {
+		r, err := parseRetryOptions(*o.Retry)
+		if err != nil {
+			return EnvironmentOptions{}, err
+		}
+		opts.Retry = &r
+	}
+
+	if o.CAFile != "" {
+		caFile, certificates, err := readCAFile(context, o.CAFile)
+		if err != nil {
+			return EnvironmentOptions{}, err
+		}
+		opts.CAFile = caFile
+		opts.CACertificates = certificates
+	}
+
+	return opts, nil
+}
+
+func parseRetryOptions(r retryOptions) (RetryOptions, error) {
+	if r.MaxRetries < 0 {
+		return RetryOptions{}, errors.New("`retry.maxRetries` must not be negative")
+	}
+
+	opts := RetryOptions{MaxRetries: r.MaxRetries}
+	if r.WaitTime != "" {
+		d, err := time.ParseDuration(r.WaitTime)
+		if err != nil || d < 0 {
+			return RetryOptions{}, fmt.Errorf("`retry.waitTime` %q is not a valid duration", r.WaitTime)
+		}
+		opts.WaitTime = d
+	}
+	return opts, nil
+}
+
+// readCAFile returns the path of the given CA file relative to the current working directory, as well as its content.
+// Relative paths in the manifest are relative to the manifest file.
+func readCAFile(context *LoaderContext, caFile string) (string, []byte, error) {
+	path := filepath.FromSlash(caFile)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(filepath.Clean(context.ManifestPath)), path)
+	}
+
+	exists, err := files.DoesFileExist(context.Fs, path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read `caFile` %q: %w", caFile, err)
+	}
+	if !exists {
+		return "", nil, fmt.Errorf("`caFile` %q does not exist", caFile)
+	}
+
+	content, err := afero.ReadFile(context.Fs, path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read `caFile` %q: %w", caFile, err)
+	}
+	return path, content, nil
+}
+
 func parseURLDefinition(context *LoaderContext, u url) (URLDefinition, error) {
 
 	// Depending on the type, the url.value either contains the env var name or the direct value of the url
@@ -29,6 +29,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func Test_extractUrlType(t *testing.T) {
@@ -1667,6 +1668,42 @@ environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {t
 `,
 			errsContain: []string{`environment-variable "not-found" was not found`},
 		},
+		{
+			name: "negative concurrent requests",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}, options: {concurrentRequests: -1}}]}]
+`,
+			errsContain: []string{"`concurrentRequests` must not be negative"},
+		},
+		{
+			name: "invalid request timeout",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}, options: {requestTimeout: "forever"}}]}]
+`,
+			errsContain: []string{"`requestTimeout` \"forever\" is not a valid positive duration"},
+		},
+		{
+			name: "invalid proxy",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}, options: {proxy: "not a url"}}]}]
+`,
+			errsContain: []string{"`proxy` \"not a url\" is not a valid URL"},
+		},
+		{
+			name: "CA file not found",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}, options: {caFile: "ca.pem"}}]}]
+`,
+			errsContain: []string{"`caFile` \"ca.pem\" does not exist"},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -1694,6 +1731,162 @@ environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {t
 	}
 }
 
+func TestLoadManifest_EnvironmentOptions(t *testing.T) {
+	t.Setenv("e", "mock token")
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "project/manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups:
+- name: b
+  environments:
+  - name: c
+    url: {value: d}
+    auth: {token: {name: e}}
+    options:
+      concurrentRequests: 10
+      requestsPerSecond: 2.5
+      retry: {waitTime: 2s, maxRetries: 5}
+      proxy: http://proxy.example.com:8080
+      caFile: certs/ca.pem
+      requestTimeout: 1m
+`), 0400))
+	assert.NoError(t, afero.WriteFile(fs, "project/certs/ca.pem", []byte("cert"), 0400))
+
+	mani, errs := LoadManifest(&LoaderContext{
+		Fs:           fs,
+		ManifestPath: "project/manifest.yaml",
+	})
+	assert.Empty(t, errs)
+
+	assert.Equal(t, EnvironmentOptions{
+		ConcurrentRequests: 10,
+		RequestsPerSecond:  2.5,
+		Retry: &RetryOptions{
+			WaitTime:   2 * time.Second,
+			MaxRetries: 5,
+		},
+		ProxyURL:       "http://proxy.example.com:8080",
+		CAFile:         filepath.Join("project", "certs", "ca.pem"),
+		CACertificates: []byte("cert"),
+		RequestTimeout: time.Minute,
+	}, mani.Environments["c"].Options)
+}
+
+func TestLoadManifest_Accounts(t *testing.T) {
+	t.Setenv("e", "mock token")
+	t.Setenv("CLIENT_ID", "client id")
+	t.Setenv("CLIENT_SECRET", "client secret")
+
+	t.Run("loads accounts of environments", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups:
+- name: b
+  environments:
+  - name: c
+    url: {value: d}
+    auth: {token: {name: e}}
+    account: my-account
+accounts:
+- name: my-account
+  accountUUID: 2c6d6b6e-9c3a-4b8e-8a0f-6a1d2e3f4a5b
+  apiUrl: {value: https://api.example.com}
+  oAuth:
+    clientId: {name: CLIENT_ID}
+    clientSecret: {name: CLIENT_SECRET}
+`), 0400))
+
+		mani, errs := LoadManifest(&LoaderContext{
+			Fs:           fs,
+			ManifestPath: "manifest.yaml",
+		})
+		assert.Empty(t, errs)
+
+		assert.Equal(t, "my-account", mani.Environments["c"].Account)
+		assert.Equal(t, map[string]Account{
+			"my-account": {
+				Name:        "my-account",
+				AccountUUID: "2c6d6b6e-9c3a-4b8e-8a0f-6a1d2e3f4a5b",
+				ApiURL:      &URLDefinition{Type: ValueURLType, Value: "https://api.example.com"},
+				OAuth: OAuth{
+					ClientID:     AuthSecret{Name: "CLIENT_ID", Value: "client id"},
+					ClientSecret: AuthSecret{Name: "CLIENT_SECRET", Value: "client secret"},
+				},
+			},
+		}, mani.Accounts)
+	})
+
+	t.Run("fails on unknown account", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups:
+- name: b
+  environments:
+  - name: c
+    url: {value: d}
+    auth: {token: {name: e}}
+    account: other-account
+`), 0400))
+
+		_, errs := LoadManifest(&LoaderContext{
+			Fs:           fs,
+			ManifestPath: "manifest.yaml",
+		})
+		assert.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], `unknown account "other-account"`)
+	})
+
+	t.Run("fails on invalid account UUID", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups:
+- name: b
+  environments:
+  - name: c
+    url: {value: d}
+    auth: {token: {name: e}}
+accounts:
+- name: my-account
+  accountUUID: not-a-uuid
+  oAuth:
+    clientId: {name: CLIENT_ID}
+    clientSecret: {name: CLIENT_SECRET}
+`), 0400))
+
+		_, errs := LoadManifest(&LoaderContext{
+			Fs:           fs,
+			ManifestPath: "manifest.yaml",
+		})
+		assert.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "invalid accountUUID")
+	})
+}
+
+func TestEnvironmentDefinition_EnvironmentID(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://abc12345.live.dynatrace.com", "abc12345"},
+		{"https://abc12345.apps.dynatrace.com/", "abc12345"},
+		{"https://managed.example.com/e/9f8e7d6c", "9f8e7d6c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			env := EnvironmentDefinition{URL: URLDefinition{Value: tt.url}}
+			assert.Equal(t, tt.want, env.EnvironmentID())
+		})
+	}
+}
+
 func TestEnvVarResolutionCanBeDeactivated(t *testing.T) {
 	e := environment{
 		Name: "TEST ENV",
@@ -1727,3 +1920,177 @@ func TestEnvVarResolutionCanBeDeactivated(t *testing.T) {
 		assert.NoError(t, gotErr)
 	})
 }
+
+func TestLoadManifest_Values(t *testing.T) {
+	t.Setenv("e", "mock token")
+
+	manifestContent := []byte(`
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups:
+- name: b
+  values: [values/group.yaml]
+  environments:
+  - name: c
+    url: {value: d}
+    auth: {token: {name: e}}
+    values: [values/c.yaml]
+  - name: f
+    url: {value: g}
+    auth: {token: {name: e}}
+`)
+
+	newFs := func(t *testing.T) afero.Fs {
+		fs := afero.NewMemMapFs()
+		assert.NoError(t, afero.WriteFile(fs, "project/manifest.yaml", manifestContent, 0400))
+		assert.NoError(t, afero.WriteFile(fs, "project/values/group.yaml", []byte(`
+alerting:
+  threshold: 10
+  enabled: true
+owner: team-a
+`), 0400))
+		assert.NoError(t, afero.WriteFile(fs, "project/values/c.yaml", []byte(`
+alerting:
+  threshold: 20
+tags: [a, b]
+`), 0400))
+		return fs
+	}
+
+	t.Run("merges values of group and environment", func(t *testing.T) {
+		mani, errs := LoadManifest(&LoaderContext{
+			Fs:           newFs(t),
+			ManifestPath: "project/manifest.yaml",
+		})
+		assert.Empty(t, errs)
+
+		assert.Equal(t, map[string]interface{}{
+			"alerting": map[string]interface{}{"threshold": 20, "enabled": true},
+			"owner":    "team-a",
+			"tags":     []interface{}{"a", "b"},
+		}, mani.Environments["c"].Values)
+		assert.Equal(t, map[string]interface{}{
+			"alerting": map[string]interface{}{"threshold": 10, "enabled": true},
+			"owner":    "team-a",
+		}, mani.Environments["f"].Values)
+	})
+
+	t.Run("additional values files take precedence", func(t *testing.T) {
+		fs := newFs(t)
+		assert.NoError(t, afero.WriteFile(fs, "override.yaml", []byte(`{alerting: {enabled: false}}`), 0400))
+
+		mani, errs := LoadManifest(&LoaderContext{
+			Fs:           fs,
+			ManifestPath: "project/manifest.yaml",
+			Opts:         LoaderOptions{ValuesFiles: []string{"override.yaml"}},
+		})
+		assert.Empty(t, errs)
+
+		assert.Equal(t, map[string]interface{}{"threshold": 20, "enabled": false}, mani.Environments["c"].Values["alerting"])
+		assert.Equal(t, map[string]interface{}{"threshold": 10, "enabled": false}, mani.Environments["f"].Values["alerting"])
+	})
+
+	t.Run("missing values file of environment", func(t *testing.T) {
+		fs := newFs(t)
+		assert.NoError(t, fs.Remove("project/values/c.yaml"))
+
+		_, errs := LoadManifest(&LoaderContext{
+			Fs:           fs,
+			ManifestPath: "project/manifest.yaml",
+		})
+		assert.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], `:b:c: failed to read values file "values/c.yaml"`)
+	})
+
+	t.Run("missing additional values file", func(t *testing.T) {
+		_, errs := LoadManifest(&LoaderContext{
+			Fs:           newFs(t),
+			ManifestPath: "project/manifest.yaml",
+			Opts:         LoaderOptions{ValuesFiles: []string{"missing.yaml"}},
+		})
+		assert.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], `failed to read values file "missing.yaml"`)
+	})
+
+	t.Run("values file not containing a map", func(t *testing.T) {
+		fs := newFs(t)
+		assert.NoError(t, afero.WriteFile(fs, "project/values/c.yaml", []byte(`[a, b]`), 0400))
+
+		_, errs := LoadManifest(&LoaderContext{
+			Fs:           fs,
+			ManifestPath: "project/manifest.yaml",
+		})
+		assert.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], `values file "values/c.yaml" must contain a map of values`)
+	})
+}
+
+func TestLoadManifest_Plugins(t *testing.T) {
+	t.Setenv("e", "mock token")
+
+	load := func(t *testing.T, plugins string) (Manifest, []error) {
+		fs := afero.NewMemMapFs()
+		assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}}]}]
+`+plugins), 0400))
+
+		return LoadManifest(&LoaderContext{
+			Fs:           fs,
+			ManifestPath: "manifest.yaml",
+		})
+	}
+
+	t.Run("plugins are loaded", func(t *testing.T) {
+		mani, errs := load(t, `
+plugins:
+- name: cmdb
+  command: ./plugins/cmdb
+  args: [--region, eu]
+  timeout: 5s
+- name: vault
+  command: vault-plugin
+`)
+		assert.Empty(t, errs)
+		assert.Equal(t, map[string]PluginDefinition{
+			"cmdb":  {Name: "cmdb", Command: "./plugins/cmdb", Args: []string{"--region", "eu"}, Timeout: 5 * time.Second},
+			"vault": {Name: "vault", Command: "vault-plugin"},
+		}, mani.Plugins)
+	})
+
+	tests := []struct {
+		name    string
+		plugins string
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			plugins: `plugins: [{command: x}]`,
+			wantErr: "missing plugin name on index `0`",
+		},
+		{
+			name:    "duplicated name",
+			plugins: `plugins: [{name: x, command: x}, {name: x, command: y}]`,
+			wantErr: `duplicated plugin name "x"`,
+		},
+		{
+			name:    "missing command",
+			plugins: `plugins: [{name: x}]`,
+			wantErr: `missing command of plugin "x"`,
+		},
+		{
+			name:    "invalid timeout",
+			plugins: `plugins: [{name: x, command: x, timeout: -1s}]`,
+			wantErr: "`timeout` \"-1s\" of plugin \"x\" is not a valid positive duration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := load(t, tt.plugins)
+			assert.Len(t, errs, 1)
+			assert.ErrorContains(t, errs[0], tt.wantErr)
+		})
+	}
+}
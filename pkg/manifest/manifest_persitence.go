@@ -55,6 +55,36 @@ type environment struct {
 
 	// Auth contains all authentication related information
 	Auth auth `yaml:"auth,omitempty"`
+
+	// Options contains optional settings of the HTTP clients used for this environment
+	Options *environmentOptions `yaml:"options,omitempty"`
+
+	// Account is the name of the account the environment belongs to
+	Account string `yaml:"account,omitempty"`
+
+	// Values lists the values files of the environment, relative to the manifest. They take precedence over the values of the group.
+	Values []string `yaml:"values,omitempty"`
+}
+
+type account struct {
+	Name        string `yaml:"name"`
+	AccountUUID string `yaml:"accountUUID"`
+	ApiURL      *url   `yaml:"apiUrl,omitempty"`
+	OAuth       oAuth  `yaml:"oAuth"`
+}
+
+type environmentOptions struct {
+	ConcurrentRequests int           `yaml:"concurrentRequests,omitempty"`
+	RequestsPerSecond  float64       `yaml:"requestsPerSecond,omitempty"`
+	Retry              *retryOptions `yaml:"retry,omitempty"`
+	Proxy              string        `yaml:"proxy,omitempty"`
+	CAFile             string        `yaml:"caFile,omitempty"`
+	RequestTimeout     string        `yaml:"requestTimeout,omitempty"`
+}
+
+type retryOptions struct {
+	WaitTime   string `yaml:"waitTime,omitempty"`
+	MaxRetries int    `yaml:"maxRetries,omitempty"`
 }
 
 type urlType string
@@ -72,10 +102,22 @@ type url struct {
 type group struct {
 	Name         string        `yaml:"name"`
 	Environments []environment `yaml:"environments"`
+
+	// Values lists the values files shared by all environments of the group, relative to the manifest
+	Values []string `yaml:"values,omitempty"`
+}
+
+type plugin struct {
+	Name    string   `yaml:"name"`
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args,omitempty"`
+	Timeout string   `yaml:"timeout,omitempty"`
 }
 
 type manifest struct {
 	ManifestVersion   string    `yaml:"manifestVersion"`
 	Projects          []project `yaml:"projects"`
 	EnvironmentGroups []group   `yaml:"environmentGroups"`
+	Accounts          []account `yaml:"accounts,omitempty"`
+	Plugins           []plugin  `yaml:"plugins,omitempty"`
 }
@@ -17,7 +17,10 @@ package manifest
 import (
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/spf13/afero"
@@ -66,12 +69,14 @@ func WriteManifest(context *WriterContext, manifestToWrite Manifest) error {
 	}
 
 	projects := toWriteableProjects(manifestToWrite.Projects)
-	groups := toWriteableEnvironmentGroups(manifestToWrite.Environments)
+	groups := toWriteableEnvironmentGroups(manifestToWrite.Environments, folder)
 
 	m := manifest{
 		ManifestVersion:   version.ManifestVersion,
 		Projects:          projects,
 		EnvironmentGroups: groups,
+		Accounts:          toWriteableAccounts(manifestToWrite.Accounts),
+		Plugins:           toWriteablePlugins(manifestToWrite.Plugins),
 	}
 
 	return persistManifestToDisk(context, m)
@@ -137,26 +142,57 @@ func extractGroupedProjectDetails(projectDefinition ProjectDefinition) (groupNam
 	return groupName, groupPath
 }
 
-func toWriteableEnvironmentGroups(environments map[string]EnvironmentDefinition) (result []group) {
+// toWriteableEnvironmentGroups converts the given environments to their persisted form. Relative paths are written
+// relative to the given folder the manifest is written to.
+func toWriteableEnvironmentGroups(environments map[string]EnvironmentDefinition, folder string) (result []group) {
 	environmentPerGroup := make(map[string][]environment)
+	valuesPerGroup := make(map[string][]string)
 
 	for name, env := range environments {
 		e := environment{
-			Name: name,
-			URL:  toWriteableURL(env),
-			Auth: getAuth(env),
+			Name:    name,
+			URL:     toWriteableURL(env),
+			Auth:    getAuth(env),
+			Options: getOptions(env.Options, folder),
+			Account: env.Account,
+			Values:  env.ValuesFiles,
 		}
 
 		environmentPerGroup[env.Group] = append(environmentPerGroup[env.Group], e)
+		if len(env.GroupValuesFiles) > 0 {
+			valuesPerGroup[env.Group] = env.GroupValuesFiles
+		}
 	}
 
 	for g, envs := range environmentPerGroup {
-		result = append(result, group{Name: g, Environments: envs})
+		result = append(result, group{Name: g, Environments: envs, Values: valuesPerGroup[g]})
 	}
 
 	return result
 }
 
+func toWriteableAccounts(accounts map[string]Account) (result []account) {
+	names := maps.Keys(accounts)
+	slices.Sort(names)
+
+	for _, name := range names {
+		a := accounts[name]
+
+		var apiURL *url
+		if a.ApiURL != nil {
+			apiURL = toWriteableURLDefinition(*a.ApiURL)
+		}
+
+		result = append(result, account{
+			Name:        name,
+			AccountUUID: a.AccountUUID,
+			ApiURL:      apiURL,
+			OAuth:       *getOAuthCredentials(&a.OAuth),
+		})
+	}
+	return result
+}
+
 func getAuth(env EnvironmentDefinition) auth {
 	return auth{
 		Token: getTokenSecret(env.Auth, env.Name),
@@ -164,6 +200,32 @@ func getAuth(env EnvironmentDefinition) auth {
 	}
 }
 
+func getOptions(o EnvironmentOptions, folder string) *environmentOptions {
+	if reflect.DeepEqual(o, EnvironmentOptions{}) {
+		return nil
+	}
+
+	opts := environmentOptions{
+		ConcurrentRequests: o.ConcurrentRequests,
+		RequestsPerSecond:  o.RequestsPerSecond,
+		Proxy:              o.ProxyURL,
+		CAFile:             toManifestRelativePath(o.CAFile, folder),
+	}
+
+	if o.RequestTimeout > 0 {
+		opts.RequestTimeout = o.RequestTimeout.String()
+	}
+
+	if o.Retry != nil {
+		opts.Retry = &retryOptions{MaxRetries: o.Retry.MaxRetries}
+		if o.Retry.WaitTime > 0 {
+			opts.Retry.WaitTime = o.Retry.WaitTime.String()
+		}
+	}
+
+	return &opts
+}
+
 func toWriteableURL(environment EnvironmentDefinition) url {
 	if environment.URL.Type == EnvironmentURLType {
 		return url{
@@ -199,17 +261,7 @@ func getOAuthCredentials(a *OAuth) *oAuth {
 
 	var te *url
 	if a.TokenEndpoint != nil {
-		switch a.TokenEndpoint.Type {
-		case ValueURLType:
-			te = &url{
-				Value: a.TokenEndpoint.Value,
-			}
-		case EnvironmentURLType:
-			te = &url{
-				Type:  urlTypeEnvironment,
-				Value: a.TokenEndpoint.Name,
-			}
-		}
+		te = toWriteableURLDefinition(*a.TokenEndpoint)
 	}
 
 	return &oAuth{
@@ -224,3 +276,56 @@ func getOAuthCredentials(a *OAuth) *oAuth {
 		TokenEndpoint: te,
 	}
 }
+
+// toWriteableURLDefinition returns the url of the given definition, which is either an environment variable or a value
+func toWriteableURLDefinition(u URLDefinition) *url {
+	switch u.Type {
+	case ValueURLType:
+		return &url{
+			Value: u.Value,
+		}
+	case EnvironmentURLType:
+		return &url{
+			Type:  urlTypeEnvironment,
+			Value: u.Name,
+		}
+	default:
+		return nil
+	}
+}
+
+func toWriteablePlugins(plugins map[string]PluginDefinition) (result []plugin) {
+	names := maps.Keys(plugins)
+	slices.Sort(names)
+
+	for _, name := range names {
+		p := plugins[name]
+
+		var timeout string
+		if p.Timeout > 0 {
+			timeout = p.Timeout.String()
+		}
+
+		result = append(result, plugin{
+			Name:    p.Name,
+			Command: p.Command,
+			Args:    p.Args,
+			Timeout: timeout,
+		})
+	}
+	return result
+}
+
+// toManifestRelativePath returns the given path relative to the folder the manifest is written to. Absolute paths and
+// paths that can't be made relative are written as they are.
+func toManifestRelativePath(path string, folder string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+
+	rel, err := filepath.Rel(folder, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -19,10 +19,13 @@ package manifest
 import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/oauth2/endpoints"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/spf13/afero"
 	"gotest.tools/assert"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 )
 
 func Test_toWriteableProjects(t *testing.T) {
@@ -349,7 +352,7 @@ func Test_toWriteableEnvironmentGroups(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if gotResult := toWriteableEnvironmentGroups(tt.input); gotResult != nil {
+			if gotResult := toWriteableEnvironmentGroups(tt.input, "."); gotResult != nil {
 				assert.Equal(t, len(gotResult), len(tt.wantResult))
 
 				// sort Entries sub-slices before checking equality of got and wanted group slices
@@ -483,3 +486,138 @@ func Test_toWritableToken(t *testing.T) {
 		})
 	}
 }
+
+func Test_getOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		input EnvironmentOptions
+		want  *environmentOptions
+	}{
+		{
+			"no options are written if none are defined",
+			EnvironmentOptions{},
+			nil,
+		},
+		{
+			"correctly transforms all options",
+			EnvironmentOptions{
+				ConcurrentRequests: 10,
+				RequestsPerSecond:  2.5,
+				Retry:              &RetryOptions{WaitTime: 2 * time.Second, MaxRetries: 5},
+				ProxyURL:           "http://proxy.example.com:8080",
+				CAFile:             filepath.Join("project", "certs", "ca.pem"),
+				CACertificates:     []byte("cert"),
+				RequestTimeout:     time.Minute,
+			},
+			&environmentOptions{
+				ConcurrentRequests: 10,
+				RequestsPerSecond:  2.5,
+				Retry:              &retryOptions{WaitTime: "2s", MaxRetries: 5},
+				Proxy:              "http://proxy.example.com:8080",
+				CAFile:             "certs/ca.pem",
+				RequestTimeout:     "1m0s",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getOptions(tt.input, "project"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toWriteableAccounts(t *testing.T) {
+	accounts := map[string]Account{
+		"b": {
+			Name:        "b",
+			AccountUUID: "uuid-b",
+			OAuth: OAuth{
+				ClientID:     AuthSecret{Name: "B_CLIENT_ID", Value: "id"},
+				ClientSecret: AuthSecret{Name: "B_CLIENT_SECRET", Value: "secret"},
+			},
+		},
+		"a": {
+			Name:        "a",
+			AccountUUID: "uuid-a",
+			ApiURL:      &URLDefinition{Type: EnvironmentURLType, Name: "API_URL", Value: "https://api.example.com"},
+			OAuth: OAuth{
+				ClientID:      AuthSecret{Name: "A_CLIENT_ID", Value: "id"},
+				ClientSecret:  AuthSecret{Name: "A_CLIENT_SECRET", Value: "secret"},
+				TokenEndpoint: &URLDefinition{Type: ValueURLType, Value: "https://sso.example.com"},
+			},
+		},
+	}
+
+	want := []account{
+		{
+			Name:        "a",
+			AccountUUID: "uuid-a",
+			ApiURL:      &url{Type: urlTypeEnvironment, Value: "API_URL"},
+			OAuth: oAuth{
+				ClientID:      authSecret{Type: typeEnvironment, Name: "A_CLIENT_ID"},
+				ClientSecret:  authSecret{Type: typeEnvironment, Name: "A_CLIENT_SECRET"},
+				TokenEndpoint: &url{Value: "https://sso.example.com"},
+			},
+		},
+		{
+			Name:        "b",
+			AccountUUID: "uuid-b",
+			OAuth: oAuth{
+				ClientID:     authSecret{Type: typeEnvironment, Name: "B_CLIENT_ID"},
+				ClientSecret: authSecret{Type: typeEnvironment, Name: "B_CLIENT_SECRET"},
+			},
+		},
+	}
+
+	assert.DeepEqual(t, want, toWriteableAccounts(accounts))
+	assert.Assert(t, toWriteableAccounts(nil) == nil)
+}
+
+func TestWriteManifest_RoundTrip(t *testing.T) {
+	t.Setenv("TOKEN", "mock token")
+
+	absoluteCAFile := filepath.Join(t.TempDir(), "ca.pem")
+
+	fs := afero.NewMemMapFs()
+	assert.NilError(t, afero.WriteFile(fs, "project/certs/ca.pem", []byte("cert"), 0400))
+	assert.NilError(t, afero.WriteFile(fs, absoluteCAFile, []byte("absolute cert"), 0400))
+	assert.NilError(t, afero.WriteFile(fs, "project/values/group.yaml", []byte("owner: team-a"), 0400))
+	assert.NilError(t, afero.WriteFile(fs, "project/values/c.yaml", []byte("threshold: 20"), 0400))
+	assert.NilError(t, afero.WriteFile(fs, "project/manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a}]
+environmentGroups:
+- name: b
+  values: [values/group.yaml]
+  environments:
+  - name: c
+    url: {value: "https://c.example.com"}
+    auth: {token: {name: TOKEN}}
+    options: {caFile: certs/ca.pem}
+    values: [values/c.yaml]
+  - name: d
+    url: {value: "https://d.example.com"}
+    auth: {token: {name: TOKEN}}
+    options: {caFile: "`+filepath.ToSlash(absoluteCAFile)+`"}
+`), 0400))
+
+	loaded, errs := LoadManifest(&LoaderContext{Fs: fs, ManifestPath: "project/manifest.yaml"})
+	assert.Assert(t, len(errs) == 0, errs)
+
+	assert.NilError(t, WriteManifest(&WriterContext{Fs: fs, ManifestPath: "project/manifest.yaml"}, loaded))
+
+	reloaded, errs := LoadManifest(&LoaderContext{Fs: fs, ManifestPath: "project/manifest.yaml"})
+	assert.Assert(t, len(errs) == 0, errs)
+
+	assert.DeepEqual(t, loaded.Environments["c"].Options, reloaded.Environments["c"].Options)
+	assert.Equal(t, filepath.Join("project", "certs", "ca.pem"), reloaded.Environments["c"].Options.CAFile)
+	assert.DeepEqual(t, loaded.Environments["d"].Options, reloaded.Environments["d"].Options)
+	assert.Equal(t, absoluteCAFile, reloaded.Environments["d"].Options.CAFile)
+
+	assert.DeepEqual(t, map[string]interface{}{"owner": "team-a", "threshold": 20}, reloaded.Environments["c"].Values)
+	assert.DeepEqual(t, []string{"values/c.yaml"}, reloaded.Environments["c"].ValuesFiles)
+	assert.DeepEqual(t, map[string]interface{}{"owner": "team-a"}, reloaded.Environments["d"].Values)
+	assert.DeepEqual(t, []string{"values/group.yaml"}, reloaded.Environments["d"].GroupValuesFiles)
+}
@@ -0,0 +1,134 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifest
+
+import (
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+)
+
+// valuesLoader loads and merges the values files referenced by groups and environments of a manifest, as well as
+// additional values files passed via [LoaderOptions.ValuesFiles]. Each file is only read once.
+type valuesLoader struct {
+	// manifestFs resolves paths relative to the manifest
+	manifestFs afero.Fs
+	// fs resolves the paths of additional values files
+	fs afero.Fs
+	// additional holds the merged values of all additional values files. They are applied on top of all others.
+	additional map[string]interface{}
+	cache      map[string]map[string]interface{}
+}
+
+func newValuesLoader(manifestFs afero.Fs, fs afero.Fs, additionalFiles []string) (*valuesLoader, error) {
+	l := &valuesLoader{
+		manifestFs: manifestFs,
+		fs:         fs,
+		cache:      make(map[string]map[string]interface{}),
+	}
+
+	additional, err := l.loadAll(fs, additionalFiles, nil)
+	if err != nil {
+		return nil, err
+	}
+	l.additional = additional
+
+	return l, nil
+}
+
+// valuesFor returns the values of an environment. Values of the group are overwritten by values of the environment,
+// which are in turn overwritten by the additional values files. Files listed later take precedence over earlier ones.
+func (l *valuesLoader) valuesFor(g group, env environment) (map[string]interface{}, error) {
+	values, err := l.loadAll(l.manifestFs, g.Values, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err = l.loadAll(l.manifestFs, env.Values, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeValues(values, l.additional), nil
+}
+
+func (l *valuesLoader) loadAll(fs afero.Fs, paths []string, values map[string]interface{}) (map[string]interface{}, error) {
+	for _, p := range paths {
+		v, err := l.load(fs, p)
+		if err != nil {
+			return nil, err
+		}
+		values = mergeValues(values, v)
+	}
+	return values, nil
+}
+
+func (l *valuesLoader) load(fs afero.Fs, path string) (map[string]interface{}, error) {
+	key := fmt.Sprintf("%p:%s", fs, path)
+	if v, found := l.cache[key]; found {
+		return v, nil
+	}
+
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read values file %q: %w", path, err)
+	}
+
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse values file %q: %w", path, err)
+	}
+
+	var values map[string]interface{}
+	if raw != nil {
+		m, ok := maps.NormalizeKeys(raw).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("values file %q must contain a map of values", path)
+		}
+		values = m
+	}
+
+	l.cache[key] = values
+	return values, nil
+}
+
+// mergeValues returns a new map containing base overwritten by override. Nested maps are merged, all other values
+// are replaced. Neither base nor override are modified.
+func mergeValues(base, override map[string]interface{}) map[string]interface{} {
+	if base == nil && override == nil {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(base)+len(override))
+	for k, v := range base {
+		result[k] = v
+	}
+
+	for k, v := range override {
+		baseMap, baseIsMap := result[k].(map[string]interface{})
+		overrideMap, overrideIsMap := v.(map[string]interface{})
+		if baseIsMap && overrideIsMap {
+			result[k] = mergeValues(baseMap, overrideMap)
+		} else {
+			result[k] = v
+		}
+	}
+
+	return result
+}
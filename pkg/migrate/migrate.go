@@ -0,0 +1,344 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package migrate converts configurations of deprecated classic APIs into configurations of the Settings 2.0 schemas
+// replacing them.
+package migrate
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	compoundParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
+	pluginParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/plugin"
+	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/persistence"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"path/filepath"
+	"strings"
+)
+
+// Options define which configurations are migrated
+type Options struct {
+	// APIs are the classic APIs to migrate configurations of. If empty, all supported APIs are migrated.
+	APIs []string
+	// Projects are the projects to migrate configurations of. If empty, configurations of all projects are migrated.
+	Projects []string
+}
+
+// Validate returns an error if any of the given APIs can not be migrated
+func (o Options) Validate() error {
+	for _, a := range o.APIs {
+		if _, found := transformations[a]; !found {
+			return fmt.Errorf("configurations of API %q can not be migrated, supported APIs are %v", a, SupportedAPIs())
+		}
+	}
+	return nil
+}
+
+func (o Options) migrates(c config.Config) bool {
+	return (len(o.APIs) == 0 || slices.Contains(o.APIs, c.Coordinate.Type)) &&
+		(len(o.Projects) == 0 || slices.Contains(o.Projects, c.Coordinate.Project))
+}
+
+// SupportedAPIs returns the sorted IDs of all classic APIs configurations can be migrated from
+func SupportedAPIs() []string {
+	ids := maps.Keys(transformations)
+	slices.Sort(ids)
+	return ids
+}
+
+// Result of a migration
+type Result struct {
+	// Projects are the given projects with the migrated configurations, and all references to them rewritten to their
+	// new coordinates
+	Projects []project.Project
+	// Migrated holds the classic configurations which were replaced, for all environments
+	Migrated []config.Config
+	// Failed holds the reasons why configurations could not be migrated. These configurations are kept unchanged.
+	Failed []error
+}
+
+// Migrate converts the configurations of the given projects which use deprecated classic APIs into configurations of
+// the Settings 2.0 schemas replacing them. A configuration is either migrated for all environments, or for none, if
+// its payload can not be transformed for any of them. References to migrated configurations are rewritten.
+func Migrate(projects []project.Project, apis api.APIs, opts Options) Result {
+	candidates := make(map[coordinate.Coordinate][]config.Config)
+	existing := make(map[coordinate.Coordinate]struct{})
+	for _, p := range projects {
+		for _, configsPerType := range p.Configs {
+			for _, configs := range configsPerType {
+				for _, c := range configs {
+					existing[c.Coordinate] = struct{}{}
+					if _, found := transformations[c.Coordinate.Type]; found && opts.migrates(c) {
+						candidates[c.Coordinate] = append(candidates[c.Coordinate], c)
+					}
+				}
+			}
+		}
+	}
+
+	coordinates := maps.Keys(candidates)
+	slices.SortFunc(coordinates, func(a, b coordinate.Coordinate) bool { return a.String() < b.String() })
+
+	var result Result
+	targets := make(map[coordinate.Coordinate]coordinate.Coordinate)
+	migrated := make(map[coordinate.Coordinate]map[string]config.Config)
+
+	for _, coord := range coordinates {
+		a, found := apis[coord.Type]
+		if !found || a.DeprecatedBy == "" {
+			continue
+		}
+
+		target := coordinate.Coordinate{Project: coord.Project, Type: a.DeprecatedBy, ConfigId: coord.ConfigId}
+		if _, found := existing[target]; found {
+			result.Failed = append(result.Failed, fmt.Errorf("failed to migrate %s: config %s already exists", coord, target))
+			continue
+		}
+
+		perEnvironment, err := migrateConfigs(candidates[coord], target)
+		if err != nil {
+			result.Failed = append(result.Failed, fmt.Errorf("failed to migrate %s: %w", coord, err))
+			continue
+		}
+
+		targets[coord] = target
+		migrated[coord] = perEnvironment
+	}
+
+	result.Failed = append(result.Failed, keepReferencedConfigs(projects, targets)...)
+
+	for _, coord := range coordinates {
+		if target, found := targets[coord]; found {
+			result.Migrated = append(result.Migrated, candidates[coord]...)
+			log.WithFields(field.Coordinate(coord)).Debug("Migrated %s to %s", coord, target)
+		}
+	}
+
+	for _, p := range projects {
+		configsPerEnvironment := make(project.ConfigsPerTypePerEnvironments, len(p.Configs))
+		for environment, configsPerType := range p.Configs {
+			configsPerEnvironment[environment] = make(project.ConfigsPerType)
+			for _, configs := range configsPerType {
+				for _, c := range configs {
+					if _, found := targets[c.Coordinate]; found {
+						c = migrated[c.Coordinate][environment]
+					}
+					c = rewriteReferences(c, targets)
+					configsPerEnvironment[environment][c.Coordinate.Type] = append(configsPerEnvironment[environment][c.Coordinate.Type], c)
+				}
+			}
+		}
+		p.Configs = configsPerEnvironment
+		result.Projects = append(result.Projects, p)
+	}
+
+	return result
+}
+
+// keepReferencedConfigs removes all configs from the given migration targets which are still referenced in a way that
+// can not be rewritten. These are references by parameters which don't support rewriting their references, and
+// references to the ID by configs which are not migrated, as these expect the ID of the classic object. Management zones
+// are the exception, if their Settings 2.0 objects are referenced by their numeric ID.
+func keepReferencedConfigs(projects []project.Project, targets map[coordinate.Coordinate]coordinate.Coordinate) []error {
+	var errs []error
+	for changed := true; changed; {
+		changed = false
+		for _, p := range projects {
+			for _, configsPerType := range p.Configs {
+				for _, configs := range configsPerType {
+					for _, c := range configs {
+						_, migrated := targets[c.Coordinate]
+
+						for name, param := range c.Parameters {
+							_, rewritable := rewriteParameter(name, param, targets)
+
+							for _, ref := range param.GetReferences() {
+								target, found := targets[ref.Config]
+								if !found {
+									continue
+								}
+
+								switch {
+								case !rewritable:
+									errs = append(errs, fmt.Errorf("failed to migrate %s: parameter %q of config %s references it and can not be rewritten", ref.Config, name, c.Coordinate))
+								case !migrated && ref.Property == config.IdParameter && !keepsNumericID(target):
+									errs = append(errs, fmt.Errorf("failed to migrate %s: config %s references its ID and is not migrated", ref.Config, c.Coordinate))
+								default:
+									continue
+								}
+								delete(targets, ref.Config)
+								changed = true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func keepsNumericID(target coordinate.Coordinate) bool {
+	return target.Type == "builtin:management-zones" && featureflags.ManagementZoneSettingsNumericIDs().Enabled()
+}
+
+// migrateConfigs migrates the given configs of all environments to the given coordinate
+func migrateConfigs(configs []config.Config, target coordinate.Coordinate) (map[string]config.Config, error) {
+	result := make(map[string]config.Config, len(configs))
+	for _, c := range configs {
+		m, err := migrateConfig(c, target)
+		if err != nil {
+			return nil, fmt.Errorf("environment %q: %w", c.Environment, err)
+		}
+		result[c.Environment] = m
+	}
+	return result, nil
+}
+
+func migrateConfig(c config.Config, target coordinate.Coordinate) (config.Config, error) {
+	payload, a, err := parseTemplate(c.Template.Content())
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	value, err := transformations[c.Coordinate.Type](payload)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	content, err := a.render(value)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	parameters := make(config.Parameters, len(c.Parameters)+1)
+	for name, p := range c.Parameters {
+		parameters[name] = p
+	}
+	parameters[config.ScopeParameter] = valueParam.New("environment")
+
+	// templates are named after the original file, so that configurations sharing a template keep sharing it
+	name := filepath.Base(c.Template.Name())
+	id := strings.TrimSuffix(name, filepath.Ext(name))
+
+	c.Template = template.NewDownloadTemplate(id, name, content)
+	c.Coordinate = target
+	c.Type = config.SettingsType{SchemaId: target.Type}
+	c.Parameters = parameters
+	c.OriginObjectId = ""
+	return c, nil
+}
+
+// rewriteReferences returns the given config with all references and dependencies on renamed configs pointing to their
+// new coordinates
+func rewriteReferences(c config.Config, renamed map[coordinate.Coordinate]coordinate.Coordinate) config.Config {
+	parameters := make(config.Parameters, len(c.Parameters))
+	for name, p := range c.Parameters {
+		if rewritten, ok := rewriteParameter(name, p, renamed); ok {
+			p = rewritten
+		}
+		parameters[name] = p
+	}
+	c.Parameters = parameters
+
+	dependsOn := make([]coordinate.Coordinate, 0, len(c.DependsOn))
+	for _, d := range c.DependsOn {
+		if target, found := renamed[d]; found {
+			d = target
+		}
+		dependsOn = append(dependsOn, d)
+	}
+	if len(dependsOn) > 0 {
+		c.DependsOn = dependsOn
+	}
+	return c
+}
+
+// rewriteParameter returns the given parameter with all references to renamed configs pointing to their new
+// coordinates. It returns false if the parameter references a renamed config, but its type does not support rewriting
+// references.
+func rewriteParameter(name string, p parameter.Parameter, renamed map[coordinate.Coordinate]coordinate.Coordinate) (parameter.Parameter, bool) {
+	references := p.GetReferences()
+	rewritten := make([]parameter.ParameterReference, len(references))
+	changed := false
+	for i, ref := range references {
+		if target, found := renamed[ref.Config]; found {
+			ref.Config = target
+			changed = true
+		}
+		rewritten[i] = ref
+	}
+	if !changed {
+		return p, true
+	}
+
+	switch p := p.(type) {
+	case *refParam.ReferenceParameter:
+		return refParam.NewWithCoordinate(rewritten[0].Config, rewritten[0].Property), true
+	case *compoundParam.CompoundParameter:
+		compound, err := compoundParam.New(name, p.GetFormatString(), rewritten)
+		if err != nil {
+			return nil, false
+		}
+		return compound, true
+	case *pluginParam.PluginParameter:
+		plugin := *p
+		plugin.References = rewritten
+		return &plugin, true
+	default:
+		return nil, false
+	}
+}
+
+// DeleteEntries returns the delete entries of the classic objects replaced by the migrated configurations. Objects
+// are identified by the name of their config, which needs to be a plain value to be resolved.
+func (r Result) DeleteEntries() []persistence.DeleteEntry {
+	var entries []persistence.DeleteEntry
+	for _, c := range r.Migrated {
+		p, found := c.Parameters[config.NameParameter]
+		if !found {
+			continue
+		}
+
+		val, err := p.ResolveValue(parameter.ResolveContext{ParameterName: config.NameParameter})
+		if err != nil {
+			log.WithFields(field.Coordinate(c.Coordinate), field.Error(err)).Warn("Failed to create delete entry for %q - unable to get name: %v", c.Coordinate, err)
+			continue
+		}
+		name, ok := val.(string)
+		if !ok {
+			log.WithFields(field.Coordinate(c.Coordinate)).Warn("Failed to create delete entry for %q - value of 'name' parameter '%v' was not a string", c.Coordinate, val)
+			continue
+		}
+
+		entry := persistence.DeleteEntry{Type: c.Coordinate.Type, ConfigName: name}
+		if !slices.Contains(entries, entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
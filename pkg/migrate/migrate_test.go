@@ -0,0 +1,246 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	compoundParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
+	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/persistence"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	zone := coordinate.Coordinate{Project: "p", Type: "management-zone", ConfigId: "zone"}
+	dashboard := coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "dashboard"}
+	jira := coordinate.Coordinate{Project: "p", Type: "notification", ConfigId: "jira"}
+
+	projects := []project.Project{
+		{
+			Id: "p",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"dev": {
+					"management-zone": {
+						{
+							Coordinate:  zone,
+							Type:        config.ClassicApiType{Api: "management-zone"},
+							Environment: "dev",
+							Template:    template.CreateTemplateFromString("p/management-zone/zone.json", `{"name": "{{.name}}", "rules": []}`),
+							Parameters:  config.Parameters{config.NameParameter: valueParam.New("Zone")},
+						},
+					},
+					"dashboard": {
+						{
+							Coordinate:  dashboard,
+							Type:        config.ClassicApiType{Api: "dashboard"},
+							Environment: "dev",
+							Template:    template.CreateTemplateFromString("p/dashboard/dashboard.json", `{}`),
+							Parameters: config.Parameters{
+								config.NameParameter: valueParam.New("Dashboard"),
+								"zoneId":             refParam.NewWithCoordinate(zone, "id"),
+							},
+							DependsOn: []coordinate.Coordinate{zone},
+						},
+					},
+					"notification": {
+						{
+							Coordinate:  jira,
+							Type:        config.ClassicApiType{Api: "notification"},
+							Environment: "dev",
+							Template:    template.CreateTemplateFromString("p/notification/jira.json", `{"type": "JIRA"}`),
+							Parameters:  config.Parameters{config.NameParameter: valueParam.New("Jira")},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result := Migrate(projects, api.NewAPIs(), Options{})
+
+	require.Len(t, result.Failed, 1)
+	assert.ErrorContains(t, result.Failed[0], `failed to migrate p:notification:jira: environment "dev": unsupported notification type "JIRA"`)
+
+	require.Len(t, result.Migrated, 1)
+	assert.Equal(t, zone, result.Migrated[0].Coordinate)
+	assert.Equal(t, []persistence.DeleteEntry{{Type: "management-zone", ConfigName: "Zone"}}, result.DeleteEntries())
+
+	require.Len(t, result.Projects, 1)
+	configs := result.Projects[0].Configs["dev"]
+	assert.NotContains(t, configs, "management-zone")
+	assert.Len(t, configs["notification"], 1, "config which failed to migrate is kept")
+
+	require.Len(t, configs["builtin:management-zones"], 1)
+	migrated := configs["builtin:management-zones"][0]
+	migratedZone := coordinate.Coordinate{Project: "p", Type: "builtin:management-zones", ConfigId: "zone"}
+	assert.Equal(t, migratedZone, migrated.Coordinate)
+	assert.Equal(t, config.SettingsType{SchemaId: "builtin:management-zones"}, migrated.Type)
+	assert.Equal(t, valueParam.New("environment"), migrated.Parameters[config.ScopeParameter])
+	assert.Equal(t, valueParam.New("Zone"), migrated.Parameters[config.NameParameter])
+	assert.Equal(t, "zone", migrated.Template.Id())
+	assert.JSONEq(t, `{"name": "{{.name}}", "rules": []}`, migrated.Template.Content())
+
+	require.Len(t, configs["dashboard"], 1)
+	assert.Equal(t, refParam.NewWithCoordinate(migratedZone, "id"), configs["dashboard"][0].Parameters["zoneId"])
+	assert.Equal(t, []coordinate.Coordinate{migratedZone}, configs["dashboard"][0].DependsOn)
+
+	_, unchanged := projects[0].Configs["dev"]["management-zone"]
+	assert.True(t, unchanged, "given projects are not modified")
+}
+
+func TestMigrate_Options(t *testing.T) {
+	zone := coordinate.Coordinate{Project: "p", Type: "management-zone", ConfigId: "zone"}
+	existing := coordinate.Coordinate{Project: "p", Type: "builtin:management-zones", ConfigId: "zone"}
+
+	projects := []project.Project{
+		{
+			Id: "p",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"dev": {
+					"management-zone": {
+						{Coordinate: zone, Environment: "dev", Template: template.CreateTemplateFromString("zone.json", `{"name": "zone"}`)},
+					},
+				},
+			},
+		},
+	}
+
+	result := Migrate(projects, api.NewAPIs(), Options{APIs: []string{"auto-tag"}})
+	assert.Empty(t, result.Migrated)
+	assert.Empty(t, result.Failed)
+
+	result = Migrate(projects, api.NewAPIs(), Options{Projects: []string{"other"}})
+	assert.Empty(t, result.Migrated)
+
+	projects[0].Configs["dev"]["builtin:management-zones"] = []config.Config{{Coordinate: existing, Environment: "dev"}}
+	result = Migrate(projects, api.NewAPIs(), Options{})
+	assert.Empty(t, result.Migrated)
+	require.Len(t, result.Failed, 1)
+	assert.ErrorContains(t, result.Failed[0], "config p:builtin:management-zones:zone already exists")
+
+	assert.ErrorContains(t, Options{APIs: []string{"dashboard"}}.Validate(), `configurations of API "dashboard" can not be migrated`)
+	assert.NoError(t, Options{APIs: []string{"management-zone", "notification"}}.Validate())
+}
+
+// opaqueParameter references configs, but does not support rewriting its references
+type opaqueParameter struct {
+	references []parameter.ParameterReference
+}
+
+func (p opaqueParameter) GetType() string { return "opaque" }
+
+func (p opaqueParameter) GetReferences() []parameter.ParameterReference { return p.references }
+
+func (p opaqueParameter) ResolveValue(parameter.ResolveContext) (interface{}, error) { return "", nil }
+
+func TestMigrate_References(t *testing.T) {
+	zone := coordinate.Coordinate{Project: "p", Type: "management-zone", ConfigId: "zone"}
+	migratedZone := coordinate.Coordinate{Project: "p", Type: "builtin:management-zones", ConfigId: "zone"}
+	dashboard := coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "dashboard"}
+	profile := coordinate.Coordinate{Project: "p", Type: "builtin:alerting.profile", ConfigId: "profile"}
+
+	newProjects := func(t *testing.T, dashboardParameters config.Parameters, profileParameters config.Parameters) []project.Project {
+		zoneName, err := compoundParam.New("zoneName", "{{ .name }} zone", []parameter.ParameterReference{{Config: zone, Property: config.NameParameter}})
+		require.NoError(t, err)
+
+		return []project.Project{
+			{
+				Id: "p",
+				Configs: project.ConfigsPerTypePerEnvironments{
+					"dev": {
+						"management-zone": {
+							{
+								Coordinate:  zone,
+								Type:        config.ClassicApiType{Api: "management-zone"},
+								Environment: "dev",
+								Template:    template.CreateTemplateFromString("p/management-zone/zone.json", `{"name": "{{.zoneName}}", "rules": []}`),
+								Parameters: config.Parameters{
+									config.NameParameter: valueParam.New("Zone"),
+									"zoneName":           zoneName,
+								},
+							},
+						},
+						"dashboard": {
+							{
+								Coordinate:  dashboard,
+								Type:        config.ClassicApiType{Api: "dashboard"},
+								Environment: "dev",
+								Template:    template.CreateTemplateFromString("p/dashboard/dashboard.json", `{}`),
+								Parameters:  dashboardParameters,
+							},
+						},
+						"builtin:alerting.profile": {
+							{
+								Coordinate:  profile,
+								Type:        config.SettingsType{SchemaId: "builtin:alerting.profile"},
+								Environment: "dev",
+								Template:    template.CreateTemplateFromString("p/profile/profile.json", `{}`),
+								Parameters:  profileParameters,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("references of compound parameters are rewritten", func(t *testing.T) {
+		dashboardTitle, err := compoundParam.New("title", "{{ .zone }} board", []parameter.ParameterReference{{Config: zone, Property: config.NameParameter}})
+		require.NoError(t, err)
+
+		result := Migrate(newProjects(t, config.Parameters{"title": dashboardTitle}, nil), api.NewAPIs(), Options{})
+		require.Empty(t, result.Failed)
+
+		configs := result.Projects[0].Configs["dev"]
+		require.Len(t, configs["builtin:management-zones"], 1)
+		assert.Equal(t, []parameter.ParameterReference{{Config: migratedZone, Property: config.NameParameter}}, configs["builtin:management-zones"][0].Parameters["zoneName"].GetReferences())
+
+		require.Len(t, configs["dashboard"], 1)
+		title := configs["dashboard"][0].Parameters["title"].(*compoundParam.CompoundParameter)
+		assert.Equal(t, []parameter.ParameterReference{{Config: migratedZone, Property: config.NameParameter}}, title.GetReferences())
+		assert.Equal(t, "{{ .zone }} board", title.GetFormatString())
+	})
+
+	t.Run("configs referenced by parameters which can't be rewritten are kept", func(t *testing.T) {
+		opaque := opaqueParameter{references: []parameter.ParameterReference{{Config: zone, Property: config.NameParameter}}}
+
+		result := Migrate(newProjects(t, config.Parameters{"opaque": opaque}, nil), api.NewAPIs(), Options{})
+		assert.Empty(t, result.Migrated)
+		require.Len(t, result.Failed, 1)
+		assert.ErrorContains(t, result.Failed[0], `failed to migrate p:management-zone:zone: parameter "opaque" of config p:dashboard:dashboard references it and can not be rewritten`)
+		assert.Equal(t, opaque, result.Projects[0].Configs["dev"]["dashboard"][0].Parameters["opaque"])
+	})
+
+	t.Run("configs whose ID is referenced by settings are kept", func(t *testing.T) {
+		t.Setenv("MONACO_FEAT_USE_MZ_NUMERIC_ID", "false")
+
+		result := Migrate(newProjects(t, nil, config.Parameters{"zoneId": refParam.NewWithCoordinate(zone, config.IdParameter)}), api.NewAPIs(), Options{})
+		assert.Empty(t, result.Migrated)
+		require.Len(t, result.Failed, 1)
+		assert.ErrorContains(t, result.Failed[0], "failed to migrate p:management-zone:zone: config p:builtin:alerting.profile:profile references its ID and is not migrated")
+	})
+}
@@ -0,0 +1,105 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// actions holds the Go template actions of a JSON template, which were replaced by placeholders for parsing
+type actions struct {
+	// inString are actions within JSON strings, replaced by a placeholder within the string
+	inString []string
+	// raw are actions outside JSON strings, e.g. for numbers, replaced by a placeholder string
+	raw []string
+}
+
+func inStringPlaceholder(i int) string {
+	return fmt.Sprintf("__MONACO_ACTION_%d__", i)
+}
+
+func rawPlaceholder(i int) string {
+	return fmt.Sprintf("__MONACO_RAW_ACTION_%d__", i)
+}
+
+// parseTemplate parses the given JSON template. As templates may contain Go template actions outside JSON strings,
+// which would be invalid JSON, all actions are replaced by placeholders before parsing.
+func parseTemplate(content string) (map[string]any, actions, error) {
+	var a actions
+	var b strings.Builder
+
+	inString, escaped := false, false
+	for i := 0; i < len(content); i++ {
+		if strings.HasPrefix(content[i:], "{{") {
+			end := strings.Index(content[i:], "}}")
+			if end < 0 {
+				return nil, actions{}, fmt.Errorf("unterminated template action at offset %d", i)
+			}
+			action := content[i : i+end+2]
+
+			if inString {
+				b.WriteString(inStringPlaceholder(len(a.inString)))
+				a.inString = append(a.inString, action)
+			} else {
+				b.WriteString(`"` + rawPlaceholder(len(a.raw)) + `"`)
+				a.raw = append(a.raw, action)
+			}
+			i += end + 1
+			continue
+		}
+
+		c := content[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		}
+		b.WriteByte(c)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(b.String()), &payload); err != nil {
+		return nil, actions{}, fmt.Errorf("template is not a valid JSON object: %w", err)
+	}
+	return payload, a, nil
+}
+
+// render returns the given payload as indented JSON, with placeholders replaced by the original template actions
+func (a actions) render(payload map[string]any) (string, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(payload); err != nil {
+		return "", err
+	}
+
+	result := b.String()
+	for i, action := range a.raw {
+		result = strings.ReplaceAll(result, `"`+rawPlaceholder(i)+`"`, action)
+	}
+	for i, action := range a.inString {
+		result = strings.ReplaceAll(result, inStringPlaceholder(i), action)
+	}
+	return result, nil
+}
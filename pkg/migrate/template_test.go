@@ -0,0 +1,61 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestParseTemplate(t *testing.T) {
+	content := `{"name": "{{.name}} ({{ .env }})", "enabled": {{ .enabled }}, "escaped": "a \"{{.quoted}}\" b", "list": [{{.first}}, 2]}`
+
+	payload, a, err := parseTemplate(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"name":    inStringPlaceholder(0) + " (" + inStringPlaceholder(1) + ")",
+		"enabled": rawPlaceholder(0),
+		"escaped": `a "` + inStringPlaceholder(2) + `" b`,
+		"list":    []any{rawPlaceholder(1), float64(2)},
+	}, payload)
+
+	rendered, err := a.render(map[string]any{"displayName": payload["name"], "active": payload["enabled"], "escaped": payload["escaped"], "list": payload["list"]})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"displayName": "{{.name}} ({{ .env }})", "active": true, "escaped": "a \"{{.quoted}}\" b", "list": [1, 2]}`,
+		replaceActions(rendered, map[string]string{"{{ .enabled }}": "true", "{{.first}}": "1"}))
+}
+
+func TestParseTemplate_Errors(t *testing.T) {
+	_, _, err := parseTemplate(`{"name": "{{.name}"}`)
+	assert.ErrorContains(t, err, "unterminated template action")
+
+	_, _, err = parseTemplate(`["a"]`)
+	assert.ErrorContains(t, err, "not a valid JSON object")
+}
+
+// replaceActions renders the given raw template actions, so that the result can be compared as JSON
+func replaceActions(s string, values map[string]string) string {
+	for action, value := range values {
+		s = strings.ReplaceAll(s, action, value)
+	}
+	return s
+}
@@ -0,0 +1,431 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// transformation converts the payload of a classic configuration into the value of the Settings 2.0 object replacing it.
+// Payload values may be placeholders of template actions, which are passed on unchanged.
+type transformation func(payload map[string]any) (map[string]any, error)
+
+// transformations holds the transformation of each classic API which configurations can be migrated from
+var transformations = map[string]transformation{
+	"management-zone":  managementZone,
+	"auto-tag":         autoTag,
+	"alerting-profile": alertingProfile,
+	"notification":     notification,
+}
+
+func managementZone(payload map[string]any) (map[string]any, error) {
+	var rules []any
+
+	for _, r := range list(payload, "rules") {
+		rule, err := attributeRule(asMap(r))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	for _, r := range list(payload, "dimensionalRules") {
+		classic := asMap(r)
+		rules = append(rules, map[string]any{
+			"enabled": classic["enabled"],
+			"type":    "DIMENSION",
+			"dimensionRule": map[string]any{
+				"appliesTo":  classic["appliesTo"],
+				"conditions": listOrEmpty(classic, "conditions"),
+			},
+		})
+	}
+
+	for _, r := range list(payload, "entitySelectorBasedRules") {
+		classic := asMap(r)
+		rules = append(rules, map[string]any{
+			"enabled":        classic["enabled"],
+			"type":           "SELECTOR",
+			"entitySelector": classic["entitySelector"],
+		})
+	}
+
+	result := map[string]any{
+		"name":  payload["name"],
+		"rules": orEmpty(rules),
+	}
+	copyIfSet(result, "description", payload, "description")
+	return result, nil
+}
+
+func autoTag(payload map[string]any) (map[string]any, error) {
+	var rules []any
+
+	for _, r := range list(payload, "rules") {
+		classic := asMap(r)
+		rule, err := attributeRule(classic)
+		if err != nil {
+			return nil, err
+		}
+		if err := addValueFormat(rule, classic); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	for _, r := range list(payload, "entitySelectorBasedRules") {
+		classic := asMap(r)
+		rule := map[string]any{
+			"enabled":        classic["enabled"],
+			"type":           "SELECTOR",
+			"entitySelector": classic["entitySelector"],
+		}
+		if err := addValueFormat(rule, classic); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	result := map[string]any{
+		"name":  payload["name"],
+		"rules": orEmpty(rules),
+	}
+	copyIfSet(result, "description", payload, "description")
+	return result, nil
+}
+
+// normalizations maps the classic auto-tag value normalizations to the ones of Settings 2.0
+var normalizations = map[string]string{
+	"LEAVE_TEXT_AS_IS": "Leave text as-is",
+	"TO_LOWER_CASE":    "To lower case",
+	"TO_UPPER_CASE":    "To upper case",
+}
+
+// addValueFormat adds the value format and normalization of a classic auto-tag rule to the given rule
+func addValueFormat(rule map[string]any, classic map[string]any) error {
+	copyIfSet(rule, "valueFormat", classic, "valueFormat")
+
+	n, found := classic["normalization"]
+	if !found || n == nil {
+		return nil
+	}
+	normalization, found := normalizations[fmt.Sprint(n)]
+	if !found {
+		return fmt.Errorf("unsupported normalization %q", n)
+	}
+	rule["valueNormalization"] = normalization
+	return nil
+}
+
+// propagations maps the classic propagation types of rules to the flags of Settings 2.0 attribute rules
+var propagations = map[string]string{
+	"SERVICE_TO_HOST_LIKE":                 "serviceToHostPropagation",
+	"SERVICE_TO_PROCESS_GROUP_LIKE":        "serviceToPGPropagation",
+	"PROCESS_GROUP_TO_HOST":                "pgToHostPropagation",
+	"PROCESS_GROUP_TO_SERVICE":             "pgToServicePropagation",
+	"HOST_TO_PROCESS_GROUP_INSTANCE":       "hostToPGPropagation",
+	"AZURE_TO_PG":                          "azureToPGPropagation",
+	"AZURE_TO_SERVICE":                     "azureToServicePropagation",
+	"CUSTOM_DEVICE_GROUP_TO_CUSTOM_DEVICE": "customDeviceGroupToCustomDevicePropagation",
+}
+
+// attributeRule converts a classic entity attribute rule of management zones and auto-tags
+func attributeRule(classic map[string]any) (map[string]any, error) {
+	attributes := map[string]any{
+		"entityType": classic["type"],
+	}
+
+	for _, p := range list(classic, "propagationTypes") {
+		flag, found := propagations[fmt.Sprint(p)]
+		if !found {
+			return nil, fmt.Errorf("unsupported propagation type %q", p)
+		}
+		attributes[flag] = true
+	}
+
+	var conditions []any
+	for _, c := range list(classic, "conditions") {
+		condition, err := attributeCondition(asMap(c))
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	attributes["conditions"] = orEmpty(conditions)
+
+	return map[string]any{
+		"enabled":       classic["enabled"],
+		"type":          "ME",
+		"attributeRule": attributes,
+	}, nil
+}
+
+// stringComparisons are the classic comparison types of plain string values
+var stringComparisons = map[string]bool{
+	"STRING":         true,
+	"INDEXED_NAME":   true,
+	"INDEXED_STRING": true,
+	"IP_ADDRESS":     true,
+}
+
+// numericOperators are operators which cannot be negated in Settings 2.0
+var numericOperators = map[string]bool{
+	"GREATER_THAN":          true,
+	"GREATER_THAN_OR_EQUAL": true,
+	"LOWER_THAN":            true,
+	"LOWER_THAN_OR_EQUAL":   true,
+}
+
+func attributeCondition(classic map[string]any) (map[string]any, error) {
+	key := asMap(classic["key"])
+	comparison := asMap(classic["comparisonInfo"])
+
+	result := map[string]any{
+		"key": key["attribute"],
+	}
+
+	switch dynamicKey := key["dynamicKey"].(type) {
+	case nil:
+	case map[string]any:
+		result["dynamicKey"] = dynamicKey["key"]
+		result["dynamicKeySource"] = dynamicKey["source"]
+	default:
+		result["dynamicKey"] = dynamicKey
+	}
+
+	operator := fmt.Sprint(comparison["operator"])
+	if negate, _ := comparison["negate"].(bool); negate {
+		if numericOperators[operator] {
+			return nil, fmt.Errorf("operator %q can not be negated", operator)
+		}
+		operator = "NOT_" + operator
+	}
+	result["operator"] = operator
+
+	if strings.HasSuffix(operator, "EXISTS") {
+		return result, nil
+	}
+
+	comparisonType := fmt.Sprint(comparison["type"])
+	value := comparison["value"]
+	switch {
+	case comparisonType == "TAG" || comparisonType == "INDEXED_TAG":
+		result["tag"] = tag(asMap(value))
+	case stringComparisons[comparisonType]:
+		result["stringValue"] = value
+		copyIfSet(result, "caseSensitive", comparison, "caseSensitive")
+	case comparisonType == "INTEGER" || comparisonType == "INDEXED_NUMBER":
+		result["integerValue"] = value
+	case comparisonType == "ENTITY_ID":
+		result["entityId"] = value
+	default:
+		switch v := value.(type) {
+		case string:
+			result["enumValue"] = v
+		case map[string]any:
+			// technology comparisons hold the enum value in a 'type' field
+			t, found := v["type"]
+			if !found {
+				return nil, fmt.Errorf("unsupported value of comparison type %q", comparisonType)
+			}
+			result["enumValue"] = t
+		default:
+			return nil, fmt.Errorf("unsupported value of comparison type %q", comparisonType)
+		}
+	}
+
+	return result, nil
+}
+
+// tag returns the string representation of a classic tag, which is '[context]key:value', where the context is
+// omitted for CONTEXTLESS tags and the value is omitted if not set
+func tag(classic map[string]any) string {
+	var b strings.Builder
+	if c, ok := classic["context"].(string); ok && c != "" && c != "CONTEXTLESS" {
+		b.WriteString("[" + c + "]")
+	}
+	b.WriteString(fmt.Sprint(classic["key"]))
+	if v, ok := classic["value"]; ok && v != nil && v != "" {
+		b.WriteString(":" + fmt.Sprint(v))
+	}
+	return b.String()
+}
+
+func alertingProfile(payload map[string]any) (map[string]any, error) {
+	var severityRules []any
+	for _, r := range list(payload, "rules") {
+		classic := asMap(r)
+		filter := asMap(classic["tagFilter"])
+
+		var tags []any
+		for _, t := range list(filter, "tagFilters") {
+			tags = append(tags, tag(asMap(t)))
+		}
+
+		severityRules = append(severityRules, map[string]any{
+			"severityLevel":        classic["severityLevel"],
+			"delayInMinutes":       classic["delayInMinutes"],
+			"tagFilterIncludeMode": filter["includeMode"],
+			"tagFilter":            orEmpty(tags),
+		})
+	}
+
+	var eventFilters []any
+	for _, f := range list(payload, "eventTypeFilters") {
+		classic := asMap(f)
+
+		if predefined, found := classic["predefinedEventFilter"]; found && predefined != nil {
+			eventFilters = append(eventFilters, map[string]any{
+				"type":             "PREDEFINED",
+				"predefinedFilter": predefined,
+			})
+			continue
+		}
+
+		custom := asMap(classic["customEventFilter"])
+		filter := map[string]any{}
+		for classicKey, key := range map[string]string{"customTitleFilter": "titleFilter", "customDescriptionFilter": "descriptionFilter"} {
+			if _, found := custom[classicKey]; !found || custom[classicKey] == nil {
+				continue
+			}
+			textFilter, err := customTextFilter(asMap(custom[classicKey]))
+			if err != nil {
+				return nil, err
+			}
+			filter[key] = textFilter
+		}
+		eventFilters = append(eventFilters, map[string]any{
+			"type":         "CUSTOM",
+			"customFilter": filter,
+		})
+	}
+
+	result := map[string]any{
+		"name":          payload["displayName"],
+		"severityRules": orEmpty(severityRules),
+		"eventFilters":  orEmpty(eventFilters),
+	}
+	copyIfSet(result, "managementZone", payload, "mzId")
+	return result, nil
+}
+
+func customTextFilter(classic map[string]any) (map[string]any, error) {
+	result := map[string]any{
+		"enabled":  classic["enabled"],
+		"value":    classic["value"],
+		"operator": classic["operator"],
+		"negate":   classic["negate"],
+	}
+
+	switch caseInsensitive := classic["caseInsensitive"].(type) {
+	case nil:
+	case bool:
+		result["caseSensitive"] = !caseInsensitive
+	default:
+		return nil, fmt.Errorf("unsupported value %q of 'caseInsensitive'", caseInsensitive)
+	}
+	return result, nil
+}
+
+func notification(payload map[string]any) (map[string]any, error) {
+	notificationType := fmt.Sprint(payload["type"])
+
+	result := map[string]any{
+		"enabled":          payload["active"],
+		"displayName":      payload["name"],
+		"alertingProfile":  payload["alertingProfile"],
+		"notificationType": notificationType,
+	}
+
+	switch notificationType {
+	case "EMAIL":
+		email := map[string]any{
+			"subject":              payload["subject"],
+			"body":                 payload["body"],
+			"recipients":           listOrEmpty(payload, "receivers"),
+			"notifyClosedProblems": true,
+		}
+		copyIfSet(email, "ccRecipients", payload, "ccReceivers")
+		copyIfSet(email, "bccRecipients", payload, "bccReceivers")
+		result["emailNotification"] = email
+
+	case "WEBHOOK":
+		var headers []any
+		for _, h := range list(payload, "headers") {
+			header := asMap(h)
+			headers = append(headers, map[string]any{
+				"name":   header["name"],
+				"value":  header["value"],
+				"secret": false,
+			})
+		}
+		webhook := map[string]any{
+			"url":                  payload["url"],
+			"acceptAnyCertificate": payload["acceptAnyCertificate"],
+			"payload":              payload["payload"],
+			"headers":              orEmpty(headers),
+			"notifyClosedProblems": true,
+		}
+		copyIfSet(webhook, "notifyEventMerges", payload, "notifyEventMergesEnabled")
+		result["webHookNotification"] = webhook
+
+	case "SLACK":
+		result["slackNotification"] = map[string]any{
+			"url":     payload["url"],
+			"channel": payload["channel"],
+			"message": payload["title"],
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported notification type %q", notificationType)
+	}
+
+	return result, nil
+}
+
+func asMap(v any) map[string]any {
+	if m, ok := v.(map[string]any); ok {
+		return m
+	}
+	return map[string]any{}
+}
+
+func list(m map[string]any, key string) []any {
+	if l, ok := m[key].([]any); ok {
+		return l
+	}
+	return nil
+}
+
+// listOrEmpty returns the list of the given key, or an empty list so that it is not rendered as null
+func listOrEmpty(m map[string]any, key string) []any {
+	return orEmpty(list(m, key))
+}
+
+func orEmpty(l []any) []any {
+	if l == nil {
+		return []any{}
+	}
+	return l
+}
+
+func copyIfSet(dst map[string]any, dstKey string, src map[string]any, srcKey string) {
+	if v, found := src[srcKey]; found && v != nil {
+		dst[dstKey] = v
+	}
+}
@@ -0,0 +1,190 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTransformations(t *testing.T) {
+	tests := []struct {
+		api     string
+		payload string
+		want    string
+	}{
+		{
+			api: "management-zone",
+			payload: `{
+				"name": "zone",
+				"rules": [{
+					"type": "SERVICE",
+					"enabled": true,
+					"propagationTypes": ["SERVICE_TO_HOST_LIKE"],
+					"conditions": [
+						{"key": {"attribute": "SERVICE_TAGS"}, "comparisonInfo": {"type": "TAG", "operator": "EQUALS", "value": {"context": "CONTEXTLESS", "key": "team", "value": "a"}, "negate": false}},
+						{"key": {"attribute": "SERVICE_NAME"}, "comparisonInfo": {"type": "STRING", "operator": "BEGINS_WITH", "value": "shop", "negate": true, "caseSensitive": false}},
+						{"key": {"attribute": "SERVICE_TYPE"}, "comparisonInfo": {"type": "SERVICE_TYPE", "operator": "EQUALS", "value": "WEB_SERVICE", "negate": false}},
+						{"key": {"attribute": "PROCESS_GROUP_CUSTOM_METADATA", "dynamicKey": {"source": "KUBERNETES", "key": "app"}}, "comparisonInfo": {"type": "STRING", "operator": "EXISTS", "negate": false}}
+					]
+				}],
+				"dimensionalRules": [{"enabled": true, "appliesTo": "METRIC", "conditions": [{"conditionType": "DIMENSION", "ruleMatcher": "EQUALS", "key": "k", "value": "v"}]}],
+				"entitySelectorBasedRules": [{"enabled": false, "entitySelector": "type(HOST)"}]
+			}`,
+			want: `{
+				"name": "zone",
+				"rules": [
+					{"enabled": true, "type": "ME", "attributeRule": {"entityType": "SERVICE", "serviceToHostPropagation": true, "conditions": [
+						{"key": "SERVICE_TAGS", "operator": "EQUALS", "tag": "team:a"},
+						{"key": "SERVICE_NAME", "operator": "NOT_BEGINS_WITH", "stringValue": "shop", "caseSensitive": false},
+						{"key": "SERVICE_TYPE", "operator": "EQUALS", "enumValue": "WEB_SERVICE"},
+						{"key": "PROCESS_GROUP_CUSTOM_METADATA", "dynamicKey": "app", "dynamicKeySource": "KUBERNETES", "operator": "EXISTS"}
+					]}},
+					{"enabled": true, "type": "DIMENSION", "dimensionRule": {"appliesTo": "METRIC", "conditions": [{"conditionType": "DIMENSION", "ruleMatcher": "EQUALS", "key": "k", "value": "v"}]}},
+					{"enabled": false, "type": "SELECTOR", "entitySelector": "type(HOST)"}
+				]
+			}`,
+		},
+		{
+			api: "auto-tag",
+			payload: `{
+				"name": "tag",
+				"description": "d",
+				"rules": [{
+					"type": "HOST",
+					"enabled": true,
+					"valueFormat": "{Host:DetectedName}",
+					"normalization": "TO_LOWER_CASE",
+					"propagationTypes": [],
+					"conditions": [{"key": {"attribute": "HOST_TAGS"}, "comparisonInfo": {"type": "TAG", "operator": "TAG_KEY_EQUALS", "value": {"context": "AWS", "key": "env"}, "negate": false}}]
+				}],
+				"entitySelectorBasedRules": [{"enabled": true, "entitySelector": "type(SERVICE)", "normalization": "LEAVE_TEXT_AS_IS"}]
+			}`,
+			want: `{
+				"name": "tag",
+				"description": "d",
+				"rules": [
+					{"enabled": true, "type": "ME", "valueFormat": "{Host:DetectedName}", "valueNormalization": "To lower case", "attributeRule": {"entityType": "HOST", "conditions": [
+						{"key": "HOST_TAGS", "operator": "TAG_KEY_EQUALS", "tag": "[AWS]env"}
+					]}},
+					{"enabled": true, "type": "SELECTOR", "entitySelector": "type(SERVICE)", "valueNormalization": "Leave text as-is"}
+				]
+			}`,
+		},
+		{
+			api: "alerting-profile",
+			payload: `{
+				"displayName": "profile",
+				"mzId": "123",
+				"rules": [{"severityLevel": "AVAILABILITY", "tagFilter": {"includeMode": "INCLUDE_ANY", "tagFilters": [{"context": "CONTEXTLESS", "key": "team", "value": "a"}]}, "delayInMinutes": 5}],
+				"eventTypeFilters": [
+					{"predefinedEventFilter": {"eventType": "OSI_HIGH_CPU", "negate": false}},
+					{"customEventFilter": {"customTitleFilter": {"enabled": true, "value": "x", "operator": "CONTAINS", "negate": false, "caseInsensitive": true}}}
+				]
+			}`,
+			want: `{
+				"name": "profile",
+				"managementZone": "123",
+				"severityRules": [{"severityLevel": "AVAILABILITY", "delayInMinutes": 5, "tagFilterIncludeMode": "INCLUDE_ANY", "tagFilter": ["team:a"]}],
+				"eventFilters": [
+					{"type": "PREDEFINED", "predefinedFilter": {"eventType": "OSI_HIGH_CPU", "negate": false}},
+					{"type": "CUSTOM", "customFilter": {"titleFilter": {"enabled": true, "value": "x", "operator": "CONTAINS", "negate": false, "caseSensitive": false}}}
+				]
+			}`,
+		},
+		{
+			api: "notification",
+			payload: `{
+				"name": "mail",
+				"alertingProfile": "abc",
+				"active": true,
+				"type": "EMAIL",
+				"subject": "s",
+				"body": "b",
+				"receivers": ["a@b.c"],
+				"ccReceivers": [],
+				"bccReceivers": []
+			}`,
+			want: `{
+				"enabled": true,
+				"displayName": "mail",
+				"alertingProfile": "abc",
+				"notificationType": "EMAIL",
+				"emailNotification": {"subject": "s", "body": "b", "recipients": ["a@b.c"], "ccRecipients": [], "bccRecipients": [], "notifyClosedProblems": true}
+			}`,
+		},
+		{
+			api: "notification",
+			payload: `{
+				"name": "hook",
+				"alertingProfile": "abc",
+				"active": false,
+				"type": "WEBHOOK",
+				"url": "https://example.com",
+				"acceptAnyCertificate": false,
+				"payload": "{}",
+				"headers": [{"name": "h", "value": "v"}],
+				"notifyEventMergesEnabled": true
+			}`,
+			want: `{
+				"enabled": false,
+				"displayName": "hook",
+				"alertingProfile": "abc",
+				"notificationType": "WEBHOOK",
+				"webHookNotification": {"url": "https://example.com", "acceptAnyCertificate": false, "payload": "{}", "headers": [{"name": "h", "value": "v", "secret": false}], "notifyEventMerges": true, "notifyClosedProblems": true}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.api, func(t *testing.T) {
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(tt.payload), &payload))
+
+			got, err := transformations[tt.api](payload)
+			require.NoError(t, err)
+
+			gotJSON, err := json.Marshal(got)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(gotJSON))
+		})
+	}
+}
+
+func TestTransformations_Errors(t *testing.T) {
+	tests := []struct {
+		api     string
+		payload map[string]any
+		wantErr string
+	}{
+		{"management-zone", map[string]any{"rules": []any{map[string]any{"propagationTypes": []any{"UNKNOWN"}}}}, `unsupported propagation type "UNKNOWN"`},
+		{"management-zone", map[string]any{"rules": []any{map[string]any{"conditions": []any{map[string]any{"comparisonInfo": map[string]any{"operator": "LOWER_THAN", "negate": true}}}}}}, `operator "LOWER_THAN" can not be negated`},
+		{"auto-tag", map[string]any{"rules": []any{map[string]any{"normalization": "TO_TITLE_CASE"}}}, `unsupported normalization "TO_TITLE_CASE"`},
+		{"notification", map[string]any{"type": "JIRA"}, `unsupported notification type "JIRA"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.api, func(t *testing.T) {
+			_, err := transformations[tt.api](tt.payload)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monaco
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/account"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+)
+
+// ClientFactory creates the clients connecting to an environment of the manifest. The returned account client is nil
+// if the environment does not belong to an account.
+type ClientFactory func(env manifest.EnvironmentDefinition, accounts map[string]manifest.Account) (*client.ClientSet, *account.Client, error)
+
+// NewClientFactory returns a ClientFactory creating clients with the given options, overwritten by the options defined
+// for each environment in the manifest.
+func NewClientFactory(base client.ClientOptions) ClientFactory {
+	return func(env manifest.EnvironmentDefinition, accounts map[string]manifest.Account) (*client.ClientSet, *account.Client, error) {
+		opts := ClientOptions(base, env.Options)
+
+		clientSet, err := CreateClientSet(env.URL.Value, env.Auth, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create clients for environment %q: %w", env.Name, err)
+		}
+
+		accountClient, err := CreateAccountClient(env, accounts, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create account client for environment %q: %w", env.Name, err)
+		}
+
+		return clientSet, accountClient, nil
+	}
+}
+
+// CreateClientSet creates the clients for the environment with the given URL, using OAuth if it is defined in the
+// given auth, and the token otherwise.
+func CreateClientSet(url string, auth manifest.Auth, opts client.ClientOptions) (*client.ClientSet, error) {
+	if auth.OAuth == nil {
+		return client.CreateClassicClientSet(url, auth.Token.Value, opts)
+	}
+	return client.CreatePlatformClientSet(url, client.PlatformAuth{
+		OauthClientID:     auth.OAuth.ClientID.Value,
+		OauthClientSecret: auth.OAuth.ClientSecret.Value,
+		Token:             auth.Token.Value,
+		OauthTokenURL:     auth.OAuth.GetTokenEndpointValue(),
+	}, opts)
+}
+
+// CreateAccountClient creates the client for the account the given environment belongs to. Policy bindings are managed
+// on the level of the environment. It returns nil if the environment does not belong to an account.
+func CreateAccountClient(env manifest.EnvironmentDefinition, accounts map[string]manifest.Account, opts client.ClientOptions) (*account.Client, error) {
+	if env.Account == "" {
+		return nil, nil
+	}
+
+	a, found := accounts[env.Account]
+	if !found {
+		return nil, fmt.Errorf("environment %q belongs to unknown account %q", env.Name, env.Account)
+	}
+
+	c, err := client.CreateAccountClient(a.GetApiURLValue(), a.AccountUUID, client.AccountAuth{
+		OauthClientID:     a.OAuth.ClientID.Value,
+		OauthClientSecret: a.OAuth.ClientSecret.Value,
+		OauthTokenURL:     a.OAuth.GetTokenEndpointValue(),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.WithEnvironment(env.EnvironmentID()), nil
+}
+
+// ClientOptions returns the given base options, overwritten by the options defined for an environment in the manifest
+func ClientOptions(base client.ClientOptions, options manifest.EnvironmentOptions) client.ClientOptions {
+	opts := base
+	opts.ConcurrentRequests = options.ConcurrentRequests
+	opts.RequestsPerSecond = options.RequestsPerSecond
+	opts.ProxyURL = options.ProxyURL
+	opts.CACertificates = options.CACertificates
+	opts.RequestTimeout = options.RequestTimeout
+
+	if options.Retry != nil {
+		opts.RetrySettings = toRetrySettings(*options.Retry)
+	}
+	return opts
+}
+
+// toRetrySettings creates rest.RetrySettings based on the defaults, overwritten by the given options.
+// Long and very long running operations keep the same ratio to normal operations as the defaults.
+func toRetrySettings(r manifest.RetryOptions) *rest.RetrySettings {
+	s := rest.DefaultRetrySettings
+
+	if r.WaitTime > 0 {
+		s.Normal.WaitTime = r.WaitTime
+		s.Long.WaitTime = r.WaitTime
+		s.VeryLong.WaitTime = r.WaitTime
+	}
+
+	if r.MaxRetries > 0 {
+		s.Normal.MaxRetries = r.MaxRetries
+		s.Long.MaxRetries = r.MaxRetries * rest.DefaultRetrySettings.Long.MaxRetries / rest.DefaultRetrySettings.Normal.MaxRetries
+		s.VeryLong.MaxRetries = r.MaxRetries * rest.DefaultRetrySettings.VeryLong.MaxRetries / rest.DefaultRetrySettings.Normal.MaxRetries
+	}
+
+	return &s
+}
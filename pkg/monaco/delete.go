@@ -0,0 +1,88 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monaco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/spf13/afero"
+)
+
+// DeleteEntries are the configurations to delete by their type, as loaded by LoadDeleteFile
+type DeleteEntries = map[string][]delete.DeletePointer
+
+// LoadDeleteFile loads the configurations to delete from the given delete file
+func LoadDeleteFile(fs afero.Fs, deleteFile string) (DeleteEntries, error) {
+	// account resources are identified by the name of the user group or policy, like classic configs
+	nameBasedTypes := api.NewAPIs().GetNames()
+	for _, r := range config.KnownAccountResources {
+		nameBasedTypes = append(nameBasedTypes, string(r))
+	}
+
+	entries, errs := delete.LoadEntriesToDelete(fs, nameBasedTypes, deleteFile)
+	if errs != nil {
+		return nil, fmt.Errorf("failed to load delete file %q: %w", deleteFile, errors.Join(errs...))
+	}
+	return entries, nil
+}
+
+// Delete deletes the given configurations from all environments of the Workspace. The deletion continues if it fails
+// for a single configuration, and errors are returned as EnvironmentErrors. Of the options, only WithClientFactory
+// applies.
+func (w *Workspace) Delete(ctx context.Context, entries DeleteEntries, opts ...Option) error {
+	o := newOptions(opts)
+
+	automationResources := map[string]config.AutomationResource{
+		string(config.Workflow):         config.Workflow,
+		string(config.BusinessCalendar): config.BusinessCalendar,
+		string(config.SchedulingRule):   config.SchedulingRule,
+	}
+
+	envErrs := make(EnvironmentErrors)
+	for _, env := range w.Manifest.Environments {
+		cl, accountClient, err := o.clientFactory(env, w.Manifest.Accounts)
+		if err != nil {
+			envErrs[env.Name] = []error{err}
+			continue
+		}
+
+		clients := delete.ClientSet{
+			Classic:    cl.Classic(),
+			Settings:   cl.Settings(),
+			Automation: cl.Automation(),
+			Document:   cl.Document(),
+		}
+		if accountClient != nil {
+			clients.Account = accountClient
+		}
+
+		envCtx := context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+		if errs := delete.Configs(envCtx, clients, api.NewAPIs(), automationResources, entries); len(errs) > 0 {
+			envErrs[env.Name] = errs
+		}
+	}
+
+	if len(envErrs) > 0 {
+		return envErrs
+	}
+	return nil
+}
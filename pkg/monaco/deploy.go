@@ -0,0 +1,366 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monaco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/tracing"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/sequential"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2/sort"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"strings"
+	"time"
+)
+
+// Option configures an operation of a Workspace on its environments
+type Option func(*options)
+
+type options struct {
+	clientFactory ClientFactory
+	dryRun        bool
+	continueOnErr bool
+	onlyChanged   bool
+	state         *state.State
+}
+
+func newOptions(opts []Option) options {
+	o := options{clientFactory: NewClientFactory(client.ClientOptions{})}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithClientFactory sets the ClientFactory creating the clients connecting to the environments. By default, clients
+// are created by NewClientFactory without any base options.
+func WithClientFactory(f ClientFactory) Option {
+	return func(o *options) {
+		o.clientFactory = f
+	}
+}
+
+// WithDryRun validates the configurations instead of deploying them, without connecting to the environments
+func WithDryRun() Option {
+	return func(o *options) {
+		o.dryRun = true
+	}
+}
+
+// WithContinueOnError continues the operation if it fails for a single configuration
+func WithContinueOnError() Option {
+	return func(o *options) {
+		o.continueOnErr = true
+	}
+}
+
+// WithOnlyChanged deploys configurations only if they changed since their last deployment
+func WithOnlyChanged() Option {
+	return func(o *options) {
+		o.onlyChanged = true
+	}
+}
+
+// WithState records the objects configurations are deployed as in the given state, and uses the recorded objects to
+// resolve references to configurations which are not deployed. Loading and saving the state is up to the caller, e.g.
+// using state.NewStore.
+func WithState(s *state.State) Option {
+	return func(o *options) {
+		o.state = s
+	}
+}
+
+// EnvironmentErrors holds the errors of an operation by the name of the environment they occurred on
+type EnvironmentErrors map[string][]error
+
+func (e EnvironmentErrors) Error() string {
+	envs := maps.Keys(e)
+	slices.Sort(envs)
+
+	msgs := make([]string, 0, len(envs))
+	for _, env := range envs {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", env, errors.Join(e[env]...)))
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// All returns the errors of all environments, sorted by environment name
+func (e EnvironmentErrors) All() []error {
+	envs := maps.Keys(e)
+	slices.Sort(envs)
+
+	var result []error
+	for _, env := range envs {
+		result = append(result, e[env]...)
+	}
+	return result
+}
+
+// PlannedConfig is a configuration as it is deployed to an environment
+type PlannedConfig struct {
+	Coordinate  coordinate.Coordinate
+	Environment string
+	// Skip states that the configuration is not deployed, but may still be referenced
+	Skip bool
+}
+
+// Plan holds the configurations to deploy
+type Plan struct {
+	// Configs are sorted by environment name, and in the order they are deployed in within each environment
+	Configs []PlannedConfig
+}
+
+// Plan validates the selected configurations by deploying them in a dry run, and returns the order they are deployed in.
+// Validation errors are returned as EnvironmentErrors, together with the Plan.
+func (w *Workspace) Plan(ctx context.Context, sel Selection) (Plan, error) {
+	environments := w.Manifest.Environments.Names()
+	slices.Sort(environments)
+
+	sortedConfigs, errs := graph.SortProjects(sel.Projects, environments)
+	if len(errs) > 0 {
+		return Plan{}, fmt.Errorf("failed to sort configurations: %w", errors.Join(errs...))
+	}
+
+	var plan Plan
+	for _, env := range environments {
+		for _, c := range sortedConfigs[env] {
+			plan.Configs = append(plan.Configs, PlannedConfig{Coordinate: c.Coordinate, Environment: env, Skip: c.Skip})
+		}
+	}
+
+	_, err := w.Deploy(ctx, sel, WithDryRun(), WithContinueOnError())
+	return plan, err
+}
+
+// DeployedConfig is a configuration deployed to an environment
+type DeployedConfig struct {
+	Coordinate  coordinate.Coordinate
+	Environment string
+	// ID is the ID of the object the configuration was deployed as
+	ID string
+	// Name is the name of the object
+	Name string
+}
+
+// DeployResult is the result of a deployment
+type DeployResult struct {
+	// Configs are the deployed configurations, sorted by environment and coordinate. With WithOnlyChanged, it also
+	// contains the configurations which were up to date already.
+	Configs []DeployedConfig
+}
+
+// Deploy deploys the selected configurations to the environments of the Workspace. Deployment errors are returned as
+// EnvironmentErrors, together with the configurations deployed successfully. A dry run returns an empty result.
+// Deployments are traced as child spans of the given context.
+func (w *Workspace) Deploy(ctx context.Context, sel Selection, opts ...Option) (DeployResult, error) {
+	o := newOptions(opts)
+
+	if err := checkEnvironments(sel.Projects, w.Manifest.Environments); err != nil {
+		return DeployResult{}, err
+	}
+
+	deployState := o.state
+	if deployState == nil {
+		// the result is taken from the state, so a state is used even if the caller does not keep one
+		deployState = state.New()
+	}
+	started := time.Now().UTC()
+
+	deployOpts := deploy.DeployConfigsOptions{
+		ContinueOnErr:      o.continueOnErr,
+		DryRun:             o.dryRun,
+		ReferencedProjects: sel.Referenced,
+		State:              deployState,
+		OnlyChanged:        o.onlyChanged,
+	}
+
+	var err error
+	if featureflags.DependencyGraphBasedDeploy().Enabled() {
+		err = w.deployGraph(ctx, sel.Projects, o, deployOpts)
+	} else {
+		err = w.deploySequential(ctx, sel.Projects, o, deployOpts)
+	}
+
+	if o.dryRun {
+		return DeployResult{}, err
+	}
+	return DeployResult{Configs: deployedConfigs(sel.Projects, deployState, started)}, err
+}
+
+func (w *Workspace) deployGraph(ctx context.Context, projects []project.Project, o options, deployOpts deploy.DeployConfigsOptions) error {
+	clients := make(deploy.EnvironmentClients, len(w.Manifest.Environments))
+	for _, env := range w.Manifest.Environments {
+		clientSet, err := o.deployClientSet(env, w.Manifest.Accounts)
+		if err != nil {
+			return err
+		}
+		clients[deploy.EnvironmentInfo{Name: env.Name, Group: env.Group}] = clientSet
+	}
+
+	err := deploy.DeployConfigGraph(ctx, projects, clients, deployOpts)
+
+	var environmentErrs deployErrors.EnvironmentDeploymentErrors
+	if errors.As(err, &environmentErrs) {
+		return EnvironmentErrors(environmentErrs)
+	}
+	return err
+}
+
+func (w *Workspace) deploySequential(ctx context.Context, projects []project.Project, o options, deployOpts deploy.DeployConfigsOptions) error {
+	sortedConfigs, errs := sort.ConfigsPerEnvironment(projects, w.Manifest.Environments.Names())
+	if errs != nil {
+		return fmt.Errorf("error during configuration sort: %w", errors.Join(errs...))
+	}
+
+	envErrs := make(EnvironmentErrors)
+	for envName, cfgs := range sortedConfigs {
+		env := w.Manifest.Environments[envName]
+		if errs := w.deployEnvironmentSequential(ctx, env, cfgs, o, deployOpts); len(errs) > 0 {
+			envErrs[envName] = errs
+		}
+
+		if len(envErrs) > 0 && !o.continueOnErr {
+			break
+		}
+	}
+
+	if len(envErrs) > 0 {
+		return envErrs
+	}
+	return nil
+}
+
+// deployEnvironmentSequential deploys the given sorted configurations to the given environment. The deployment is traced
+// as a span of the environment, like the graph based deployment does.
+func (w *Workspace) deployEnvironmentSequential(ctx context.Context, env manifest.EnvironmentDefinition, sortedConfigs []config.Config, o options, deployOpts deploy.DeployConfigsOptions) (errs []error) {
+	ctx = context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+	ctx, span := tracing.Tracer().Start(ctx, "deploy environment", trace.WithAttributes(tracing.Environment.String(env.Name), tracing.EnvironmentGroup.String(env.Group)))
+	defer func() {
+		tracing.EndSpan(span, errors.Join(errs...))
+	}()
+
+	clientSet, err := o.deployClientSet(env, w.Manifest.Accounts)
+	if err != nil {
+		return []error{err}
+	}
+	return sequential.DeployConfigs(ctx, clientSet, api.NewAPIs(), sortedConfigs, deployOpts)
+}
+
+func (o options) deployClientSet(env manifest.EnvironmentDefinition, accounts map[string]manifest.Account) (deploy.ClientSet, error) {
+	if o.dryRun {
+		return deploy.DummyClientSet, nil
+	}
+
+	cl, accountClient, err := o.clientFactory(env, accounts)
+	if err != nil {
+		return deploy.ClientSet{}, err
+	}
+
+	clientSet := deploy.ClientSet{
+		Classic:    cl.Classic(),
+		Settings:   cl.Settings(),
+		Automation: cl.Automation(),
+		Bucket:     cl.Bucket(),
+		Extension:  cl.Extension(),
+		Document:   cl.Document(),
+	}
+	if accountClient != nil {
+		clientSet.Account = accountClient
+	}
+
+	return clientSet, nil
+}
+
+// deployedConfigs returns the configurations of the given projects which were recorded in the state since started
+func deployedConfigs(projects []project.Project, st *state.State, started time.Time) []DeployedConfig {
+	var result []DeployedConfig
+	for _, p := range projects {
+		for env, cfgsPerType := range p.Configs {
+			for _, cfgs := range cfgsPerType {
+				for _, c := range cfgs {
+					if e, found := st.Get(env, c.Coordinate); found && !c.Skip && !e.DeployedAt.Before(started) {
+						result = append(result, DeployedConfig{Coordinate: c.Coordinate, Environment: env, ID: e.ID, Name: e.Name})
+					}
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(result, func(a, b DeployedConfig) bool {
+		if a.Environment != b.Environment {
+			return a.Environment < b.Environment
+		}
+		return a.Coordinate.String() < b.Coordinate.String()
+	})
+	return result
+}
+
+func checkEnvironments(projects []project.Project, envs manifest.Environments) error {
+	for _, p := range projects {
+		for envName, cfgPerType := range p.Configs {
+			if _, found := envs[envName]; !found {
+				return fmt.Errorf("cannot find environment `%s`", envName)
+			}
+			for _, cfgs := range cfgPerType {
+				if err := checkConfigsForEnvironment(envs[envName], cfgs); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func checkConfigsForEnvironment(env manifest.EnvironmentDefinition, cfgs []config.Config) error {
+	for i := range cfgs {
+		if !cfgs[i].Skip && onlyAvailableOnPlatform(&cfgs[i]) && !platformEnvironment(env) {
+			return fmt.Errorf("enviroment %q is not specified as platform, but at least one of configurations (e.g. %q) is platform exclusive", env.Name, cfgs[i].Coordinate)
+		}
+		if _, isAccount := cfgs[i].Type.(config.AccountType); !cfgs[i].Skip && isAccount && env.Account == "" {
+			return fmt.Errorf("enviroment %q does not belong to an account, but at least one of configurations (e.g. %q) is an account resource", env.Name, cfgs[i].Coordinate)
+		}
+	}
+	return nil
+}
+
+func platformEnvironment(e manifest.EnvironmentDefinition) bool {
+	return e.Auth.OAuth != nil
+}
+
+func onlyAvailableOnPlatform(c *config.Config) bool {
+	switch c.Type.(type) {
+	case config.AutomationType, config.BucketType, config.DocumentType:
+		return true
+	default:
+		return false
+	}
+}
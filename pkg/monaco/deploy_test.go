@@ -0,0 +1,254 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monaco_test
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/tracing"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/emulator"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/monaco"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWorkspace_Deploy(t *testing.T) {
+	server := httptest.NewServer(emulator.New())
+	defer server.Close()
+
+	t.Setenv("EMULATOR_URL", server.URL)
+	t.Setenv("EMULATOR_TOKEN", "dt0c01.ANY.TOKEN")
+
+	fs, manifestPath := writeTestFiles(t, map[string]string{
+		"manifest.yaml": testManifest,
+		"project/config.yaml": `configs:
+- id: attribute
+  type:
+    api: request-attributes
+  config:
+    name: Attribute
+    template: attribute.json
+- id: other
+  type:
+    api: request-attributes
+  config:
+    name: Other
+    template: attribute.json
+    parameters:
+      description:
+        type: reference
+        configId: attribute
+        property: name
+`,
+		"project/attribute.json": `{"name": "{{.name}}", "enabled": true}`,
+	})
+
+	ws, err := monaco.Load(fs, manifestPath)
+	require.NoError(t, err)
+	sel, err := ws.Select()
+	require.NoError(t, err)
+
+	attribute := coordinate.Coordinate{Project: "project", Type: "request-attributes", ConfigId: "attribute"}
+	other := coordinate.Coordinate{Project: "project", Type: "request-attributes", ConfigId: "other"}
+
+	t.Run("plan returns configurations in deployment order", func(t *testing.T) {
+		plan, err := ws.Plan(context.TODO(), sel)
+		require.NoError(t, err)
+
+		assert.Equal(t, []monaco.PlannedConfig{
+			{Coordinate: attribute, Environment: "emulated"},
+			{Coordinate: other, Environment: "emulated"},
+		}, plan.Configs)
+	})
+
+	t.Run("deployed configurations are returned", func(t *testing.T) {
+		result, err := ws.Deploy(context.TODO(), sel)
+		require.NoError(t, err)
+
+		require.Len(t, result.Configs, 2)
+		assert.Equal(t, attribute, result.Configs[0].Coordinate)
+		assert.Equal(t, "Attribute", result.Configs[0].Name)
+		assert.NotEmpty(t, result.Configs[0].ID)
+		assert.Equal(t, other, result.Configs[1].Coordinate)
+	})
+
+	t.Run("deployed configurations are recorded in the given state", func(t *testing.T) {
+		st := state.New()
+		result, err := ws.Deploy(context.TODO(), sel, monaco.WithState(st))
+		require.NoError(t, err)
+
+		e, found := st.Get("emulated", attribute)
+		require.True(t, found)
+		assert.Equal(t, result.Configs[0].ID, e.ID)
+	})
+
+	t.Run("dry runs return no configurations", func(t *testing.T) {
+		result, err := ws.Deploy(context.TODO(), sel, monaco.WithDryRun())
+		require.NoError(t, err)
+
+		assert.Empty(t, result.Configs)
+	})
+
+	t.Run("only selected configurations are deployed", func(t *testing.T) {
+		sel, err := ws.Select(monaco.WithProjects("project"))
+		require.NoError(t, err)
+
+		result, err := ws.Deploy(context.TODO(), monaco.Selection{Projects: sel.Projects})
+		require.NoError(t, err)
+		assert.Len(t, result.Configs, 2)
+	})
+
+	t.Run("errors are returned per environment", func(t *testing.T) {
+		t.Setenv("EMULATOR_URL", "http://127.0.0.1:1")
+		ws, err := monaco.Load(fs, manifestPath)
+		require.NoError(t, err)
+
+		_, err = ws.Deploy(context.TODO(), sel, monaco.WithClientFactory(monaco.NewClientFactory(client.ClientOptions{})))
+
+		var envErrs monaco.EnvironmentErrors
+		require.ErrorAs(t, err, &envErrs)
+		assert.Contains(t, envErrs, "emulated")
+	})
+}
+
+func TestWorkspace_DeploySequentialTracesConfigsAsChildSpans(t *testing.T) {
+	t.Setenv("MONACO_FEAT_GRAPH_DEPLOY", "false")
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	server := httptest.NewServer(emulator.New())
+	defer server.Close()
+
+	t.Setenv("EMULATOR_URL", server.URL)
+	t.Setenv("EMULATOR_TOKEN", "dt0c01.ANY.TOKEN")
+
+	fs, manifestPath := writeTestFiles(t, map[string]string{
+		"manifest.yaml": testManifest,
+		"project/config.yaml": `configs:
+- id: attribute
+  type:
+    api: request-attributes
+  config:
+    name: Attribute
+    template: attribute.json
+`,
+		"project/attribute.json": `{"name": "{{.name}}", "enabled": true}`,
+	})
+
+	ws, err := monaco.Load(fs, manifestPath)
+	require.NoError(t, err)
+	sel, err := ws.Select()
+	require.NoError(t, err)
+
+	st := state.New()
+	_, err = ws.Deploy(context.TODO(), sel, monaco.WithState(st))
+	require.NoError(t, err)
+	_, err = ws.Deploy(context.TODO(), sel, monaco.WithState(st), monaco.WithOnlyChanged())
+	require.NoError(t, err)
+
+	var environments, configs []sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		switch s.Name() {
+		case "deploy environment":
+			environments = append(environments, s)
+		case "deploy config":
+			configs = append(configs, s)
+		}
+	}
+	require.Len(t, environments, 2)
+	require.Len(t, configs, 2)
+
+	for i, env := range environments {
+		assert.Equal(t, "emulated", spanAttribute(env, tracing.Environment).AsString())
+
+		cfg := configs[i]
+		assert.Equal(t, env.SpanContext().SpanID(), cfg.Parent().SpanID(), "configs are traced as children of their environment")
+		assert.Equal(t, "project:request-attributes:attribute", spanAttribute(cfg, tracing.Coordinate).AsString())
+	}
+
+	assert.Equal(t, "request-attributes", spanAttribute(configs[0], tracing.API).AsString())
+	assert.False(t, spanAttribute(configs[0], tracing.Unchanged).AsBool())
+	assert.True(t, spanAttribute(configs[1], tracing.Unchanged).AsBool())
+
+	requests := 0
+	for _, s := range recorder.Ended() {
+		if s.Parent().SpanID() == configs[0].SpanContext().SpanID() {
+			requests++
+		}
+	}
+	assert.Positive(t, requests, "requests are traced as children of the deployed config")
+}
+
+// spanAttribute returns the value of the attribute with the given key of the span
+func spanAttribute(s sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, a := range s.Attributes() {
+		if a.Key == key {
+			return a.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestWorkspace_Render(t *testing.T) {
+	t.Setenv("EMULATOR_URL", "http://localhost")
+	t.Setenv("EMULATOR_TOKEN", "dt0c01.ANY.TOKEN")
+
+	fs, manifestPath := writeTestFiles(t, map[string]string{
+		"manifest.yaml": testManifest,
+		"project/config.yaml": `configs:
+- id: attribute
+  type:
+    api: request-attributes
+  config:
+    name: Attribute
+    template: attribute.json
+`,
+		"project/attribute.json": `{"name": "{{.name}}"}`,
+	})
+
+	ws, err := monaco.Load(fs, manifestPath)
+	require.NoError(t, err)
+	sel, err := ws.Select()
+	require.NoError(t, err)
+
+	rendered, err := ws.Render(context.TODO(), sel)
+	require.NoError(t, err)
+
+	require.Len(t, rendered, 1)
+	assert.Equal(t, "emulated", rendered[0].Environment)
+	assert.Contains(t, rendered[0].Content, `"name": "Attribute"`)
+
+	t.Run("unselected configurations are not returned", func(t *testing.T) {
+		rendered, err := ws.Render(context.TODO(), monaco.Selection{})
+		require.NoError(t, err)
+		assert.Empty(t, rendered)
+	})
+}
@@ -0,0 +1,41 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package monaco is the library API of monaco. It loads manifests and their projects from an afero.Fs, and renders,
+// plans, deploys, downloads and deletes configurations, returning structured results instead of logging them.
+//
+// A typical use loads a Workspace, selects the configurations to work with, and deploys them:
+//
+//	ws, err := monaco.Load(fs, "manifest.yaml", monaco.WithEnvironments("production"))
+//	if err != nil {
+//		return err
+//	}
+//	sel, err := ws.Select(monaco.WithProjects("dashboards"))
+//	if err != nil {
+//		return err
+//	}
+//	result, err := ws.Deploy(ctx, sel, monaco.WithContinueOnError())
+//
+// # Compatibility
+//
+// The exported API of this package follows the semantic versioning of the module. Within a major version, exported
+// identifiers are neither removed nor changed incompatibly; new functions, options and fields of result types may be
+// added. Code using this package should therefore construct result and option types only through the provided
+// functions, and not rely on the exhaustiveness of struct literals.
+//
+// The types of other packages exposed by this package, like manifest.Manifest or project.Project, are not covered by
+// this guarantee beyond what those packages state themselves.
+package monaco
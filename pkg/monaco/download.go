@@ -0,0 +1,97 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monaco
+
+import (
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/tracing"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	dlaccount "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/account"
+	dlautomation "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/dependency_resolution"
+	dldocument "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/document"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/id_extraction"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/settings"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Download downloads all classic configurations, settings objects, and, if available on the environment, automation
+// resources, documents and account resources of the given environment of the Workspace into a project with the given
+// name. References between the downloaded configurations are resolved, and IDs are extracted into parameters.
+// download.WriteToDisk persists the returned configurations as a project. Of the options, only WithClientFactory
+// applies. The download is traced as a child span of the given context.
+func (w *Workspace) Download(ctx context.Context, environment string, projectName string, opts ...Option) (_ project.ConfigsPerType, err error) {
+	o := newOptions(opts)
+
+	env, found := w.Manifest.Environments[environment]
+	if !found {
+		return nil, fmt.Errorf("environment %q is not defined in the manifest", environment)
+	}
+
+	cl, accountClient, err := o.clientFactory(env, w.Manifest.Accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := tracing.Tracer().Start(ctx, "download environment", trace.WithAttributes(tracing.Environment.String(env.Name), tracing.EnvironmentGroup.String(env.Group)))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	configs := make(project.ConfigsPerType)
+	add := func(cfgs project.ConfigsPerType, err error) error {
+		if err != nil {
+			return err
+		}
+		for t, c := range cfgs {
+			configs[t] = c
+		}
+		return nil
+	}
+
+	apis := api.NewAPIs().Filter(func(a api.API) bool {
+		return a.SkipDownload || a.DeprecatedBy != ""
+	})
+	if err := add(classic.NewDownloader(cl.Classic(), classic.WithAPIs(apis)).Download(ctx, projectName)); err != nil {
+		return nil, err
+	}
+	if err := add(settings.NewDownloader(cl.Settings()).Download(ctx, projectName)); err != nil {
+		return nil, err
+	}
+	if cl.Automation() != nil {
+		if err := add(dlautomation.NewDownloader(cl.Automation()).Download(ctx, projectName)); err != nil {
+			return nil, err
+		}
+	}
+	if cl.Document() != nil {
+		if err := add(dldocument.NewDownloader(cl.Document()).Download(ctx, projectName)); err != nil {
+			return nil, err
+		}
+	}
+	if accountClient != nil {
+		if err := add(dlaccount.NewDownloader(accountClient).Download(ctx, projectName)); err != nil {
+			return nil, err
+		}
+	}
+
+	configs = dependency_resolution.ResolveDependencies(configs)
+	// must happen after dependency resolution, as it removes IDs from the payloads the resolution searches in
+	return id_extraction.ExtractIDsIntoYAML(configs), nil
+}
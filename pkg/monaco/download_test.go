@@ -0,0 +1,69 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monaco_test
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/emulator"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/monaco"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWorkspace_DownloadTracesRequestsAsChildSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	server := httptest.NewServer(emulator.New())
+	defer server.Close()
+
+	t.Setenv("EMULATOR_URL", server.URL)
+	t.Setenv("EMULATOR_TOKEN", "dt0c01.ANY.TOKEN")
+
+	fs, manifestPath := writeTestFiles(t, map[string]string{"manifest.yaml": testManifest})
+	ws, err := monaco.Load(fs, manifestPath, monaco.WithoutProjects())
+	require.NoError(t, err)
+
+	_, err = ws.Download(context.TODO(), "emulated", "project")
+	require.NoError(t, err)
+
+	var download sdktrace.ReadOnlySpan
+	children := 0
+	for _, s := range recorder.Ended() {
+		if s.Name() == "download environment" {
+			download = s
+		}
+	}
+	require.NotNil(t, download)
+
+	for _, s := range recorder.Ended() {
+		if s.Parent().SpanID() == download.SpanContext().SpanID() {
+			children++
+		}
+	}
+	assert.Positive(t, children, "requests are traced as children of the download span")
+}
@@ -0,0 +1,174 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monaco
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/spf13/afero"
+	"path/filepath"
+)
+
+// Workspace is a loaded manifest together with all of its projects
+type Workspace struct {
+	// ManifestPath is the path of the manifest the Workspace was loaded from
+	ManifestPath string
+	Manifest     manifest.Manifest
+	Projects     []project.Project
+}
+
+// LoadOption configures how Load loads a Workspace
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	groups             []string
+	environments       []string
+	valuesFiles        []string
+	dontResolveEnvVars bool
+	withoutProjects    bool
+}
+
+// WithGroups restricts the loaded environments to the ones of the given environment groups
+func WithGroups(groups ...string) LoadOption {
+	return func(o *loadOptions) {
+		o.groups = append(o.groups, groups...)
+	}
+}
+
+// WithEnvironments restricts the loaded environments to the given ones
+func WithEnvironments(environments ...string) LoadOption {
+	return func(o *loadOptions) {
+		o.environments = append(o.environments, environments...)
+	}
+}
+
+// WithValuesFiles applies the given values files on top of the ones defined in the manifest. Files given later take
+// precedence.
+func WithValuesFiles(files ...string) LoadOption {
+	return func(o *loadOptions) {
+		o.valuesFiles = append(o.valuesFiles, files...)
+	}
+}
+
+// WithoutEnvVarResolution loads the manifest without resolving the environment variables holding URLs and credentials.
+// A Workspace loaded this way can be rendered and planned, but not deployed.
+func WithoutEnvVarResolution() LoadOption {
+	return func(o *loadOptions) {
+		o.dontResolveEnvVars = true
+	}
+}
+
+// WithoutProjects loads only the manifest, but not its projects. A Workspace loaded this way can be used to download
+// and delete configurations.
+func WithoutProjects() LoadOption {
+	return func(o *loadOptions) {
+		o.withoutProjects = true
+	}
+}
+
+// ManifestLoadError is returned by Load if the manifest could not be loaded
+type ManifestLoadError struct {
+	ManifestPath string
+	Errors       []error
+}
+
+func (e ManifestLoadError) Error() string {
+	return fmt.Sprintf("failed to load manifest %q: %s", e.ManifestPath, errors.Join(e.Errors...))
+}
+
+func (e ManifestLoadError) Unwrap() []error {
+	return e.Errors
+}
+
+// ProjectLoadError is returned by Load if the projects of the manifest could not be loaded
+type ProjectLoadError struct {
+	Errors []error
+}
+
+func (e ProjectLoadError) Error() string {
+	return fmt.Sprintf("failed to load projects: %s", errors.Join(e.Errors...))
+}
+
+func (e ProjectLoadError) Unwrap() []error {
+	return e.Errors
+}
+
+// Load loads the manifest at the given path, and all projects defined in it. Paths of projects and values files are
+// relative to the folder of the manifest. Errors are returned as ManifestLoadError or ProjectLoadError.
+func Load(fs afero.Fs, manifestPath string, opts ...LoadOption) (*Workspace, error) {
+	var o loadOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	manifestPath = filepath.Clean(manifestPath)
+
+	m, errs := manifest.LoadManifest(&manifest.LoaderContext{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Groups:       o.groups,
+		Environments: o.environments,
+		Opts: manifest.LoaderOptions{
+			DontResolveEnvVars: o.dontResolveEnvVars,
+			ValuesFiles:        o.valuesFiles,
+		},
+	})
+	if len(errs) > 0 {
+		return nil, ManifestLoadError{ManifestPath: manifestPath, Errors: errs}
+	}
+
+	ws := &Workspace{
+		ManifestPath: manifestPath,
+		Manifest:     m,
+	}
+	if o.withoutProjects {
+		return ws, nil
+	}
+
+	projects, errs := project.LoadProjects(fs, projectLoaderContext(manifestPath, m))
+	if len(errs) > 0 {
+		return nil, ProjectLoadError{Errors: errs}
+	}
+
+	ws.Projects = projects
+	return ws, nil
+}
+
+// Reload loads the projects with the given IDs again, and keeps all other projects of the Workspace as they are.
+// Errors are returned as ProjectLoadError, in which case the Workspace is not changed.
+func (w *Workspace) Reload(fs afero.Fs, projectIds ...string) error {
+	projects, errs := project.ReloadProjects(fs, projectLoaderContext(w.ManifestPath, w.Manifest), w.Projects, projectIds)
+	if len(errs) > 0 {
+		return ProjectLoadError{Errors: errs}
+	}
+
+	w.Projects = projects
+	return nil
+}
+
+func projectLoaderContext(manifestPath string, m manifest.Manifest) project.ProjectLoaderContext {
+	return project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(manifestPath),
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	}
+}
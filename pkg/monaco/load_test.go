@@ -0,0 +1,108 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monaco_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/monaco"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+const testManifest = `manifestVersion: 1.0
+projects:
+- name: project
+environmentGroups:
+- name: default
+  environments:
+  - name: emulated
+    url:
+      type: environment
+      value: EMULATOR_URL
+    auth:
+      token:
+        name: EMULATOR_TOKEN
+`
+
+// writeTestFiles writes the given files into a temporary folder of the returned file system, and returns the path
+// of the manifest
+func writeTestFiles(t *testing.T, files map[string]string) (afero.Fs, string) {
+	fs := afero.NewMemMapFs()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, name), []byte(content), 0644))
+	}
+	return fs, filepath.Join(dir, "manifest.yaml")
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("EMULATOR_URL", "http://localhost")
+	t.Setenv("EMULATOR_TOKEN", "dt0c01.ANY.TOKEN")
+
+	fs, manifestPath := writeTestFiles(t, map[string]string{
+		"manifest.yaml": testManifest,
+		"project/config.yaml": `configs:
+- id: attribute
+  type:
+    api: request-attributes
+  config:
+    name: Attribute
+    template: attribute.json
+`,
+		"project/attribute.json": `{"name": "{{.name}}"}`,
+	})
+
+	t.Run("manifest and projects are loaded", func(t *testing.T) {
+		ws, err := monaco.Load(fs, manifestPath)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"emulated"}, ws.Manifest.Environments.Names())
+		require.Len(t, ws.Projects, 1)
+		assert.Equal(t, "project", ws.Projects[0].Id)
+	})
+
+	t.Run("projects are not loaded without projects", func(t *testing.T) {
+		ws, err := monaco.Load(fs, manifestPath, monaco.WithoutProjects())
+		require.NoError(t, err)
+
+		assert.Empty(t, ws.Projects)
+	})
+
+	t.Run("unknown environments fail to load the manifest", func(t *testing.T) {
+		_, err := monaco.Load(fs, manifestPath, monaco.WithEnvironments("unknown"))
+
+		var manifestErr monaco.ManifestLoadError
+		assert.ErrorAs(t, err, &manifestErr)
+	})
+
+	t.Run("invalid projects fail to load", func(t *testing.T) {
+		fs, manifestPath := writeTestFiles(t, map[string]string{
+			"manifest.yaml":       testManifest,
+			"project/config.yaml": `configs: [{id: broken}]`,
+		})
+
+		_, err := monaco.Load(fs, manifestPath)
+
+		var projectErr monaco.ProjectLoadError
+		require.ErrorAs(t, err, &projectErr)
+		assert.NotEmpty(t, projectErr.Errors)
+	})
+}
@@ -0,0 +1,72 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monaco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/render"
+)
+
+// RenderError is returned by Workspace.Render if configurations could not be rendered
+type RenderError struct {
+	Errors []error
+}
+
+func (e RenderError) Error() string {
+	return fmt.Sprintf("failed to render configurations: %s", errors.Join(e.Errors...))
+}
+
+func (e RenderError) Unwrap() []error {
+	return e.Errors
+}
+
+// Render renders the payloads of the selected configurations for all environments of the Workspace, as they would be
+// deployed. All projects of the Workspace are rendered, as the selected configurations may reference configurations of
+// others, but only the selected configurations are returned. See render.Render for how references are rendered. Errors are
+// returned as RenderError. Nothing is rendered if the given context is done already.
+func (w *Workspace) Render(ctx context.Context, sel Selection) ([]render.Config, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rendered, errs := render.Render(w.Projects, w.Manifest.Environments.Names())
+	if len(errs) > 0 {
+		return nil, RenderError{Errors: errs}
+	}
+
+	selected := make(map[coordinate.Coordinate]struct{})
+	for _, p := range sel.Projects {
+		for _, cfgsPerType := range p.Configs {
+			for _, cfgs := range cfgsPerType {
+				for _, c := range cfgs {
+					selected[c.Coordinate] = struct{}{}
+				}
+			}
+		}
+	}
+
+	result := make([]render.Config, 0, len(rendered))
+	for _, c := range rendered {
+		if _, found := selected[c.Coordinate]; found {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,235 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monaco
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/slices"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2/selection"
+	"strings"
+)
+
+// Selection holds the projects to work with, and the projects holding configurations which are not deployed, but may
+// be referenced by the selected ones
+type Selection struct {
+	Projects   []project.Project
+	Referenced []project.Project
+}
+
+// SelectOption configures which configurations Workspace.Select selects
+type SelectOption func(*selectOptions)
+
+type selectOptions struct {
+	projects         []string
+	remoteReferences bool
+	selector         selection.Selector
+	noDeps           bool
+}
+
+// WithProjects selects the projects with the given IDs or group IDs, and all projects they depend on
+func WithProjects(ids ...string) SelectOption {
+	return func(o *selectOptions) {
+		o.projects = append(o.projects, ids...)
+	}
+}
+
+// WithRemoteReferences selects only the projects given by WithProjects, without the projects they depend on. References
+// to configurations of other projects are resolved by looking up the referenced objects on the environments.
+func WithRemoteReferences() SelectOption {
+	return func(o *selectOptions) {
+		o.remoteReferences = true
+	}
+}
+
+// WithSelector narrows the selected configurations down further than to the projects given by WithProjects
+func WithSelector(s selection.Selector) SelectOption {
+	return func(o *selectOptions) {
+		o.selector = s
+	}
+}
+
+// WithoutDependencies states that configurations the ones matching the selector depend on are not selected, but
+// resolved like remote references. It requires a selector given by WithSelector.
+func WithoutDependencies() SelectOption {
+	return func(o *selectOptions) {
+		o.noDeps = true
+	}
+}
+
+// Select returns the configurations of the Workspace matching the given options. Without options, all projects are
+// selected.
+func (w *Workspace) Select(opts ...SelectOption) (Selection, error) {
+	var o selectOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := o.selector.Validate(); err != nil {
+		return Selection{}, err
+	}
+	if o.noDeps && o.selector.IsEmpty() {
+		return Selection{}, errors.New("configurations can only be selected without their dependencies by a selector")
+	}
+
+	environments := w.Manifest.Environments.Names()
+
+	filteredProjects, err := filterProjects(w.Projects, o.projects, environments, o.remoteReferences)
+	if err != nil {
+		return Selection{}, fmt.Errorf("error while loading relevant projects to deploy: %w", err)
+	}
+
+	var referencedProjects []project.Project
+	if o.remoteReferences {
+		referencedProjects = projectsNotIn(w.Projects, filteredProjects)
+	}
+
+	if !o.selector.IsEmpty() {
+		var excludedProjects []project.Project
+		filteredProjects, excludedProjects, err = selection.Select(filteredProjects, environments, o.selector, !o.noDeps)
+		if err != nil {
+			return Selection{}, fmt.Errorf("error while selecting configurations to deploy: %w", err)
+		}
+
+		// configurations which are not selected may still be referenced if their deployment is skipped with noDeps
+		referencedProjects = append(referencedProjects, excludedProjects...)
+	}
+
+	return Selection{Projects: filteredProjects, Referenced: referencedProjects}, nil
+}
+
+// filterProjects returns the projects with the given names, and all projects they depend on. If withoutDependencies is
+// set, only the projects with the given names are returned.
+func filterProjects(projects []project.Project, specificProjects []string, specificEnvironments []string, withoutDependencies bool) ([]project.Project, error) {
+
+	if len(specificProjects) > 0 {
+		filtered, err := filterProjectsByName(projects, specificProjects)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if withoutDependencies {
+			lookupMap := toProjectMap(projects)
+			result := make([]project.Project, 0, len(filtered))
+			for _, id := range filtered {
+				result = append(result, lookupMap[id])
+			}
+			return result, nil
+		}
+
+		projectsWithDependencies, err := loadProjectsWithDependencies(projects, filtered, specificEnvironments)
+
+		if err != nil {
+			return nil, err
+		}
+
+		projects = projectsWithDependencies
+	}
+
+	return projects, nil
+}
+
+func filterProjectsByName(projects []project.Project, names []string) ([]string, error) {
+	var result []string
+
+	foundProjects := map[string]struct{}{}
+
+	for _, p := range projects {
+		if slices.Contains(names, p.Id) {
+			foundProjects[p.Id] = struct{}{}
+			result = append(result, p.Id)
+		} else if slices.Contains(names, p.GroupId) {
+			foundProjects[p.GroupId] = struct{}{}
+			result = append(result, p.Id)
+		}
+	}
+
+	var notFoundProjects []string
+
+	for _, name := range names {
+		if _, found := foundProjects[name]; !found {
+			notFoundProjects = append(notFoundProjects, name)
+		}
+	}
+
+	if notFoundProjects != nil {
+		return nil, fmt.Errorf("no project with names `%s` found", strings.Join(names, ", "))
+	}
+
+	return result, nil
+}
+
+func loadProjectsWithDependencies(projects []project.Project, projectIdsToLoad []string, environments []string) ([]project.Project, error) {
+	lookupMap := toProjectMap(projects)
+	alreadyChecked := map[string]struct{}{}
+	toCheck := append(make([]string, 0, len(projectIdsToLoad)), projectIdsToLoad...)
+
+	var result []project.Project
+	var unknownProjects []string
+
+	for len(toCheck) > 0 {
+		current := toCheck[0]
+		toCheck = toCheck[1:]
+
+		if _, found := alreadyChecked[current]; found {
+			continue
+		}
+
+		if project, found := lookupMap[current]; found {
+			alreadyChecked[current] = struct{}{}
+			result = append(result, project)
+
+			// we need to load only the dependencies of environments we are going to deploy
+			for _, env := range environments {
+				toCheck = append(toCheck, project.Dependencies[env]...)
+			}
+		} else {
+			unknownProjects = append(unknownProjects, current)
+		}
+	}
+
+	if unknownProjects != nil {
+		return nil, fmt.Errorf("error while gathering dependencies. no projects with name `%s` found", unknownProjects)
+	}
+
+	return result, nil
+}
+
+// projectsNotIn returns all projects which are not contained in the given subset
+func projectsNotIn(projects []project.Project, subset []project.Project) []project.Project {
+	ids := toProjectMap(subset)
+
+	var result []project.Project
+	for _, p := range projects {
+		if _, found := ids[p.Id]; !found {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func toProjectMap(projects []project.Project) map[string]project.Project {
+	result := make(map[string]project.Project)
+
+	for _, p := range projects {
+		result[p.Id] = p
+	}
+
+	return result
+}
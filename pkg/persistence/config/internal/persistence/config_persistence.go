@@ -31,11 +31,16 @@ type EnvironmentOverride struct {
 }
 
 type ConfigDefinition struct {
-	Name           ConfigParameter            `yaml:"name,omitempty"`
-	Parameters     map[string]ConfigParameter `yaml:"parameters,omitempty"`
-	Template       string                     `yaml:"template,omitempty"`
-	Skip           ConfigParameter            `yaml:"skip,omitempty"`
-	OriginObjectId string                     `yaml:"originObjectId,omitempty"`
+	Name                ConfigParameter            `yaml:"name,omitempty"`
+	Parameters          map[string]ConfigParameter `yaml:"parameters,omitempty"`
+	Template            string                     `yaml:"template,omitempty"`
+	Skip                ConfigParameter            `yaml:"skip,omitempty"`
+	OriginObjectId      string                     `yaml:"originObjectId,omitempty"`
+	Description         string                     `yaml:"description,omitempty"`
+	Owner               string                     `yaml:"owner,omitempty"`
+	Labels              map[string]string          `yaml:"labels,omitempty"`
+	DependsOn           []string                   `yaml:"dependsOn,omitempty"`
+	EnvironmentSpecific []string                   `yaml:"environmentSpecific,omitempty"`
 }
 
 type TopLevelConfigDefinition struct {
@@ -22,6 +22,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/mitchellh/mapstructure"
+	"golang.org/x/exp/slices"
 )
 
 const ApiTypeBucket = "bucket"
@@ -31,6 +32,9 @@ type TypeDefinition struct {
 	Settings   SettingsDefinition   `yaml:"settings,omitempty"`
 	Entities   EntitiesDefinition   `yaml:"entities,omitempty"`
 	Automation AutomationDefinition `yaml:"automation,omitempty"`
+	Extension  ExtensionDefinition  `yaml:"extension,omitempty"`
+	Document   DocumentDefinition   `yaml:"document,omitempty"`
+	Account    AccountDefinition    `yaml:"account,omitempty"`
 }
 
 type SettingsDefinition struct {
@@ -47,6 +51,27 @@ type AutomationDefinition struct {
 	Resource config.AutomationResource `yaml:"resource"`
 }
 
+// ExtensionDefinition defines a monitoring configuration of an Extensions 2.0 extension
+type ExtensionDefinition struct {
+	// Name of the extension
+	Name string `yaml:"name,omitempty"`
+	// Archive is the path of the signed extension package, relative to the config file
+	Archive string          `yaml:"archive,omitempty"`
+	Scope   ConfigParameter `yaml:"scope,omitempty"`
+}
+
+// DocumentDefinition defines a Dynatrace Platform document, like a dashboard or a notebook
+type DocumentDefinition struct {
+	Kind config.DocumentKind `yaml:"kind,omitempty"`
+	// Private documents are only visible to their owner
+	Private bool `yaml:"private,omitempty"`
+}
+
+// AccountDefinition defines a resource of the Dynatrace account management
+type AccountDefinition struct {
+	Resource config.AccountResource `yaml:"resource"`
+}
+
 // UnmarshalYAML Custom unmarshaler that knows how to handle TypeDefinition.
 // 'type' section can come as string or as struct as it is defind in `TypeDefinition`
 // function parameter more than once if necessary.
@@ -76,6 +101,9 @@ func (c *TypeDefinition) IsSound(knownApis map[string]struct{}) error {
 	settingsErrs := c.Settings.isSettingsSound()
 	entitiesErrs := c.Entities.isEntitiesSound()
 	automationErr := c.Automation.isSound()
+	extensionErr := c.Extension.isSound()
+	documentErr := c.Document.isSound()
+	accountErr := c.Account.isSound()
 
 	types := 0
 	var err error
@@ -96,9 +124,21 @@ func (c *TypeDefinition) IsSound(knownApis map[string]struct{}) error {
 		types++
 		err = automationErr
 	}
+	if c.IsExtension() {
+		types++
+		err = extensionErr
+	}
+	if c.IsDocument() {
+		types++
+		err = documentErr
+	}
+	if c.IsAccount() {
+		types++
+		err = accountErr
+	}
 
 	typesSound := 0
-	for _, e := range []error{classicErrs, settingsErrs, entitiesErrs, automationErr} {
+	for _, e := range []error{classicErrs, settingsErrs, entitiesErrs, automationErr, extensionErr, documentErr, accountErr} {
 		if e == nil {
 			typesSound += 1
 		}
@@ -186,6 +226,55 @@ func (c *AutomationDefinition) isSound() error {
 	}
 }
 
+func (c *TypeDefinition) IsExtension() bool {
+	return c.Extension != ExtensionDefinition{}
+}
+
+func (c *ExtensionDefinition) isSound() error {
+	var s []string
+	if c.Name == "" {
+		s = append(s, "type.extension.name")
+	}
+	if c.Archive == "" {
+		s = append(s, "type.extension.archive")
+	}
+	if c.Scope == nil {
+		s = append(s, "type.extension.scope")
+	}
+	if s == nil {
+		return nil
+	}
+	return fmt.Errorf("next property missing: %v", s)
+}
+
+func (c *TypeDefinition) IsDocument() bool {
+	return c.Document != DocumentDefinition{}
+}
+
+func (c *DocumentDefinition) isSound() error {
+	if c.Kind == "" {
+		return errors.New("missing 'type.document.kind' property")
+	}
+	if !slices.Contains(config.KnownDocumentKinds, c.Kind) {
+		return fmt.Errorf("unknown document kind %q", c.Kind)
+	}
+	return nil
+}
+
+func (c *TypeDefinition) IsAccount() bool {
+	return c.Account != AccountDefinition{}
+}
+
+func (c *AccountDefinition) isSound() error {
+	if c.Resource == "" {
+		return errors.New("missing 'type.account.resource' property")
+	}
+	if !slices.Contains(config.KnownAccountResources, c.Resource) {
+		return fmt.Errorf("unknown account resource %q", c.Resource)
+	}
+	return nil
+}
+
 func (c *TypeDefinition) GetApiType() string {
 	switch {
 	case c.IsSettings():
@@ -196,6 +285,12 @@ func (c *TypeDefinition) GetApiType() string {
 		return c.Entities.EntitiesType
 	case c.IsAutomation():
 		return string(c.Automation.Resource)
+	case c.IsExtension():
+		return c.Extension.Name
+	case c.IsDocument():
+		return string(config.DocumentTypeId)
+	case c.IsAccount():
+		return string(c.Account.Resource)
 	default:
 		return ""
 	}
@@ -20,6 +20,7 @@ package persistence
 
 import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 	"testing"
@@ -171,6 +172,92 @@ func Test_typeDefinition_isSound(t *testing.T) {
 				result: true,
 			},
 		},
+		{
+			name: "Extension - sound",
+			fields: fields{
+				configType: TypeDefinition{
+					Extension: ExtensionDefinition{
+						Name:    "com.example.extension",
+						Archive: "extension.zip",
+						Scope:   "environment",
+					},
+				},
+			},
+			want: expect{
+				result: true,
+			},
+		},
+		{
+			name: "Extension - incomplete",
+			fields: fields{
+				configType: TypeDefinition{
+					Extension: ExtensionDefinition{
+						Name: "com.example.extension",
+					},
+				},
+			},
+			want: expect{false, "next property missing: [type.extension.archive type.extension.scope]"},
+		},
+		{
+			name: "Document - sound",
+			fields: fields{
+				configType: TypeDefinition{
+					Document: DocumentDefinition{
+						Kind:    config.NotebookKind,
+						Private: true,
+					},
+				},
+			},
+			want: expect{
+				result: true,
+			},
+		},
+		{
+			name: "Document - missing kind",
+			fields: fields{
+				configType: TypeDefinition{
+					Document: DocumentDefinition{
+						Private: true,
+					},
+				},
+			},
+			want: expect{false, "missing 'type.document.kind' property"},
+		},
+		{
+			name: "Document - unknown kind",
+			fields: fields{
+				configType: TypeDefinition{
+					Document: DocumentDefinition{
+						Kind: "launchpad",
+					},
+				},
+			},
+			want: expect{false, `unknown document kind "launchpad"`},
+		},
+		{
+			name: "Account - sound",
+			fields: fields{
+				configType: TypeDefinition{
+					Account: AccountDefinition{
+						Resource: config.PolicyBinding,
+					},
+				},
+			},
+			want: expect{
+				result: true,
+			},
+		},
+		{
+			name: "Account - unknown resource",
+			fields: fields{
+				configType: TypeDefinition{
+					Account: AccountDefinition{
+						Resource: "service-user",
+					},
+				},
+			},
+			want: expect{false, `unknown account resource "service-user"`},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
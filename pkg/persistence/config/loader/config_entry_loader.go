@@ -24,6 +24,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/extension"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/internal/persistence"
 	"github.com/spf13/afero"
@@ -105,6 +106,7 @@ func parseDefinitionForEnvironment(
 	configDefinition := persistence.ConfigDefinition{
 		Parameters:     make(map[string]persistence.ConfigParameter),
 		OriginObjectId: definition.Config.OriginObjectId,
+		Labels:         make(map[string]string),
 	}
 
 	applyOverrides(&configDefinition, definition.Config)
@@ -139,10 +141,30 @@ func applyOverrides(base *persistence.ConfigDefinition, override persistence.Con
 		base.OriginObjectId = override.OriginObjectId
 	}
 
+	if override.Description != "" {
+		base.Description = override.Description
+	}
+
+	if override.Owner != "" {
+		base.Owner = override.Owner
+	}
+
+	if override.DependsOn != nil {
+		base.DependsOn = override.DependsOn
+	}
+
+	if override.EnvironmentSpecific != nil {
+		base.EnvironmentSpecific = override.EnvironmentSpecific
+	}
+
 	for name, param := range override.Parameters {
 		base.Parameters[name] = param
 	}
 
+	for key, value := range override.Labels {
+		base.Labels[key] = value
+	}
+
 }
 
 func getConfigFromDefinition(
@@ -200,16 +222,31 @@ func getConfigFromDefinition(
 			parameters[config.NameParameter] = name
 		}
 
-	} else if t.ID() == config.ClassicApiTypeId {
+	} else if t.ID() == config.ClassicApiTypeId || t.ID() == config.ExtensionTypeId || t.ID() == config.DocumentTypeId || isNamedAccountResource(t) {
 		errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, "missing parameter `name`"))
 	}
 
+	if configType.IsExtension() {
+		t, err = loadExtensionType(fs, context, configType.Extension)
+		if err != nil {
+			errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, err.Error()))
+		}
+	}
+
+	dependsOn, dependsOnErrs := parseDependsOn(context, environment, configId, definition.DependsOn)
+	errs = append(errs, dependsOnErrs...)
+
 	if errs != nil {
 		return config.Config{}, errs
 	}
 
-	if configType.IsSettings() {
-		scopeParam, err := parseParameter(context, environment, configId, config.ScopeParameter, configType.Settings.Scope)
+	if configType.IsSettings() || configType.IsExtension() {
+		scope := configType.Settings.Scope
+		if configType.IsExtension() {
+			scope = configType.Extension.Scope
+		}
+
+		scopeParam, err := parseParameter(context, environment, configId, config.ScopeParameter, scope)
 		if err != nil {
 			return config.Config{}, []error{fmt.Errorf("failed to parse scope: %w", err)}
 		}
@@ -234,9 +271,72 @@ func getConfigFromDefinition(
 		Parameters:     parameters,
 		Skip:           skipConfig,
 		OriginObjectId: definition.OriginObjectId,
+		Description:    definition.Description,
+		Owner:          definition.Owner,
+		Labels:         labels(definition.Labels),
+		DependsOn:      dependsOn,
+
+		EnvironmentSpecific: definition.EnvironmentSpecific,
 	}, nil
 }
 
+// loadExtensionType loads the extension package of the given definition, which must contain the defined extension
+func loadExtensionType(fs afero.Fs, context *singleConfigEntryLoadContext, definition persistence.ExtensionDefinition) (config.ExtensionType, error) {
+	archivePath := filepath.Join(context.Folder, filepath.FromSlash(definition.Archive))
+	content, err := afero.ReadFile(fs, archivePath)
+	if err != nil {
+		return config.ExtensionType{}, fmt.Errorf("failed to read extension package %q: %w", definition.Archive, err)
+	}
+
+	p, err := extension.ReadPackage(content)
+	if err != nil {
+		return config.ExtensionType{}, fmt.Errorf("invalid extension package %q: %w", definition.Archive, err)
+	}
+
+	if p.Name != definition.Name {
+		return config.ExtensionType{}, fmt.Errorf("extension package %q contains extension %q, not %q", definition.Archive, p.Name, definition.Name)
+	}
+
+	return config.ExtensionType{
+		Name:           p.Name,
+		Version:        p.Version,
+		Archive:        archivePath,
+		ArchiveContent: content,
+	}, nil
+}
+
+// parseDependsOn parses the coordinates of the configs the given config explicitly depends on
+func parseDependsOn(context *singleConfigEntryLoadContext, environment manifest.EnvironmentDefinition, configId string, dependsOn []string) ([]coordinate.Coordinate, []error) {
+	var result []coordinate.Coordinate
+	var errs []error
+
+	for _, d := range dependsOn {
+		c, err := coordinate.Parse(d)
+		if err != nil {
+			errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, fmt.Sprintf("invalid `dependsOn` entry: %s", err)))
+			continue
+		}
+
+		if c.Project == context.ProjectId && c.Type == context.Type && c.ConfigId == configId {
+			errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, "config must not depend on itself"))
+			continue
+		}
+
+		result = append(result, c)
+	}
+
+	return result, errs
+}
+
+// labels returns nil for empty labels, so that configs without labels are equal regardless of whether they were loaded
+// with overrides
+func labels(l map[string]string) map[string]string {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
 func getType(typeDef persistence.TypeDefinition) (config.Type, error) {
 	switch {
 	case typeDef.IsSettings():
@@ -264,11 +364,34 @@ func getType(typeDef persistence.TypeDefinition) (config.Type, error) {
 			Resource: typeDef.Automation.Resource,
 		}, nil
 
+	case typeDef.IsExtension():
+		// the version and package are loaded from the archive
+		return config.ExtensionType{
+			Name: typeDef.Extension.Name,
+		}, nil
+
+	case typeDef.IsDocument():
+		return config.DocumentType{
+			Kind:    typeDef.Document.Kind,
+			Private: typeDef.Document.Private,
+		}, nil
+
+	case typeDef.IsAccount():
+		return config.AccountType{
+			Resource: typeDef.Account.Resource,
+		}, nil
+
 	default:
 		return nil, errors.New("unknown type")
 	}
 }
 
+// isNamedAccountResource returns true if the given type is an account resource which is identified by its name
+func isNamedAccountResource(t config.Type) bool {
+	a, ok := t.(config.AccountType)
+	return ok && (a.Resource == config.UserGroup || a.Resource == config.Policy)
+}
+
 func parseSkip(
 	context *singleConfigEntryLoadContext,
 	environmentDefinition manifest.EnvironmentDefinition,
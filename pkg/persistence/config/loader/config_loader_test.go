@@ -19,6 +19,8 @@
 package loader
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
@@ -29,10 +31,12 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
 	ref "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/values"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -52,6 +56,9 @@ func Test_parseConfigs(t *testing.T) {
 				Auth: manifest.Auth{
 					Token: manifest.AuthSecret{Name: "token var"},
 				},
+				Values: map[string]interface{}{
+					"alerting": map[string]interface{}{"threshold": 10},
+				},
 			},
 		},
 		ParametersSerDe: config.DefaultParameterParsers,
@@ -706,6 +713,132 @@ configs:
       resource: does-not-exist`,
 			wantErrorsContain: []string{`unknown automation resource "does-not-exist"`},
 		},
+		{
+			name:             "load a private notebook",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: notebook-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+  type:
+    document:
+      kind: notebook
+      private: true`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "document",
+						ConfigId: "notebook-id",
+					},
+					Type: config.DocumentType{
+						Kind:    config.NotebookKind,
+						Private: true,
+					},
+					Template: template.CreateTemplateFromString("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Star Trek > Star Wars"},
+					},
+					Skip:        false,
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "documents require a name",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: dashboard-id
+  config:
+    template: 'profile.json'
+  type:
+    document:
+      kind: dashboard`,
+			wantErrorsContain: []string{"missing parameter `name`"},
+		},
+		{
+			name:             "load a policy",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: policy-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+  type:
+    account:
+      resource: policy`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "policy",
+						ConfigId: "policy-id",
+					},
+					Type: config.AccountType{
+						Resource: config.Policy,
+					},
+					Template: template.CreateTemplateFromString("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Star Trek > Star Wars"},
+					},
+					Skip:        false,
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "user groups require a name",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: group-id
+  config:
+    template: 'profile.json'
+  type:
+    account:
+      resource: user-group`,
+			wantErrorsContain: []string{"missing parameter `name`"},
+		},
+		{
+			name:             "policy bindings do not require a name",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: binding-id
+  config:
+    template: 'profile.json'
+  type:
+    account:
+      resource: policy-binding`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "policy-binding",
+						ConfigId: "binding-id",
+					},
+					Type: config.AccountType{
+						Resource: config.PolicyBinding,
+					},
+					Template:    template.CreateTemplateFromString("profile.json", "{}"),
+					Parameters:  config.Parameters{},
+					Skip:        false,
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
 		{
 			name:             "fails to load with a parameter that is 'id'",
 			filePathArgument: "test-file.yaml",
@@ -914,6 +1047,165 @@ configs:
 				},
 			},
 		},
+		{
+			name:             "Metadata is loaded and labels are merged with overrides",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek Service'
+    template: 'profile.json'
+    description: 'Alerts the bridge'
+    owner: 'enterprise'
+    labels:
+      team: 'bridge'
+      tier: 'critical'
+  type:
+    api: some-api
+  environmentOverrides:
+    - environment: 'env name'
+      override:
+        owner: 'voyager'
+        labels:
+          tier: 'low'
+`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "some-api",
+						ConfigId: "profile-id",
+					},
+					Type: config.ClassicApiType{
+						Api: "some-api",
+					},
+					Template: template.CreateTemplateFromString("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Star Trek Service"},
+					},
+					Skip:        false,
+					Environment: "env name",
+					Group:       "default",
+					Description: "Alerts the bridge",
+					Owner:       "voyager",
+					Labels:      map[string]string{"team": "bridge", "tier": "low"},
+				},
+			},
+		},
+		{
+			name:             "DependsOn is loaded",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek Service'
+    template: 'profile.json'
+    dependsOn:
+      - 'project:workflow:bridge-alert'
+      - 'other-project:builtin:alerting.profile:ops'
+  type:
+    api: some-api
+`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "some-api",
+						ConfigId: "profile-id",
+					},
+					Type: config.ClassicApiType{
+						Api: "some-api",
+					},
+					Template: template.CreateTemplateFromString("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Star Trek Service"},
+					},
+					Skip:        false,
+					Environment: "env name",
+					Group:       "default",
+					DependsOn: []coordinate.Coordinate{
+						{Project: "project", Type: "workflow", ConfigId: "bridge-alert"},
+						{Project: "other-project", Type: "builtin:alerting.profile", ConfigId: "ops"},
+					},
+				},
+			},
+		},
+		{
+			name:             "DependsOn with invalid coordinate or on itself fails",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek Service'
+    template: 'profile.json'
+    dependsOn:
+      - 'workflow:bridge-alert'
+      - 'project:some-api:profile-id'
+  type:
+    api: some-api
+`,
+			wantErrorsContain: []string{"invalid `dependsOn` entry", "config must not depend on itself"},
+		},
+		{
+			name:             "Values parameter is resolved from the values of the environment",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile
+  config:
+    name: Star Trek Service
+    template: profile.json
+    parameters:
+      threshold:
+        type: values
+        key: alerting.threshold
+  type:
+    api: some-api`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "some-api",
+						ConfigId: "profile",
+					},
+					Type: config.ClassicApiType{
+						Api: "some-api",
+					},
+					Template: template.CreateTemplateFromString("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":      &value.ValueParameter{Value: "Star Trek Service"},
+						"threshold": &values.ValuesParameter{Key: "alerting.threshold", Value: 10},
+					},
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "Values parameter with unknown key reports an error",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile
+  config:
+    name: Star Trek Service
+    template: profile.json
+    parameters:
+      threshold:
+        type: values
+        key: alerting.missing.threshold
+  type:
+    api: some-api`,
+			wantErrorsContain: []string{`failed to resolve values key "alerting.missing.threshold" for environment "env name": "alerting.missing" is not defined`},
+		},
 		{
 			name: "Bucket with FF off",
 			envVars: map[string]string{
@@ -957,6 +1249,161 @@ configs:
 	}
 }
 
+func Test_parseConfigs_Extension(t *testing.T) {
+	testLoaderContext := &LoaderContext{
+		ProjectId: "project",
+		Path:      "some-dir/",
+		KnownApis: map[string]struct{}{"some-api": {}},
+		Environments: []manifest.EnvironmentDefinition{
+			{
+				Name:  "env name",
+				URL:   manifest.URLDefinition{Type: manifest.ValueURLType, Value: "env url"},
+				Group: "default",
+			},
+		},
+		ParametersSerDe: config.DefaultParameterParsers,
+	}
+
+	archive := createExtensionPackage(t, "name: com.example.extension\nversion: 1.2.3\n")
+
+	tests := []struct {
+		name              string
+		fileContentOnDisk string
+		wantConfigs       []config.Config
+		wantErrorsContain []string
+	}{
+		{
+			name: "loads extension config",
+			fileContentOnDisk: `
+configs:
+- id: sql-server
+  config:
+    name: 'SQL Server'
+    template: 'profile.json'
+  type:
+    extension:
+      name: com.example.extension
+      archive: extensions/extension.zip
+      scope: environment`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "com.example.extension",
+						ConfigId: "sql-server",
+					},
+					Type: config.ExtensionType{
+						Name:           "com.example.extension",
+						Version:        "1.2.3",
+						Archive:        "extensions/extension.zip",
+						ArchiveContent: archive,
+					},
+					Template: template.CreateTemplateFromString("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter:  &value.ValueParameter{Value: "SQL Server"},
+						config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+					},
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name: "fails on missing properties",
+			fileContentOnDisk: `
+configs:
+- id: sql-server
+  config:
+    name: 'SQL Server'
+    template: 'profile.json'
+  type:
+    extension:
+      name: com.example.extension`,
+			wantErrorsContain: []string{"type.extension.archive type.extension.scope"},
+		},
+		{
+			name: "fails on missing name",
+			fileContentOnDisk: `
+configs:
+- id: sql-server
+  config:
+    template: 'profile.json'
+  type:
+    extension:
+      name: com.example.extension
+      archive: extensions/extension.zip
+      scope: environment`,
+			wantErrorsContain: []string{"missing parameter `name`"},
+		},
+		{
+			name: "fails on missing package",
+			fileContentOnDisk: `
+configs:
+- id: sql-server
+  config:
+    name: 'SQL Server'
+    template: 'profile.json'
+  type:
+    extension:
+      name: com.example.extension
+      archive: unknown.zip
+      scope: environment`,
+			wantErrorsContain: []string{"failed to read extension package \"unknown.zip\""},
+		},
+		{
+			name: "fails on package of other extension",
+			fileContentOnDisk: `
+configs:
+- id: sql-server
+  config:
+    name: 'SQL Server'
+    template: 'profile.json'
+  type:
+    extension:
+      name: com.example.other
+      archive: extensions/extension.zip
+      scope: environment`,
+			wantErrorsContain: []string{"contains extension \"com.example.extension\", not \"com.example.other\""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFs := afero.NewMemMapFs()
+			_ = afero.WriteFile(testFs, "test-file.yaml", []byte(tt.fileContentOnDisk), 0644)
+			_ = afero.WriteFile(testFs, "profile.json", []byte("{}"), 0644)
+			_ = afero.WriteFile(testFs, "extensions/extension.zip", archive, 0644)
+
+			gotConfigs, gotErrors := LoadConfig(testFs, testLoaderContext, "test-file.yaml")
+			if len(tt.wantErrorsContain) != 0 {
+				assert.Equal(t, len(tt.wantErrorsContain), len(gotErrors), "expected %v errors but got %v", len(tt.wantErrorsContain), len(gotErrors))
+
+				for i, err := range gotErrors {
+					assert.ErrorContains(t, err, tt.wantErrorsContain[i])
+				}
+				return
+			}
+			assert.Empty(t, gotErrors, "expected no errors but got: %v", gotErrors)
+			assert.Equal(t, tt.wantConfigs, gotConfigs)
+		})
+	}
+}
+
+// createExtensionPackage creates a signed extension package containing the given extension.yaml
+func createExtensionPackage(t *testing.T, manifest string) []byte {
+	createZip := func(name string, content []byte) []byte {
+		buffer := new(bytes.Buffer)
+		w := zip.NewWriter(buffer)
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return buffer.Bytes()
+	}
+
+	return createZip("extension.zip", createZip("extension.yaml", []byte(manifest)))
+}
+
 func Test_validateParameter(t *testing.T) {
 	knownAPIs := map[string]struct{}{"some-api": {}, "other-api": {}}
 
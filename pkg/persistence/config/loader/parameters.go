@@ -129,8 +129,11 @@ func parseParameter(context *singleConfigEntryLoadContext, environment manifest.
 				Type:     context.Type,
 				ConfigId: configId,
 			},
+			Group:         environment.Group,
+			Environment:   environment.Name,
 			ParameterName: name,
 			Value:         maps.ToStringMap(val),
+			Values:        environment.Values,
 		})
 	}
 
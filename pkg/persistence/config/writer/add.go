@@ -0,0 +1,154 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package writer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/internal/persistence"
+	"github.com/spf13/afero"
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+	"path/filepath"
+)
+
+// AddConfigs adds the given configs to the config files of their project and type, creating the files if they do not
+// exist yet. In contrast to WriteConfigs, configs already defined in the files are kept, and the files are written in
+// the canonical form of FormatConfigFile.
+// Nothing is written if one of the configs is already defined, or if one of their templates already exists.
+func AddConfigs(context *WriterContext, configs []config.Config) []error {
+	definitions, templates, errs := toTopLevelDefinitions(context, configs)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	for _, t := range templates {
+		fullTemplatePath := filepath.Join(context.OutputFolder, t.templatePath)
+		if exists, err := afero.Exists(context.Fs, fullTemplatePath); err != nil {
+			errs = append(errs, newConfigWriterError(context, err))
+		} else if exists {
+			errs = append(errs, newConfigWriterError(context, fmt.Errorf("template %q already exists", fullTemplatePath)))
+		}
+	}
+
+	configFiles := make(map[string][]byte, len(definitions))
+	for apiCoord, definition := range definitions {
+		configFile := filepath.Join(context.OutputFolder, context.ProjectFolder, sanitize(apiCoord.api), "config.yaml")
+
+		content, err := addToConfigFile(context.Fs, configFile, definition)
+		if err != nil {
+			errs = append(errs, newConfigWriterError(context, err))
+			continue
+		}
+		configFiles[configFile] = content
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	for configFile, content := range configFiles {
+		if err := context.Fs.MkdirAll(filepath.Dir(configFile), 0777); err != nil {
+			errs = append(errs, newConfigWriterError(context, err))
+			continue
+		}
+
+		if err := afero.WriteFile(context.Fs, configFile, content, 0664); err != nil {
+			errs = append(errs, newConfigWriterError(context, err))
+		}
+	}
+
+	errs = append(errs, writeTemplates(context, templates)...)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// addToConfigFile returns the content of the given config file with the configs of the given definition appended.
+func addToConfigFile(fs afero.Fs, configFile string, definition persistence.TopLevelDefinition) ([]byte, error) {
+	added, err := yamlv2.Marshal(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := afero.ReadFile(fs, configFile)
+	if errors.Is(err, afero.ErrFileNotFound) || (err == nil && len(bytes.TrimSpace(existing)) == 0) {
+		return FormatConfigFile(added)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var doc, addedDoc yaml.Node
+	if err := yaml.Unmarshal(existing, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %q: %w", configFile, err)
+	}
+	if err := yaml.Unmarshal(added, &addedDoc); err != nil {
+		return nil, err
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config file %q does not define configs", configFile)
+	}
+
+	configs := mappingValue(root, "configs")
+	if configs == nil {
+		configs = &yaml.Node{Kind: yaml.SequenceNode}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "configs"}, configs)
+	}
+
+	existingIds := map[string]struct{}{}
+	for _, c := range configs.Content {
+		if id := mappingValue(c, "id"); id != nil {
+			existingIds[id.Value] = struct{}{}
+		}
+	}
+
+	for _, c := range mappingValue(addedDoc.Content[0], "configs").Content {
+		id := mappingValue(c, "id").Value
+		if _, exists := existingIds[id]; exists {
+			return nil, fmt.Errorf("config %q is already defined in config file %q", id, configFile)
+		}
+		configs.Content = append(configs.Content, c)
+	}
+
+	merged, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, err
+	}
+	return FormatConfigFile(merged)
+}
+
+// mappingValue returns the value of the given key of a mapping node, or nil if the node is no mapping or does not
+// contain the key.
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package writer
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+func newSettingsConfig(id string) config.Config {
+	return config.Config{
+		Template:   template.NewDownloadTemplate(id, id, "{}"),
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: id},
+		Type:       config.SettingsType{SchemaId: "builtin:alerting.profile"},
+		Parameters: map[string]parameter.Parameter{
+			config.NameParameter:  &value.ValueParameter{Value: id},
+			config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+		},
+	}
+}
+
+func writeTestFile(t *testing.T, fs afero.Fs, path, content string) {
+	require.NoError(t, fs.MkdirAll(filepath.Dir(path), 0777))
+	require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+}
+
+func newAddContext(fs afero.Fs) *WriterContext {
+	return &WriterContext{
+		Fs:              fs,
+		OutputFolder:    "test",
+		ProjectFolder:   "project",
+		ParametersSerde: config.DefaultParameterParsers,
+	}
+}
+
+func TestAddConfigs_CreatesConfigFile(t *testing.T) {
+	fs := testutils.TempFs(t)
+
+	errs := AddConfigs(newAddContext(fs), []config.Config{newSettingsConfig("profile")})
+	require.Empty(t, errs)
+
+	content, err := afero.ReadFile(fs, "test/project/builtinalerting.profile/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, `configs:
+  - id: profile
+    config:
+      name: profile
+      template: profile.json
+      skip: false
+    type:
+      settings:
+        schema: builtin:alerting.profile
+        scope: environment
+`, string(content))
+
+	templ, err := afero.ReadFile(fs, "test/project/builtinalerting.profile/profile.json")
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(templ))
+}
+
+func TestAddConfigs_KeepsExistingConfigs(t *testing.T) {
+	fs := testutils.TempFs(t)
+	writeTestFile(t, fs, "test/project/builtinalerting.profile/config.yaml", `# alerting profiles of the team
+configs:
+  - id: existing # keep me
+    config:
+      name: existing
+      template: existing.json
+    type:
+      settings:
+        schema: builtin:alerting.profile
+        scope: environment
+`)
+
+	errs := AddConfigs(newAddContext(fs), []config.Config{newSettingsConfig("added")})
+	require.Empty(t, errs)
+
+	content, err := afero.ReadFile(fs, "test/project/builtinalerting.profile/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, `# alerting profiles of the team
+configs:
+  - id: added
+    config:
+      name: added
+      template: added.json
+      skip: false
+    type:
+      settings:
+        schema: builtin:alerting.profile
+        scope: environment
+  - id: existing # keep me
+    config:
+      name: existing
+      template: existing.json
+    type:
+      settings:
+        schema: builtin:alerting.profile
+        scope: environment
+`, string(content))
+}
+
+func TestAddConfigs_FailsForExistingConfig(t *testing.T) {
+	fs := testutils.TempFs(t)
+	existing := `configs:
+  - id: profile
+    config:
+      name: profile
+      template: other.json
+    type:
+      settings:
+        schema: builtin:alerting.profile
+        scope: environment
+`
+	writeTestFile(t, fs, "test/project/builtinalerting.profile/config.yaml", existing)
+
+	errs := AddConfigs(newAddContext(fs), []config.Config{newSettingsConfig("profile")})
+	assert.Len(t, errs, 1)
+
+	content, err := afero.ReadFile(fs, "test/project/builtinalerting.profile/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, existing, string(content))
+
+	exists, err := afero.Exists(fs, "test/project/builtinalerting.profile/profile.json")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestAddConfigs_FailsForExistingTemplate(t *testing.T) {
+	fs := testutils.TempFs(t)
+	writeTestFile(t, fs, "test/project/builtinalerting.profile/profile.json", `{"enabled": true}`)
+
+	errs := AddConfigs(newAddContext(fs), []config.Config{newSettingsConfig("profile")})
+	assert.Len(t, errs, 1)
+
+	exists, err := afero.Exists(fs, "test/project/builtinalerting.profile/config.yaml")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	templ, err := afero.ReadFile(fs, "test/project/builtinalerting.profile/profile.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{"enabled": true}`, string(templ))
+}
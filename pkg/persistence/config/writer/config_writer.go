@@ -255,6 +255,8 @@ func toTopLevelConfigDefinition(context *serializerContext, configs []config.Con
 		})
 	}
 
+	environmentOverrideConfigs = addMetadata(&config, environmentOverrideConfigs, configs)
+
 	// We need to extract the configType from the original configs.
 	// Since they all should have the same configType (they have all the same coordinate), we can take any one.
 	ct, err := extractConfigType(context, configs[0])
@@ -262,6 +264,10 @@ func toTopLevelConfigDefinition(context *serializerContext, configs []config.Con
 		return persistence.TopLevelConfigDefinition{}, nil, []error{fmtDetailedConfigWriterError(context, "failed to extract config type: %w", err)}
 	}
 
+	if archive, found := extensionArchive(context, configs[0]); found {
+		templates = append(templates, archive)
+	}
+
 	return persistence.TopLevelConfigDefinition{
 		Id:                   context.config.ConfigId,
 		Config:               config,
@@ -271,6 +277,81 @@ func toTopLevelConfigDefinition(context *serializerContext, configs []config.Con
 	}, templates, nil
 }
 
+// extensionArchive returns the extension package of the given config, if it is an extension config. Packages are
+// written next to the config file, which references them by their file name.
+func extensionArchive(context *serializerContext, c config.Config) (configTemplate, bool) {
+	t, ok := c.Type.(config.ExtensionType)
+	if !ok {
+		return configTemplate{}, false
+	}
+
+	return configTemplate{
+		templatePath: filepath.Join(context.configFolder, filepath.Base(t.Archive)),
+		content:      string(t.ArchiveContent),
+	}, true
+}
+
+// metadata holds the descriptive properties and explicit dependencies of a config, which are not part of the
+// extraction of shared values, as they are usually the same in all environments
+type metadata struct {
+	description string
+	owner       string
+	labels      map[string]string
+	dependsOn   []string
+
+	environmentSpecific []string
+}
+
+func metadataOf(c config.Config) metadata {
+	m := metadata{description: c.Description, owner: c.Owner, environmentSpecific: c.EnvironmentSpecific}
+	if len(c.Labels) > 0 {
+		m.labels = c.Labels
+	}
+	for _, d := range c.DependsOn {
+		m.dependsOn = append(m.dependsOn, d.String())
+	}
+	return m
+}
+
+func (m metadata) applyTo(definition *persistence.ConfigDefinition) {
+	definition.Description = m.description
+	definition.Owner = m.owner
+	definition.Labels = m.labels
+	definition.DependsOn = m.dependsOn
+	definition.EnvironmentSpecific = m.environmentSpecific
+}
+
+// addMetadata adds the metadata of the given configs to the base definition if it is shared by all of them, or to the
+// environment overrides otherwise. Overrides are created for environments which have none yet.
+func addMetadata(base *persistence.ConfigDefinition, overrides []persistence.EnvironmentOverride, configs []config.Config) []persistence.EnvironmentOverride {
+	shared := metadataOf(configs[0])
+	for _, c := range configs[1:] {
+		if !reflect.DeepEqual(shared, metadataOf(c)) {
+			return addEnvironmentMetadata(overrides, configs)
+		}
+	}
+
+	shared.applyTo(base)
+	return overrides
+}
+
+func addEnvironmentMetadata(overrides []persistence.EnvironmentOverride, configs []config.Config) []persistence.EnvironmentOverride {
+	for _, c := range configs {
+		m := metadataOf(c)
+		if reflect.DeepEqual(m, metadata{}) {
+			continue
+		}
+
+		i := slices.IndexFunc(overrides, func(o persistence.EnvironmentOverride) bool { return o.Environment == c.Environment })
+		if i < 0 {
+			overrides = append(overrides, persistence.EnvironmentOverride{Environment: c.Environment})
+			i = len(overrides) - 1
+		}
+		m.applyTo(&overrides[i].Override)
+	}
+	return overrides
+}
+
 func extractConfigType(context *serializerContext, cfg config.Config) (persistence.TypeDefinition, error) {
 
 	switch t := cfg.Type.(type) {
@@ -306,6 +387,35 @@ func extractConfigType(context *serializerContext, cfg config.Config) (persisten
 			},
 		}, nil
 
+	case config.ExtensionType:
+		serializedScope, err := getScope(context, cfg)
+		if err != nil {
+			return persistence.TypeDefinition{}, err
+		}
+
+		return persistence.TypeDefinition{
+			Extension: persistence.ExtensionDefinition{
+				Name:    t.Name,
+				Archive: filepath.Base(t.Archive),
+				Scope:   serializedScope,
+			},
+		}, nil
+
+	case config.DocumentType:
+		return persistence.TypeDefinition{
+			Document: persistence.DocumentDefinition{
+				Kind:    t.Kind,
+				Private: t.Private,
+			},
+		}, nil
+
+	case config.AccountType:
+		return persistence.TypeDefinition{
+			Account: persistence.AccountDefinition{
+				Resource: t.Resource,
+			},
+		}, nil
+
 	default:
 		return persistence.TypeDefinition{}, fmtDetailedConfigWriterError(context, "unknown config-type (ID: %q)", cfg.Type.ID())
 	}
@@ -696,6 +806,13 @@ func isValueParameter(param parameter.Parameter) bool {
 	return param.GetType() == value.ValueParameterType
 }
 
+// isShorthandValue returns whether a value parameter with the given value is written in its shorthand form, i.e.
+// the value itself instead of a mapping with 'type' and 'value'. Only strings are written as shorthand.
+func isShorthandValue(v any) bool {
+	_, isString := v.(string)
+	return isString
+}
+
 func toValueShorthandDefinition(context *detailedSerializerContext, parameterName string,
 	param parameter.Parameter) (persistence.ConfigParameter, error) {
 	if param.GetType() == value.ValueParameterType {
@@ -705,21 +822,19 @@ func toValueShorthandDefinition(context *detailedSerializerContext, parameterNam
 			return nil, fmtDetailedConfigWriterError(context.serializerContext, "%s:%s: parameter of type `%s` is no value param", context.config, parameterName, param.GetType())
 		}
 
-		switch valueParam.Value.(type) {
-		// strings can be shorthanded
-		case string:
+		if isShorthandValue(valueParam.Value) {
 			return valueParam.Value, nil
-		default:
-			result, err := context.ParametersSerde[param.GetType()].Serializer(newParameterSerializerContext(context, parameterName, param))
-
-			if err != nil {
-				return nil, err
-			}
+		}
 
-			result["type"] = valueParam.GetType()
+		result, err := context.ParametersSerde[param.GetType()].Serializer(newParameterSerializerContext(context, parameterName, param))
 
-			return result, nil
+		if err != nil {
+			return nil, err
 		}
+
+		result["type"] = valueParam.GetType()
+
+		return result, nil
 	}
 
 	return nil, fmtDetailedConfigWriterError(context.serializerContext, "%s:%s: unknown special type `%s`", context.config, parameterName, param.GetType())
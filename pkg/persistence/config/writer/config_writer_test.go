@@ -36,6 +36,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExtractCommonBase(t *testing.T) {
@@ -977,6 +978,92 @@ func TestWriteConfigs(t *testing.T) {
 				"project/scheduling-rule/a.json",
 			},
 		},
+		{
+			name: "Documents",
+			configs: []config.Config{
+				{
+					Template: template.CreateTemplateFromString("project/document/a.json", ""),
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "document",
+						ConfigId: "configId",
+					},
+					Type: config.DocumentType{
+						Kind:    config.DashboardKind,
+						Private: true,
+					},
+					Parameters: map[string]parameter.Parameter{
+						config.NameParameter: &value.ValueParameter{Value: "name"},
+					},
+					Skip: false,
+				},
+			},
+			expectedConfigs: map[string]persistence.TopLevelDefinition{
+				"document": {
+					Configs: []persistence.TopLevelConfigDefinition{
+						{
+							Id: "configId",
+							Config: persistence.ConfigDefinition{
+								Name:     "name",
+								Template: "a.json",
+								Skip:     false,
+							},
+							Type: persistence.TypeDefinition{
+								Document: persistence.DocumentDefinition{
+									Kind:    "dashboard",
+									Private: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedTemplatePaths: []string{
+				"project/document/a.json",
+			},
+		},
+		{
+			name: "Account resources",
+			configs: []config.Config{
+				{
+					Template: template.CreateTemplateFromString("project/user-group/a.json", ""),
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "user-group",
+						ConfigId: "configId",
+					},
+					Type: config.AccountType{
+						Resource: config.UserGroup,
+					},
+					Parameters: map[string]parameter.Parameter{
+						config.NameParameter: &value.ValueParameter{Value: "name"},
+					},
+					Skip: false,
+				},
+			},
+			expectedConfigs: map[string]persistence.TopLevelDefinition{
+				"user-group": {
+					Configs: []persistence.TopLevelConfigDefinition{
+						{
+							Id: "configId",
+							Config: persistence.ConfigDefinition{
+								Name:     "name",
+								Template: "a.json",
+								Skip:     false,
+							},
+							Type: persistence.TypeDefinition{
+								Account: persistence.AccountDefinition{
+									Resource: config.UserGroup,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedTemplatePaths: []string{
+				"project/user-group/a.json",
+			},
+		},
 		{
 			name: "Reference scope",
 			configs: []config.Config{
@@ -1169,3 +1256,153 @@ func TestOrderedConfigs(t *testing.T) {
 	}
 
 }
+
+func TestWriteConfigsWithMetadata(t *testing.T) {
+	newConfig := func(environment, owner string, labels map[string]string) config.Config {
+		return config.Config{
+			Template:    template.CreateTemplateFromString("project/alerting-profile/a.json", ""),
+			Coordinate:  coordinate.Coordinate{Project: "project", Type: "alerting-profile", ConfigId: "configId"},
+			Type:        config.ClassicApiType{Api: "alerting-profile"},
+			Environment: environment,
+			Group:       environment,
+			Parameters: map[string]parameter.Parameter{
+				config.NameParameter: &value.ValueParameter{Value: "name"},
+			},
+			Description: "description",
+			Owner:       owner,
+			Labels:      labels,
+		}
+	}
+	withDependsOn := func(c config.Config) config.Config {
+		c.DependsOn = []coordinate.Coordinate{{Project: "project", Type: "builtin:alerting.profile", ConfigId: "profile"}}
+		return c
+	}
+
+	tests := []struct {
+		name                         string
+		configs                      []config.Config
+		expectedConfig               persistence.ConfigDefinition
+		expectedEnvironmentOverrides []persistence.EnvironmentOverride
+	}{
+		{
+			name: "shared metadata is written to the base config",
+			configs: []config.Config{
+				newConfig("dev", "team", map[string]string{"tier": "critical"}),
+				newConfig("prod", "team", map[string]string{"tier": "critical"}),
+			},
+			expectedConfig: persistence.ConfigDefinition{
+				Name:        "name",
+				Template:    "a.json",
+				Skip:        false,
+				Description: "description",
+				Owner:       "team",
+				Labels:      map[string]string{"tier": "critical"},
+			},
+		},
+		{
+			name: "different metadata is written to environment overrides",
+			configs: []config.Config{
+				newConfig("dev", "dev-team", nil),
+				newConfig("prod", "prod-team", map[string]string{"tier": "critical"}),
+			},
+			expectedConfig: persistence.ConfigDefinition{
+				Name:     "name",
+				Template: "a.json",
+				Skip:     false,
+			},
+			expectedEnvironmentOverrides: []persistence.EnvironmentOverride{
+				{
+					Environment: "dev",
+					Override:    persistence.ConfigDefinition{Description: "description", Owner: "dev-team"},
+				},
+				{
+					Environment: "prod",
+					Override:    persistence.ConfigDefinition{Description: "description", Owner: "prod-team", Labels: map[string]string{"tier": "critical"}},
+				},
+			},
+		},
+		{
+			name: "dependsOn is written as coordinates",
+			configs: []config.Config{
+				withDependsOn(newConfig("dev", "team", nil)),
+				withDependsOn(newConfig("prod", "team", nil)),
+			},
+			expectedConfig: persistence.ConfigDefinition{
+				Name:        "name",
+				Template:    "a.json",
+				Skip:        false,
+				Description: "description",
+				Owner:       "team",
+				DependsOn:   []string{"project:builtin:alerting.profile:profile"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := testutils.TempFs(t)
+
+			errs := WriteConfigs(&WriterContext{
+				Fs:              fs,
+				OutputFolder:    "test",
+				ProjectFolder:   "project",
+				ParametersSerde: config.DefaultParameterParsers,
+			}, tc.configs)
+			assert.Empty(t, errs)
+
+			content, err := afero.ReadFile(fs, "test/project/alerting-profile/config.yaml")
+			assert.NoError(t, err)
+
+			var s persistence.TopLevelDefinition
+			assert.NoError(t, yaml.Unmarshal(content, &s))
+			require.Len(t, s.Configs, 1)
+
+			assert.Equal(t, tc.expectedConfig, s.Configs[0].Config)
+			assert.ElementsMatch(t, tc.expectedEnvironmentOverrides, s.Configs[0].EnvironmentOverrides)
+		})
+	}
+}
+
+func TestWriteConfigsWithExtensionPackage(t *testing.T) {
+	c := config.Config{
+		Template:   template.NewDownloadTemplate("monitoring-configuration", "SQL Server", "{}"),
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "com.example.extension", ConfigId: "monitoring-configuration"},
+		Type: config.ExtensionType{
+			Name:           "com.example.extension",
+			Version:        "1.2.3",
+			Archive:        "com.example.extension-1.2.3.zip",
+			ArchiveContent: []byte("archive"),
+		},
+		Environment: "dev",
+		Group:       "dev",
+		Parameters: map[string]parameter.Parameter{
+			config.NameParameter:  &value.ValueParameter{Value: "SQL Server"},
+			config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+		},
+	}
+
+	fs := testutils.TempFs(t)
+	errs := WriteConfigs(&WriterContext{
+		Fs:              fs,
+		OutputFolder:    "test",
+		ProjectFolder:   "project",
+		ParametersSerde: config.DefaultParameterParsers,
+	}, []config.Config{c})
+	assert.Empty(t, errs)
+
+	content, err := afero.ReadFile(fs, "test/project/com.example.extension/config.yaml")
+	assert.NoError(t, err)
+
+	var s persistence.TopLevelDefinition
+	assert.NoError(t, yaml.Unmarshal(content, &s))
+	require.Len(t, s.Configs, 1)
+	assert.Equal(t, persistence.ExtensionDefinition{
+		Name:    "com.example.extension",
+		Archive: "com.example.extension-1.2.3.zip",
+		Scope:   "environment",
+	}, s.Configs[0].Type.Extension)
+
+	archive, err := afero.ReadFile(fs, "test/project/com.example.extension/com.example.extension-1.2.3.zip")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("archive"), archive)
+}
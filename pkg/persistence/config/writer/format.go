@@ -0,0 +1,262 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package writer
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/internal/persistence"
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	topLevelDefinitionType       = reflect.TypeOf(persistence.TopLevelDefinition{})
+	topLevelConfigDefinitionType = reflect.TypeOf(persistence.TopLevelConfigDefinition{})
+	typeDefinitionType           = reflect.TypeOf(persistence.TypeDefinition{})
+	configParameterType          = reflect.TypeOf((*persistence.ConfigParameter)(nil)).Elem()
+)
+
+// FormatConfigFile returns the given config file in the canonical form the writer produces:
+//   - properties are ordered as written by the writer, parameters and other maps alphabetically
+//   - configs are sorted by their ID
+//   - value parameters holding a string use the shorthand syntax
+//   - classic API types use the long syntax
+//   - scalars are only quoted if required, and mappings and sequences use the block style
+//
+// Comments are kept. Files using YAML aliases keep the order of configs and properties, so that anchors remain defined
+// before their aliases, and anchored nodes are not replaced by their shorthand.
+func FormatConfigFile(content []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return content, nil
+	}
+
+	f := formatter{reorder: !containsAlias(&doc)}
+	f.format(doc.Content[0], topLevelDefinitionType)
+
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+func containsAlias(n *yaml.Node) bool {
+	if n.Kind == yaml.AliasNode {
+		return true
+	}
+	for _, c := range n.Content {
+		if containsAlias(c) {
+			return true
+		}
+	}
+	return false
+}
+
+type formatter struct {
+	// reorder states whether configs and properties are sorted
+	reorder bool
+}
+
+// format formats the node n, which holds a value of type t. Nodes not matching the type are formatted generically.
+func (f formatter) format(n *yaml.Node, t reflect.Type) {
+	if n.Kind == yaml.AliasNode {
+		return
+	}
+	resetStyle(n)
+
+	switch {
+	case t == configParameterType:
+		f.formatParameter(n)
+	case t == typeDefinitionType && n.Kind == yaml.ScalarNode && n.Anchor == "":
+		// classic APIs can be defined by their name only
+		api := *n
+		*n = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{{Kind: yaml.ScalarNode, Tag: "!!str", Value: "api"}, &api}}
+	case t.Kind() == reflect.Struct && n.Kind == yaml.MappingNode:
+		f.formatStruct(n, t)
+	case t.Kind() == reflect.Map && n.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			resetStyle(n.Content[i])
+			f.format(n.Content[i+1], t.Elem())
+			hoistHeadComment(n.Content[i], n.Content[i+1])
+		}
+		f.sortKeys(n)
+	case t.Kind() == reflect.Slice && n.Kind == yaml.SequenceNode:
+		for _, c := range n.Content {
+			f.format(c, t.Elem())
+		}
+		if t.Elem() == topLevelConfigDefinitionType && f.reorder {
+			sort.SliceStable(n.Content, func(i, j int) bool { return configID(n.Content[i]) < configID(n.Content[j]) })
+		}
+	default:
+		f.formatAny(n)
+	}
+}
+
+// formatStruct orders the properties of n like the fields of the struct type t. Unknown properties are kept at the end.
+func (f formatter) formatStruct(n *yaml.Node, t reflect.Type) {
+	fieldIndex := make(map[string]int)
+	fieldType := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		fieldIndex[name] = i
+		fieldType[name] = t.Field(i).Type
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		resetStyle(n.Content[i])
+		if ft, found := fieldType[n.Content[i].Value]; found {
+			f.format(n.Content[i+1], ft)
+		} else {
+			f.formatAny(n.Content[i+1])
+		}
+		hoistHeadComment(n.Content[i], n.Content[i+1])
+	}
+
+	f.sortPairs(n, func(key string) (int, string) {
+		if i, found := fieldIndex[key]; found {
+			return i, ""
+		}
+		return t.NumField(), "" // keep unknown properties in their order
+	})
+}
+
+// formatParameter formats a parameter definition, using the shorthand syntax for value parameters where possible
+func (f formatter) formatParameter(n *yaml.Node) {
+	f.formatAny(n)
+
+	if n.Kind != yaml.MappingNode || len(n.Content) != 4 || n.Anchor != "" {
+		return
+	}
+	var paramType, val *yaml.Node
+	for i := 0; i < len(n.Content); i += 2 {
+		switch n.Content[i].Value {
+		case "type":
+			paramType = n.Content[i+1]
+		case "value":
+			val = n.Content[i+1]
+		}
+	}
+	if paramType == nil || val == nil || paramType.Value != value.ValueParameterType || val.Kind != yaml.ScalarNode || val.Anchor != "" {
+		return
+	}
+
+	var v any
+	if err := val.Decode(&v); err != nil || !isShorthandValue(v) {
+		return
+	}
+
+	// keep the comments of all replaced nodes
+	shorthand := *val
+	shorthand.HeadComment, shorthand.LineComment, shorthand.FootComment = n.HeadComment, n.LineComment, n.FootComment
+	for _, c := range n.Content {
+		shorthand.HeadComment = joinComments(shorthand.HeadComment, c.HeadComment)
+		shorthand.LineComment = joinComments(shorthand.LineComment, c.LineComment)
+		shorthand.FootComment = joinComments(shorthand.FootComment, c.FootComment)
+	}
+	*n = shorthand
+}
+
+// formatAny formats a node of arbitrary content, ordering the keys of all mappings alphabetically
+func (f formatter) formatAny(n *yaml.Node) {
+	if n.Kind == yaml.AliasNode {
+		return
+	}
+	resetStyle(n)
+	for _, c := range n.Content {
+		f.formatAny(c)
+	}
+	if n.Kind == yaml.MappingNode {
+		f.sortKeys(n)
+	}
+}
+
+func (f formatter) sortKeys(n *yaml.Node) {
+	f.sortPairs(n, func(key string) (int, string) { return 0, key })
+}
+
+// sortPairs sorts the key-value pairs of the mapping n by the rank, and then by the name returned for their keys
+func (f formatter) sortPairs(n *yaml.Node, rank func(key string) (int, string)) {
+	if !f.reorder || len(n.Content)%2 != 0 {
+		return
+	}
+
+	type pair struct{ key, value *yaml.Node }
+	pairs := make([]pair, 0, len(n.Content)/2)
+	for i := 0; i < len(n.Content); i += 2 {
+		pairs = append(pairs, pair{n.Content[i], n.Content[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		ri, ni := rank(pairs[i].key.Value)
+		rj, nj := rank(pairs[j].key.Value)
+		if ri != rj {
+			return ri < rj
+		}
+		return ni < nj
+	})
+
+	for i, p := range pairs {
+		n.Content[2*i], n.Content[2*i+1] = p.key, p.value
+	}
+}
+
+// hoistHeadComment moves the head comment of a scalar value to its key, as it is written before the key anyway
+func hoistHeadComment(key, value *yaml.Node) {
+	if value.Kind == yaml.ScalarNode && value.HeadComment != "" {
+		key.HeadComment = joinComments(key.HeadComment, value.HeadComment)
+		value.HeadComment = ""
+	}
+}
+
+// resetStyle removes quoting and flow styles, so that they are only used where required
+func resetStyle(n *yaml.Node) {
+	n.Style &= yaml.TaggedStyle
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!merge" {
+		n.Tag = "" // the merge key is resolved implicitly, but written with an explicit tag otherwise
+	}
+}
+
+func configID(n *yaml.Node) string {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == "id" {
+			return n.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+func joinComments(comments ...string) string {
+	var nonEmpty []string
+	for _, c := range comments {
+		if c != "" {
+			nonEmpty = append(nonEmpty, c)
+		}
+	}
+	return strings.Join(nonEmpty, "\n")
+}
@@ -0,0 +1,234 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package writer
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFormatConfigFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		given string
+		want  string
+	}{
+		{
+			name: "orders configs and properties",
+			given: `configs:
+- type:
+    api: dashboard
+  id: z
+  config:
+    template: z.json
+    name: Z
+- config:
+    parameters:
+      zone: {type: reference, property: id, configType: management-zone, configId: zone}
+      b: b
+      a: a
+    name: A
+    template: a.json
+  environmentOverrides:
+  - override:
+      skip: true
+    environment: prod
+  id: a
+  type:
+    settings:
+      scope: environment
+      schema: builtin:tags.auto-tagging
+`,
+			want: `configs:
+  - id: a
+    config:
+      name: A
+      parameters:
+        a: a
+        b: b
+        zone:
+          configId: zone
+          configType: management-zone
+          property: id
+          type: reference
+      template: a.json
+    type:
+      settings:
+        schema: builtin:tags.auto-tagging
+        scope: environment
+    environmentOverrides:
+      - environment: prod
+        override:
+          skip: true
+  - id: z
+    config:
+      name: Z
+      template: z.json
+    type:
+      api: dashboard
+`,
+		},
+		{
+			name: "uses shorthand for string values only",
+			given: `configs:
+- id: a
+  config:
+    name: {type: value, value: "A"}
+    parameters:
+      text:
+        type: value
+        value: some text
+      number:
+        type: value
+        value: 5
+      object:
+        type: value
+        value: {b: 1, a: 2}
+      env:
+        type: environment
+        name: ENV
+    template: a.json
+  type: dashboard
+`,
+			want: `configs:
+  - id: a
+    config:
+      name: A
+      parameters:
+        env:
+          name: ENV
+          type: environment
+        number:
+          type: value
+          value: 5
+        object:
+          type: value
+          value:
+            a: 2
+            b: 1
+        text: some text
+      template: a.json
+    type:
+      api: dashboard
+`,
+		},
+		{
+			name: "quotes only where required",
+			given: `configs:
+- id: 'a'
+  config:
+    name: "A"
+    parameters:
+      bool: "true"
+      number: '42'
+      text: "plain"
+    template: "a.json"
+  type:
+    api: dashboard
+`,
+			want: `configs:
+  - id: a
+    config:
+      name: A
+      parameters:
+        bool: "true"
+        number: "42"
+        text: plain
+      template: a.json
+    type:
+      api: dashboard
+`,
+		},
+		{
+			name: "keeps comments",
+			given: `# dashboards of team A
+configs:
+- id: a # the dashboard
+  config:
+    parameters:
+      # reused in other projects
+      owner:
+        type: value
+        value: team-a
+    name: A
+    template: a.json
+  type:
+    api: dashboard
+`,
+			want: `# dashboards of team A
+configs:
+  - id: a # the dashboard
+    config:
+      name: A
+      parameters:
+        # reused in other projects
+        owner: team-a
+      template: a.json
+    type:
+      api: dashboard
+`,
+		},
+		{
+			name: "keeps order of files with aliases",
+			given: `configs:
+- id: z
+  type: dashboard
+  config: &base
+    template: board.json
+    name: "Z"
+- id: a
+  type: dashboard
+  config:
+    <<: *base
+    name: A
+`,
+			want: `configs:
+  - id: z
+    type:
+      api: dashboard
+    config: &base
+      template: board.json
+      name: Z
+  - id: a
+    type:
+      api: dashboard
+    config:
+      <<: *base
+      name: A
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatConfigFile([]byte(tt.given))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+
+			again, err := FormatConfigFile(got)
+			require.NoError(t, err)
+			assert.Equal(t, string(got), string(again), "formatting is idempotent")
+		})
+	}
+}
+
+func TestFormatConfigFile_InvalidYAML(t *testing.T) {
+	_, err := FormatConfigFile([]byte("configs:\n- id: a\n  config: [\n"))
+	assert.ErrorContains(t, err, "failed to parse config file")
+}
@@ -0,0 +1,281 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package writer
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/internal/persistence"
+	"github.com/spf13/afero"
+	"golang.org/x/exp/slices"
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+)
+
+// PromotionEnvironment identifies an environment and its group, whose overrides apply to it
+type PromotionEnvironment struct {
+	Name  string
+	Group string
+}
+
+// Promotion describes which configs are promoted from one environment to another
+type Promotion struct {
+	From PromotionEnvironment
+	To   PromotionEnvironment
+	// Configs are the coordinates of the configs to promote
+	Configs []coordinate.Coordinate
+}
+
+// PromoteConfigFile returns the content of the given config file with the environment overrides of the target
+// environment changed, so that the promoted configs defined in the file resolve to the same definition as in the
+// source environment. Parameters marked as environment-specific keep the value of the target environment.
+// The returned flag states whether the content changed. Parameters only defined for the target environment cannot be
+// removed by overrides and result in an error.
+func PromoteConfigFile(fs afero.Fs, configFile string, projectId string, promotion Promotion) ([]byte, bool, error) {
+	content, err := afero.ReadFile(fs, configFile)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var definition persistence.TopLevelDefinition
+	if err := yamlv2.Unmarshal(content, &definition); err != nil {
+		return nil, false, fmt.Errorf("failed to parse config file %q: %w", configFile, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, false, fmt.Errorf("failed to parse config file %q: %w", configFile, err)
+	}
+	if len(doc.Content) == 0 {
+		return content, false, nil
+	}
+
+	configs := mappingValue(doc.Content[0], "configs")
+	if configs == nil || configs.Kind != yaml.SequenceNode || len(configs.Content) != len(definition.Configs) {
+		return content, false, nil
+	}
+
+	changed := false
+	for i, c := range definition.Configs {
+		coord := coordinate.Coordinate{Project: projectId, Type: c.Type.GetApiType(), ConfigId: c.Id}
+		if !slices.Contains(promotion.Configs, coord) {
+			continue
+		}
+
+		entryChanged, err := promoteConfigEntry(configs.Content[i], c, promotion)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to promote config %q in config file %q: %w", coord, configFile, err)
+		}
+		changed = changed || entryChanged
+	}
+
+	if !changed {
+		return content, false, nil
+	}
+
+	// only the modified document is written back - the rest of the file keeps its order and style
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, false, fmt.Errorf("failed to write config file %q: %w", configFile, err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, false, fmt.Errorf("failed to write config file %q: %w", configFile, err)
+	}
+	return b.Bytes(), true, nil
+}
+
+// promoteConfigEntry replaces the environment override of the target environment in the given config node
+func promoteConfigEntry(node *yaml.Node, definition persistence.TopLevelConfigDefinition, promotion Promotion) (bool, error) {
+	source := effectiveDefinition(definition, promotion.From, true)
+	target := effectiveDefinition(definition, promotion.To, false)
+
+	var current persistence.ConfigDefinition
+	if i := slices.IndexFunc(definition.EnvironmentOverrides, func(o persistence.EnvironmentOverride) bool {
+		return o.Environment == promotion.To.Name
+	}); i >= 0 {
+		current = definition.EnvironmentOverrides[i].Override
+	}
+
+	environmentSpecific := effectiveDefinition(definition, promotion.To, true).EnvironmentSpecific
+	override, err := promotedOverride(source, target, current, environmentSpecific)
+	if err != nil {
+		return false, fmt.Errorf("%w, so it cannot be promoted from environment %q to %q", err, promotion.From.Name, promotion.To.Name)
+	}
+
+	if reflect.DeepEqual(override, current) {
+		return false, nil
+	}
+
+	return true, setEnvironmentOverride(node, promotion.To.Name, override)
+}
+
+// effectiveDefinition merges the base definition with the overrides of the given environment's group, and of the
+// environment itself if includeEnvironment is set
+func effectiveDefinition(definition persistence.TopLevelConfigDefinition, env PromotionEnvironment, includeEnvironment bool) persistence.ConfigDefinition {
+	result := persistence.ConfigDefinition{Parameters: map[string]persistence.ConfigParameter{}}
+	mergeDefinition(&result, definition.Config)
+
+	for _, o := range definition.GroupOverrides {
+		if o.Group == env.Group {
+			mergeDefinition(&result, o.Override)
+		}
+	}
+
+	if includeEnvironment {
+		for _, o := range definition.EnvironmentOverrides {
+			if o.Environment == env.Name {
+				mergeDefinition(&result, o.Override)
+			}
+		}
+	}
+	return result
+}
+
+func mergeDefinition(base *persistence.ConfigDefinition, override persistence.ConfigDefinition) {
+	if override.Name != nil {
+		base.Name = override.Name
+	}
+	if override.Template != "" {
+		base.Template = override.Template
+	}
+	if override.Skip != nil {
+		base.Skip = override.Skip
+	}
+	if override.EnvironmentSpecific != nil {
+		base.EnvironmentSpecific = override.EnvironmentSpecific
+	}
+	for name, param := range override.Parameters {
+		base.Parameters[name] = param
+	}
+}
+
+// promotedOverride returns the override of the target environment making the target definition equal to the source.
+// Properties which are not promoted, like the origin object ID or labels, are kept from the current override.
+func promotedOverride(source, target, current persistence.ConfigDefinition, environmentSpecific []string) (persistence.ConfigDefinition, error) {
+	override := current
+	override.Name, override.Template, override.Skip, override.Parameters = nil, "", nil, nil
+
+	isSpecific := func(name string) bool { return slices.Contains(environmentSpecific, name) }
+
+	if isSpecific(config.NameParameter) {
+		override.Name = current.Name
+	} else if !reflect.DeepEqual(source.Name, target.Name) {
+		override.Name = source.Name
+	}
+
+	if isSpecific(config.SkipParameter) {
+		override.Skip = current.Skip
+	} else if !reflect.DeepEqual(source.Skip, target.Skip) {
+		override.Skip = source.Skip
+	}
+
+	if source.Template != target.Template {
+		override.Template = source.Template
+	}
+
+	parameters := map[string]persistence.ConfigParameter{}
+	for name, param := range current.Parameters {
+		if isSpecific(name) {
+			parameters[name] = param
+		}
+	}
+
+	for name, param := range source.Parameters {
+		if !isSpecific(name) && !reflect.DeepEqual(param, target.Parameters[name]) {
+			parameters[name] = param
+		}
+	}
+
+	for name := range target.Parameters {
+		if _, found := source.Parameters[name]; !found && !isSpecific(name) {
+			return persistence.ConfigDefinition{}, fmt.Errorf("parameter %q is only defined for the target environment and cannot be removed by an override", name)
+		}
+	}
+
+	if len(parameters) > 0 {
+		override.Parameters = parameters
+	}
+
+	return override, nil
+}
+
+// setEnvironmentOverride replaces the override of the given environment in the config node. The override entry is
+// removed if the override is empty, and added if it does not exist yet.
+func setEnvironmentOverride(node *yaml.Node, environment string, override persistence.ConfigDefinition) error {
+	empty := reflect.DeepEqual(override, persistence.ConfigDefinition{})
+
+	var overrideNode yaml.Node
+	if err := overrideNode.Encode(override); err != nil {
+		return err
+	}
+
+	overrides := mappingValue(node, "environmentOverrides")
+	if overrides == nil {
+		if empty {
+			return nil
+		}
+		overrides = &yaml.Node{Kind: yaml.SequenceNode}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "environmentOverrides"}, overrides)
+	}
+
+	for i, entry := range overrides.Content {
+		if env := mappingValue(entry, "environment"); env == nil || env.Value != environment {
+			continue
+		}
+
+		if empty {
+			overrides.Content = append(overrides.Content[:i], overrides.Content[i+1:]...)
+			if len(overrides.Content) == 0 {
+				removeMappingKey(node, "environmentOverrides")
+			}
+			return nil
+		}
+
+		if existing := mappingValue(entry, "override"); existing != nil {
+			*existing = overrideNode
+		} else {
+			entry.Content = append(entry.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "override"}, &overrideNode)
+		}
+		return nil
+	}
+
+	if !empty {
+		overrides.Content = append(overrides.Content, &yaml.Node{
+			Kind: yaml.MappingNode,
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Value: "environment"}, {Kind: yaml.ScalarNode, Value: environment},
+				{Kind: yaml.ScalarNode, Value: "override"}, &overrideNode,
+			},
+		})
+	}
+	return nil
+}
+
+func removeMappingKey(n *yaml.Node, key string) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			n.Content = append(n.Content[:i], n.Content[i+2:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,244 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package writer
+
+import (
+	"testing"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromoteConfigFile(t *testing.T) {
+	promotion := Promotion{
+		From:    PromotionEnvironment{Name: "dev", Group: "development"},
+		To:      PromotionEnvironment{Name: "prod", Group: "production"},
+		Configs: []coordinate.Coordinate{{Project: "project", Type: "dashboard", ConfigId: "overview"}},
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		want        string
+		wantChanged bool
+		wantErr     string
+	}{
+		{
+			name: "adds override of target environment",
+			content: `configs:
+  - id: overview
+    config:
+      name: Overview
+      template: overview.json
+    type:
+      api: dashboard
+    environmentOverrides:
+      - environment: dev
+        override:
+          name: New Overview
+          skip: true
+`,
+			want: `configs:
+  - id: overview
+    config:
+      name: Overview
+      template: overview.json
+    type:
+      api: dashboard
+    environmentOverrides:
+      - environment: dev
+        override:
+          name: New Overview
+          skip: true
+      - environment: prod
+        override:
+          name: New Overview
+          skip: true
+`,
+			wantChanged: true,
+		},
+		{
+			name: "keeps order and comments of the rest of the file",
+			content: `configs:
+  # the overview dashboard
+  - id: overview
+    type:
+      api: dashboard
+    config:
+      template: overview.json
+      name: Overview
+    environmentOverrides:
+      - environment: dev
+        override:
+          name: New Overview
+`,
+			want: `configs:
+  # the overview dashboard
+  - id: overview
+    type:
+      api: dashboard
+    config:
+      template: overview.json
+      name: Overview
+    environmentOverrides:
+      - environment: dev
+        override:
+          name: New Overview
+      - environment: prod
+        override:
+          name: New Overview
+`,
+			wantChanged: true,
+		},
+		{
+			name: "removes override of target environment if it is no longer needed",
+			content: `configs:
+  - id: overview
+    config:
+      name: Overview
+      parameters:
+        threshold: 10
+      template: overview.json
+    type:
+      api: dashboard
+    environmentOverrides:
+      - environment: prod
+        override:
+          parameters:
+            threshold: 20
+`,
+			want: `configs:
+  - id: overview
+    config:
+      name: Overview
+      parameters:
+        threshold: 10
+      template: overview.json
+    type:
+      api: dashboard
+`,
+			wantChanged: true,
+		},
+		{
+			name: "keeps environment-specific parameters and properties which are not promoted",
+			content: `configs:
+  - id: overview
+    config:
+      name: Overview
+      parameters:
+        url: https://dev.example.com
+      template: overview.json
+      environmentSpecific:
+        - url
+    type:
+      api: dashboard
+    groupOverrides:
+      - group: development
+        override:
+          template: new-overview.json
+    environmentOverrides:
+      - environment: prod
+        override:
+          parameters:
+            url: https://prod.example.com
+          originObjectId: object-id
+`,
+			want: `configs:
+  - id: overview
+    config:
+      name: Overview
+      parameters:
+        url: https://dev.example.com
+      template: overview.json
+      environmentSpecific:
+        - url
+    type:
+      api: dashboard
+    groupOverrides:
+      - group: development
+        override:
+          template: new-overview.json
+    environmentOverrides:
+      - environment: prod
+        override:
+          parameters:
+            url: https://prod.example.com
+          template: new-overview.json
+          originObjectId: object-id
+`,
+			wantChanged: true,
+		},
+		{
+			name: "does not change configs which are not promoted",
+			content: `configs:
+  - id: other
+    config:
+      name: Other
+      template: other.json
+    type:
+      api: dashboard
+    environmentOverrides:
+      - environment: dev
+        override:
+          name: New Other
+`,
+			wantChanged: false,
+		},
+		{
+			name: "fails if a parameter is only defined for the target environment",
+			content: `configs:
+  - id: overview
+    config:
+      name: Overview
+      template: overview.json
+    type:
+      api: dashboard
+    groupOverrides:
+      - group: production
+        override:
+          parameters:
+            threshold: 20
+`,
+			wantErr: `parameter "threshold" is only defined for the target environment`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "config.yaml", []byte(tt.content), 0644))
+
+			got, changed, err := PromoteConfigFile(fs, "config.yaml", "project", promotion)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantChanged, changed)
+			if tt.wantChanged {
+				assert.Equal(t, tt.want, string(got))
+			} else {
+				assert.Equal(t, tt.content, string(got))
+			}
+		})
+	}
+}
@@ -25,6 +25,11 @@ var namePattern = regexp.MustCompile(`[^a-zA-Z0-9-_.]+`)
 
 const MaxFilenameLengthWithoutFileExtension = 254
 
+// SanitizeFileName returns the given name as it is used in file names written by the writer
+func SanitizeFileName(name string) string {
+	return sanitize(name)
+}
+
 // sanitize removes special characters, limits to max 254 characters in name, no special characters except '-', '_', and '.'
 func sanitize(name string) string {
 	processedString := namePattern.ReplaceAllString(name, "")
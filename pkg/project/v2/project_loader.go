@@ -29,8 +29,10 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	configErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/plugin"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/spf13/afero"
+	"golang.org/x/exp/maps"
 )
 
 type ProjectLoaderContext struct {
@@ -67,17 +69,42 @@ func newDuplicateConfigIdentifierError(c config.Config) DuplicateConfigIdentifie
 	}
 }
 
+// UnknownDependencyError is returned if a config explicitly depends on a config which does not exist in its environment
+type UnknownDependencyError struct {
+	Location           coordinate.Coordinate           `json:"location"`
+	EnvironmentDetails configErrors.EnvironmentDetails `json:"environmentDetails"`
+	Dependency         coordinate.Coordinate           `json:"dependency"`
+}
+
+func (e UnknownDependencyError) Coordinates() coordinate.Coordinate {
+	return e.Location
+}
+
+func (e UnknownDependencyError) LocationDetails() configErrors.EnvironmentDetails {
+	return e.EnvironmentDetails
+}
+
+func (e UnknownDependencyError) Error() string {
+	return fmt.Sprintf("`%s` depends on unknown config `%s`", e.Location, e.Dependency)
+}
+
+func newUnknownDependencyError(c config.Config, dependency coordinate.Coordinate) UnknownDependencyError {
+	return UnknownDependencyError{
+		Location: c.Coordinate,
+		EnvironmentDetails: configErrors.EnvironmentDetails{
+			Group:       c.Group,
+			Environment: c.Environment,
+		},
+		Dependency: dependency,
+	}
+}
+
 func LoadProjects(fs afero.Fs, context ProjectLoaderContext) ([]Project, []error) {
+	context = withPlugins(context)
 	environments := toEnvironmentSlice(context.Manifest.Environments)
 	projects := make([]Project, 0)
 
-	var workingDirFs afero.Fs
-
-	if context.WorkingDir == "." {
-		workingDirFs = fs
-	} else {
-		workingDirFs = afero.NewBasePathFs(fs, context.WorkingDir)
-	}
+	workingDirFs := workingDirFs(fs, context)
 
 	log.Info("Loading %d projects...", len(context.Manifest.Projects))
 
@@ -98,9 +125,129 @@ func LoadProjects(fs afero.Fs, context ProjectLoaderContext) ([]Project, []error
 		return nil, errors
 	}
 
+	if errors = validateDependsOn(projects); errors != nil {
+		return nil, errors
+	}
+
 	return projects, nil
 }
 
+// ReloadProjects loads the projects with the given IDs again, and keeps all other projects as they are. It is used to
+// pick up changes of single projects without loading all projects of the manifest.
+func ReloadProjects(fs afero.Fs, context ProjectLoaderContext, projects []Project, projectIds []string) ([]Project, []error) {
+	context = withPlugins(context)
+	environments := toEnvironmentSlice(context.Manifest.Environments)
+	workingDirFs := workingDirFs(fs, context)
+
+	log.Info("Reloading %d projects...", len(projectIds))
+
+	result := make([]Project, 0, len(projects))
+	var errors []error
+
+	for _, p := range projects {
+		projectDefinition, found := context.Manifest.Projects[p.Id]
+		if !found || !containsProject(projectIds, p.Id) {
+			result = append(result, p)
+			continue
+		}
+
+		project, projectErrors := loadProject(workingDirFs, context, projectDefinition, environments)
+		if projectErrors != nil {
+			errors = append(errors, projectErrors...)
+			continue
+		}
+
+		result = append(result, project)
+	}
+
+	if errors != nil {
+		return nil, errors
+	}
+
+	if errors = validateDependsOn(result); errors != nil {
+		return nil, errors
+	}
+
+	return result, nil
+}
+
+// withPlugins returns the given context, parsing plugin parameters using the plugins defined in the manifest. Each call
+// creates new plugins, so responses of plugins are cached for a single load of the projects.
+func withPlugins(context ProjectLoaderContext) ProjectLoaderContext {
+	if len(context.Manifest.Plugins) == 0 {
+		return context
+	}
+
+	plugins := make(map[string]*plugin.Plugin, len(context.Manifest.Plugins))
+	for name, p := range context.Manifest.Plugins {
+		plugins[name] = plugin.New(name, pluginCommand(context.WorkingDir, p.Command), p.Args, p.Timeout)
+	}
+
+	serDes := maps.Clone(context.ParametersSerde)
+	if serDes == nil {
+		serDes = make(map[string]parameter.ParameterSerDe)
+	}
+	serDes[plugin.PluginParameterType] = plugin.NewSerDe(plugins)
+	context.ParametersSerde = serDes
+	return context
+}
+
+// pluginCommand returns the command to invoke for a plugin. Relative commands containing a separator are paths relative
+// to the manifest, commands without separator are looked up in the PATH.
+func pluginCommand(workingDir string, command string) string {
+	command = filepath.FromSlash(command)
+	if filepath.IsAbs(command) || !strings.ContainsRune(command, filepath.Separator) {
+		return command
+	}
+
+	command = filepath.Join(workingDir, command)
+	if abs, err := filepath.Abs(command); err == nil {
+		return abs
+	}
+	return command
+}
+
+func workingDirFs(fs afero.Fs, context ProjectLoaderContext) afero.Fs {
+	if context.WorkingDir == "." {
+		return fs
+	}
+	return afero.NewBasePathFs(fs, context.WorkingDir)
+}
+
+// validateDependsOn checks that all configs which configs explicitly depend on exist in the same environment.
+// In contrast to references, dependencies are not resolved during deployment, so unknown ones would go unnoticed.
+func validateDependsOn(projects []Project) []error {
+	known := make(map[string]map[coordinate.Coordinate]struct{})
+	for _, p := range projects {
+		for environment, configsPerType := range p.Configs {
+			if _, found := known[environment]; !found {
+				known[environment] = make(map[coordinate.Coordinate]struct{})
+			}
+			for _, configs := range configsPerType {
+				for _, c := range configs {
+					known[environment][c.Coordinate] = struct{}{}
+				}
+			}
+		}
+	}
+
+	var errs []error
+	for _, p := range projects {
+		for environment, configsPerType := range p.Configs {
+			for _, configs := range configsPerType {
+				for _, c := range configs {
+					for _, d := range c.DependsOn {
+						if _, found := known[environment][d]; !found {
+							errs = append(errs, newUnknownDependencyError(c, d))
+						}
+					}
+				}
+			}
+		}
+	}
+	return errs
+}
+
 func toEnvironmentSlice(environments map[string]manifest.EnvironmentDefinition) []manifest.EnvironmentDefinition {
 	var result []manifest.EnvironmentDefinition
 
@@ -157,7 +304,7 @@ func loadProject(fs afero.Fs, context ProjectLoaderContext, projectDefinition ma
 func loadConfigsOfProject(fs afero.Fs, loadingContext ProjectLoaderContext, projectDefinition manifest.ProjectDefinition,
 	environments []manifest.EnvironmentDefinition) ([]config.Config, []error) {
 
-	configFiles, err := findConfigFiles(fs, projectDefinition.Path)
+	configFiles, err := FindConfigFiles(fs, projectDefinition.Path)
 	if err != nil {
 		return nil, []error{fmt.Errorf("failed to walk files: %w", err)}
 	}
@@ -184,10 +331,10 @@ func loadConfigsOfProject(fs afero.Fs, loadingContext ProjectLoaderContext, proj
 	return configs, errs
 }
 
-// findConfigFiles finds all YAML files within the given root directory.
+// FindConfigFiles finds all YAML files within the given root directory, which are loaded as config files of a project.
 // Hidden directories (start with a dot (.)) are excluded.
 // Directories marked as hidden on Windows are not excluded.
-func findConfigFiles(fs afero.Fs, root string) ([]string, error) {
+func FindConfigFiles(fs afero.Fs, root string) ([]string, error) {
 	var configFiles []string
 
 	err := afero.Walk(fs, root, func(curPath string, info os.FileInfo, err error) error {
@@ -238,7 +385,7 @@ func toDependenciesMap(projectId string, configs []config.Config) DependenciesPe
 			continue
 		}
 
-		for _, ref := range c.References() {
+		for _, ref := range c.Dependencies() {
 			// ignore project on same project
 			if projectId == ref.Project {
 				continue
@@ -24,6 +24,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/spf13/afero"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -513,6 +514,38 @@ func TestLoadProjects_ReturnsErrOnOverlappingCoordinate_InSameFile(t *testing.T)
 	assert.Equal(t, len(gotErrs), 1, "Expected to fail on overlapping coordinates")
 }
 
+func TestLoadProjects_DependsOnAddsProjectDependency(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "project/alerting-profile/profile.yaml", []byte("configs:\n- id: profile\n  config:\n    name: Test Profile\n    template: profile.json\n    dependsOn: ['project2:dashboard:board']\n  type:\n    api: alerting-profile"), 0644)
+	_ = afero.WriteFile(testFs, "project/alerting-profile/profile.json", []byte("{}"), 0644)
+	_ = afero.WriteFile(testFs, "project2/dashboard/board.yaml", []byte("configs:\n- id: board\n  config:\n    name: Test Dashboard\n    template: board.json\n  type:\n    api: dashboard"), 0644)
+	_ = afero.WriteFile(testFs, "project2/dashboard/board.json", []byte("{}"), 0644)
+
+	context := getSimpleProjectLoaderContext([]string{"project", "project2"})
+
+	got, gotErrs := LoadProjects(testFs, context)
+
+	assert.Equal(t, len(gotErrs), 0, "Expected to load projects without error")
+	for _, p := range got {
+		if p.Id == "project" {
+			assert.DeepEqual(t, p.Dependencies, DependenciesPerEnvironment{"env": {"project2"}})
+		}
+	}
+}
+
+func TestLoadProjects_ReturnsErrOnDependsOnUnknownConfig(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "project/alerting-profile/profile.yaml", []byte("configs:\n- id: profile\n  config:\n    name: Test Profile\n    template: profile.json\n    dependsOn: ['project:dashboard:missing']\n  type:\n    api: alerting-profile"), 0644)
+	_ = afero.WriteFile(testFs, "project/alerting-profile/profile.json", []byte("{}"), 0644)
+
+	context := getSimpleProjectLoaderContext([]string{"project"})
+
+	_, gotErrs := LoadProjects(testFs, context)
+
+	assert.Equal(t, len(gotErrs), 1, "Expected to fail on unknown dependency")
+	assert.ErrorContains(t, gotErrs[0], "`project:alerting-profile:profile` depends on unknown config `project:dashboard:missing`")
+}
+
 func Test_loadProject_returnsErrorIfProjectPathDoesNotExist(t *testing.T) {
 	fs := afero.NewMemMapFs()
 	ctx := ProjectLoaderContext{}
@@ -526,6 +559,54 @@ func Test_loadProject_returnsErrorIfProjectPathDoesNotExist(t *testing.T) {
 	assert.ErrorContains(t, gotErrs[0], "filepath `this/does/not/exist` does not exist")
 }
 
+func TestReloadProjects_ReloadsOnlyGivenProjects(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "a/dashboard/board.yaml", []byte("configs:\n- id: board\n  config:\n    name: Board A\n    template: board.json\n  type:\n    api: dashboard"), 0644)
+	_ = afero.WriteFile(testFs, "a/dashboard/board.json", []byte("{}"), 0644)
+	_ = afero.WriteFile(testFs, "b/dashboard/board.yaml", []byte("configs:\n- id: board\n  config:\n    name: Board B\n    template: board.json\n  type:\n    api: dashboard"), 0644)
+	_ = afero.WriteFile(testFs, "b/dashboard/board.json", []byte("{}"), 0644)
+
+	context := getSimpleProjectLoaderContext([]string{"a", "b"})
+
+	loaded, gotErrs := LoadProjects(testFs, context)
+	assert.Equal(t, len(gotErrs), 0, "Expected to load projects without error")
+
+	_ = afero.WriteFile(testFs, "a/dashboard/second.yaml", []byte("configs:\n- id: second\n  config:\n    name: Second A\n    template: board.json\n  type:\n    api: dashboard"), 0644)
+	_ = afero.WriteFile(testFs, "b/dashboard/second.yaml", []byte("configs:\n- id: second\n  config:\n    name: Second B\n    template: board.json\n  type:\n    api: dashboard"), 0644)
+
+	got, gotErrs := ReloadProjects(testFs, context, loaded, []string{"a"})
+
+	assert.Equal(t, len(gotErrs), 0, "Expected to reload project without error")
+	assert.Equal(t, len(got), 2, "Expected both projects to be returned")
+
+	for _, p := range got {
+		switch p.Id {
+		case "a":
+			assert.Equal(t, len(p.Configs["env"]["dashboard"]), 2, "Expected reloaded project to contain the new config")
+		case "b":
+			assert.Equal(t, len(p.Configs["env"]["dashboard"]), 1, "Expected other project to be kept as loaded before")
+		}
+	}
+}
+
+func TestReloadProjects_ReturnsErrorsOfReloadedProject(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "a/dashboard/board.yaml", []byte("configs:\n- id: board\n  config:\n    name: Board A\n    template: board.json\n  type:\n    api: dashboard"), 0644)
+	_ = afero.WriteFile(testFs, "a/dashboard/board.json", []byte("{}"), 0644)
+
+	context := getSimpleProjectLoaderContext([]string{"a"})
+
+	loaded, gotErrs := LoadProjects(testFs, context)
+	assert.Equal(t, len(gotErrs), 0, "Expected to load project without error")
+
+	_ = afero.WriteFile(testFs, "a/dashboard/board.yaml", []byte("configs:\n- id: board\n  config:\n    name: Board A\n    template: missing.json\n  type:\n    api: dashboard"), 0644)
+
+	got, gotErrs := ReloadProjects(testFs, context, loaded, []string{"a"})
+
+	assert.Assert(t, len(gotErrs) > 0, "Expected reloading a broken project to fail")
+	assert.Equal(t, len(got), 0)
+}
+
 func getSimpleProjectLoaderContext(projects []string) ProjectLoaderContext {
 	return getTestProjectLoaderContext([]string{"alerting-profile", "dashboard"}, projects)
 }
@@ -569,3 +650,36 @@ func getFullProjectLoaderContext(apis []string, projects []string, environments
 		ParametersSerde: config.DefaultParameterParsers,
 	}
 }
+
+func Test_pluginCommand(t *testing.T) {
+	workingDir, err := filepath.Abs("project")
+	assert.NilError(t, err)
+	absoluteCommand := filepath.Join(t.TempDir(), "bin", "cmdb-lookup")
+
+	tests := []struct {
+		name    string
+		command string
+		want    string
+	}{
+		{
+			name:    "command without separator is looked up in the PATH",
+			command: "cmdb-lookup",
+			want:    "cmdb-lookup",
+		},
+		{
+			name:    "relative command is resolved against the working directory",
+			command: "plugins/cmdb-lookup",
+			want:    filepath.Join(workingDir, "plugins", "cmdb-lookup"),
+		},
+		{
+			name:    "absolute command is kept",
+			command: filepath.ToSlash(absoluteCommand),
+			want:    absoluteCommand,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pluginCommand("project", tt.command))
+		})
+	}
+}
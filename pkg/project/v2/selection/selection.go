@@ -0,0 +1,221 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package selection narrows loaded projects down to the configurations selected by their type, coordinate or labels,
+// together with the configurations they depend on.
+package selection
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"golang.org/x/exp/slices"
+	gonumGraph "gonum.org/v1/gonum/graph"
+	"path"
+	"strings"
+)
+
+// Selector selects configurations. A configuration is selected if it matches any of the values given for each
+// criterion. A Selector without any values selects all configurations.
+type Selector struct {
+	// Types are the types of the selected configurations, e.g. API IDs, Settings schema IDs or Automation resources
+	Types []string
+	// Coordinates are glob patterns in the form 'project:type:id', which the coordinates of the selected configurations
+	// match. See path.Match for the supported pattern syntax.
+	Coordinates []string
+	// Labels are labels of the selected configurations, either in the form 'key=value', or just 'key' to select
+	// configurations having the label with any value
+	Labels []string
+}
+
+// IsEmpty returns whether the Selector has no values, and therefore selects all configurations
+func (s Selector) IsEmpty() bool {
+	return len(s.Types) == 0 && len(s.Coordinates) == 0 && len(s.Labels) == 0
+}
+
+// Validate returns an error if any of the coordinate patterns or labels is malformed
+func (s Selector) Validate() error {
+	var errs []error
+	for _, pattern := range s.Coordinates {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("invalid config pattern %q: %w", pattern, err))
+		}
+	}
+	for _, label := range s.Labels {
+		if key, _, _ := strings.Cut(label, "="); key == "" {
+			errs = append(errs, fmt.Errorf("invalid label %q: key must not be empty", label))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Matches returns whether the given configuration is selected
+func (s Selector) Matches(c config.Config) bool {
+	if len(s.Types) > 0 && !slices.Contains(s.Types, c.Coordinate.Type) {
+		return false
+	}
+
+	if len(s.Coordinates) > 0 && !slices.ContainsFunc(s.Coordinates, func(pattern string) bool {
+		matched, _ := path.Match(pattern, c.Coordinate.String())
+		return matched
+	}) {
+		return false
+	}
+
+	if len(s.Labels) > 0 && !slices.ContainsFunc(s.Labels, func(label string) bool {
+		return hasLabel(c, label)
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// hasLabel returns whether the config has the given label in the form 'key=value' or 'key'
+func hasLabel(c config.Config, label string) bool {
+	key, value, withValue := strings.Cut(label, "=")
+	actual, found := c.Labels[key]
+	return found && (!withValue || actual == value)
+}
+
+// Select splits the configurations of the given projects in the selected configurations and all others. If
+// withDependencies is set, all configurations the selected ones depend on are selected as well. Otherwise, a warning is
+// logged for each dependency which is not selected. Projects are only contained in the results if they contain at
+// least one configuration. An error is returned if no configuration is selected in any of the environments.
+func Select(projects []project.Project, environments []string, s Selector, withDependencies bool) (selected []project.Project, excluded []project.Project, err error) {
+	if err := s.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	graphs := graph.New(projects, environments)
+
+	selectedPerEnvironment := make(map[string]map[coordinate.Coordinate]struct{}, len(environments))
+	count := 0
+	for _, env := range environments {
+		coordinates := selectInEnvironment(graphs[env], s, withDependencies)
+		selectedPerEnvironment[env] = coordinates
+		count += len(coordinates)
+	}
+
+	if count == 0 {
+		return nil, nil, errors.New("no configurations match the given selection")
+	}
+
+	selected, excluded = SelectCoordinates(projects, selectedPerEnvironment)
+	log.Info("Selected %d configurations", count)
+	return selected, excluded, nil
+}
+
+// SelectCoordinates splits the configurations of the given projects in the ones with the given coordinates per
+// environment, and all others. In contrast to Select, dependencies are never added, and environments without
+// coordinates have no selected configurations.
+func SelectCoordinates(projects []project.Project, coordinatesPerEnvironment map[string]map[coordinate.Coordinate]struct{}) (selected []project.Project, excluded []project.Project) {
+	for _, p := range projects {
+		selectedPart, excludedPart := split(p, coordinatesPerEnvironment)
+		if hasConfigs(selectedPart) {
+			selected = append(selected, selectedPart)
+		}
+		if hasConfigs(excludedPart) {
+			excluded = append(excluded, excludedPart)
+		}
+	}
+	return selected, excluded
+}
+
+// selectInEnvironment returns the coordinates of all configurations in the dependency graph which are selected
+func selectInEnvironment(g gonumGraph.Directed, s Selector, withDependencies bool) map[coordinate.Coordinate]struct{} {
+	result := make(map[coordinate.Coordinate]struct{})
+
+	var matched []graph.ConfigNode
+	nodes := g.Nodes()
+	for nodes.Next() {
+		n := nodes.Node().(graph.ConfigNode)
+		if s.Matches(*n.Config) {
+			matched = append(matched, n)
+			result[n.Config.Coordinate] = struct{}{}
+		}
+	}
+
+	for _, n := range matched {
+		addDependencies(g, n, result, withDependencies)
+	}
+	return result
+}
+
+// addDependencies adds the coordinates of all configurations the given node depends on to the result, or warns about
+// each of them which is not contained in the result if withDependencies is not set.
+func addDependencies(g gonumGraph.Directed, n graph.ConfigNode, result map[coordinate.Coordinate]struct{}, withDependencies bool) {
+	dependencies := g.To(n.ID())
+	for dependencies.Next() {
+		dependency := dependencies.Node().(graph.ConfigNode)
+		if _, found := result[dependency.Config.Coordinate]; found {
+			continue
+		}
+
+		if !withDependencies {
+			log.Warn("Configuration %s depends on %s, which is not selected. The reference is resolved using the deployment state, or by looking up the object on the environment.", n.Config.Coordinate, dependency.Config.Coordinate)
+			continue
+		}
+
+		log.Debug("Selecting %s, as %s depends on it", dependency.Config.Coordinate, n.Config.Coordinate)
+		result[dependency.Config.Coordinate] = struct{}{}
+		addDependencies(g, dependency, result, withDependencies)
+	}
+}
+
+// split returns copies of the given project, one containing only the selected configurations, and one containing all
+// other configurations
+func split(p project.Project, selectedPerEnvironment map[string]map[coordinate.Coordinate]struct{}) (project.Project, project.Project) {
+	selected := project.Project{Id: p.Id, GroupId: p.GroupId, Configs: make(project.ConfigsPerTypePerEnvironments), Dependencies: p.Dependencies}
+	excluded := project.Project{Id: p.Id, GroupId: p.GroupId, Configs: make(project.ConfigsPerTypePerEnvironments), Dependencies: p.Dependencies}
+
+	for env, configsPerType := range p.Configs {
+		coordinates, found := selectedPerEnvironment[env]
+		if !found {
+			// environments which are not deployed are kept as they are
+			excluded.Configs[env] = configsPerType
+			continue
+		}
+
+		selected.Configs[env] = make(project.ConfigsPerType)
+		excluded.Configs[env] = make(project.ConfigsPerType)
+		for t, configs := range configsPerType {
+			for _, c := range configs {
+				if _, found := coordinates[c.Coordinate]; found {
+					selected.Configs[env][t] = append(selected.Configs[env][t], c)
+				} else {
+					excluded.Configs[env][t] = append(excluded.Configs[env][t], c)
+				}
+			}
+		}
+	}
+	return selected, excluded
+}
+
+func hasConfigs(p project.Project) bool {
+	for _, configsPerType := range p.Configs {
+		for _, configs := range configsPerType {
+			if len(configs) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package selection
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const env = "env"
+
+func newConfig(coord coordinate.Coordinate, references ...coordinate.Coordinate) config.Config {
+	params := config.Parameters{}
+	for _, r := range references {
+		params[r.ConfigId] = reference.NewWithCoordinate(r, config.IdParameter)
+	}
+	return config.Config{Coordinate: coord, Environment: env, Parameters: params}
+}
+
+var (
+	zone    = coordinate.Coordinate{Project: "infra", Type: "builtin:management-zones", ConfigId: "zone"}
+	profile = coordinate.Coordinate{Project: "team", Type: "builtin:alerting.profile", ConfigId: "team-profile"}
+	other   = coordinate.Coordinate{Project: "team", Type: "builtin:alerting.profile", ConfigId: "other-profile"}
+	board   = coordinate.Coordinate{Project: "team", Type: "dashboard", ConfigId: "team-dashboard"}
+)
+
+func testProjects() []project.Project {
+	return []project.Project{
+		{
+			Id: "infra",
+			Configs: project.ConfigsPerTypePerEnvironments{env: {
+				zone.Type: {newConfig(zone)},
+			}},
+		},
+		{
+			Id: "team",
+			Configs: project.ConfigsPerTypePerEnvironments{env: {
+				profile.Type: {newConfig(profile, zone), newConfig(other)},
+				board.Type:   {newConfig(board, zone)},
+			}},
+			Dependencies: project.DependenciesPerEnvironment{env: {"infra"}},
+		},
+	}
+}
+
+func coordinates(projects []project.Project) []coordinate.Coordinate {
+	var result []coordinate.Coordinate
+	for _, p := range projects {
+		p.ForEveryConfigDo(func(c config.Config) {
+			result = append(result, c.Coordinate)
+		})
+	}
+	return result
+}
+
+func TestSelector_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector Selector
+		want     bool
+	}{
+		{"empty selector", Selector{}, true},
+		{"matching type", Selector{Types: []string{"dashboard", "builtin:alerting.profile"}}, true},
+		{"other type", Selector{Types: []string{"dashboard"}}, false},
+		{"exact coordinate", Selector{Coordinates: []string{"team:builtin:alerting.profile:team-profile"}}, true},
+		{"glob coordinate", Selector{Coordinates: []string{"team:*:team-*"}}, true},
+		{"other coordinate", Selector{Coordinates: []string{"infra:*:*"}}, false},
+		{"matching type and coordinate", Selector{Types: []string{"builtin:alerting.profile"}, Coordinates: []string{"team:*"}}, true},
+		{"matching type but other coordinate", Selector{Types: []string{"builtin:alerting.profile"}, Coordinates: []string{"infra:*"}}, false},
+		{"matching label", Selector{Labels: []string{"team=payments"}}, true},
+		{"matching label key", Selector{Labels: []string{"team"}}, true},
+		{"any matching label", Selector{Labels: []string{"team=shipping", "tier=critical"}}, true},
+		{"other label value", Selector{Labels: []string{"team=shipping"}}, false},
+		{"other label key", Selector{Labels: []string{"owner"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newConfig(profile)
+			c.Labels = map[string]string{"team": "payments", "tier": "critical"}
+			assert.Equal(t, tt.want, tt.selector.Matches(c))
+		})
+	}
+}
+
+func TestSelector_Validate(t *testing.T) {
+	assert.NoError(t, Selector{Coordinates: []string{"team:*:[a-z]*"}}.Validate())
+	assert.Error(t, Selector{Coordinates: []string{"team:*:[a-z"}}.Validate())
+	assert.Error(t, Selector{Labels: []string{"=value"}}.Validate())
+}
+
+func TestSelect_IncludesDependencies(t *testing.T) {
+	selected, excluded, err := Select(testProjects(), []string{env}, Selector{Coordinates: []string{"team:*:team-profile"}}, true)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []coordinate.Coordinate{zone, profile}, coordinates(selected))
+	assert.ElementsMatch(t, []coordinate.Coordinate{other, board}, coordinates(excluded))
+}
+
+func TestSelect_ExcludesDependenciesWithoutDeps(t *testing.T) {
+	selected, excluded, err := Select(testProjects(), []string{env}, Selector{Types: []string{"builtin:alerting.profile"}}, false)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []coordinate.Coordinate{profile, other}, coordinates(selected))
+	assert.ElementsMatch(t, []coordinate.Coordinate{zone, board}, coordinates(excluded))
+
+	for _, p := range selected {
+		assert.NotEqual(t, "infra", p.Id, "expected project without selected configurations to be omitted")
+	}
+}
+
+func TestSelect_FailsIfNothingIsSelected(t *testing.T) {
+	_, _, err := Select(testProjects(), []string{env}, Selector{Types: []string{"workflow"}}, true)
+	assert.Error(t, err)
+}
@@ -102,11 +102,11 @@ func configsToSortData(configs []config.Config) ([][]bool, []int) {
 	matrix := make([][]bool, numConfigs)
 	inDegrees := make([]int, len(configs))
 
-	// build lookup tables for References between configs.
+	// build lookup tables for dependencies between configs.
 	// with this we need to calculate the references only once and can use the map-lookup with takes O(1)
 	refLookup := make(referencesLookup, len(configs))
 	for i := range configs {
-		refs := configs[i].References()
+		refs := configs[i].Dependencies()
 		c := make(map[coordinate.Coordinate]struct{}, len(refs))
 		for ir := range refs {
 			c[refs[ir]] = struct{}{}
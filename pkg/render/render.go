@@ -0,0 +1,164 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package render renders the payloads of configurations as they would be deployed, without requiring an environment.
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/writer"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"golang.org/x/exp/slices"
+	"path/filepath"
+	"strings"
+)
+
+// Config is the rendered payload of a configuration in an environment
+type Config struct {
+	Coordinate  coordinate.Coordinate
+	Environment string
+	// Content is the rendered template of the configuration, indented if it is a JSON payload
+	Content string
+}
+
+// Path returns the path the rendered configuration is written to, relative to the output folder:
+// <environment>/<project>/<type>/<config id>.json
+func (c Config) Path() string {
+	return filepath.Join(c.Environment, c.Coordinate.Project, writer.SanitizeFileName(c.Coordinate.Type), writer.SanitizeFileName(c.Coordinate.ConfigId)+".json")
+}
+
+// Render renders the configurations of the given projects for each of the given environments.
+//
+// Configurations are rendered in the order they would be deployed in. References are resolved to placeholder IDs
+// derived from the coordinate of the referenced configuration, so that rendering the same projects always produces the
+// same payloads. Skipped configurations, and configurations referencing skipped ones, are not rendered.
+// If a configuration fails to render, configurations referencing it are not rendered either.
+func Render(projects []project.Project, environments []string) ([]Config, []error) {
+	environments = slices.Clone(environments)
+	slices.Sort(environments)
+
+	sortedConfigs, errs := graph.SortProjects(projects, environments)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	var result []Config
+	for _, env := range environments {
+		entities := entityLookup{}
+
+		for i := range sortedConfigs[env] {
+			c := &sortedConfigs[env][i]
+
+			content, rendered, err := renderConfig(c, entities)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("environment %q: %w", env, err))
+				continue
+			}
+			if rendered {
+				result = append(result, Config{Coordinate: c.Coordinate, Environment: env, Content: content})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return result, nil
+}
+
+// renderConfig returns the rendered payload of the given config, and records the placeholder entity it is resolved
+// to by configurations referencing it. The payload is not rendered if the config is skipped.
+func renderConfig(c *config.Config, entities entityLookup) (string, bool, error) {
+	skipped := config.ResolvedEntity{Coordinate: c.Coordinate, Skip: true}
+
+	if c.Skip || entities.skipsReference(c) {
+		entities[c.Coordinate] = skipped
+		return "", false, nil
+	}
+
+	properties, errs := c.ResolveParameterValues(entities)
+	if len(errs) > 0 {
+		entities[c.Coordinate] = skipped
+		return "", false, fmt.Errorf("failed to resolve parameter properties of config %s: %w", c.Coordinate, errors.Join(errs...))
+	}
+
+	content, err := c.Render(properties)
+	if err != nil {
+		entities[c.Coordinate] = skipped
+		return "", false, fmt.Errorf("failed to render JSON template of config %s: %w", c.Coordinate, err)
+	}
+
+	name := c.Coordinate.ConfigId
+	if n, found := properties[config.NameParameter]; found {
+		name = fmt.Sprint(n)
+	}
+	properties[config.IdParameter] = PlaceholderID(c.Coordinate)
+
+	entities[c.Coordinate] = config.ResolvedEntity{
+		EntityName: name,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+	}
+
+	return indent(content), true, nil
+}
+
+// PlaceholderID returns the ID references to the configuration with the given coordinate are resolved to when
+// rendering. It is a UUID derived from the coordinate.
+func PlaceholderID(c coordinate.Coordinate) string {
+	return idutils.GenerateUUIDFromCoordinate(c)
+}
+
+// indent returns the given content indented if it is a JSON payload, or unchanged otherwise
+func indent(content string) string {
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(content), "", "  "); err != nil {
+		return content
+	}
+	return strings.TrimRight(b.String(), " \t\r\n") + "\n"
+}
+
+// entityLookup holds the placeholder entities of rendered configurations, implementing config.EntityLookup
+type entityLookup map[coordinate.Coordinate]config.ResolvedEntity
+
+func (e entityLookup) GetResolvedProperty(c coordinate.Coordinate, propertyName string) (any, bool) {
+	if entity, found := e[c]; found {
+		p, found := entity.Properties[propertyName]
+		return p, found
+	}
+	return nil, false
+}
+
+func (e entityLookup) GetResolvedEntity(c coordinate.Coordinate) (config.ResolvedEntity, bool) {
+	entity, found := e[c]
+	return entity, found
+}
+
+func (e entityLookup) skipsReference(c *config.Config) bool {
+	for _, ref := range c.References() {
+		if entity, found := e[ref]; found && entity.Skip {
+			return true
+		}
+	}
+	return false
+}
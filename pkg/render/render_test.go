@@ -0,0 +1,130 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package render_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+var (
+	tagCoordinate       = coordinate.Coordinate{Project: "project", Type: "auto-tag", ConfigId: "tag"}
+	dashboardCoordinate = coordinate.Coordinate{Project: "project", Type: "dashboard", ConfigId: "dashboard"}
+)
+
+func newProject(environment string, tagSkipped bool, dashboardTemplate string) project.Project {
+	return project.Project{
+		Id: "project",
+		Configs: project.ConfigsPerTypePerEnvironments{
+			environment: {
+				"auto-tag": []config.Config{
+					{
+						Template:    template.NewDownloadTemplate("tag", "tag", `{"name": "{{ .name }}"}`),
+						Coordinate:  tagCoordinate,
+						Type:        config.ClassicApiType{Api: "auto-tag"},
+						Environment: environment,
+						Parameters: map[string]parameter.Parameter{
+							config.NameParameter: value.New("Tag " + environment),
+						},
+						Skip: tagSkipped,
+					},
+				},
+				"dashboard": []config.Config{
+					{
+						Template:    template.NewDownloadTemplate("dashboard", "dashboard", dashboardTemplate),
+						Coordinate:  dashboardCoordinate,
+						Type:        config.ClassicApiType{Api: "dashboard"},
+						Environment: environment,
+						Parameters: map[string]parameter.Parameter{
+							config.NameParameter: value.New("Dashboard"),
+							"tagId":              reference.NewWithCoordinate(tagCoordinate, config.IdParameter),
+							"tagName":            reference.NewWithCoordinate(tagCoordinate, config.NameParameter),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	p := newProject("dev", false, `{"name": "{{ .name }}", "tag": {"id": "{{ .tagId }}", "name": "{{ .tagName }}"}}`)
+
+	rendered, errs := render.Render([]project.Project{p}, []string{"dev"})
+	require.Empty(t, errs)
+	require.Len(t, rendered, 2)
+
+	assert.Equal(t, render.Config{
+		Coordinate:  tagCoordinate,
+		Environment: "dev",
+		Content:     "{\n  \"name\": \"Tag dev\"\n}\n",
+	}, rendered[0])
+
+	assert.Equal(t, render.Config{
+		Coordinate:  dashboardCoordinate,
+		Environment: "dev",
+		Content: `{
+  "name": "Dashboard",
+  "tag": {
+    "id": "` + render.PlaceholderID(tagCoordinate) + `",
+    "name": "Tag dev"
+  }
+}
+`,
+	}, rendered[1])
+
+	again, errs := render.Render([]project.Project{p}, []string{"dev"})
+	require.Empty(t, errs)
+	assert.Equal(t, rendered, again, "rendering must be deterministic")
+}
+
+func TestRender_SkipsConfigsReferencingSkippedConfigs(t *testing.T) {
+	p := newProject("dev", true, `{"tag": "{{ .tagId }}"}`)
+
+	rendered, errs := render.Render([]project.Project{p}, []string{"dev"})
+	require.Empty(t, errs)
+	assert.Empty(t, rendered)
+}
+
+func TestRender_ReportsInvalidTemplates(t *testing.T) {
+	p := newProject("dev", false, `{"tag": {{ .tagId }}}`)
+
+	_, errs := render.Render([]project.Project{p}, []string{"dev"})
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], `environment "dev"`)
+	assert.ErrorContains(t, errs[0], "failed to render JSON template of config project:dashboard:dashboard")
+}
+
+func TestConfig_Path(t *testing.T) {
+	c := render.Config{
+		Coordinate:  coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "my/profile"},
+		Environment: "dev",
+	}
+	assert.Equal(t, filepath.Join("dev", "project", "builtinalerting.profile", "myprofile.json"), c.Path())
+}
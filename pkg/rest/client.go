@@ -24,7 +24,11 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/timeutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/tracing"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/trafficlogs"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 	"io"
 	"net/http"
 	"net/url"
@@ -58,6 +62,19 @@ func (c Client) Get(ctx context.Context, url string) (Response, error) {
 	return c.executeRequest(req)
 }
 
+// GetFile sends a GET request accepting the given content type, which is used to download files instead of their
+// JSON representation
+func (c Client) GetFile(ctx context.Context, url string, contentType string) (Response, error) {
+	req, err := c.request(ctx, http.MethodGet, url)
+
+	if err != nil {
+		return Response{}, err
+	}
+	req.Header.Set("Accept", contentType)
+
+	return c.executeRequest(req)
+}
+
 func (c Client) GetWithRetry(ctx context.Context, url string, settings RetrySetting) (resp Response, err error) {
 	resp, err = c.Get(ctx, url)
 
@@ -72,7 +89,7 @@ func (c Client) GetWithRetry(ctx context.Context, url string, settings RetrySett
 			log.WithCtxFields(ctx).Warn("Retrying failed GET request %s (HTTP %d)", url, resp.StatusCode)
 		}
 		time.Sleep(settings.WaitTime)
-		resp, err = c.Get(ctx, url)
+		resp, err = c.Get(withRetry(ctx, i+1), url)
 		if err == nil && resp.IsSuccess() {
 			return resp, err
 		}
@@ -122,6 +139,18 @@ func (c Client) PostMultiPartFile(ctx context.Context, url string, data *bytes.B
 	return c.executeRequest(req)
 }
 
+func (c Client) PatchMultiPartFile(ctx context.Context, url string, data *bytes.Buffer, contentType string) (Response, error) {
+	req, err := c.requestWithBody(ctx, http.MethodPatch, url, data)
+
+	if err != nil {
+		return Response{}, err
+	}
+
+	req.Header.Set("Content-type", contentType)
+
+	return c.executeRequest(req)
+}
+
 func (c Client) Put(ctx context.Context, url string, data []byte) (Response, error) {
 	req, err := c.requestWithBody(ctx, http.MethodPut, url, bytes.NewBuffer(data))
 
@@ -146,7 +175,24 @@ func (c Client) requestWithBody(ctx context.Context, method string, url string,
 	return req, nil
 }
 
-func (c Client) executeRequest(request *http.Request) (Response, error) {
+func (c Client) executeRequest(request *http.Request) (response Response, err error) {
+	ctx, span := tracing.Tracer().Start(request.Context(), "HTTP "+request.Method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.HTTPMethod(request.Method),
+		semconv.HTTPURL(request.URL.String()),
+		tracing.Retry.Int(retryFromContext(request.Context())),
+	))
+	request = request.WithContext(ctx)
+	attempts := 0
+	defer func() {
+		span.SetAttributes(tracing.Attempts.Int(attempts))
+		if response.StatusCode != 0 {
+			span.SetAttributes(semconv.HTTPStatusCode(response.StatusCode))
+			if err == nil && !response.IsSuccess() {
+				span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", response.StatusCode))
+			}
+		}
+		tracing.EndSpan(span, err)
+	}()
 
 	request.Header.Set("User-Agent", "Dynatrace-config-as-code-http-client")
 
@@ -161,7 +207,8 @@ func (c Client) executeRequest(request *http.Request) (Response, error) {
 		}
 	}
 
-	response, err := c.rateLimitStrategy.ExecuteRequest(timeutils.NewTimelineProvider(), func() (Response, error) {
+	response, err = c.rateLimitStrategy.ExecuteRequest(timeutils.NewTimelineProvider(), func() (Response, error) {
+		attempts++
 		resp, err := c.client.Do(request)
 		if err != nil {
 			if isConnectionResetErr(err) {
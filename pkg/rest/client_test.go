@@ -17,7 +17,15 @@
 package rest
 
 import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/tracing"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"golang.org/x/net/context"
 	"net/http"
 	"net/http/httptest"
@@ -38,3 +46,33 @@ func TestClient_executeRequestReportsEOFConnectionErrors(t *testing.T) {
 
 	assert.ErrorContains(t, err, "Unable to connect")
 }
+
+func TestClient_executeRequestCreatesSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	restClient := NewRestClient(server.Client(), nil, CreateRateLimitStrategy())
+
+	_, err := restClient.Get(withRetry(context.Background(), 2), server.URL+"/some-url")
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "HTTP GET", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Subset(t, spans[0].Attributes(), []attribute.KeyValue{
+		semconv.HTTPMethod(http.MethodGet),
+		semconv.HTTPURL(server.URL + "/some-url"),
+		semconv.HTTPStatusCode(http.StatusNotFound),
+		tracing.Attempts.Int(1),
+		tracing.Retry.Int(2),
+	})
+}
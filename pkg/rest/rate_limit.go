@@ -20,8 +20,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/throttle"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/timeutils"
@@ -33,12 +35,73 @@ type RateLimitStrategy interface {
 	ExecuteRequest(timelineProvider timeutils.TimelineProvider, callback func() (Response, error)) (Response, error)
 }
 
-// CreateRateLimitStrategy creates a RateLimitStrategy. In the future this can be extended to instantiate
-// different rate limiting strategies based on e.g. environment variables. The current implementation
-// always returns the strategy simpleSleepRateLimitStrategy, which suspends the current goroutine until
+// CreateRateLimitStrategy creates the RateLimitStrategy selected for this run.
+// By default, this is the strategy simpleSleepRateLimitStrategy, which suspends the current goroutine until
 // the time in the rate limiting header 'X-RateLimit-Reset' is up.
+// If the feature flag featureflags.TokenBucketRateLimit is enabled, an adaptive token bucket strategy configured by
+// TokenBucketOptionsFromEnv is created instead.
 func CreateRateLimitStrategy() RateLimitStrategy {
-	return &simpleSleepRateLimitStrategy{}
+	return CreateRateLimitStrategyWithBudget(0)
+}
+
+// CreateRateLimitStrategyWithBudget creates the RateLimitStrategy selected for this run (see CreateRateLimitStrategy),
+// which does not send more than requestsPerSecond requests. If requestsPerSecond is not positive, the default budget
+// of the selected strategy is used.
+func CreateRateLimitStrategyWithBudget(requestsPerSecond float64) RateLimitStrategy {
+	if featureflags.TokenBucketRateLimit().Enabled() {
+		opts := TokenBucketOptionsFromEnv()
+		if requestsPerSecond > 0 {
+			opts.RequestsPerSecond = requestsPerSecond
+		}
+		return NewTokenBucketRateLimitStrategy(opts)
+	}
+	return NewRequestsPerSecondLimitStrategy(&simpleSleepRateLimitStrategy{}, requestsPerSecond)
+}
+
+// NewRequestsPerSecondLimitStrategy wraps the given RateLimitStrategy and delays requests, so that no more than
+// requestsPerSecond requests are sent. The returned strategy is safe for concurrent use, and is meant to be shared by all
+// clients accessing the same environment. If requestsPerSecond is not positive, the given strategy is returned as is.
+func NewRequestsPerSecondLimitStrategy(strategy RateLimitStrategy, requestsPerSecond float64) RateLimitStrategy {
+	if requestsPerSecond <= 0 {
+		return strategy
+	}
+	return &requestsPerSecondLimitStrategy{
+		strategy: strategy,
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+	}
+}
+
+// requestsPerSecondLimitStrategy spaces out requests evenly, so that a fixed budget of requests per second is not exceeded.
+// Rate limiting responses of the server are handled by the wrapped strategy.
+type requestsPerSecondLimitStrategy struct {
+	strategy RateLimitStrategy
+	interval time.Duration
+
+	mutex sync.Mutex
+	// next is the earliest point in time the next request may be sent
+	next time.Time
+}
+
+func (s *requestsPerSecondLimitStrategy) ExecuteRequest(timelineProvider timeutils.TimelineProvider, callback func() (Response, error)) (Response, error) {
+	return s.strategy.ExecuteRequest(timelineProvider, func() (Response, error) {
+		s.waitForNextSlot(timelineProvider)
+		return callback()
+	})
+}
+
+func (s *requestsPerSecondLimitStrategy) waitForNextSlot(timelineProvider timeutils.TimelineProvider) {
+	s.mutex.Lock()
+	now := timelineProvider.Now()
+	if s.next.Before(now) {
+		s.next = now
+	}
+	wait := s.next.Sub(now)
+	s.next = s.next.Add(s.interval)
+	s.mutex.Unlock()
+
+	if wait > 0 {
+		timelineProvider.Sleep(wait)
+	}
 }
 
 // simpleSleepRateLimitStrategy, is a rate limiting strategy which suspends the current goroutine until
@@ -195,3 +195,42 @@ func TestHandleEmptyResponse(t *testing.T) {
 	_, err := rateLimitStrategy.ExecuteRequest(timelineProvider, callback)
 	assert.ErrorContains(t, err, "foo Error")
 }
+
+func TestRequestsPerSecondLimitStrategy_SpacesOutRequests(t *testing.T) {
+	rateLimitStrategy := NewRequestsPerSecondLimitStrategy(&simpleSleepRateLimitStrategy{}, 4)
+	timelineProvider := createTimelineProviderMock(t)
+	callback := func() (Response, error) {
+		return Response{StatusCode: 200}, nil
+	}
+
+	timelineProvider.EXPECT().Now().Times(3).Return(time.Unix(0, 0))
+	timelineProvider.EXPECT().Sleep(250 * time.Millisecond).Times(1)
+	timelineProvider.EXPECT().Sleep(500 * time.Millisecond).Times(1)
+
+	for i := 0; i < 3; i++ {
+		response, err := rateLimitStrategy.ExecuteRequest(timelineProvider, callback)
+		assert.NilError(t, err)
+		assert.Equal(t, response.StatusCode, 200)
+	}
+}
+
+func TestRequestsPerSecondLimitStrategy_DoesNotWaitIfBudgetIsAvailable(t *testing.T) {
+	rateLimitStrategy := NewRequestsPerSecondLimitStrategy(&simpleSleepRateLimitStrategy{}, 4)
+	timelineProvider := createTimelineProviderMock(t)
+	callback := func() (Response, error) {
+		return Response{StatusCode: 200}, nil
+	}
+
+	timelineProvider.EXPECT().Now().Times(1).Return(time.Unix(0, 0))
+	timelineProvider.EXPECT().Now().Times(1).Return(time.Unix(1, 0))
+
+	for i := 0; i < 2; i++ {
+		_, err := rateLimitStrategy.ExecuteRequest(timelineProvider, callback)
+		assert.NilError(t, err)
+	}
+}
+
+func TestRequestsPerSecondLimitStrategy_ReturnsWrappedStrategyWithoutLimit(t *testing.T) {
+	strategy := &simpleSleepRateLimitStrategy{}
+	assert.Equal(t, NewRequestsPerSecondLimitStrategy(strategy, 0), RateLimitStrategy(strategy))
+}
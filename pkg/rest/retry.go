@@ -56,7 +56,7 @@ func SendWithRetry(ctx context.Context, sendWithBody SendRequestWithBody, object
 	for i := 0; i < setting.MaxRetries; i++ {
 		log.WithCtxFields(ctx).Warn("Failed to send HTTP request. Waiting for %s before retrying...", setting.WaitTime)
 		time.Sleep(setting.WaitTime)
-		resp, err = sendWithBody(ctx, path, body)
+		resp, err = sendWithBody(withRetry(ctx, i+1), path, body)
 		if err == nil && resp.IsSuccess() {
 			return resp, err
 		}
@@ -77,3 +77,19 @@ func SendWithRetryWithInitialTry(ctx context.Context, sendWithBody SendRequestWi
 
 	return SendWithRetry(ctx, sendWithBody, objectName, path, body, setting)
 }
+
+// ctxKeyRetry is the context key holding the number of the retry a request is sent for
+type ctxKeyRetry struct{}
+
+// withRetry returns a context marking requests sent with it as the given retry of a previously failed request
+func withRetry(ctx context.Context, retry int) context.Context {
+	return context.WithValue(ctx, ctxKeyRetry{}, retry)
+}
+
+// retryFromContext returns the number of the retry requests sent with the given context are, or 0 for initial requests
+func retryFromContext(ctx context.Context) int {
+	if r, ok := ctx.Value(ctxKeyRetry{}).(int); ok {
+		return r
+	}
+	return 0
+}
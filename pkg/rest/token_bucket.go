@@ -0,0 +1,272 @@
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rest
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/rand"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/timeutils"
+)
+
+// TokenBucketOptions configure the RateLimitStrategy created by NewTokenBucketRateLimitStrategy
+type TokenBucketOptions struct {
+	// RequestsPerSecond is the rate tokens are added to the bucket with. It is the upper bound of the adaptive rate.
+	RequestsPerSecond float64
+	// MinRequestsPerSecond is the lower bound of the adaptive rate when the server signals to slow down.
+	MinRequestsPerSecond float64
+	// Burst is the capacity of the bucket, i.e. the number of requests that may be sent at once after a quiet period.
+	Burst int
+	// MaxRetries is the number of times a request is retried if it was answered with HTTP 429.
+	MaxRetries int
+	// InitialBackoff is the backoff of the first retry. It is doubled for each further retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the upper bound of the backoff between retries.
+	MaxBackoff time.Duration
+}
+
+// DefaultTokenBucketOptions are used for all options of NewTokenBucketRateLimitStrategy which are not set.
+var DefaultTokenBucketOptions = TokenBucketOptions{
+	RequestsPerSecond:    10,
+	MinRequestsPerSecond: 0.5,
+	Burst:                10,
+	MaxRetries:           10,
+	InitialBackoff:       time.Second,
+	MaxBackoff:           time.Minute,
+}
+
+var envTokenBucketOptions struct {
+	once sync.Once
+	opts TokenBucketOptions
+}
+
+// TokenBucketOptionsFromEnv returns the DefaultTokenBucketOptions, overwritten by the values defined in the
+// environment.RateLimitRequestsPerSecondEnvKey, environment.RateLimitBurstEnvKey and environment.RateLimitMaxRetriesEnvKey
+// environment variables. The environment variables are only read by the first call.
+func TokenBucketOptionsFromEnv() TokenBucketOptions {
+	envTokenBucketOptions.once.Do(func() {
+		envTokenBucketOptions.opts = readTokenBucketOptionsFromEnv()
+	})
+	return envTokenBucketOptions.opts
+}
+
+func readTokenBucketOptionsFromEnv() TokenBucketOptions {
+	opts := DefaultTokenBucketOptions
+	opts.RequestsPerSecond = environment.GetEnvValueFloatLog(environment.RateLimitRequestsPerSecondEnvKey)
+	opts.Burst = environment.GetEnvValueIntLog(environment.RateLimitBurstEnvKey)
+	opts.MaxRetries = environment.GetEnvValueIntLog(environment.RateLimitMaxRetriesEnvKey)
+	return opts
+}
+
+// NewTokenBucketRateLimitStrategy creates a RateLimitStrategy which proactively limits requests using a token bucket.
+// Its rate adapts to the 'X-RateLimit-*' headers returned by the server and is halved whenever a request is answered
+// with HTTP 429. Such requests are retried with an exponential backoff plus jitter.
+//
+// The returned strategy is safe for concurrent use, and is meant to be shared by all clients accessing the same environment.
+func NewTokenBucketRateLimitStrategy(opts TokenBucketOptions) RateLimitStrategy {
+	opts = withTokenBucketDefaults(opts)
+	return &tokenBucketRateLimitStrategy{
+		opts:   opts,
+		jitter: randomJitter,
+		rate:   opts.RequestsPerSecond,
+		tokens: float64(opts.Burst),
+	}
+}
+
+func withTokenBucketDefaults(opts TokenBucketOptions) TokenBucketOptions {
+	if opts.RequestsPerSecond <= 0 {
+		opts.RequestsPerSecond = DefaultTokenBucketOptions.RequestsPerSecond
+	}
+	if opts.MinRequestsPerSecond <= 0 {
+		opts.MinRequestsPerSecond = math.Min(DefaultTokenBucketOptions.MinRequestsPerSecond, opts.RequestsPerSecond)
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = 1
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = DefaultTokenBucketOptions.InitialBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultTokenBucketOptions.MaxBackoff
+	}
+	return opts
+}
+
+// randomJitter returns a random duration in [0, d/2)
+func randomJitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return 0
+	}
+	j, err := rand.Int(int64(d / 2))
+	if err != nil {
+		return 0
+	}
+	return time.Duration(j)
+}
+
+type tokenBucketRateLimitStrategy struct {
+	opts TokenBucketOptions
+	// jitter returns a random duration that is added to a backoff of the given duration
+	jitter func(time.Duration) time.Duration
+
+	mutex sync.Mutex
+	// rate is the current rate in requests per second
+	rate float64
+	// tokens is the number of available tokens. It is negative if requests are waiting for tokens.
+	tokens float64
+	// lastRefill is the point in time tokens were last added to the bucket
+	lastRefill time.Time
+}
+
+func (s *tokenBucketRateLimitStrategy) ExecuteRequest(timelineProvider timeutils.TimelineProvider, callback func() (Response, error)) (Response, error) {
+	for attempt := 0; ; attempt++ {
+		s.acquire(timelineProvider)
+
+		response, err := callback()
+		if err != nil {
+			return Response{}, err
+		}
+
+		if response.StatusCode != http.StatusTooManyRequests {
+			s.adapt(response, timelineProvider.Now())
+			return response, nil
+		}
+
+		if attempt >= s.opts.MaxRetries {
+			log.Debug("Rate limit reached, giving up after %d retries", attempt)
+			return response, nil
+		}
+
+		backoff := s.backoff(response, attempt, timelineProvider.Now())
+		log.Debug("Rate limit reached (retry: %d/%d). Reducing rate to %.2f requests per second and retrying in %s", attempt+1, s.opts.MaxRetries, s.currentRate(), backoff)
+		timelineProvider.Sleep(backoff)
+	}
+}
+
+// acquire takes a token from the bucket, suspending the current goroutine until the token is available.
+// Tokens are reserved in order, so waiting requests are served first come, first served.
+func (s *tokenBucketRateLimitStrategy) acquire(timelineProvider timeutils.TimelineProvider) {
+	s.mutex.Lock()
+	s.refill(timelineProvider.Now())
+	s.tokens--
+	var wait time.Duration
+	if s.tokens < 0 {
+		wait = time.Duration(-s.tokens / s.rate * float64(time.Second))
+	}
+	s.mutex.Unlock()
+
+	if wait > 0 {
+		timelineProvider.Sleep(wait)
+	}
+}
+
+func (s *tokenBucketRateLimitStrategy) refill(now time.Time) {
+	if s.lastRefill.IsZero() {
+		s.lastRefill = now
+		return
+	}
+
+	elapsed := now.Sub(s.lastRefill).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	s.tokens = math.Min(float64(s.opts.Burst), s.tokens+elapsed*s.rate)
+	s.lastRefill = now
+}
+
+// adapt sets the rate to what is left of the server's rate limit until it resets.
+// If the server does not return rate limit headers, the rate is slowly increased again up to the configured rate.
+func (s *tokenBucketRateLimitStrategy) adapt(response Response, now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.refill(now)
+
+	remaining, okRemaining := headerInt(response, "X-RateLimit-Remaining")
+	reset, okReset := resetTime(response)
+	if okRemaining && okReset && reset.After(now) {
+		s.setRate(float64(remaining) / reset.Sub(now).Seconds())
+		return
+	}
+
+	s.setRate(s.rate + s.opts.RequestsPerSecond/10)
+}
+
+// backoff reduces the rate and returns how long to wait before retrying a rate limited request.
+// If the server told when its limit resets, the backoff lasts until then, otherwise it grows exponentially.
+func (s *tokenBucketRateLimitStrategy) backoff(response Response, attempt int, now time.Time) time.Duration {
+	s.mutex.Lock()
+	s.refill(now)
+	s.setRate(s.rate / 2)
+	s.tokens = math.Min(s.tokens, 0)
+	s.mutex.Unlock()
+
+	var backoff time.Duration
+	if reset, ok := resetTime(response); ok && reset.After(now) {
+		backoff = reset.Sub(now)
+	} else if retryAfter, ok := headerInt(response, "Retry-After"); ok && retryAfter > 0 {
+		backoff = time.Duration(retryAfter) * time.Second
+	} else {
+		backoff = s.opts.InitialBackoff * time.Duration(math.Pow(2, float64(attempt)))
+	}
+
+	backoff += s.jitter(backoff)
+	if backoff > s.opts.MaxBackoff || backoff < 0 {
+		backoff = s.opts.MaxBackoff
+	}
+	return backoff
+}
+
+func (s *tokenBucketRateLimitStrategy) setRate(rate float64) {
+	s.rate = math.Max(s.opts.MinRequestsPerSecond, math.Min(s.opts.RequestsPerSecond, rate))
+}
+
+func (s *tokenBucketRateLimitStrategy) currentRate() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.rate
+}
+
+func headerInt(response Response, key string) (int64, bool) {
+	values := response.Headers[http.CanonicalHeaderKey(key)]
+	if len(values) == 0 || values[0] == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// resetTime returns the point in time the server's rate limit resets, as defined by the 'X-RateLimit-Reset' header
+func resetTime(response Response) (time.Time, bool) {
+	microseconds, ok := headerInt(response, "X-RateLimit-Reset")
+	if !ok {
+		return time.Time{}, false
+	}
+	return timeutils.ConvertMicrosecondsToUnixTime(microseconds), true
+}
@@ -0,0 +1,200 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2023 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTimeline is a timeutils.TimelineProvider whose clock only advances when sleeping
+type fakeTimeline struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (f *fakeTimeline) Now() time.Time {
+	return f.now
+}
+
+func (f *fakeTimeline) Sleep(d time.Duration) {
+	f.sleeps = append(f.sleeps, d)
+	f.now = f.now.Add(d)
+}
+
+func newTestTokenBucket(opts TokenBucketOptions) *tokenBucketRateLimitStrategy {
+	s := NewTokenBucketRateLimitStrategy(opts).(*tokenBucketRateLimitStrategy)
+	s.jitter = func(time.Duration) time.Duration { return 0 }
+	return s
+}
+
+func okResponse() (Response, error) {
+	return Response{StatusCode: http.StatusOK}, nil
+}
+
+func TestTokenBucket_BurstIsNotDelayed(t *testing.T) {
+	s := newTestTokenBucket(TokenBucketOptions{RequestsPerSecond: 2, Burst: 3})
+	timeline := &fakeTimeline{now: time.Unix(0, 0)}
+
+	for i := 0; i < 3; i++ {
+		_, err := s.ExecuteRequest(timeline, okResponse)
+		assert.NoError(t, err)
+	}
+
+	assert.Empty(t, timeline.sleeps)
+}
+
+func TestTokenBucket_RequestsAreDelayedAfterBurst(t *testing.T) {
+	s := newTestTokenBucket(TokenBucketOptions{RequestsPerSecond: 2, Burst: 1})
+	timeline := &fakeTimeline{now: time.Unix(0, 0)}
+
+	for i := 0; i < 3; i++ {
+		_, err := s.ExecuteRequest(timeline, okResponse)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, 500 * time.Millisecond}, timeline.sleeps)
+}
+
+func TestTokenBucket_RetriesTooManyRequestsWithExponentialBackoff(t *testing.T) {
+	s := newTestTokenBucket(TokenBucketOptions{RequestsPerSecond: 100, Burst: 100, MaxRetries: 5, InitialBackoff: time.Second})
+	timeline := &fakeTimeline{now: time.Unix(0, 0)}
+
+	calls := 0
+	resp, err := s.ExecuteRequest(timeline, func() (Response, error) {
+		calls++
+		if calls <= 3 {
+			return Response{StatusCode: http.StatusTooManyRequests}, nil
+		}
+		return okResponse()
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, timeline.sleeps[:3])
+}
+
+func TestTokenBucket_BackoffHonorsResetHeader(t *testing.T) {
+	s := newTestTokenBucket(TokenBucketOptions{RequestsPerSecond: 100, Burst: 100, MaxRetries: 1})
+	timeline := &fakeTimeline{now: time.Unix(0, 0)}
+
+	calls := 0
+	_, err := s.ExecuteRequest(timeline, func() (Response, error) {
+		calls++
+		if calls == 1 {
+			return Response{
+				StatusCode: http.StatusTooManyRequests,
+				Headers: map[string][]string{
+					"X-Ratelimit-Reset": {strconv.FormatInt((42 * time.Second).Microseconds(), 10)},
+				},
+			}, nil
+		}
+		return okResponse()
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42*time.Second, timeline.sleeps[0])
+}
+
+func TestTokenBucket_BackoffIsCappedAndJittered(t *testing.T) {
+	s := newTestTokenBucket(TokenBucketOptions{RequestsPerSecond: 100, Burst: 100, MaxRetries: 2, InitialBackoff: 10 * time.Second, MaxBackoff: 15 * time.Second})
+	s.jitter = func(d time.Duration) time.Duration { return d / 4 }
+	timeline := &fakeTimeline{now: time.Unix(0, 0)}
+
+	resp, err := s.ExecuteRequest(timeline, func() (Response, error) {
+		return Response{StatusCode: http.StatusTooManyRequests}, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "expected last response to be returned once retries are exhausted")
+	assert.Equal(t, 12500*time.Millisecond, timeline.sleeps[0])
+	assert.Contains(t, timeline.sleeps, 15*time.Second)
+}
+
+func TestTokenBucket_TooManyRequestsHalvesRate(t *testing.T) {
+	s := newTestTokenBucket(TokenBucketOptions{RequestsPerSecond: 8, MinRequestsPerSecond: 3, Burst: 10, MaxRetries: 3})
+	timeline := &fakeTimeline{now: time.Unix(0, 0)}
+
+	_, _ = s.ExecuteRequest(timeline, func() (Response, error) {
+		return Response{StatusCode: http.StatusTooManyRequests}, nil
+	})
+
+	assert.Equal(t, float64(3), s.currentRate(), "expected rate to be halved down to the minimum")
+}
+
+func TestTokenBucket_RateAdaptsToRemainingLimit(t *testing.T) {
+	s := newTestTokenBucket(TokenBucketOptions{RequestsPerSecond: 50, MinRequestsPerSecond: 1, Burst: 10})
+	timeline := &fakeTimeline{now: time.Unix(0, 0)}
+
+	_, err := s.ExecuteRequest(timeline, func() (Response, error) {
+		return Response{
+			StatusCode: http.StatusOK,
+			Headers: map[string][]string{
+				"X-Ratelimit-Remaining": {"20"},
+				"X-Ratelimit-Reset":     {strconv.FormatInt((10 * time.Second).Microseconds(), 10)},
+			},
+		}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), s.currentRate())
+
+	// without headers, the rate recovers towards the configured rate
+	_, err = s.ExecuteRequest(timeline, okResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(7), s.currentRate())
+}
+
+func TestTokenBucket_ErrorsAreReturned(t *testing.T) {
+	s := newTestTokenBucket(TokenBucketOptions{})
+	timeline := &fakeTimeline{now: time.Unix(0, 0)}
+
+	_, err := s.ExecuteRequest(timeline, func() (Response, error) {
+		return Response{}, errors.New("foo Error")
+	})
+	assert.ErrorContains(t, err, "foo Error")
+}
+
+func TestCreateRateLimitStrategy_SelectedByFeatureFlag(t *testing.T) {
+	_, isTokenBucket := CreateRateLimitStrategyWithBudget(5).(*tokenBucketRateLimitStrategy)
+	assert.False(t, isTokenBucket)
+
+	t.Setenv("MONACO_FEAT_TOKEN_BUCKET_RATE_LIMIT", "true")
+	s, isTokenBucket := CreateRateLimitStrategyWithBudget(5).(*tokenBucketRateLimitStrategy)
+	assert.True(t, isTokenBucket)
+	assert.Equal(t, float64(5), s.opts.RequestsPerSecond)
+}
+
+func TestReadTokenBucketOptionsFromEnv(t *testing.T) {
+	t.Setenv(environment.RateLimitRequestsPerSecondEnvKey, "0.5")
+	t.Setenv(environment.RateLimitBurstEnvKey, "3")
+	t.Setenv(environment.RateLimitMaxRetriesEnvKey, "4")
+
+	opts := readTokenBucketOptionsFromEnv()
+	assert.Equal(t, 0.5, opts.RequestsPerSecond)
+	assert.Equal(t, 3, opts.Burst)
+	assert.Equal(t, 4, opts.MaxRetries)
+	assert.Equal(t, DefaultTokenBucketOptions.MaxBackoff, opts.MaxBackoff)
+}